@@ -18,6 +18,7 @@ abstract class CeinturesTrainingAPI {
   Future<InstantiatedBeltQuestion> instantiateTraining(
       InstantiateTrainingQuestionIn args);
   Future<QuestionAnswersOut> evaluateTraining(EvaluateAnswerTrainingIn args);
+  Future<Enonce> showHint(ShowHintIn args);
 }
 
 class ServerCeinturesAPI implements CeinturesAPI {
@@ -93,4 +94,15 @@ class ServerCeinturesAPI implements CeinturesAPI {
         });
     return questionAnswersOutFromJson(checkServerError(resp.body));
   }
+
+  @override
+  Future<Enonce> showHint(ShowHintIn args) async {
+    const serverEndpoint = "/api/student/ceintures/training/hint";
+    final uri = buildMode.serverURL(serverEndpoint);
+    final resp = await http
+        .post(uri, body: jsonEncode(showHintInToJson(args)), headers: {
+      'Content-type': 'application/json',
+    });
+    return enonceFromJson(checkServerError(resp.body));
+  }
 }
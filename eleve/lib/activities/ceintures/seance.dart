@@ -138,6 +138,7 @@ class SeanceController {
     for (var i = 0; i < res.length; i++) {
       final answer = res[i];
       _controllers[i].setFeedback(answer.results);
+      _controllers[i].setItemFeedback(answer.itemResults);
       _controllers[i].buttonEnabled = true;
       _controllers[i].buttonLabel = "Essayer à nouveau...";
     }
@@ -428,6 +429,7 @@ class __TrainingViewState extends State<_TrainingView> {
   InstantiatedBeltQuestion? question;
   QuestionController? controller;
   _State state = _State.answering;
+  List<Enonce> hints = []; // revealed for the current question
 
   @override
   void didUpdateWidget(covariant _TrainingView oldWidget) {
@@ -452,6 +454,7 @@ class __TrainingViewState extends State<_TrainingView> {
       state = _State.answering;
       question = res;
       controller = QuestionController.fromQuestion(res.question);
+      hints = [];
     });
   }
 
@@ -459,7 +462,17 @@ class __TrainingViewState extends State<_TrainingView> {
   Widget build(BuildContext context) {
     final question = this.question;
     return Scaffold(
-      appBar: AppBar(title: const Text("Entraînement")),
+      appBar: AppBar(
+        title: const Text("Entraînement"),
+        actions: [
+          if (question != null && question.nbHints != 0)
+            IconButton(
+              tooltip: "Indice (${hints.length}/${question.nbHints})",
+              onPressed: _onShowHint,
+              icon: const Icon(Icons.lightbulb_outline),
+            ),
+        ],
+      ),
       body: question == null
           ? CircularProgressIndicator()
           : QuestionView(
@@ -480,6 +493,49 @@ class __TrainingViewState extends State<_TrainingView> {
     }
   }
 
+  // reveal the next hint, if any, and display
+  // all the hints revealed so far
+  void _onShowHint() async {
+    final question = this.question;
+    if (question == null) return;
+
+    if (hints.length < question.nbHints) {
+      final Enonce hint;
+      try {
+        hint = await widget.trainingMeta.api.showHint(
+          ShowHintIn(
+            widget.trainingMeta.tokens,
+            widget.idQuestion,
+            question.params,
+            hints.length,
+          ),
+        );
+      } catch (error) {
+        if (!mounted) return;
+        showError("Impossible d'afficher l'indice", error, context);
+        return;
+      }
+      if (!mounted) return;
+      setState(() {
+        hints.add(hint);
+      });
+    }
+
+    Navigator.of(context).push(
+      MaterialPageRoute<void>(
+        builder: (context) => Scaffold(
+          appBar: AppBar(),
+          body: CorrectionView(
+            [for (final hint in hints) ...hint],
+            Colors.yellowAccent,
+            null,
+            title: hints.length == 1 ? "Indice" : "Indices",
+          ),
+        ),
+      ),
+    );
+  }
+
   void _evaluate() async {
     if (question == null || controller == null) return;
 
@@ -498,6 +554,9 @@ class __TrainingViewState extends State<_TrainingView> {
       controller?.buttonLabel = "Recommencer";
       controller?.buttonEnabled = true;
       controller?.setFeedback(result.isCorrect ? null : result.results);
+      if (!result.isCorrect) {
+        controller?.setItemFeedback(result.itemResults);
+      }
     });
 
     if (result.isCorrect) {
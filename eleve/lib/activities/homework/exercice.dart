@@ -17,6 +17,15 @@ abstract class ExerciceAPI {
   /// [evaluate] must evaluate the exercice answers and return the feedback and the new version
   /// of the questions if needed
   Future<EvaluateWorkOut> evaluate(EvaluateWorkIn params);
+
+  /// [showHint] returns the hint [hintIndex] of the question [questionIndex],
+  /// instantiated with the question [params]
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  );
 }
 
 class ExerciceController {
@@ -29,6 +38,9 @@ class ExerciceController {
   final QuestionRepeat questionRepeat;
   final int questionTimeLimit;
 
+  /// [hintPenalty] is the number of points removed for each hint used
+  final int hintPenalty;
+
   /// [questionIndex] is the current question, or -1 for the summary
   int questionIndex = -1;
   ExerciceStep step = .answering;
@@ -40,12 +52,17 @@ class ExerciceController {
   /// the one in use
   List<InstantiatedQuestion>? _newQuestions;
 
+  /// [_hints] stores the hints already revealed, by question index.
+  /// It is reset when the questions are replaced.
+  Map<int, List<Enonce>> _hints = {};
+
   ExerciceController(
     this.exercice,
     this.progression,
     this.questionRepeat,
-    this.questionTimeLimit,
-  ) {
+    this.questionTimeLimit, {
+    this.hintPenalty = 0,
+  }) {
     _questions = _createControllers();
     _refreshQuestions();
   }
@@ -91,6 +108,7 @@ class ExerciceController {
     if (_newQuestions != null) {
       exercice = exercice.copyWithQuestions(_newQuestions!);
       _newQuestions = null;
+      _hints = {};
     }
     step = .answering;
     _questions = _createControllers();
@@ -111,6 +129,7 @@ class ExerciceController {
       /// [_showFeedback] set the given feedback (for the current question)
       /// and set the state to [displayingFeedback]
       _questions[questionIndex].setFeedback(resp.result.results);
+      _questions[questionIndex].setItemFeedback(resp.result.itemResults);
 
       final isOneTry = questionRepeat == QuestionRepeat.oneTry;
       _questions[questionIndex].buttonEnabled = !isOneTry;
@@ -140,6 +159,16 @@ class ExerciceController {
     return exercice.questions[questionIndex].question.correction;
   }
 
+  /// the number of hints available for the current question
+  int get currentNbHints => exercice.questions[questionIndex].nbHints;
+
+  /// the hints already revealed for the current question
+  List<Enonce> get currentHints => _hints[questionIndex] ?? [];
+
+  void addHint(Enonce hint) {
+    _hints.putIfAbsent(questionIndex, () => []).add(hint);
+  }
+
   /// checks if the student as already try at least once the current question
   bool get isCurrentCorrectionEnabled {
     return progression[questionIndex].isNotEmpty;
@@ -649,6 +678,12 @@ class _QuestionsRouteState extends State<_QuestionsRoute> {
       appBar: AppBar(
         title: Text("Question ${ct.questionIndex + 1}"),
         actions: [
+          if (ct.currentNbHints != 0)
+            IconButton(
+              tooltip: "Indice (${ct.currentHints.length}/${ct.currentNbHints})",
+              onPressed: _onShowHint,
+              icon: const Icon(Icons.lightbulb_outline),
+            ),
           if (widget.onShowCorrectAnswer != null)
             TextButton(
               onPressed: widget.onShowCorrectAnswer,
@@ -934,6 +969,71 @@ class _QuestionsRouteState extends State<_QuestionsRoute> {
     );
   }
 
+  // reveal the next hint, if any, and display
+  // all the hints revealed so far
+  void _onShowHint() async {
+    final ct = widget.controller;
+    final index = ct.questionIndex;
+    final hints = ct.currentHints;
+    if (hints.length < ct.currentNbHints) {
+      if (ct.hintPenalty != 0 && !noticeSandbox) {
+        final confirm = await showDialog<bool>(
+          context: context,
+          builder: (context) => AlertDialog(
+            title: const Text("Utiliser un indice"),
+            content: Text(
+              "Chaque indice utilisé retire ${ct.hintPenalty} point(s) à la note de la question.",
+            ),
+            actions: [
+              TextButton(
+                onPressed: () => Navigator.of(context).pop(false),
+                child: const Text("Annuler"),
+              ),
+              ElevatedButton(
+                onPressed: () => Navigator.of(context).pop(true),
+                child: const Text("Voir l'indice"),
+              ),
+            ],
+          ),
+        );
+        if (!mounted) return;
+        if (!(confirm ?? false)) return;
+      }
+
+      final Enonce hint;
+      try {
+        hint = await widget.api.showHint(
+          ct.exercice.iD,
+          index,
+          ct.exercice.questions[index].params,
+          hints.length,
+        );
+      } catch (error) {
+        if (!mounted) return;
+        showError("Impossible d'afficher l'indice", error, context);
+        return;
+      }
+      if (!mounted) return;
+      setState(() {
+        ct.addHint(hint);
+      });
+    }
+
+    Navigator.of(context).push(
+      MaterialPageRoute<void>(
+        builder: (context) => Scaffold(
+          appBar: AppBar(),
+          body: CorrectionView(
+            [for (final hint in ct.currentHints) ...hint],
+            Colors.yellowAccent,
+            null,
+            title: ct.currentHints.length == 1 ? "Indice" : "Indices",
+          ),
+        ),
+      ),
+    );
+  }
+
   void _onRetryQuestion() {
     setState(() {
       widget.controller.ensureNewQuestions();
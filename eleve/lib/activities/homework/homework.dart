@@ -26,13 +26,20 @@ extension on SheetProgression {
 abstract class HomeworkAPI {
   Future<Sheets> loadSheets(bool loadNonNoted);
   Future<SheetProgression> loadTravail(IdTravail id);
-  Future<InstantiatedWork> loadWork(IdTask id);
+  Future<InstantiatedWork> loadWork(IdTask idTask, IdTravail idTravail);
   Future<StudentEvaluateTaskOut> evaluateExercice(
     IdTask idTask,
     IdTravail idTravail,
     EvaluateWorkIn ex,
   );
   Future<void> resetTask(IdTravail idTravail, IdTask idTask);
+  Future<StudentShowHintOut> showHint(
+    IdTask idTask,
+    IdTravail idTravail,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  );
 }
 
 class ServerHomeworkAPI implements HomeworkAPI {
@@ -65,11 +72,15 @@ class ServerHomeworkAPI implements HomeworkAPI {
   }
 
   @override
-  Future<InstantiatedWork> loadWork(IdTask idTask) async {
+  Future<InstantiatedWork> loadWork(IdTask idTask, IdTravail idTravail) async {
     const serverEndpoint = "/api/student/homework/task/instantiate";
     final uri = buildMode.serverURL(
       serverEndpoint,
-      query: {studentIDKey: studentID, "id": idTask.toString()},
+      query: {
+        studentIDKey: studentID,
+        "id": idTask.toString(),
+        "idTravail": idTravail.toString(),
+      },
     );
     final resp = await http.get(uri);
     return instantiatedWorkFromJson(checkServerError(resp.body));
@@ -110,6 +121,35 @@ class ServerHomeworkAPI implements HomeworkAPI {
     );
     checkServerError(resp.body);
   }
+
+  @override
+  Future<StudentShowHintOut> showHint(
+    IdTask idTask,
+    IdTravail idTravail,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    const serverEndpoint = "/api/student/homework/task/hint";
+    final uri = buildMode.serverURL(serverEndpoint);
+    final resp = await http.post(
+      uri,
+      body: jsonEncode(
+        studentShowHintInToJson(
+          StudentShowHintIn(
+            studentID,
+            idTravail,
+            idTask,
+            questionIndex,
+            params,
+            hintIndex,
+          ),
+        ),
+      ),
+      headers: {'Content-type': 'application/json'},
+    );
+    return studentShowHintOutFromJson(checkServerError(resp.body));
+  }
 }
 
 class HomeworkDisabled extends StatelessWidget {
@@ -287,7 +327,7 @@ class _SheetListViewState extends State<_SheetListView> {
     int nbSheets = 0;
     for (var sheet in sheets) {
       if (sheet.sheet.ignoreForMark) continue; // the student has a dispense
-      final ma = sheetMark(sheet.tasks);
+      final ma = sheetMark(sheet.tasks, sheet.tasksStatus);
       nbSheets += 1;
       total += 20.0 * ma.mark.toDouble() / ma.bareme; // convert to 20
     }
@@ -459,7 +499,7 @@ class _FreeSheetList extends StatefulWidget {
     int totalMark = 0;
     int totalBareme = 0;
     for (var sheet in group) {
-      final ma = sheetMark(sheet.tasks);
+      final ma = sheetMark(sheet.tasks, sheet.tasksStatus);
       totalMark += ma.mark;
       totalBareme += ma.bareme;
     }
@@ -574,7 +614,7 @@ class _SheetSummary extends StatelessWidget {
 
   @override
   Widget build(BuildContext context) {
-    final ma = sheetMark(sheet.tasks);
+    final ma = sheetMark(sheet.tasks, sheet.tasksStatus);
 
     final hasNotation = sheet.sheet.noted;
     final total = sheet.tasks.length;
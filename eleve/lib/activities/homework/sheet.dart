@@ -2,6 +2,7 @@ import 'package:eleve/activities/homework/exercice.dart';
 import 'package:eleve/activities/homework/homework.dart';
 import 'package:eleve/shared/errors.dart';
 import 'package:eleve/shared/title.dart';
+import 'package:eleve/types/src_maths_questions_client.dart';
 import 'package:eleve/types/src_prof_homework.dart';
 import 'package:eleve/types/src_sql_homework.dart';
 import 'package:eleve/types/src_sql_tasks.dart';
@@ -30,12 +31,25 @@ extension on TaskProgressionHeader {
   }
 }
 
-MarkBareme sheetMark(List<TaskProgressionHeader> tasks) {
+/// [taskStatus] returns the access status of the task at [index],
+/// defaulting to a mandatory, unlocked task for older servers
+TaskStatus taskStatus(List<TaskStatus> status, int index) {
+  return index < status.length
+      ? status[index]
+      : const TaskStatus(false, false);
+}
+
+/// [sheetMark] ignores the optional tasks
+MarkBareme sheetMark(
+  List<TaskProgressionHeader> tasks,
+  List<TaskStatus> status,
+) {
   int mark = 0;
   int bareme = 0;
-  for (var element in tasks) {
-    mark += element.mark;
-    bareme += element.bareme;
+  for (var i = 0; i < tasks.length; i++) {
+    if (taskStatus(status, i).optional) continue;
+    mark += tasks[i].mark;
+    bareme += tasks[i].bareme;
   }
   return MarkBareme(mark, bareme);
 }
@@ -52,6 +66,23 @@ class _ExerciceAPI implements ExerciceAPI {
     final res = await api.evaluateExercice(idTask, idTravail, params);
     return res.ex;
   }
+
+  @override
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    final res = await api.showHint(
+      idTask,
+      idTravail,
+      questionIndex,
+      params,
+      hintIndex,
+    );
+    return res.hint;
+  }
 }
 
 class TravailUpdated extends Notification {
@@ -71,10 +102,12 @@ class SheetHome extends StatefulWidget {
 
 class _SheetHomeState extends State<SheetHome> {
   List<TaskProgressionHeader> tasks = [];
+  List<TaskStatus> tasksStatus = [];
 
   @override
   void initState() {
     tasks = widget.sheet.tasks;
+    tasksStatus = widget.sheet.tasksStatus;
     super.initState();
   }
 
@@ -99,7 +132,10 @@ class _SheetHomeState extends State<SheetHome> {
         ),
       ),
     );
-    final instantiatedExercice = await widget.api.loadWork(task.id);
+    final instantiatedExercice = await widget.api.loadWork(
+      task.id,
+      widget.sheet.idTravail,
+    );
     if (!mounted) return;
 
     Navigator.of(context).pop(); // remove the dialog
@@ -111,6 +147,9 @@ class _SheetHomeState extends State<SheetHome> {
       task.progression,
       widget.sheet.sheet.questionRepeat,
       widget.sheet.sheet.questionTimeLimit,
+      hintPenalty: widget.sheet.sheet.noted
+          ? widget.sheet.sheet.hintPenalty
+          : 0,
     );
 
     // TODO: for now we always show a correction (when available)
@@ -140,6 +179,7 @@ class _SheetHomeState extends State<SheetHome> {
 
       setState(() {
         tasks = resp.tasks;
+        tasksStatus = resp.tasksStatus;
       });
       TravailUpdated(resp).dispatch(context);
     } catch (e) {
@@ -214,6 +254,7 @@ class _SheetHomeState extends State<SheetHome> {
             Expanded(
               child: _TaskList(
                 tasks,
+                tasksStatus,
                 hasNotation,
                 widget.sheet.sheet.questionRepeat,
                 (ex) => _startExercice(ex, isExpired),
@@ -229,6 +270,7 @@ class _SheetHomeState extends State<SheetHome> {
 
 class _TaskList extends StatelessWidget {
   final List<TaskProgressionHeader> tasks;
+  final List<TaskStatus> tasksStatus;
   final bool hasNotation;
   final QuestionRepeat questionRepeat;
   final void Function(TaskProgressionHeader) onStart;
@@ -236,6 +278,7 @@ class _TaskList extends StatelessWidget {
 
   const _TaskList(
     this.tasks,
+    this.tasksStatus,
     this.hasNotation,
     this.questionRepeat,
     this.onStart,
@@ -244,22 +287,22 @@ class _TaskList extends StatelessWidget {
 
   @override
   Widget build(BuildContext context) {
-    final children = tasks
-        .map(
-          (task) => Padding(
-            padding: const EdgeInsets.all(4.0),
-            child: _TaskTile(
-              task,
-              questionRepeat,
-              onStart: onStart,
-              onReset: onReset,
-              hasNotation: hasNotation,
-            ),
-          ),
-        )
-        .toList();
+    final children = List<Widget>.generate(
+      tasks.length,
+      (index) => Padding(
+        padding: const EdgeInsets.all(4.0),
+        child: _TaskTile(
+          tasks[index],
+          taskStatus(tasksStatus, index),
+          questionRepeat,
+          onStart: onStart,
+          onReset: onReset,
+          hasNotation: hasNotation,
+        ),
+      ),
+    );
 
-    final total = sheetMark(tasks);
+    final total = sheetMark(tasks, tasksStatus);
     return ListView(
       children: [
         ...children,
@@ -277,6 +320,7 @@ class _TaskList extends StatelessWidget {
 class _TaskTile extends StatelessWidget {
   const _TaskTile(
     this.task,
+    this.status,
     this.questionRepeat, {
     required this.onStart,
     required this.onReset,
@@ -284,11 +328,19 @@ class _TaskTile extends StatelessWidget {
   });
 
   final TaskProgressionHeader task;
+  final TaskStatus status;
   final QuestionRepeat questionRepeat;
   final void Function(TaskProgressionHeader p1) onStart;
   final void Function(TaskProgressionHeader p1) onReset;
   final bool hasNotation;
 
+  Widget? get _subtitle {
+    if (status.locked) {
+      return const Text("Termine d'abord les tâches précédentes.");
+    }
+    return task.chapter.isEmpty ? null : Text(task.chapter);
+  }
+
   @override
   Widget build(BuildContext context) {
     // retry is activated when the task is completed AND for unlimited tasks
@@ -305,11 +357,18 @@ class _TaskTile extends StatelessWidget {
         children: [
           ListTile(
             dense: true,
+            enabled: !status.locked,
             onTap: () => onStart(task),
-            leading: getCompletion(task).icon,
+            leading: status.locked
+                ? const Icon(Icons.lock, color: Colors.grey)
+                : getCompletion(task).icon,
             title: Text(task.title),
-            subtitle: task.chapter.isEmpty ? null : Text(task.chapter),
-            trailing: Text("${task.mark} / ${task.bareme}"),
+            subtitle: _subtitle,
+            trailing: Text(
+              status.optional
+                  ? "Bonus : ${task.mark} / ${task.bareme}"
+                  : "${task.mark} / ${task.bareme}",
+            ),
           ),
           // when the exercice is completed,
           // allow the student to do it again :
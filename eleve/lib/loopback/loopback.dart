@@ -109,6 +109,7 @@ class _EditorLoopbackState extends State<EditorLoopback> {
       ScaffoldMessenger.of(context).showSnackBar(snack);
       setState(() {
         ct.setFeedback(res.answers.results);
+        ct.controller.setItemFeedback(res.answers.itemResults);
       });
     } catch (e) {
       _showError(e);
@@ -321,6 +322,23 @@ class LoopbackServerAPI implements LoopbackAPI {
     return evaluateWorkOutFromJson(checkServerError(resp.body));
   }
 
+  @override
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    final uri = buildMode.serverURL("/api/exercices/hint");
+    final args = ShowWorkHintIn(id, questionIndex, params, hintIndex);
+    final resp = await http.post(
+      uri,
+      body: jsonEncode(showWorkHintInToJson(args)),
+      headers: {'Content-type': 'application/json'},
+    );
+    return enonceFromJson(checkServerError(resp.body));
+  }
+
   @override
   Future<LoopbackEvaluateCeintureOut> evaluateCeinture(
     LoopbackEvaluateCeintureIn params,
@@ -47,9 +47,12 @@ class LoopbackQuestionW extends StatefulWidget {
     final errorMessage = errors.length >= 2
         ? "${errors.length} champs sont incorrects."
         : "Un champ est incorrect.";
+    final hints = rep.hints.values.toList();
     return SnackBar(
       backgroundColor: isValid ? Colors.lightGreen : Colors.red.shade400,
-      content: Text(isValid ? "Bonne réponse" : errorMessage),
+      content: Text(isValid
+          ? "Bonne réponse"
+          : [errorMessage, ...hints].join("\n")),
       action: SnackBarAction(
           textColor: isValid ? Colors.black : Colors.white,
           label: "Afficher la correction",
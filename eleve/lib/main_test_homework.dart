@@ -46,27 +46,30 @@ final qu1 = numberQuestion("Test 1");
 final qu2 = numberQuestion("Test 2");
 final qu3 = numberQuestion("Test 3");
 
-final quI1 = InstantiatedQuestion(1, qu1, DifficultyTag.diff1, []);
-final quI2 = InstantiatedQuestion(2, qu2, DifficultyTag.diff2, []);
-final quI3 = InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, []);
+final quI1 = InstantiatedQuestion(1, qu1, DifficultyTag.diff1, [], 2);
+final quI2 = InstantiatedQuestion(2, qu2, DifficultyTag.diff2, [], 0);
+final quI3 = InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, [], 0);
 
 final quI1bis = InstantiatedQuestion(
   1,
   numberQuestion("Variante 1"),
   DifficultyTag.diff1,
   [],
+  0,
 );
 final quI2bis = InstantiatedQuestion(
   2,
   numberQuestion("Variante 2"),
   DifficultyTag.diff2,
   [],
+  0,
 );
 final quI3bis = InstantiatedQuestion(
   3,
   numberQuestion("Variante 3"),
   DifficultyTag.diffEmpty,
   [],
+  0,
 );
 
 const qu1Answer = {0: NumberAnswer(0)};
@@ -119,6 +122,7 @@ class _API implements HomeworkAPI {
         MatiereTag.mathematiques,
         QuestionRepeat.unlimited,
         0,
+        1,
       ),
       [
         const TaskProgressionHeader(
@@ -146,6 +150,7 @@ class _API implements HomeworkAPI {
           5,
         ),
       ],
+      [const TaskStatus(false, false), const TaskStatus(false, true)],
     ),
     SheetProgression(
       2,
@@ -158,6 +163,7 @@ class _API implements HomeworkAPI {
         MatiereTag.mathematiques,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(
@@ -171,6 +177,7 @@ class _API implements HomeworkAPI {
         ),
         const TaskProgressionHeader(2, "Ex 2", "Entiers", false, [], 3, 5),
       ],
+      [],
     ),
     SheetProgression(
       3,
@@ -183,6 +190,7 @@ class _API implements HomeworkAPI {
         MatiereTag.allemand,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(
@@ -196,6 +204,7 @@ class _API implements HomeworkAPI {
         ),
         const TaskProgressionHeader(2, "Ex 2", "", false, [], 0, 5),
       ],
+      [],
     ),
     SheetProgression(
       4,
@@ -208,6 +217,7 @@ class _API implements HomeworkAPI {
         MatiereTag.histoireGeo,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(
@@ -223,6 +233,7 @@ class _API implements HomeworkAPI {
         ),
         const TaskProgressionHeader(2, "Ex 2", "", false, [], 4, 5),
       ],
+      [],
     ),
     SheetProgression(
       5,
@@ -235,11 +246,13 @@ class _API implements HomeworkAPI {
         MatiereTag.histoireGeo,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(1, "Ex 1", "", false, [], 0, 6),
         const TaskProgressionHeader(2, "Ex 2", "", false, [], 0, 5),
       ],
+      [],
     ),
     SheetProgression(
       6,
@@ -252,11 +265,13 @@ class _API implements HomeworkAPI {
         MatiereTag.histoireGeo,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(1, "Ex 1", "", false, [], 0, 6),
         const TaskProgressionHeader(2, "Ex 2", "", false, [], 0, 5),
       ],
+      [],
     ),
     SheetProgression(
       7,
@@ -269,11 +284,13 @@ class _API implements HomeworkAPI {
         MatiereTag.histoireGeo,
         QuestionRepeat.unlimited,
         0,
+        0,
       ),
       [
         const TaskProgressionHeader(1, "Ex 1", "", false, [], 0, 6),
         const TaskProgressionHeader(2, "Ex 2", "", false, [], 0, 5),
       ],
+      [],
     ),
   ];
 
@@ -314,7 +331,7 @@ class _API implements HomeworkAPI {
           ),
           [quI1bis, quI2bis, quI3bis],
           questionIndex,
-          QuestionAnswersOut({0: isCorrect}, {}),
+          QuestionAnswersOut({0: isCorrect}, {}, {}, {}),
         ),
         isCorrect ? 2 : 0,
         false,
@@ -324,7 +341,21 @@ class _API implements HomeworkAPI {
   }
 
   @override
-  Future<InstantiatedWork> loadWork(IdTask id) async {
+  Future<StudentShowHintOut> showHint(
+    IdTask idTask,
+    IdTravail idTravail,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    await Future<void>.delayed(const Duration(milliseconds: 200));
+    return StudentShowHintOut([
+      TextBlock([T("Indice ${hintIndex + 1}")], false, false, false),
+    ], true);
+  }
+
+  @override
+  Future<InstantiatedWork> loadWork(IdTask id, IdTravail idTravail) async {
     return InstantiatedWork(
       WorkID(0, WorkKind.workExercice, false),
       "Exo de Test",
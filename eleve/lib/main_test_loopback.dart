@@ -127,9 +127,9 @@ class _LoopbackTestApp extends StatelessWidget {
     return _showRoute(
       LoopbackShowCeinture(
         [
-          InstantiatedBeltQuestion(1, qu1, []),
-          InstantiatedBeltQuestion(2, qu1, []),
-          InstantiatedBeltQuestion(3, qu1, []),
+          InstantiatedBeltQuestion(1, qu1, [], 0),
+          InstantiatedBeltQuestion(2, qu1, [], 0),
+          InstantiatedBeltQuestion(3, qu1, [], 0),
         ],
         0,
         [origin, origin, origin],
@@ -173,7 +173,7 @@ class _LoopbackAPI implements LoopbackAPI {
       ),
       [quI1bis, quI2bis, quI3bis],
       questionIndex,
-      QuestionAnswersOut({0: isCorrect}, {}),
+      QuestionAnswersOut({0: isCorrect}, {}, {}, {}),
     );
   }
 
@@ -187,7 +187,7 @@ class _LoopbackAPI implements LoopbackAPI {
     return LoopbackEvaluateQuestionOut(
       QuestionAnswersOut({
         0: (data.data[0] as NumberAnswer).value == qu1Answer[0]!.value,
-      }, qu1Answer),
+      }, qu1Answer, {}, {}),
     );
   }
 
@@ -208,8 +208,20 @@ class _LoopbackAPI implements LoopbackAPI {
     return LoopbackEvaluateCeintureOut(
       args.answers.map((an) {
         final isCorrect = 0 == (an.answer.data[0] as NumberAnswer).value;
-        return QuestionAnswersOut({0: isCorrect}, {});
+        return QuestionAnswersOut({0: isCorrect}, {}, {}, {});
       }).toList(),
     );
   }
+
+  @override
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    return [
+      TextBlock([T("Indice ${hintIndex + 1}")], false, false, false),
+    ];
+  }
 }
@@ -123,27 +123,30 @@ final qu1 = numberQuestion("Test 1");
 final qu2 = numberQuestion("Test 2", withCorrection: false);
 final qu3 = numberQuestion("Test 3");
 
-final quI1 = InstantiatedQuestion(1, qu1, DifficultyTag.diff1, []);
-final quI2 = InstantiatedQuestion(2, qu2, DifficultyTag.diff2, []);
-final quI3 = InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, []);
+final quI1 = InstantiatedQuestion(1, qu1, DifficultyTag.diff1, [], 0);
+final quI2 = InstantiatedQuestion(2, qu2, DifficultyTag.diff2, [], 0);
+final quI3 = InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, [], 0);
 
 final quI1bis = InstantiatedQuestion(
   1,
   numberQuestion("Variante 1"),
   DifficultyTag.diff3,
   [],
+  0,
 );
 final quI2bis = InstantiatedQuestion(
   2,
   numberQuestion("Variante 2"),
   DifficultyTag.diff2,
   [],
+  0,
 );
 final quI3bis = InstantiatedQuestion(
   3,
   numberQuestion("Variante 3"),
   DifficultyTag.diff2,
   [],
+  0,
 );
 
 const qu1Answer = {0: NumberAnswer(0), 1: NumberAnswer(0)};
@@ -375,9 +378,9 @@ class _DecrassageAPI implements DecrassageAPI {
   Future<InstantiatedQuestionsOut> loadQuestions(List<int> ids) async {
     await Future<void>.delayed(const Duration(seconds: 1));
     return [
-      InstantiatedQuestion(1, qu1, DifficultyTag.diff1, []),
-      InstantiatedQuestion(2, qu2, DifficultyTag.diff2, []),
-      InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, []),
+      InstantiatedQuestion(1, qu1, DifficultyTag.diff1, [], 0),
+      InstantiatedQuestion(2, qu2, DifficultyTag.diff2, [], 0),
+      InstantiatedQuestion(3, qu3, DifficultyTag.diffEmpty, [], 0),
     ];
   }
 
@@ -390,6 +393,8 @@ class _DecrassageAPI implements DecrassageAPI {
             answer.idQuestion.toDouble(),
       },
       {0: NumberAnswer(answer.idQuestion.toDouble())},
+      {},
+      {},
     );
   }
 }
@@ -448,7 +453,7 @@ class _LoopbackQuestionState extends State<_LoopbackQuestion> {
     const rep = {0: true, 1: false, 2: true, 3: true};
 
     final snack = LoopbackQuestionW.serverValidation(
-      const QuestionAnswersOut(rep, {}),
+      const QuestionAnswersOut(rep, {}, {}, {}),
       () {},
     );
     ScaffoldMessenger.of(context).showSnackBar(snack);
@@ -494,9 +499,21 @@ class _ExerciceSequentialAPI implements ExerciceAPI {
       ProgressionExt(params.progression, nextQuestion),
       [quI1bis, quI2bis, quI3bis],
       questionIndex,
-      QuestionAnswersOut({0: isCorrect}, {}),
+      QuestionAnswersOut({0: isCorrect}, {}, {}, {}),
     );
   }
+
+  @override
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    return [
+      TextBlock([T("Indice ${hintIndex + 1}")], false, false, false),
+    ];
+  }
 }
 
 class _ExerciceSequential extends StatelessWidget {
@@ -574,9 +591,21 @@ class _ExerciceParallelAPI implements ExerciceAPI {
       ),
       [quI1bis, quI2bis, quI3bis],
       questionIndex,
-      QuestionAnswersOut({0: isCorrect}, {}),
+      QuestionAnswersOut({0: isCorrect}, {}, {}, {}),
     );
   }
+
+  @override
+  Future<Enonce> showHint(
+    WorkID id,
+    int questionIndex,
+    Params params,
+    int hintIndex,
+  ) async {
+    return [
+      TextBlock([T("Indice ${hintIndex + 1}")], false, false, false),
+    ];
+  }
 }
 
 class _ExerciceParallel extends StatelessWidget {
@@ -0,0 +1,108 @@
+import 'package:eleve/questions/fields.dart';
+import 'package:eleve/types/src_maths_questions_client.dart';
+import 'package:flutter/material.dart';
+
+/// [CategoriesController] stores the category
+/// chosen for each item.
+class CategoriesController extends FieldController {
+  final CategoriesFieldBlock data;
+  final List<int?> categories;
+
+  /// [itemResults] is true for the items put in the right category,
+  /// or null if no feedback is displayed
+  List<bool>? itemResults;
+
+  CategoriesController(super.onChange, this.data)
+      : categories = List<int?>.filled(data.items.length, null);
+
+  @override
+  void setItemResults(List<bool>? results) {
+    itemResults = results;
+  }
+
+  /// [isItemWrong] returns true if [item] is marked as wrong
+  bool isItemWrong(int item) {
+    final results = itemResults;
+    return results != null && item < results.length && !results[item];
+  }
+
+  void setCategory(int item, int? category) {
+    categories[item] = category;
+    onChange();
+  }
+
+  @override
+  bool hasValidData() => categories.every((category) => category != null);
+
+  @override
+  Answer getData() => CategoriesAnswer(categories.map((e) => e!).toList());
+
+  @override
+  void setData(Answer answer) {
+    final ans = (answer as CategoriesAnswer).categories;
+    for (var i = 0; i < categories.length && i < ans.length; i++) {
+      categories[i] = ans[i];
+    }
+    onChange();
+  }
+}
+
+class CategoriesFieldW extends StatefulWidget {
+  final Color color;
+  final CategoriesController controller;
+
+  const CategoriesFieldW(this.color, this.controller, {super.key});
+
+  @override
+  State<CategoriesFieldW> createState() => _CategoriesFieldWState();
+}
+
+class _CategoriesFieldWState extends State<CategoriesFieldW> {
+  static const fontSize = 16.0;
+
+  @override
+  Widget build(BuildContext context) {
+    final ct = widget.controller;
+    final color = ct.hasError ? Colors.red : widget.color;
+    return Container(
+      padding: const EdgeInsets.all(6),
+      decoration: BoxDecoration(
+        border: Border.all(color: color),
+        borderRadius: BorderRadius.circular(5),
+      ),
+      child: Column(
+        children: List.generate(
+          ct.data.items.length,
+          (item) => Padding(
+            padding: const EdgeInsets.symmetric(vertical: 4),
+            child: Row(
+              children: [
+                Expanded(
+                    child: TextRow(
+                        buildText(ct.data.items[item], TextS(), fontSize))),
+                Wrap(
+                  spacing: 4,
+                  children: List.generate(
+                    ct.data.categories.length,
+                    (category) => ChoiceChip(
+                      selectedColor: ct.isItemWrong(item) ? Colors.red : color,
+                      label: TextRow(buildText(
+                          ct.data.categories[category], TextS(), 14)),
+                      selected: ct.categories[item] == category,
+                      onSelected: ct.isEnabled
+                          ? (selected) => setState(() {
+                                ct.setCategory(
+                                    item, selected ? category : null);
+                              })
+                          : null,
+                    ),
+                  ),
+                ),
+              ],
+            ),
+          ),
+        ),
+      ),
+    );
+  }
+}
@@ -39,7 +39,7 @@ FunctionGraph function({String? label, Color? color}) {
 
 final questionList = [
   const InstantiatedQuestion(
-      0, Question([NumberFieldBlock(0, 10)], []), DifficultyTag.diff1, []),
+      0, Question([NumberFieldBlock(0, 10)], []), DifficultyTag.diff1, [], 0),
   InstantiatedQuestion(
       0,
       Question([
@@ -56,9 +56,10 @@ final questionList = [
         ], 1)
       ], []),
       DifficultyTag.diffEmpty,
-      []),
+      [],
+      0),
   const InstantiatedQuestion(
-      0, Question([NumberFieldBlock(0, 10)], []), DifficultyTag.diff3, []),
+      0, Question([NumberFieldBlock(0, 10)], []), DifficultyTag.diff3, [], 0),
 ];
 
 final proofB = proofFieldBlockFromJson(jsonDecode("""
@@ -17,6 +17,11 @@ abstract class FieldController {
     _hasError = hasError;
   }
 
+  /// [setItemResults] is called with the result of each item,
+  /// for fields made of several items, or [null] to remove the
+  /// feedback. The default implementation ignores it.
+  void setItemResults(List<bool>? results) {}
+
   bool _isEnabled = true;
 
   /// [isEnabled] is true if the field is actionnable.
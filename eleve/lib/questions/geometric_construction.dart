@@ -1,3 +1,5 @@
+import 'dart:math';
+
 import 'package:eleve/questions/fields.dart';
 import 'package:eleve/questions/function_graph.dart';
 import 'package:eleve/questions/repere.dart';
@@ -80,6 +82,10 @@ extension on IntCoord {
   bool equals(IntCoord other) {
     return x == other.x && y == other.y;
   }
+
+  Coord toCoord() {
+    return Coord(x.toDouble(), y.toDouble());
+  }
 }
 
 class _VectorPairController extends GeometricConstructionController {
@@ -140,6 +146,61 @@ class _VectorPairController extends GeometricConstructionController {
   }
 }
 
+enum AnglePointID { vertex, a, b }
+
+/// [_AngleController] is used for angles, built with two
+/// half-lines [vertex, a) and [vertex, b)
+class _AngleController extends GeometricConstructionController {
+  IntCoord vertex;
+  IntCoord a;
+  IntCoord b;
+  bool _hasData = false;
+
+  _AngleController(void Function() onChange, RepereBounds bounds)
+      : vertex = IntCoord(bounds.width ~/ 4 - 2, bounds.height ~/ 4),
+        a = IntCoord(bounds.width ~/ 4 + 2, bounds.height ~/ 4),
+        b = IntCoord(bounds.width ~/ 4, bounds.height ~/ 4 + 2),
+        super(onChange);
+
+  void setPoint(IntCoord point, AnglePointID id) {
+    switch (id) {
+      case AnglePointID.vertex:
+        vertex = point;
+        break;
+      case AnglePointID.a:
+        a = point;
+        break;
+      case AnglePointID.b:
+        b = point;
+        break;
+    }
+    _hasData = true;
+    onChange();
+  }
+
+  @override
+  bool hasValidData() {
+    if (a.equals(vertex) || b.equals(vertex)) {
+      return false;
+    }
+    return _hasData;
+  }
+
+  @override
+  Answer getData() {
+    return DoublePointPairAnswer(vertex, a, vertex, b);
+  }
+
+  @override
+  void setData(Answer answer) {
+    final ans = (answer as DoublePointPairAnswer);
+    vertex = ans.from1;
+    a = ans.to1;
+    b = ans.to2;
+    _hasData = true;
+  }
+}
+
 extension on GeometricConstructionFieldBlock {
   RepereBounds bounds() {
     final bg = background;
@@ -160,10 +221,14 @@ abstract class GeometricConstructionController extends FieldController {
       void Function() onChange, GeometricConstructionFieldBlock block) {
     if (block.field is GFPoint) {
       return _PointController(onChange);
-    } else if (block.field is GFVector) {
+    } else if (block.field is GFVector ||
+        block.field is GFCircle ||
+        block.field is GFSegment) {
       return _VectorController(onChange, block.bounds());
     } else if (block.field is GFVectorPair) {
       return _VectorPairController(onChange, block.bounds());
+    } else if (block.field is GFAngle) {
+      return _AngleController(onChange, block.bounds());
     } else {
       throw "unsupported field type";
     }
@@ -273,11 +338,25 @@ class _GeometricConstructionFieldWState
       );
       //
     } else if (ct is _VectorController) {
-      final data = (widget.data.field as GFVector);
+      final data = widget.data.field;
       final from = ct.from;
       final to = ct.to;
       final CustomPainter linePainter;
-      if (data.asLine) {
+      if (data is GFCircle) {
+        linePainter = _CirclePainter(metrics, from, to, color: color);
+      } else if (data is GFSegment) {
+        linePainter = _SegmentPainter(
+            metrics.logicalIntToVisual(from), metrics.logicalIntToVisual(to),
+            color: color);
+        texts.addAll([
+          if (data.labelFrom.isNotEmpty)
+            PositionnedText(
+                data.labelFrom, PosPoint(from.toCoord(), LabelPos.topLeft)),
+          if (data.labelTo.isNotEmpty)
+            PositionnedText(
+                data.labelTo, PosPoint(to.toCoord(), LabelPos.topRight)),
+        ]);
+      } else if (data is GFVector && data.asLine) {
         linePainter = _AffineLinePainter(
           metrics,
           from,
@@ -382,6 +461,41 @@ class _GeometricConstructionFieldWState
           color: color,
         ),
       );
+    } else if (ct is _AngleController) {
+      final vertex = metrics.logicalIntToVisual(ct.vertex);
+      return NotificationListener<PointMovedNotification<AnglePointID>>(
+        onNotification: (event) {
+          setState(() {
+            ct.setPoint(event.logicalPos, event.id);
+          });
+          return true;
+        },
+        child: BaseRepere<AnglePointID>(
+          metrics,
+          showGrid,
+          showOrigin,
+          [
+            backgroundPaint,
+            CustomPaint(
+              size: metrics.size,
+              painter: _AnglePainter(vertex, metrics.logicalIntToVisual(ct.a),
+                  metrics.logicalIntToVisual(ct.b),
+                  color: color),
+            ),
+            DraggableGridPoint(ct.vertex, vertex, AnglePointID.vertex,
+                zoomFactor,
+                disabled: !ct.isEnabled, color: color),
+            DraggableGridPoint(ct.a, metrics.logicalIntToVisual(ct.a),
+                AnglePointID.a, zoomFactor,
+                disabled: !ct.isEnabled, color: color),
+            DraggableGridPoint(ct.b, metrics.logicalIntToVisual(ct.b),
+                AnglePointID.b, zoomFactor,
+                disabled: !ct.isEnabled, color: color),
+          ],
+          texts,
+          color: color,
+        ),
+      );
     } else {
       throw "unsupported field type";
     }
@@ -433,3 +547,128 @@ class _AffineLinePainter extends CustomPainter {
         !to.equals(oldDelegate.to);
   }
 }
+
+
+class _CirclePainter extends CustomPainter {
+  final RepereMetrics metrics;
+  final IntCoord center;
+  final IntCoord point;
+  final Color color;
+
+  _CirclePainter(this.metrics, this.center, this.point, {Color? color})
+      : color = color ?? Colors.teal;
+
+  @override
+  void paint(Canvas canvas, Size size) {
+    final c = metrics.logicalIntToVisual(center);
+    final radius = (metrics.logicalIntToVisual(point) - c).distance;
+    canvas.drawCircle(
+        c,
+        radius,
+        Paint()
+          ..style = PaintingStyle.stroke
+          ..color = color
+          ..strokeWidth = 1.5);
+  }
+
+  @override
+  bool shouldRepaint(covariant _CirclePainter oldDelegate) {
+    return metrics != oldDelegate.metrics ||
+        !center.equals(oldDelegate.center) ||
+        !point.equals(oldDelegate.point);
+  }
+
+  @override
+  bool? hitTest(Offset position) {
+    return false;
+  }
+}
+
+class _SegmentPainter extends CustomPainter {
+  final Offset from;
+  final Offset to;
+  final Color color;
+
+  _SegmentPainter(this.from, this.to, {Color? color})
+      : color = color ?? Colors.teal;
+
+  @override
+  void paint(Canvas canvas, Size size) {
+    canvas.drawLine(
+        from,
+        to,
+        Paint()
+          ..color = color
+          ..strokeWidth = 1.5);
+  }
+
+  @override
+  bool shouldRepaint(covariant _SegmentPainter oldDelegate) {
+    return from != oldDelegate.from || to != oldDelegate.to;
+  }
+
+  @override
+  bool? hitTest(Offset position) {
+    return false;
+  }
+}
+
+/// [_AnglePainter] draws the two half-lines [vertex, a) and [vertex, b),
+/// and an arc marking the angle.
+class _AnglePainter extends CustomPainter {
+  final Offset vertex;
+  final Offset a;
+  final Offset b;
+  final Color color;
+
+  _AnglePainter(this.vertex, this.a, this.b, {Color? color})
+      : color = color ?? Colors.teal;
+
+  /// returns a point far away on the half-line [vertex, p)
+  Offset _farPoint(Offset p, Size size) {
+    final dir = p - vertex;
+    if (dir.distance == 0) {
+      return p;
+    }
+    final scale = (size.width + size.height) / dir.distance;
+    return vertex + dir * scale;
+  }
+
+  @override
+  void paint(Canvas canvas, Size size) {
+    final paint = Paint()
+      ..color = color
+      ..strokeWidth = 1.5;
+    canvas.save();
+    canvas.clipRect(Offset.zero & size);
+    canvas.drawLine(vertex, _farPoint(a, size), paint);
+    canvas.drawLine(vertex, _farPoint(b, size), paint);
+    canvas.restore();
+
+    if (a == vertex || b == vertex) {
+      return;
+    }
+    final startAngle = (a - vertex).direction;
+    var sweepAngle = (b - vertex).direction - startAngle;
+    // always draw the geometric angle, between 0 and pi
+    if (sweepAngle > pi) {
+      sweepAngle -= 2 * pi;
+    } else if (sweepAngle < -pi) {
+      sweepAngle += 2 * pi;
+    }
+    canvas.drawArc(Rect.fromCircle(center: vertex, radius: 20), startAngle,
+        sweepAngle, false, paint..style = PaintingStyle.stroke);
+  }
+
+  @override
+  bool shouldRepaint(covariant _AnglePainter oldDelegate) {
+    return vertex != oldDelegate.vertex ||
+        a != oldDelegate.a ||
+        b != oldDelegate.b;
+  }
+
+  @override
+  bool? hitTest(Offset position) {
+    return false;
+  }
+}
@@ -0,0 +1,132 @@
+import 'package:eleve/questions/fields.dart';
+import 'package:eleve/types/src_maths_questions_client.dart';
+import 'package:flutter/material.dart';
+
+/// [MatchingController] stores, for each left item, the
+/// index of the selected right item.
+class MatchingController extends FieldController {
+  final MatchingFieldBlock data;
+  final List<int?> indices;
+
+  /// [itemResults] is the result of each association,
+  /// or null if no feedback is displayed
+  List<bool>? itemResults;
+
+  MatchingController(super.onChange, this.data)
+      : indices = List<int?>.filled(data.left.length, null);
+
+  @override
+  void setItemResults(List<bool>? results) {
+    itemResults = results;
+  }
+
+  /// [isItemWrong] returns true if the association
+  /// of the left item [index] is marked as wrong
+  bool isItemWrong(int index) {
+    final results = itemResults;
+    return results != null && index < results.length && !results[index];
+  }
+
+  void setIndex(int leftIndex, int? rightIndex) {
+    indices[leftIndex] = rightIndex;
+    onChange();
+  }
+
+  @override
+  bool hasValidData() => indices.every((index) => index != null);
+
+  @override
+  Answer getData() => MatchingAnswer(indices.map((e) => e!).toList());
+
+  @override
+  void setData(Answer answer) {
+    final ans = (answer as MatchingAnswer).indices;
+    for (var i = 0; i < indices.length && i < ans.length; i++) {
+      indices[i] = ans[i];
+    }
+    onChange();
+  }
+}
+
+class MatchingFieldW extends StatefulWidget {
+  final Color color;
+  final MatchingController controller;
+
+  const MatchingFieldW(this.color, this.controller, {super.key});
+
+  @override
+  State<MatchingFieldW> createState() => _MatchingFieldWState();
+}
+
+class _MatchingFieldWState extends State<MatchingFieldW> {
+  static const fontSize = 16.0;
+
+  Widget _rightChoice(int leftIndex) {
+    final ct = widget.controller;
+    final right = ct.data.right;
+    return DropdownButton<int>(
+      isDense: true,
+      focusColor: widget.color,
+      dropdownColor: widget.color,
+      hint: const Text("Choisir"),
+      value: ct.indices[leftIndex],
+      alignment: Alignment.center,
+      selectedItemBuilder: (_) => List.generate(
+        right.length,
+        (index) => Padding(
+          padding: const EdgeInsets.symmetric(horizontal: 5.0),
+          child: TextRow(buildText(right[index], TextS(), fontSize)),
+        ),
+      ),
+      items: List.generate(
+          right.length,
+          (index) => DropdownMenuItem<int>(
+                value: index,
+                child: Padding(
+                  padding: const EdgeInsets.symmetric(horizontal: 3),
+                  child: TextRow(buildText(right[index], TextS(), fontSize),
+                      verticalPadding: 1),
+                ),
+              )),
+      onChanged: ct.isEnabled
+          ? (v) => setState(() {
+                ct.setIndex(leftIndex, v);
+              })
+          : null,
+    );
+  }
+
+  @override
+  Widget build(BuildContext context) {
+    final ct = widget.controller;
+    final color = ct.hasError ? Colors.red : widget.color;
+    return Container(
+      padding: const EdgeInsets.all(6),
+      decoration: BoxDecoration(
+        border: Border.all(color: color),
+        borderRadius: BorderRadius.circular(5),
+      ),
+      child: Table(
+        defaultVerticalAlignment: TableCellVerticalAlignment.middle,
+        columnWidths: const {
+          0: FlexColumnWidth(),
+          1: IntrinsicColumnWidth(),
+          2: FlexColumnWidth(),
+        },
+        children: List.generate(
+          ct.data.left.length,
+          (index) => TableRow(children: [
+            TextRow(buildText(ct.data.left[index], TextS(), fontSize),
+                verticalPadding: 4),
+            Padding(
+              padding: const EdgeInsets.symmetric(horizontal: 8),
+              child: Icon(Icons.arrow_forward,
+                  color: ct.isItemWrong(index) ? Colors.red : color),
+            ),
+            Center(child: _rightChoice(index)),
+          ]),
+        ),
+      ),
+    );
+  }
+}
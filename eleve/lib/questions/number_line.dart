@@ -0,0 +1,170 @@
+import 'package:eleve/questions/fields.dart';
+import 'package:eleve/types/src_maths_questions_client.dart';
+import 'package:flutter/material.dart';
+
+/// [NumberLineController] stores the membership of
+/// each piece (segments and points) of the line.
+class NumberLineController extends FieldController {
+  final NumberLineFieldBlock data;
+  final List<bool> pieces;
+
+  NumberLineController(super.onChange, this.data)
+      : pieces = List<bool>.filled(2 * data.ticks.length + 1, false);
+
+  void togglePiece(int piece) {
+    pieces[piece] = !pieces[piece];
+    onChange();
+  }
+
+  /// the student must at least select one piece
+  @override
+  bool hasValidData() => pieces.any((p) => p);
+
+  @override
+  Answer getData() => NumberLineAnswer(pieces.toList());
+
+  @override
+  void setData(Answer answer) {
+    final ans = (answer as NumberLineAnswer).pieces;
+    for (var i = 0; i < pieces.length && i < ans.length; i++) {
+      pieces[i] = ans[i];
+    }
+    onChange();
+  }
+}
+
+/// [NumberLineW] draws the [ticks] on a line, highlighting the
+/// selected [pieces] : the piece 2k is the segment before the tick k,
+/// the piece 2k+1 is the tick k itself.
+/// If [onTap] is not null, the pieces are selectable.
+class NumberLineW extends StatelessWidget {
+  final List<String> ticks;
+  final List<bool> pieces;
+  final Color color;
+  final void Function(int piece)? onTap;
+
+  const NumberLineW(this.ticks, this.pieces, this.color,
+      {this.onTap, super.key});
+
+  static const _tickWidth = 40.0;
+  static const _fontSize = 14.0;
+
+  Widget _segment(int piece) {
+    final isIn = pieces[piece];
+    final isLast = piece == pieces.length - 1;
+    return Expanded(
+      child: GestureDetector(
+        onTap: onTap == null ? null : () => onTap!(piece),
+        child: Container(
+          height: 40,
+          color: Colors.transparent,
+          alignment: Alignment.center,
+          child: Row(
+            children: [
+              Expanded(
+                child: Container(
+                  height: isIn ? 6 : 2,
+                  color: isIn ? color.withValues(alpha: 0.6) : Colors.grey,
+                ),
+              ),
+              if (isLast) const Icon(Icons.arrow_forward_ios, size: 12),
+            ],
+          ),
+        ),
+      ),
+    );
+  }
+
+  /// returns the symbol displayed at the tick [k],
+  /// using the french brackets notation
+  String _symbol(int k) {
+    final isIn = pieces[2 * k + 1];
+    final before = pieces[2 * k];
+    final after = pieces[2 * k + 2];
+    if (before && after) return isIn ? "" : "○";
+    if (after) return isIn ? "[" : "]"; // start of an interval
+    if (before) return isIn ? "]" : "["; // end of an interval
+    return isIn ? "●" : "";
+  }
+
+  Widget _tick(int k) {
+    final piece = 2 * k + 1;
+    return GestureDetector(
+      onTap: onTap == null ? null : () => onTap!(piece),
+      child: SizedBox(
+        width: _tickWidth,
+        child: Column(
+          children: [
+            SizedBox(
+              height: 40,
+              child: Stack(
+                alignment: Alignment.center,
+                children: [
+                  Container(width: 2, height: 16, color: Colors.black),
+                  Text(
+                    _symbol(k),
+                    style: TextStyle(
+                        color: color,
+                        fontSize: 26,
+                        fontWeight: FontWeight.bold),
+                  ),
+                ],
+              ),
+            ),
+            textMath(ticks[k], const TextStyle(fontSize: _fontSize)),
+          ],
+        ),
+      ),
+    );
+  }
+
+  @override
+  Widget build(BuildContext context) {
+    final children = <Widget>[_segment(0)];
+    for (var k = 0; k < ticks.length; k++) {
+      children.add(_tick(k));
+      children.add(_segment(2 * k + 2));
+    }
+    return Padding(
+      padding: const EdgeInsets.symmetric(vertical: 8, horizontal: 4),
+      child: Row(
+        crossAxisAlignment: CrossAxisAlignment.start,
+        children: children,
+      ),
+    );
+  }
+}
+
+class NumberLineFieldW extends StatefulWidget {
+  final Color color;
+  final NumberLineController controller;
+
+  const NumberLineFieldW(this.color, this.controller, {super.key});
+
+  @override
+  State<NumberLineFieldW> createState() => _NumberLineFieldWState();
+}
+
+class _NumberLineFieldWState extends State<NumberLineFieldW> {
+  @override
+  Widget build(BuildContext context) {
+    final ct = widget.controller;
+    final color = ct.hasError ? Colors.red : widget.color;
+    return Column(
+      children: [
+        const Text(
+          "Toucher les segments et les graduations pour les sélectionner.",
+          style: TextStyle(fontStyle: FontStyle.italic, fontSize: 12),
+        ),
+        NumberLineW(
+          ct.data.ticks,
+          ct.pieces,
+          color,
+          onTap: ct.isEnabled
+              ? (piece) => setState(() => ct.togglePiece(piece))
+              : null,
+        ),
+      ],
+    );
+  }
+}
@@ -1,5 +1,6 @@
 import 'dart:async';
 
+import 'package:eleve/questions/categories.dart';
 import 'package:eleve/questions/dropdown.dart';
 import 'package:eleve/questions/expression.dart';
 import 'package:eleve/questions/fields.dart';
@@ -7,7 +8,9 @@ import 'package:eleve/questions/function_graph.dart';
 import 'package:eleve/questions/function_points.dart';
 import 'package:eleve/questions/geometric_construction.dart';
 import 'package:eleve/questions/image.dart';
+import 'package:eleve/questions/matching.dart';
 import 'package:eleve/questions/number.dart';
+import 'package:eleve/questions/number_line.dart';
 import 'package:eleve/questions/ordered_list.dart';
 import 'package:eleve/questions/probas_tree.dart';
 import 'package:eleve/questions/proof.dart';
@@ -17,6 +20,7 @@ import 'package:eleve/questions/sets.dart';
 import 'package:eleve/questions/sign_table.dart';
 import 'package:eleve/questions/sign_table_field.dart';
 import 'package:eleve/questions/table.dart';
+import 'package:eleve/questions/text_field.dart';
 import 'package:eleve/questions/variation_table.dart';
 import 'package:eleve/questions/variation_table_field.dart';
 import 'package:eleve/questions/vector.dart';
@@ -64,6 +68,14 @@ Map<int, FieldController> _createFieldControllers(
       fields[block.iD] = ProofController(block, onChange);
     } else if (block is SetFieldBlock) {
       fields[block.iD] = SetController(onChange, block.sets);
+    } else if (block is TextFieldBlock) {
+      fields[block.iD] = TextController(onChange);
+    } else if (block is MatchingFieldBlock) {
+      fields[block.iD] = MatchingController(onChange, block);
+    } else if (block is CategoriesFieldBlock) {
+      fields[block.iD] = CategoriesController(onChange, block);
+    } else if (block is NumberLineFieldBlock) {
+      fields[block.iD] = NumberLineController(onChange, block);
     }
   }
   return fields;
@@ -135,9 +147,19 @@ class QuestionController extends ChangeNotifier {
   void setFeedback(QuestionFeedback? feedback) {
     fields.forEach((key, field) =>
         field.setError(feedback == null ? false : !(feedback[key] ?? false)));
+    if (feedback == null) {
+      setItemFeedback(null);
+    }
     setFieldsEnabled(feedback == null);
   }
 
+  /// [setItemFeedback] shows which items are wrong, for
+  /// the fields made of several items. If [itemResults] is null,
+  /// it removes the item indicators.
+  void setItemFeedback(Map<int, List<bool>>? itemResults) {
+    fields.forEach((key, field) => field.setItemResults(itemResults?[key]));
+  }
+
   /// [setFieldsEnabled] set the enabled property for all the question fields
   void setFieldsEnabled(bool enabled) {
     for (var field in fields.values) {
@@ -262,6 +284,13 @@ class _FieldsBuilder {
     rows.add(Center(child: ImageW(element)));
   }
 
+  void _handleNumberLineBlock(NumberLineBlock element) {
+    // start a new row
+    _flushCurrentRow();
+
+    rows.add(NumberLineW(element.ticks, element.pieces, _color));
+  }
+
   void _handleNumberFieldBlock(NumberFieldBlock element) {
     final ct = fields[element.iD] as NumberController;
     _currentRow.add(WidgetSpan(
@@ -433,6 +462,39 @@ class _FieldsBuilder {
     rows.add(SetFieldW(_color, ct));
   }
 
+  void _handleTextFieldBlock(TextFieldBlock element) {
+    final ct = fields[element.iD] as TextController;
+    _currentRow.add(WidgetSpan(
+        child: TextFieldW(_color, ct, sizeHint: element.sizeHint)));
+  }
+
+  void _handleMatchingFieldBlock(MatchingFieldBlock element) {
+    final ct = fields[element.iD] as MatchingController;
+
+    // start a new line
+    _flushCurrentRow();
+
+    rows.add(MatchingFieldW(_color, ct));
+  }
+
+  void _handleCategoriesFieldBlock(CategoriesFieldBlock element) {
+    final ct = fields[element.iD] as CategoriesController;
+
+    // start a new line
+    _flushCurrentRow();
+
+    rows.add(CategoriesFieldW(_color, ct));
+  }
+
+  void _handleNumberLineFieldBlock(NumberLineFieldBlock element) {
+    final ct = fields[element.iD] as NumberLineController;
+
+    // start a new line
+    _flushCurrentRow();
+
+    rows.add(NumberLineFieldW(_color, ct));
+  }
+
   /// populate [rows]
   void _build() {
     for (var element in _content) {
@@ -454,6 +516,8 @@ class _FieldsBuilder {
         _handleTableBlock(element);
       } else if (element is ImageBlock) {
         _handleImageBlock(element);
+      } else if (element is NumberLineBlock) {
+        _handleNumberLineBlock(element);
 
         // editable widgets
       } else if (element is NumberFieldBlock) {
@@ -486,6 +550,14 @@ class _FieldsBuilder {
         _handleProofFieldBlock(element);
       } else if (element is SetFieldBlock) {
         _handleSetFieldBlock(element);
+      } else if (element is TextFieldBlock) {
+        _handleTextFieldBlock(element);
+      } else if (element is MatchingFieldBlock) {
+        _handleMatchingFieldBlock(element);
+      } else if (element is CategoriesFieldBlock) {
+        _handleCategoriesFieldBlock(element);
+      } else if (element is NumberLineFieldBlock) {
+        _handleNumberLineFieldBlock(element);
       }
 
       lastIsText = element is TextBlock;
@@ -527,8 +599,11 @@ class CorrectionView extends StatelessWidget {
   /// If not null, [footerQuote] is displayed at the bottom of the screen.
   final QuoteData? footerQuote;
 
+  /// [title] is displayed above the content
+  final String title;
+
   const CorrectionView(this.correction, this.color, this.footerQuote,
-      {super.key});
+      {super.key, this.title = "Correction"});
 
   @override
   Widget build(BuildContext context) {
@@ -539,7 +614,7 @@ class CorrectionView extends StatelessWidget {
         children: [
           Padding(
             padding: const EdgeInsets.symmetric(vertical: 8.0),
-            child: ColoredTitle("Correction", color),
+            child: ColoredTitle(title, color),
           ),
           Expanded(
               child: _ListRows(
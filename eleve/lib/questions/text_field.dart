@@ -0,0 +1,72 @@
+import 'package:eleve/questions/fields.dart';
+import 'package:eleve/types/src_maths_questions_client.dart';
+import 'package:flutter/material.dart';
+
+/// [TextController] handles a free text answer.
+class TextController extends FieldController {
+  final TextEditingController textController;
+
+  TextController(void Function() onChange)
+      : textController = TextEditingController(),
+        super(onChange) {
+    textController.addListener(onChange);
+  }
+
+  String get text => textController.text.trim();
+
+  @override
+  bool hasValidData() => text.isNotEmpty;
+
+  @override
+  Answer getData() => TextAnswer(text);
+
+  @override
+  void setData(Answer answer) {
+    textController.text = (answer as TextAnswer).text;
+  }
+}
+
+class TextFieldW extends StatelessWidget {
+  final Color color;
+  final TextController controller;
+  final int sizeHint;
+
+  const TextFieldW(this.color, this.controller,
+      {super.key, this.sizeHint = 10});
+
+  // takes the hint into account, with some additional padding
+  double get width => 12.0 * (sizeHint + 4).clamp(8, 40);
+
+  @override
+  Widget build(BuildContext context) {
+    final fieldColor = controller.hasError ? Colors.red : color;
+    final border =
+        UnderlineInputBorder(borderSide: BorderSide(color: fieldColor));
+    return Padding(
+      padding: const EdgeInsets.symmetric(horizontal: 4),
+      child: SizedBox(
+        width: width,
+        child: TextField(
+          enabled: controller.isEnabled,
+          controller: controller.textController,
+          autocorrect: false,
+          enableSuggestions: false,
+          decoration: InputDecoration(
+            isDense: true,
+            contentPadding: const EdgeInsets.only(top: 10, bottom: 2),
+            disabledBorder: border,
+            focusedBorder: border,
+            enabledBorder: border,
+            border: border,
+          ),
+          cursorColor: fieldColor,
+          style: TextStyle(
+              color:
+                  controller.hasError ? Colors.red.shade200 : Colors.white),
+          textAlign: TextAlign.center,
+          textAlignVertical: TextAlignVertical.center,
+        ),
+      ),
+    );
+  }
+}
@@ -13,6 +13,8 @@ Answer answerFromJson(dynamic json_) {
   final kind = json['Kind'] as String;
   final data = json['Data'];
   switch (kind) {
+    case "CategoriesAnswer":
+      return categoriesAnswerFromJson(data);
     case "DoublePointAnswer":
       return doublePointAnswerFromJson(data);
     case "DoublePointPairAnswer":
@@ -21,8 +23,12 @@ Answer answerFromJson(dynamic json_) {
       return expressionAnswerFromJson(data);
     case "FunctionPointsAnswer":
       return functionPointsAnswerFromJson(data);
+    case "MatchingAnswer":
+      return matchingAnswerFromJson(data);
     case "NumberAnswer":
       return numberAnswerFromJson(data);
+    case "NumberLineAnswer":
+      return numberLineAnswerFromJson(data);
     case "OrderedListAnswer":
       return orderedListAnswerFromJson(data);
     case "PointAnswer":
@@ -37,6 +43,8 @@ Answer answerFromJson(dynamic json_) {
       return signTableAnswerFromJson(data);
     case "TableAnswer":
       return tableAnswerFromJson(data);
+    case "TextAnswer":
+      return textAnswerFromJson(data);
     case "TreeAnswer":
       return treeAnswerFromJson(data);
     case "VariationTableAnswer":
@@ -49,7 +57,9 @@ Answer answerFromJson(dynamic json_) {
 }
 
 Map<String, dynamic> answerToJson(Answer item) {
-  if (item is DoublePointAnswer) {
+  if (item is CategoriesAnswer) {
+    return {'Kind': "CategoriesAnswer", 'Data': categoriesAnswerToJson(item)};
+  } else if (item is DoublePointAnswer) {
     return {'Kind': "DoublePointAnswer", 'Data': doublePointAnswerToJson(item)};
   } else if (item is DoublePointPairAnswer) {
     return {
@@ -63,8 +73,12 @@ Map<String, dynamic> answerToJson(Answer item) {
       'Kind': "FunctionPointsAnswer",
       'Data': functionPointsAnswerToJson(item),
     };
+  } else if (item is MatchingAnswer) {
+    return {'Kind': "MatchingAnswer", 'Data': matchingAnswerToJson(item)};
   } else if (item is NumberAnswer) {
     return {'Kind': "NumberAnswer", 'Data': numberAnswerToJson(item)};
+  } else if (item is NumberLineAnswer) {
+    return {'Kind': "NumberLineAnswer", 'Data': numberLineAnswerToJson(item)};
   } else if (item is OrderedListAnswer) {
     return {'Kind': "OrderedListAnswer", 'Data': orderedListAnswerToJson(item)};
   } else if (item is PointAnswer) {
@@ -79,6 +93,8 @@ Map<String, dynamic> answerToJson(Answer item) {
     return {'Kind': "SignTableAnswer", 'Data': signTableAnswerToJson(item)};
   } else if (item is TableAnswer) {
     return {'Kind': "TableAnswer", 'Data': tableAnswerToJson(item)};
+  } else if (item is TextAnswer) {
+    return {'Kind': "TextAnswer", 'Data': textAnswerToJson(item)};
   } else if (item is TreeAnswer) {
     return {'Kind': "TreeAnswer", 'Data': treeAnswerToJson(item)};
   } else if (item is VariationTableAnswer) {
@@ -189,6 +205,8 @@ Block blockFromJson(dynamic json_) {
   final kind = json['Kind'] as String;
   final data = json['Data'];
   switch (kind) {
+    case "CategoriesFieldBlock":
+      return categoriesFieldBlockFromJson(data);
     case "DropDownFieldBlock":
       return dropDownFieldBlockFromJson(data);
     case "ExpressionFieldBlock":
@@ -205,8 +223,14 @@ Block blockFromJson(dynamic json_) {
       return geometricConstructionFieldBlockFromJson(data);
     case "ImageBlock":
       return imageBlockFromJson(data);
+    case "MatchingFieldBlock":
+      return matchingFieldBlockFromJson(data);
     case "NumberFieldBlock":
       return numberFieldBlockFromJson(data);
+    case "NumberLineBlock":
+      return numberLineBlockFromJson(data);
+    case "NumberLineFieldBlock":
+      return numberLineFieldBlockFromJson(data);
     case "OrderedListFieldBlock":
       return orderedListFieldBlockFromJson(data);
     case "ProofFieldBlock":
@@ -225,6 +249,8 @@ Block blockFromJson(dynamic json_) {
       return tableFieldBlockFromJson(data);
     case "TextBlock":
       return textBlockFromJson(data);
+    case "TextFieldBlock":
+      return textFieldBlockFromJson(data);
     case "TreeBlock":
       return treeBlockFromJson(data);
     case "TreeFieldBlock":
@@ -241,7 +267,12 @@ Block blockFromJson(dynamic json_) {
 }
 
 Map<String, dynamic> blockToJson(Block item) {
-  if (item is DropDownFieldBlock) {
+  if (item is CategoriesFieldBlock) {
+    return {
+      'Kind': "CategoriesFieldBlock",
+      'Data': categoriesFieldBlockToJson(item),
+    };
+  } else if (item is DropDownFieldBlock) {
     return {
       'Kind': "DropDownFieldBlock",
       'Data': dropDownFieldBlockToJson(item),
@@ -272,8 +303,20 @@ Map<String, dynamic> blockToJson(Block item) {
     };
   } else if (item is ImageBlock) {
     return {'Kind': "ImageBlock", 'Data': imageBlockToJson(item)};
+  } else if (item is MatchingFieldBlock) {
+    return {
+      'Kind': "MatchingFieldBlock",
+      'Data': matchingFieldBlockToJson(item),
+    };
   } else if (item is NumberFieldBlock) {
     return {'Kind': "NumberFieldBlock", 'Data': numberFieldBlockToJson(item)};
+  } else if (item is NumberLineBlock) {
+    return {'Kind': "NumberLineBlock", 'Data': numberLineBlockToJson(item)};
+  } else if (item is NumberLineFieldBlock) {
+    return {
+      'Kind': "NumberLineFieldBlock",
+      'Data': numberLineFieldBlockToJson(item),
+    };
   } else if (item is OrderedListFieldBlock) {
     return {
       'Kind': "OrderedListFieldBlock",
@@ -298,6 +341,8 @@ Map<String, dynamic> blockToJson(Block item) {
     return {'Kind': "TableFieldBlock", 'Data': tableFieldBlockToJson(item)};
   } else if (item is TextBlock) {
     return {'Kind': "TextBlock", 'Data': textBlockToJson(item)};
+  } else if (item is TextFieldBlock) {
+    return {'Kind': "TextFieldBlock", 'Data': textFieldBlockToJson(item)};
   } else if (item is TreeBlock) {
     return {'Kind': "TreeBlock", 'Data': treeBlockToJson(item)};
   } else if (item is TreeFieldBlock) {
@@ -319,6 +364,58 @@ Map<String, dynamic> blockToJson(Block item) {
   }
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.CategoriesAnswer
+class CategoriesAnswer implements Answer {
+  final List<int> categories;
+
+  const CategoriesAnswer(this.categories);
+
+  @override
+  String toString() {
+    return "CategoriesAnswer($categories)";
+  }
+}
+
+CategoriesAnswer categoriesAnswerFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return CategoriesAnswer(listIntFromJson(json['Categories']));
+}
+
+Map<String, dynamic> categoriesAnswerToJson(CategoriesAnswer item) {
+  return {"Categories": listIntToJson(item.categories)};
+}
+
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.CategoriesFieldBlock
+class CategoriesFieldBlock implements Block {
+  final List<TextLine> categories;
+  final List<TextLine> items;
+  final int iD;
+
+  const CategoriesFieldBlock(this.categories, this.items, this.iD);
+
+  @override
+  String toString() {
+    return "CategoriesFieldBlock($categories, $items, $iD)";
+  }
+}
+
+CategoriesFieldBlock categoriesFieldBlockFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return CategoriesFieldBlock(
+    listTextLineFromJson(json['Categories']),
+    listTextLineFromJson(json['Items']),
+    intFromJson(json['ID']),
+  );
+}
+
+Map<String, dynamic> categoriesFieldBlockToJson(CategoriesFieldBlock item) {
+  return {
+    "Categories": listTextLineToJson(item.categories),
+    "Items": listTextLineToJson(item.items),
+    "ID": intToJson(item.iD),
+  };
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.DoublePointAnswer
 class DoublePointAnswer implements Answer {
   final IntCoord from;
@@ -787,6 +884,44 @@ Map<String, dynamic> functionsGraphBlockToJson(FunctionsGraphBlock item) {
   };
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.GFAngle
+class GFAngle implements GeoField {
+  const GFAngle();
+
+  @override
+  String toString() {
+    return "GFAngle()";
+  }
+}
+
+GFAngle gFAngleFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return GFAngle();
+}
+
+Map<String, dynamic> gFAngleToJson(GFAngle item) {
+  return {};
+}
+
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.GFCircle
+class GFCircle implements GeoField {
+  const GFCircle();
+
+  @override
+  String toString() {
+    return "GFCircle()";
+  }
+}
+
+GFCircle gFCircleFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return GFCircle();
+}
+
+Map<String, dynamic> gFCircleToJson(GFCircle item) {
+  return {};
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.GFPoint
 class GFPoint implements GeoField {
   const GFPoint();
@@ -806,6 +941,34 @@ Map<String, dynamic> gFPointToJson(GFPoint item) {
   return {};
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.GFSegment
+class GFSegment implements GeoField {
+  final String labelFrom;
+  final String labelTo;
+
+  const GFSegment(this.labelFrom, this.labelTo);
+
+  @override
+  String toString() {
+    return "GFSegment($labelFrom, $labelTo)";
+  }
+}
+
+GFSegment gFSegmentFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return GFSegment(
+    stringFromJson(json['LabelFrom']),
+    stringFromJson(json['LabelTo']),
+  );
+}
+
+Map<String, dynamic> gFSegmentToJson(GFSegment item) {
+  return {
+    "LabelFrom": stringToJson(item.labelFrom),
+    "LabelTo": stringToJson(item.labelTo),
+  };
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.GFVector
 class GFVector implements GeoField {
   final String lineLabel;
@@ -861,8 +1024,14 @@ GeoField geoFieldFromJson(dynamic json_) {
   final kind = json['Kind'] as String;
   final data = json['Data'];
   switch (kind) {
+    case "GFAngle":
+      return gFAngleFromJson(data);
+    case "GFCircle":
+      return gFCircleFromJson(data);
     case "GFPoint":
       return gFPointFromJson(data);
+    case "GFSegment":
+      return gFSegmentFromJson(data);
     case "GFVector":
       return gFVectorFromJson(data);
     case "GFVectorPair":
@@ -873,8 +1042,14 @@ GeoField geoFieldFromJson(dynamic json_) {
 }
 
 Map<String, dynamic> geoFieldToJson(GeoField item) {
-  if (item is GFPoint) {
+  if (item is GFAngle) {
+    return {'Kind': "GFAngle", 'Data': gFAngleToJson(item)};
+  } else if (item is GFCircle) {
+    return {'Kind': "GFCircle", 'Data': gFCircleToJson(item)};
+  } else if (item is GFPoint) {
     return {'Kind': "GFPoint", 'Data': gFPointToJson(item)};
+  } else if (item is GFSegment) {
+    return {'Kind': "GFSegment", 'Data': gFSegmentToJson(item)};
   } else if (item is GFVector) {
     return {'Kind': "GFVector", 'Data': gFVectorToJson(item)};
   } else if (item is GFVectorPair) {
@@ -941,6 +1116,58 @@ Map<String, dynamic> imageBlockToJson(ImageBlock item) {
   return {"URL": stringToJson(item.uRL), "Scale": intToJson(item.scale)};
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.MatchingAnswer
+class MatchingAnswer implements Answer {
+  final List<int> indices;
+
+  const MatchingAnswer(this.indices);
+
+  @override
+  String toString() {
+    return "MatchingAnswer($indices)";
+  }
+}
+
+MatchingAnswer matchingAnswerFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return MatchingAnswer(listIntFromJson(json['Indices']));
+}
+
+Map<String, dynamic> matchingAnswerToJson(MatchingAnswer item) {
+  return {"Indices": listIntToJson(item.indices)};
+}
+
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.MatchingFieldBlock
+class MatchingFieldBlock implements Block {
+  final List<TextLine> left;
+  final List<TextLine> right;
+  final int iD;
+
+  const MatchingFieldBlock(this.left, this.right, this.iD);
+
+  @override
+  String toString() {
+    return "MatchingFieldBlock($left, $right, $iD)";
+  }
+}
+
+MatchingFieldBlock matchingFieldBlockFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return MatchingFieldBlock(
+    listTextLineFromJson(json['Left']),
+    listTextLineFromJson(json['Right']),
+    intFromJson(json['ID']),
+  );
+}
+
+Map<String, dynamic> matchingFieldBlockToJson(MatchingFieldBlock item) {
+  return {
+    "Left": listTextLineToJson(item.left),
+    "Right": listTextLineToJson(item.right),
+    "ID": intToJson(item.iD),
+  };
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.Node
 class Node implements Assertion {
   final Assertion left;
@@ -1018,6 +1245,80 @@ Map<String, dynamic> numberFieldBlockToJson(NumberFieldBlock item) {
   return {"ID": intToJson(item.iD), "SizeHint": intToJson(item.sizeHint)};
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.NumberLineAnswer
+class NumberLineAnswer implements Answer {
+  final List<bool> pieces;
+
+  const NumberLineAnswer(this.pieces);
+
+  @override
+  String toString() {
+    return "NumberLineAnswer($pieces)";
+  }
+}
+
+NumberLineAnswer numberLineAnswerFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return NumberLineAnswer(listBoolFromJson(json['Pieces']));
+}
+
+Map<String, dynamic> numberLineAnswerToJson(NumberLineAnswer item) {
+  return {"Pieces": listBoolToJson(item.pieces)};
+}
+
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.NumberLineBlock
+class NumberLineBlock implements Block {
+  final List<String> ticks;
+  final List<bool> pieces;
+
+  const NumberLineBlock(this.ticks, this.pieces);
+
+  @override
+  String toString() {
+    return "NumberLineBlock($ticks, $pieces)";
+  }
+}
+
+NumberLineBlock numberLineBlockFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return NumberLineBlock(
+    listStringFromJson(json['Ticks']),
+    listBoolFromJson(json['Pieces']),
+  );
+}
+
+Map<String, dynamic> numberLineBlockToJson(NumberLineBlock item) {
+  return {
+    "Ticks": listStringToJson(item.ticks),
+    "Pieces": listBoolToJson(item.pieces),
+  };
+}
+
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.NumberLineFieldBlock
+class NumberLineFieldBlock implements Block {
+  final List<String> ticks;
+  final int iD;
+
+  const NumberLineFieldBlock(this.ticks, this.iD);
+
+  @override
+  String toString() {
+    return "NumberLineFieldBlock($ticks, $iD)";
+  }
+}
+
+NumberLineFieldBlock numberLineFieldBlockFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return NumberLineFieldBlock(
+    listStringFromJson(json['Ticks']),
+    intFromJson(json['ID']),
+  );
+}
+
+Map<String, dynamic> numberLineFieldBlockToJson(NumberLineFieldBlock item) {
+  return {"Ticks": listStringToJson(item.ticks), "ID": intToJson(item.iD)};
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.OrderedListAnswer
 class OrderedListAnswer implements Answer {
   final List<int> indices;
@@ -1225,12 +1526,15 @@ Map<String, dynamic> questionAnswersInToJson(QuestionAnswersIn item) {
 class QuestionAnswersOut {
   final Map<int, bool> results;
   final Answers expectedAnswers;
+  final Map<int, String> hints;
+  final Map<int, List<bool>> itemResults;
 
-  const QuestionAnswersOut(this.results, this.expectedAnswers);
+  const QuestionAnswersOut(
+      this.results, this.expectedAnswers, this.hints, this.itemResults);
 
   @override
   String toString() {
-    return "QuestionAnswersOut($results, $expectedAnswers)";
+    return "QuestionAnswersOut($results, $expectedAnswers, $hints, $itemResults)";
   }
 }
 
@@ -1239,6 +1543,8 @@ QuestionAnswersOut questionAnswersOutFromJson(dynamic json_) {
   return QuestionAnswersOut(
     dictIntToBoolFromJson(json['Results']),
     answersFromJson(json['ExpectedAnswers']),
+    dictIntToStringFromJson(json['Hints']),
+    dictIntToListBoolFromJson(json['ItemResults']),
   );
 }
 
@@ -1246,6 +1552,8 @@ Map<String, dynamic> questionAnswersOutToJson(QuestionAnswersOut item) {
   return {
     "Results": dictIntToBoolToJson(item.results),
     "ExpectedAnswers": answersToJson(item.expectedAnswers),
+    "Hints": dictIntToStringToJson(item.hints),
+    "ItemResults": dictIntToListBoolToJson(item.itemResults),
   };
 }
 
@@ -1641,6 +1949,27 @@ Map<String, dynamic> tableFieldBlockToJson(TableFieldBlock item) {
   };
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.TextAnswer
+class TextAnswer implements Answer {
+  final String text;
+
+  const TextAnswer(this.text);
+
+  @override
+  String toString() {
+    return "TextAnswer($text)";
+  }
+}
+
+TextAnswer textAnswerFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return TextAnswer(stringFromJson(json['Text']));
+}
+
+Map<String, dynamic> textAnswerToJson(TextAnswer item) {
+  return {"Text": stringToJson(item.text)};
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.TextBlock
 class TextBlock implements Block {
   final TextLine parts;
@@ -1675,6 +2004,28 @@ Map<String, dynamic> textBlockToJson(TextBlock item) {
   };
 }
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions/client.TextFieldBlock
+class TextFieldBlock implements Block {
+  final int sizeHint;
+  final int iD;
+
+  const TextFieldBlock(this.sizeHint, this.iD);
+
+  @override
+  String toString() {
+    return "TextFieldBlock($sizeHint, $iD)";
+  }
+}
+
+TextFieldBlock textFieldBlockFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return TextFieldBlock(intFromJson(json['SizeHint']), intFromJson(json['ID']));
+}
+
+Map<String, dynamic> textFieldBlockToJson(TextFieldBlock item) {
+  return {"SizeHint": intToJson(item.sizeHint), "ID": intToJson(item.iD)};
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.TextLine
 typedef TextLine = List<TextOrMath>;
 
@@ -2046,6 +2397,33 @@ Map<String, dynamic> dictIntToBoolToJson(Map<int, bool> item) {
   return item.map((k, v) => MapEntry(intToJson(k).toString(), boolToJson(v)));
 }
 
+Map<int, List<bool>> dictIntToListBoolFromJson(dynamic json) {
+  if (json == null) {
+    return {};
+  }
+  return (json as Map<String, dynamic>).map(
+    (k, v) => MapEntry(int.parse(k), listBoolFromJson(v)),
+  );
+}
+
+Map<String, dynamic> dictIntToListBoolToJson(Map<int, List<bool>> item) {
+  return item.map(
+      (k, v) => MapEntry(intToJson(k).toString(), listBoolToJson(v)));
+}
+
+Map<int, String> dictIntToStringFromJson(dynamic json) {
+  if (json == null) {
+    return {};
+  }
+  return (json as Map<String, dynamic>).map(
+    (k, v) => MapEntry(int.parse(k), stringFromJson(v)),
+  );
+}
+
+Map<String, dynamic> dictIntToStringToJson(Map<int, String> item) {
+  return item.map((k, v) => MapEntry(intToJson(k).toString(), stringToJson(v)));
+}
+
 List<Assertion> listAssertionFromJson(dynamic json) {
   if (json == null) {
     return [];
@@ -331,6 +331,40 @@ Map<String, dynamic> selectQuestionsOutToJson(SelectQuestionsOut item) {
   return {"Questions": listInstantiatedBeltQuestionToJson(item.questions)};
 }
 
+// github.com/benoitkugler/maths-online/server/src/prof/ceintures.ShowHintIn
+class ShowHintIn {
+  final StudentTokens tokens;
+  final IdBeltquestion question;
+  final Params params;
+  final int hintIndex;
+
+  const ShowHintIn(this.tokens, this.question, this.params, this.hintIndex);
+
+  @override
+  String toString() {
+    return "ShowHintIn($tokens, $question, $params, $hintIndex)";
+  }
+}
+
+ShowHintIn showHintInFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return ShowHintIn(
+    studentTokensFromJson(json['Tokens']),
+    intFromJson(json['Question']),
+    paramsFromJson(json['Params']),
+    intFromJson(json['HintIndex']),
+  );
+}
+
+Map<String, dynamic> showHintInToJson(ShowHintIn item) {
+  return {
+    "Tokens": studentTokensToJson(item.tokens),
+    "Question": intToJson(item.question),
+    "Params": paramsToJson(item.params),
+    "HintIndex": intToJson(item.hintIndex),
+  };
+}
+
 // github.com/benoitkugler/maths-online/server/src/prof/ceintures.Stage
 class Stage {
   final Domain domain;
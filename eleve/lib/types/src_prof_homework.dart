@@ -1,6 +1,7 @@
 // Code generated by gomacro/generator/dart. DO NOT EDIT
 
 import 'predefined.dart';
+import 'src_maths_questions_client.dart';
 import 'src_pass.dart';
 import 'src_sql_events.dart';
 import 'src_sql_homework.dart';
@@ -18,6 +19,7 @@ class Sheet {
   final MatiereTag matiere;
   final QuestionRepeat questionRepeat;
   final int questionTimeLimit;
+  final int hintPenalty;
 
   const Sheet(
     this.id,
@@ -28,11 +30,12 @@ class Sheet {
     this.matiere,
     this.questionRepeat,
     this.questionTimeLimit,
+    this.hintPenalty,
   );
 
   @override
   String toString() {
-    return "Sheet($id, $title, $noted, $deadline, $ignoreForMark, $matiere, $questionRepeat, $questionTimeLimit)";
+    return "Sheet($id, $title, $noted, $deadline, $ignoreForMark, $matiere, $questionRepeat, $questionTimeLimit, $hintPenalty)";
   }
 }
 
@@ -47,6 +50,7 @@ Sheet sheetFromJson(dynamic json_) {
     matiereTagFromJson(json['Matiere']),
     questionRepeatFromJson(json['QuestionRepeat']),
     intFromJson(json['QuestionTimeLimit']),
+    intFromJson(json['HintPenalty']),
   );
 }
 
@@ -60,6 +64,7 @@ Map<String, dynamic> sheetToJson(Sheet item) {
     "Matiere": matiereTagToJson(item.matiere),
     "QuestionRepeat": questionRepeatToJson(item.questionRepeat),
     "QuestionTimeLimit": intToJson(item.questionTimeLimit),
+    "HintPenalty": intToJson(item.hintPenalty),
   };
 }
 
@@ -68,12 +73,18 @@ class SheetProgression {
   final IdTravail idTravail;
   final Sheet sheet;
   final List<TaskProgressionHeader> tasks;
+  final List<TaskStatus> tasksStatus;
 
-  const SheetProgression(this.idTravail, this.sheet, this.tasks);
+  const SheetProgression(
+    this.idTravail,
+    this.sheet,
+    this.tasks,
+    this.tasksStatus,
+  );
 
   @override
   String toString() {
-    return "SheetProgression($idTravail, $sheet, $tasks)";
+    return "SheetProgression($idTravail, $sheet, $tasks, $tasksStatus)";
   }
 }
 
@@ -83,6 +94,7 @@ SheetProgression sheetProgressionFromJson(dynamic json_) {
     intFromJson(json['IdTravail']),
     sheetFromJson(json['Sheet']),
     listTaskProgressionHeaderFromJson(json['Tasks']),
+    listTaskStatusFromJson(json['TasksStatus']),
   );
 }
 
@@ -91,6 +103,7 @@ Map<String, dynamic> sheetProgressionToJson(SheetProgression item) {
     "IdTravail": intToJson(item.idTravail),
     "Sheet": sheetToJson(item.sheet),
     "Tasks": listTaskProgressionHeaderToJson(item.tasks),
+    "TasksStatus": listTaskStatusToJson(item.tasksStatus),
   };
 }
 
@@ -214,6 +227,109 @@ dynamic studentSheetsToJson(StudentSheets item) {
   return listSheetProgressionToJson(item);
 }
 
+// github.com/benoitkugler/maths-online/server/src/prof/homework.StudentShowHintIn
+class StudentShowHintIn {
+  final EncryptedID studentID;
+  final IdTravail idTravail;
+  final IdTask idTask;
+  final int questionIndex;
+  final Params params;
+  final int hintIndex;
+
+  const StudentShowHintIn(
+    this.studentID,
+    this.idTravail,
+    this.idTask,
+    this.questionIndex,
+    this.params,
+    this.hintIndex,
+  );
+
+  @override
+  String toString() {
+    return "StudentShowHintIn($studentID, $idTravail, $idTask, $questionIndex, $params, $hintIndex)";
+  }
+}
+
+StudentShowHintIn studentShowHintInFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return StudentShowHintIn(
+    stringFromJson(json['StudentID']),
+    intFromJson(json['IdTravail']),
+    intFromJson(json['IdTask']),
+    intFromJson(json['QuestionIndex']),
+    paramsFromJson(json['Params']),
+    intFromJson(json['HintIndex']),
+  );
+}
+
+Map<String, dynamic> studentShowHintInToJson(StudentShowHintIn item) {
+  return {
+    "StudentID": stringToJson(item.studentID),
+    "IdTravail": intToJson(item.idTravail),
+    "IdTask": intToJson(item.idTask),
+    "QuestionIndex": intToJson(item.questionIndex),
+    "Params": paramsToJson(item.params),
+    "HintIndex": intToJson(item.hintIndex),
+  };
+}
+
+// github.com/benoitkugler/maths-online/server/src/prof/homework.StudentShowHintOut
+class StudentShowHintOut {
+  final Enonce hint;
+  final bool wasHintRegistred;
+
+  const StudentShowHintOut(this.hint, this.wasHintRegistred);
+
+  @override
+  String toString() {
+    return "StudentShowHintOut($hint, $wasHintRegistred)";
+  }
+}
+
+StudentShowHintOut studentShowHintOutFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return StudentShowHintOut(
+    enonceFromJson(json['Hint']),
+    boolFromJson(json['WasHintRegistred']),
+  );
+}
+
+Map<String, dynamic> studentShowHintOutToJson(StudentShowHintOut item) {
+  return {
+    "Hint": enonceToJson(item.hint),
+    "WasHintRegistred": boolToJson(item.wasHintRegistred),
+  };
+}
+
+// github.com/benoitkugler/maths-online/server/src/prof/homework.TaskStatus
+class TaskStatus {
+  final bool optional;
+  final bool locked;
+
+  const TaskStatus(this.optional, this.locked);
+
+  @override
+  String toString() {
+    return "TaskStatus($optional, $locked)";
+  }
+}
+
+TaskStatus taskStatusFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return TaskStatus(
+    boolFromJson(json['Optional']),
+    boolFromJson(json['Locked']),
+  );
+}
+
+Map<String, dynamic> taskStatusToJson(TaskStatus item) {
+  return {
+    "Optional": boolToJson(item.optional),
+    "Locked": boolToJson(item.locked),
+  };
+}
+
 List<SheetProgression> listSheetProgressionFromJson(dynamic json) {
   if (json == null) {
     return [];
@@ -237,3 +353,14 @@ List<dynamic> listTaskProgressionHeaderToJson(
 ) {
   return item.map(taskProgressionHeaderToJson).toList();
 }
+
+List<TaskStatus> listTaskStatusFromJson(dynamic json) {
+  if (json == null) {
+    return [];
+  }
+  return (json as List<dynamic>).map(taskStatusFromJson).toList();
+}
+
+List<dynamic> listTaskStatusToJson(List<TaskStatus> item) {
+  return item.map(taskStatusToJson).toList();
+}
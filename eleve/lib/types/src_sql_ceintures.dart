@@ -165,24 +165,27 @@ dynamic rankToJson(Rank item) => item.toValue();
 class Stat {
   final int success;
   final int failure;
+  final int hints;
 
-  const Stat(this.success, this.failure);
+  const Stat(this.success, this.failure, this.hints);
 
   @override
   String toString() {
-    return "Stat($success, $failure)";
+    return "Stat($success, $failure, $hints)";
   }
 }
 
 Stat statFromJson(dynamic json_) {
   final json = (json_ as Map<String, dynamic>);
-  return Stat(intFromJson(json['Success']), intFromJson(json['Failure']));
+  return Stat(intFromJson(json['Success']), intFromJson(json['Failure']),
+      intFromJson(json['Hints']));
 }
 
 Map<String, dynamic> statToJson(Stat item) {
   return {
     "Success": intToJson(item.success),
     "Failure": intToJson(item.failure),
+    "Hints": intToJson(item.hints),
   };
 }
 
@@ -146,12 +146,18 @@ class InstantiatedBeltQuestion {
   final IdBeltquestion id;
   final Question question;
   final Params params;
+  final int nbHints;
 
-  const InstantiatedBeltQuestion(this.id, this.question, this.params);
+  const InstantiatedBeltQuestion(
+    this.id,
+    this.question,
+    this.params,
+    this.nbHints,
+  );
 
   @override
   String toString() {
-    return "InstantiatedBeltQuestion($id, $question, $params)";
+    return "InstantiatedBeltQuestion($id, $question, $params, $nbHints)";
   }
 }
 
@@ -161,6 +167,7 @@ InstantiatedBeltQuestion instantiatedBeltQuestionFromJson(dynamic json_) {
     intFromJson(json['Id']),
     questionFromJson(json['Question']),
     paramsFromJson(json['Params']),
+    intFromJson(json['NbHints']),
   );
 }
 
@@ -171,6 +178,7 @@ Map<String, dynamic> instantiatedBeltQuestionToJson(
     "Id": intToJson(item.id),
     "Question": questionToJson(item.question),
     "Params": paramsToJson(item.params),
+    "NbHints": intToJson(item.nbHints),
   };
 }
 
@@ -180,17 +188,19 @@ class InstantiatedQuestion {
   final Question question;
   final DifficultyTag difficulty;
   final Params params;
+  final int nbHints;
 
   const InstantiatedQuestion(
     this.id,
     this.question,
     this.difficulty,
     this.params,
+    this.nbHints,
   );
 
   @override
   String toString() {
-    return "InstantiatedQuestion($id, $question, $difficulty, $params)";
+    return "InstantiatedQuestion($id, $question, $difficulty, $params, $nbHints)";
   }
 }
 
@@ -201,6 +211,7 @@ InstantiatedQuestion instantiatedQuestionFromJson(dynamic json_) {
     questionFromJson(json['Question']),
     difficultyTagFromJson(json['Difficulty']),
     paramsFromJson(json['Params']),
+    intFromJson(json['NbHints']),
   );
 }
 
@@ -210,6 +221,7 @@ Map<String, dynamic> instantiatedQuestionToJson(InstantiatedQuestion item) {
     "Question": questionToJson(item.question),
     "Difficulty": difficultyTagToJson(item.difficulty),
     "Params": paramsToJson(item.params),
+    "NbHints": intToJson(item.nbHints),
   };
 }
 
@@ -317,6 +329,45 @@ Map<String, dynamic> progressionExtToJson(ProgressionExt item) {
   };
 }
 
+// github.com/benoitkugler/maths-online/server/src/tasks.ShowWorkHintIn
+class ShowWorkHintIn {
+  final WorkID iD;
+  final int questionIndex;
+  final Params params;
+  final int hintIndex;
+
+  const ShowWorkHintIn(
+    this.iD,
+    this.questionIndex,
+    this.params,
+    this.hintIndex,
+  );
+
+  @override
+  String toString() {
+    return "ShowWorkHintIn($iD, $questionIndex, $params, $hintIndex)";
+  }
+}
+
+ShowWorkHintIn showWorkHintInFromJson(dynamic json_) {
+  final json = (json_ as Map<String, dynamic>);
+  return ShowWorkHintIn(
+    workIDFromJson(json['ID']),
+    intFromJson(json['QuestionIndex']),
+    paramsFromJson(json['Params']),
+    intFromJson(json['HintIndex']),
+  );
+}
+
+Map<String, dynamic> showWorkHintInToJson(ShowWorkHintIn item) {
+  return {
+    "ID": workIDToJson(item.iD),
+    "QuestionIndex": intToJson(item.questionIndex),
+    "Params": paramsToJson(item.params),
+    "HintIndex": intToJson(item.hintIndex),
+  };
+}
+
 // github.com/benoitkugler/maths-online/server/src/tasks.TaskProgressionHeader
 class TaskProgressionHeader {
   final IdTask id;
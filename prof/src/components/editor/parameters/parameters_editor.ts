@@ -1,21 +1,58 @@
 import {
   ParameterEntryKind,
   type ErrParameters,
+  type Fn,
   type ParameterEntry,
   type Parameters,
   type Rp,
+  type Variable,
   Int,
 } from "@/controller/api_gen";
 import { ExpressionColor, variableToString } from "@/controller/editor";
 import type { Token } from "../utils/interpolated_text";
 
+/** Returns the index of the '=' sign of a definition,
+ * ignoring the ones of the comparison operators (==, !=, <=, >=),
+ * or -1 if there is none.
+ */
+function assignmentIndex(line: string) {
+  for (let i = 0; i < line.length; i++) {
+    if (line[i] != "=") continue;
+    if (line[i + 1] == "=") {
+      i++; // skip ==
+      continue;
+    }
+    if (i > 0 && "!<>".includes(line[i - 1])) continue;
+    return i;
+  }
+  return -1;
+}
+
+// matches f(x), or f_1(x_A)
+const functionRe = /^([^\s_(),])(?:_(\w+))?\s*\(\s*([^\s_(),])(?:_(\w+))?\s*\)$/;
+
+function parseVariable(name: string, indice: string | undefined): Variable {
+  return { Name: name.codePointAt(0)! as Int, Indice: indice || "" };
+}
+
+const comparisonRe = /==|!=|<|>|≠|≤|≥/;
+
+/** Returns true if the line is a constraint, like a != b */
+function isConstraint(line: string) {
+  return assignmentIndex(line) == -1 && comparisonRe.test(line);
+}
+
 function tokenizeLine(line: string): Token[] {
   if (line.startsWith("#")) {
     // we have a comment
     return [{ Content: line + "\n", Kind: "color: green" }];
   }
 
-  const i = line.indexOf("=");
+  if (isConstraint(line)) {
+    return [{ Content: line + "\n", Kind: "color: darkorange" }];
+  }
+
+  const i = assignmentIndex(line);
   if (i == -1) {
     // invalid line
     return [{ Content: line + "\n", Kind: "" }];
@@ -83,7 +120,12 @@ export function parseParameters(text: string): {
         currentComment = "";
       }
 
-      const i = line.indexOf("=");
+      if (isConstraint(line)) {
+        out.push({ Kind: ParameterEntryKind.Ct, Data: line.trim() });
+        continue;
+      }
+
+      const i = assignmentIndex(line);
       if (i == -1) {
         return {
           params: [],
@@ -97,9 +139,18 @@ export function parseParameters(text: string): {
       const expression = line.substring(i + 1).trim();
       // differentiate between regular var and intrisic
       // by finding the number of variables
+      const fn = functionRe.exec(vars);
       if (vars.split(",").length > 1) {
         // we have an intrinsic
         out.push({ Kind: ParameterEntryKind.In, Data: line });
+      } else if (fn != null) {
+        // we have a function definition, like f(x) = 2x + 1
+        const data: Fn = {
+          function: parseVariable(fn[1], fn[2]),
+          variable: parseVariable(fn[3], fn[4]),
+          expression: expression,
+        };
+        out.push({ Kind: ParameterEntryKind.Fn, Data: data });
       } else {
         // we have a single variable
         const rp: Rp = {
@@ -144,6 +195,7 @@ export function parametersToString(params: Parameters) {
 
   params.forEach((entry) => {
     let rp: Rp;
+    let fn: Fn;
     let s: string;
     switch (entry.Kind) {
       case ParameterEntryKind.Co:
@@ -158,6 +210,18 @@ export function parametersToString(params: Parameters) {
         s = entry.Data as string;
         lines.push(s);
         return;
+      case ParameterEntryKind.Fn:
+        fn = entry.Data as Fn;
+        lines.push(
+          `${variableToString(fn.function)}(${variableToString(
+            fn.variable
+          )}) = ${fn.expression}`
+        );
+        return;
+      case ParameterEntryKind.Ct:
+        s = entry.Data as string;
+        lines.push(s);
+        return;
     }
   });
   return lines.join("\n");
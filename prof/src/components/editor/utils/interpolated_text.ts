@@ -12,6 +12,7 @@ export interface Token {
 const reLaTeX = /\$([^$]+)\$/g;
 const reExpression = /&([^&]+)&/g;
 const reNumberField = /#([^#]+)#/g;
+const reBlank = /\[\[\s*(texte|choix|expression)\s*:(.*?)\]\]/g;
 
 export function splitByRegexp<T>(
   re: RegExp,
@@ -71,15 +72,22 @@ export function defautTokenize(
   allowNumberField = false
 ): Token[] {
   if (allowNumberField) {
-    const chunks = splitByRegexp(reNumberField, input, true, false);
     const out: Token[] = [];
-    for (const chunk of chunks) {
-      if (chunk.Kind) {
-        // number field
-        out.push({ Content: chunk.Content, Kind: styles.numberField });
-      } else {
-        // regular
-        out.push(...itemize(chunk.Content).map(partToToken));
+    for (const blank of splitByRegexp(reBlank, input, true, false)) {
+      if (blank.Kind) {
+        // inline field [[ ]]
+        out.push({ Content: blank.Content, Kind: styles.numberField });
+        continue;
+      }
+      const chunks = splitByRegexp(reNumberField, blank.Content, true, false);
+      for (const chunk of chunks) {
+        if (chunk.Kind) {
+          // number field
+          out.push({ Content: chunk.Content, Kind: styles.numberField });
+        } else {
+          // regular
+          out.push(...itemize(chunk.Content).map(partToToken));
+        }
       }
     }
     return out;
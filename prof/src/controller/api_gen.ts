@@ -117,6 +117,7 @@ export interface Variable {
 }
 
 export const BlockKind = {
+  CategoriesFieldBlock: "CategoriesFieldBlock",
   ExpressionFieldBlock: "ExpressionFieldBlock",
   FigureBlock: "FigureBlock",
   FormulaBlock: "FormulaBlock",
@@ -124,7 +125,10 @@ export const BlockKind = {
   FunctionsGraphBlock: "FunctionsGraphBlock",
   GeometricConstructionFieldBlock: "GeometricConstructionFieldBlock",
   ImageBlock: "ImageBlock",
+  MatchingFieldBlock: "MatchingFieldBlock",
   NumberFieldBlock: "NumberFieldBlock",
+  NumberLineBlock: "NumberLineBlock",
+  NumberLineFieldBlock: "NumberLineFieldBlock",
   OrderedListFieldBlock: "OrderedListFieldBlock",
   ProofFieldBlock: "ProofFieldBlock",
   RadioFieldBlock: "RadioFieldBlock",
@@ -134,6 +138,7 @@ export const BlockKind = {
   TableBlock: "TableBlock",
   TableFieldBlock: "TableFieldBlock",
   TextBlock: "TextBlock",
+  TextFieldBlock: "TextFieldBlock",
   TreeBlock: "TreeBlock",
   TreeFieldBlock: "TreeFieldBlock",
   VariationTableBlock: "VariationTableBlock",
@@ -144,6 +149,7 @@ export type BlockKind = (typeof BlockKind)[keyof typeof BlockKind];
 
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Block
 export type Block =
+  | { Kind: "CategoriesFieldBlock"; Data: CategoriesFieldBlock }
   | { Kind: "ExpressionFieldBlock"; Data: ExpressionFieldBlock }
   | { Kind: "FigureBlock"; Data: FigureBlock }
   | { Kind: "FormulaBlock"; Data: FormulaBlock }
@@ -154,7 +160,10 @@ export type Block =
       Data: GeometricConstructionFieldBlock;
     }
   | { Kind: "ImageBlock"; Data: ImageBlock }
+  | { Kind: "MatchingFieldBlock"; Data: MatchingFieldBlock }
   | { Kind: "NumberFieldBlock"; Data: NumberFieldBlock }
+  | { Kind: "NumberLineBlock"; Data: NumberLineBlock }
+  | { Kind: "NumberLineFieldBlock"; Data: NumberLineFieldBlock }
   | { Kind: "OrderedListFieldBlock"; Data: OrderedListFieldBlock }
   | { Kind: "ProofFieldBlock"; Data: ProofFieldBlock }
   | { Kind: "RadioFieldBlock"; Data: RadioFieldBlock }
@@ -164,17 +173,25 @@ export type Block =
   | { Kind: "TableBlock"; Data: TableBlock }
   | { Kind: "TableFieldBlock"; Data: TableFieldBlock }
   | { Kind: "TextBlock"; Data: TextBlock }
+  | { Kind: "TextFieldBlock"; Data: TextFieldBlock }
   | { Kind: "TreeBlock"; Data: TreeBlock }
   | { Kind: "TreeFieldBlock"; Data: TreeFieldBlock }
   | { Kind: "VariationTableBlock"; Data: VariationTableBlock }
   | { Kind: "VariationTableFieldBlock"; Data: VariationTableFieldBlock }
   | { Kind: "VectorFieldBlock"; Data: VectorFieldBlock };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.CategoriesFieldBlock
+export interface CategoriesFieldBlock {
+  Categories: Interpolated[] | null;
+  Items: (Interpolated[] | null)[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Co
 export type Co = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ComparisonLevel
 export const ComparisonLevel = {
   AsLinearEquation: 102,
+  AsSolutionSet: 103,
+  AsComplexNumber: 104,
   ExpandedSubstitutions: 2,
   SimpleSubstitutions: 1,
   Strict: 0,
@@ -184,6 +201,8 @@ export type ComparisonLevel =
 
 export const ComparisonLevelLabels: Record<ComparisonLevel, string> = {
   [ComparisonLevel.AsLinearEquation]: "",
+  [ComparisonLevel.AsSolutionSet]: "",
+  [ComparisonLevel.AsComplexNumber]: "",
   [ComparisonLevel.ExpandedSubstitutions]: "Complète",
   [ComparisonLevel.SimpleSubstitutions]: "Simple",
   [ComparisonLevel.Strict]: "Exacte",
@@ -194,6 +213,8 @@ export interface CoordExpression {
   X: string;
   Y: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Ct
+export type Ct = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Enonce
 export type Enonce = Block[] | null;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ErrParameters
@@ -206,6 +227,8 @@ export interface ErrQuestionInvalid {
   ErrParameters: ErrParameters;
   ErrEnonce: errEnonce;
   ErrCorrection: errEnonce;
+  ErrHint: errEnonce;
+  HintIndex: Int;
   Kind: ErrorKind;
 }
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ErrorKind
@@ -213,6 +236,7 @@ export const ErrorKind = {
   ErrParameters_: 0,
   ErrEnonce: 1,
   ErrCorrection: 2,
+  ErrHint: 3,
 } as const;
 export type ErrorKind = (typeof ErrorKind)[keyof typeof ErrorKind];
 
@@ -220,6 +244,7 @@ export const ErrorKindLabels: Record<ErrorKind, string> = {
   [ErrorKind.ErrParameters_]: "",
   [ErrorKind.ErrEnonce]: "",
   [ErrorKind.ErrCorrection]: "",
+  [ErrorKind.ErrHint]: "",
 };
 
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ExpressionFieldBlock
@@ -228,6 +253,7 @@ export interface ExpressionFieldBlock {
   Label: Interpolated;
   ComparisonLevel: ComparisonLevel;
   ShowFractionHelp: boolean;
+  Hints: WrongAnswerHint[] | null;
 }
 // github.com/benoitkugler/maths-online/server/src/maths/questions.FigureBlock
 export interface FigureBlock {
@@ -249,6 +275,12 @@ export type FiguresOrGraphs =
   | { Kind: "FigureBlock"; Data: FigureBlock }
   | { Kind: "FunctionsGraphBlock"; Data: FunctionsGraphBlock };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Fn
+export interface Fn {
+  function: Variable;
+  variable: Variable;
+  expression: string;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.FormulaBlock
 export interface FormulaBlock {
   Parts: Interpolated;
@@ -309,10 +341,41 @@ export interface GFAffineLine {
   A: string;
   B: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFAngle
+export interface GFAngle {
+  Measure: string;
+  Vertex: CoordExpression;
+  MustHaveVertex: boolean;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFCircle
+export interface GFCircle {
+  Center: CoordExpression;
+  Radius: string;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFLine
+export interface GFLine {
+  Label: string;
+  Through: CoordExpression;
+  A: CoordExpression;
+  B: CoordExpression;
+  Perpendicular: boolean;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GFPoint
 export interface GFPoint {
   Answer: CoordExpression;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFPointOnCurve
+export interface GFPointOnCurve {
+  Function: string;
+  Variable: Variable;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFSegment
+export interface GFSegment {
+  From: CoordExpression;
+  To: CoordExpression;
+  LabelFrom: string;
+  LabelTo: string;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GFVector
 export interface GFVector {
   Answer: CoordExpression;
@@ -326,7 +389,12 @@ export interface GFVectorPair {
 
 export const GeoFieldKind = {
   GFAffineLine: "GFAffineLine",
+  GFAngle: "GFAngle",
+  GFCircle: "GFCircle",
+  GFLine: "GFLine",
   GFPoint: "GFPoint",
+  GFPointOnCurve: "GFPointOnCurve",
+  GFSegment: "GFSegment",
   GFVector: "GFVector",
   GFVectorPair: "GFVectorPair",
 } as const;
@@ -335,7 +403,12 @@ export type GeoFieldKind = (typeof GeoFieldKind)[keyof typeof GeoFieldKind];
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GeoField
 export type GeoField =
   | { Kind: "GFAffineLine"; Data: GFAffineLine }
+  | { Kind: "GFAngle"; Data: GFAngle }
+  | { Kind: "GFCircle"; Data: GFCircle }
+  | { Kind: "GFLine"; Data: GFLine }
   | { Kind: "GFPoint"; Data: GFPoint }
+  | { Kind: "GFPointOnCurve"; Data: GFPointOnCurve }
+  | { Kind: "GFSegment"; Data: GFSegment }
   | { Kind: "GFVector"; Data: GFVector }
   | { Kind: "GFVectorPair"; Data: GFVectorPair };
 
@@ -344,6 +417,8 @@ export interface GeometricConstructionFieldBlock {
   Field: GeoField;
   Background: FiguresOrGraphs;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Hints
+export type Hints = Enonce[] | null;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ImageBlock
 export interface ImageBlock {
   URL: string;
@@ -353,10 +428,25 @@ export interface ImageBlock {
 export type In = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Interpolated
 export type Interpolated = string;
+// github.com/benoitkugler/maths-online/server/src/maths/questions.MatchingFieldBlock
+export interface MatchingFieldBlock {
+  Left: Interpolated[] | null;
+  Right: Interpolated[] | null;
+  AdditionalRight: Interpolated[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.NumberFieldBlock
 export interface NumberFieldBlock {
   Expression: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.NumberLineBlock
+export interface NumberLineBlock {
+  Set: string;
+  Ticks: string[] | null;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.NumberLineFieldBlock
+export interface NumberLineFieldBlock {
+  Answer: NumberLineBlock;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.OrderedListFieldBlock
 export interface OrderedListFieldBlock {
   Label: Interpolated;
@@ -366,6 +456,8 @@ export interface OrderedListFieldBlock {
 
 export const ParameterEntryKind = {
   Co: "Co",
+  Ct: "Ct",
+  Fn: "Fn",
   In: "In",
   Rp: "Rp",
 } as const;
@@ -375,6 +467,8 @@ export type ParameterEntryKind =
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ParameterEntry
 export type ParameterEntry =
   | { Kind: "Co"; Data: Co }
+  | { Kind: "Ct"; Data: Ct }
+  | { Kind: "Fn"; Data: Fn }
   | { Kind: "In"; Data: In }
   | { Kind: "Rp"; Data: Rp };
 
@@ -430,6 +524,7 @@ export interface QuestionPage {
   enonce: Enonce;
   parameters: Parameters;
   correction: Enonce;
+  hints: Hints;
 }
 // github.com/benoitkugler/maths-online/server/src/maths/questions.RadioFieldBlock
 export interface RadioFieldBlock {
@@ -475,6 +570,16 @@ export interface TextBlock {
   Italic: boolean;
   Smaller: boolean;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.TextFieldBlock
+export interface TextFieldBlock {
+  Answers: string[] | null;
+  IgnoreCase: boolean;
+  IgnoreAccents: boolean;
+  IgnorePunctuation: boolean;
+  UseRegexp: boolean;
+  Correction: string;
+  MaxTypos: Int;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.TextKind
 export const TextKind = {
   Text: 0,
@@ -540,6 +645,12 @@ export const VectorPairCriterionLabels: Record<VectorPairCriterion, string> = {
   [VectorPairCriterion.VectorOrthogonal]: "Vecteurs orthogonaux",
 };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.WrongAnswerHint
+export interface WrongAnswerHint {
+  Answer: string;
+  Hint: string;
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions.errEnonce
 export interface errEnonce {
   Error: string;
@@ -768,6 +879,14 @@ export interface CheckQuestionParametersOut {
   ErrDefinition: ErrParameters;
   Variables: Variable[] | null;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ConvertLaTeXIn
+export interface ConvertLaTeXIn {
+  LaTeX: string;
+}
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ConvertLaTeXOut
+export interface ConvertLaTeXOut {
+  Expression: string;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/editor.DeleteExerciceOut
 export interface DeleteExerciceOut {
   Deleted: boolean;
@@ -842,18 +961,41 @@ export interface ExportExerciceLatexOut {
   IsValid: boolean;
   Latex: string;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ExportQuestionHTMLOut
+export interface ExportQuestionHTMLOut {
+  Error: ErrQuestionInvalid;
+  IsValid: boolean;
+  HTML: string;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/editor.ExportQuestionLatexOut
 export interface ExportQuestionLatexOut {
   Error: ErrQuestionInvalid;
   IsValid: boolean;
   Latex: string;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ExportQuestionSVGOut
+export interface ExportQuestionSVGOut {
+  Error: ErrQuestionInvalid;
+  IsValid: boolean;
+  Figures: string[] | null;
+}
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ExportQuestiongroupLMSOut
+export interface ExportQuestiongroupLMSOut {
+  Filename: string;
+  Content: string;
+  Report: string[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/editor.GenerateSyntaxHintIn
 export interface GenerateSyntaxHintIn {
   Block: ExpressionFieldBlock;
   SharedParameters: Parameters;
   QuestionParameters: Parameters;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/editor.ImportQuestiongroupsLMSOut
+export interface ImportQuestiongroupsLMSOut {
+  Groups: QuestiongroupExt[] | null;
+  Report: string[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/editor.Index
 export type Index = LevelItems[] | null;
 // github.com/benoitkugler/maths-online/server/src/prof/editor.LevelItems
@@ -1003,6 +1145,12 @@ export interface CopyTravailIn {
   IdTravail: IdTravail;
   IdClassroom: IdClassroom;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/homework.CopyTravailManyIn
+export interface CopyTravailManyIn {
+  IdTravail: IdTravail;
+  IdClassrooms: IdClassroom[] | null;
+  Shift: Int;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/homework.CopyTravailToOut
 export interface CopyTravailToOut {
   Travail: Travail;
@@ -1034,6 +1182,18 @@ export interface Homeworks {
   Sheets: Record<IdSheet, SheetExt> | null;
   Travaux: ClassroomTravaux[] | null;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/homework.HomeworkStudentAttemptIn
+export interface HomeworkStudentAttemptIn {
+  IdTravail: IdTravail;
+  IdStudent: IdStudent;
+  IdTask: IdTask;
+  Index: Int;
+}
+// github.com/benoitkugler/maths-online/server/src/prof/homework.HomeworkStudentAttemptOut
+export interface HomeworkStudentAttemptOut {
+  HasAnswer: boolean;
+  HTML: string;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/homework.HowemorkMarksIn
 export interface HowemorkMarksIn {
   IdClassroom: IdClassroom;
@@ -1058,6 +1218,11 @@ export interface ReorderSheetTasksIn {
   IdSheet: IdSheet;
   Tasks: IdTask[] | null;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/homework.SetTravailGroupsIn
+export interface SetTravailGroupsIn {
+  IdTravail: IdTravail;
+  Assignments: TravailGroups;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/homework.SheetExt
 export interface SheetExt {
   Sheet: Sheet;
@@ -1070,6 +1235,7 @@ export interface StudentTravailMark {
   Mark: number;
   Dispensed: boolean;
   NbTries: Int;
+  NbHints: Int;
 }
 // github.com/benoitkugler/maths-online/server/src/prof/homework.TaskExt
 export interface TaskExt {
@@ -1080,15 +1246,24 @@ export interface TaskExt {
   GroupID: Int;
   Bareme: TaskBareme;
   NbProgressions: Int;
+  Optional: boolean;
+  Requirement: TaskRequirement;
+  MinMark: Int;
 }
 // github.com/benoitkugler/maths-online/server/src/prof/homework.TaskStat
 export interface TaskStat {
+  IdTask: IdTask;
   IdWork: WorkID;
   Title: string;
   QuestionStats: QuestionStat[] | null;
   NbSuccess: Int;
   NbFailure: Int;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/homework.TravailGroupsOut
+export interface TravailGroupsOut {
+  Assignments: TravailGroups;
+  Groups: StudentGroupExt[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/homework.TravailMarks
 export interface TravailMarks {
   Marks: Record<IdStudent, StudentTravailMark> | null;
@@ -1282,6 +1457,11 @@ export interface StudentExt {
   Student: Student;
   Success: StudentAdvance;
 }
+// github.com/benoitkugler/maths-online/server/src/prof/teacher.StudentGroupExt
+export interface StudentGroupExt {
+  Group: StudentGroup;
+  Students: IdStudent[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/prof/teacher.StudentHeader
 export interface StudentHeader {
   Id: IdStudent;
@@ -1295,6 +1475,7 @@ export interface TeacherSettings {
   HasEditorSimplified: boolean;
   Contact: Contact;
   FavoriteMatiere: MatiereTag;
+  WeeklyDigest: boolean;
 }
 // github.com/benoitkugler/maths-online/server/src/prof/teacher.Visibility
 export const Visibility = {
@@ -1418,6 +1599,7 @@ export interface Beltquestion {
   Parameters: Parameters;
   Enonce: Enonce;
   Correction: Enonce;
+  Hints: Hints;
   Repeat: Int;
   Title: string;
 }
@@ -1504,6 +1686,7 @@ export const RankLabels: Record<Rank, string> = {
 export interface Stat {
   Success: Int;
   Failure: Int;
+  Hints: Int;
 }
 // github.com/benoitkugler/maths-online/server/src/sql/ceintures.Stats
 export type Stats = Ar12_Ar11_Stat;
@@ -1596,6 +1779,7 @@ export interface Question {
   Enonce: Enonce;
   Parameters: Parameters;
   Correction: Enonce;
+  Hints: Hints;
 }
 // github.com/benoitkugler/maths-online/server/src/sql/editor.Questiongroup
 export interface Questiongroup {
@@ -1640,6 +1824,14 @@ export interface StudentAdvance {
 }
 export type IdSheet = Int & { __opaque_int__: "IdSheet" };
 export type IdTravail = Int & { __opaque_int__: "IdTravail" };
+export type IdTravailSchedule = Int & {
+  __opaque_int__: "IdTravailSchedule";
+};
+// github.com/benoitkugler/maths-online/server/src/sql/homework.OptionalIdSheet
+export interface OptionalIdSheet {
+  Valid: boolean;
+  ID: IdSheet;
+}
 // github.com/benoitkugler/maths-online/server/src/sql/homework.OptionalIdTravail
 export interface OptionalIdTravail {
   Valid: boolean;
@@ -1668,6 +1860,29 @@ export interface Sheet {
   Public: boolean;
   Matiere: MatiereTag;
 }
+// github.com/benoitkugler/maths-online/server/src/sql/homework.SheetTask
+export interface SheetTask {
+  IdSheet: IdSheet;
+  IdTask: IdTask;
+  Optional: boolean;
+  Requirement: TaskRequirement;
+  MinMark: Int;
+}
+// github.com/benoitkugler/maths-online/server/src/sql/homework.TaskRequirement
+export const TaskRequirement = {
+  NoRequirement: 0,
+  RequireCompletion: 1,
+  RequireMinMark: 2,
+} as const;
+export type TaskRequirement =
+  (typeof TaskRequirement)[keyof typeof TaskRequirement];
+
+export const TaskRequirementLabels: Record<TaskRequirement, string> = {
+  [TaskRequirement.NoRequirement]: "Aucune",
+  [TaskRequirement.RequireCompletion]: "Tâches précédentes terminées",
+  [TaskRequirement.RequireMinMark]: "Note minimale aux tâches précédentes",
+};
+
 // github.com/benoitkugler/maths-online/server/src/sql/homework.Travail
 export interface Travail {
   Id: IdTravail;
@@ -1678,6 +1893,7 @@ export interface Travail {
   ShowAfter: Time;
   QuestionRepeat: QuestionRepeat;
   QuestionTimeLimit: Int;
+  HintPenalty: Int;
 }
 // github.com/benoitkugler/maths-online/server/src/sql/homework.TravailException
 export interface TravailException {
@@ -1688,6 +1904,28 @@ export interface TravailException {
 }
 // github.com/benoitkugler/maths-online/server/src/sql/homework.TravailExceptions
 export type TravailExceptions = TravailException[] | null;
+// github.com/benoitkugler/maths-online/server/src/sql/homework.TravailGroup
+export interface TravailGroup {
+  IdTravail: IdTravail;
+  IdStudentGroup: IdStudentGroup;
+  IdSheet: OptionalIdSheet;
+}
+// github.com/benoitkugler/maths-online/server/src/sql/homework.TravailGroups
+export type TravailGroups = TravailGroup[] | null;
+// github.com/benoitkugler/maths-online/server/src/sql/homework.TravailSchedule
+export interface TravailSchedule {
+  Id: IdTravailSchedule;
+  IdClassroom: IdClassroom;
+  IdSheet: IdSheet;
+  IdTeacher: IdTeacher;
+  Noted: boolean;
+  QuestionRepeat: QuestionRepeat;
+  QuestionTimeLimit: Int;
+  NextShowAfter: Time;
+  DeadlineDelay: Int;
+  Period: Int;
+  Until: Time;
+}
 // github.com/benoitkugler/maths-online/server/src/sql/reviews.Approval
 export const Approval = {
   Neutral: 0,
@@ -1773,6 +2011,7 @@ export interface Contact {
 export type Date = Date_;
 export type IdClassroom = Int & { __opaque_int__: "IdClassroom" };
 export type IdStudent = Int & { __opaque_int__: "IdStudent" };
+export type IdStudentGroup = Int & { __opaque_int__: "IdStudentGroup" };
 export type IdTeacher = Int & { __opaque_int__: "IdTeacher" };
 // github.com/benoitkugler/maths-online/server/src/sql/teacher.MatiereTag
 export const MatiereTag = {
@@ -1813,6 +2052,12 @@ export interface Student {
   id_classroom: IdClassroom;
   Clients: Clients;
 }
+// github.com/benoitkugler/maths-online/server/src/sql/teacher.StudentGroup
+export interface StudentGroup {
+  Id: IdStudentGroup;
+  IdClassroom: IdClassroom;
+  Name: string;
+}
 // github.com/benoitkugler/maths-online/server/src/sql/teacher.Students
 export type Students = Record<IdStudent, Student> | null;
 // github.com/benoitkugler/maths-online/server/src/sql/trivial.CategoriesQuestions
@@ -1986,6 +2231,34 @@ export abstract class AbstractAPI {
     );
   }
 
+  /** Returns an URL with method GET */
+  HomeworkExportMarks(
+    id_classroom: IdClassroom,
+    id_travaux: string,
+    format: string,
+    token: string
+  ) {
+    return (
+      this.baseURL +
+      "/api/prof/homework/marks/export" +
+      `?id-classroom=${id_classroom}&id-travaux=${id_travaux}&format=${format}&token=${token}`
+    );
+  }
+
+  /** Returns an URL with method GET */
+  HomeworkExportPapers(
+    id_sheet: IdSheet,
+    copies: number,
+    shuffle: string,
+    token: string
+  ) {
+    return (
+      this.baseURL +
+      "/api/prof/homework/sheet/export/papers" +
+      `?id-sheet=${id_sheet}&copies=${copies}&shuffle=${shuffle}&token=${token}`
+    );
+  }
+
   /** TeacherGetSettings performs the request and handles the error */
   async TeacherGetSettings() {
     const fullUrl = this.baseURL + "/api/prof/settings";
@@ -2179,6 +2452,70 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** TeacherGetStudentGroups performs the request and handles the error */
+  async TeacherGetStudentGroups(params: { "id-classroom": Int }) {
+    const fullUrl = this.baseURL + "/api/prof/classrooms/groups";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<StudentGroupExt[] | null> = await Axios.get(
+        fullUrl,
+        {
+          headers: this.getHeaders(),
+          params: { "id-classroom": String(params["id-classroom"]) },
+        },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** TeacherCreateStudentGroup performs the request and handles the error */
+  async TeacherCreateStudentGroup(params: { "id-classroom": Int }) {
+    const fullUrl = this.baseURL + "/api/prof/classrooms/groups";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<StudentGroupExt> = await Axios.put(
+        fullUrl,
+        null,
+        {
+          headers: this.getHeaders(),
+          params: { "id-classroom": String(params["id-classroom"]) },
+        },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** TeacherUpdateStudentGroup performs the request and handles the error */
+  async TeacherUpdateStudentGroup(params: StudentGroupExt) {
+    const fullUrl = this.baseURL + "/api/prof/classrooms/groups";
+    this.startRequest();
+    try {
+      await Axios.post(fullUrl, params, { headers: this.getHeaders() });
+      return true;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** TeacherDeleteStudentGroup performs the request and handles the error */
+  async TeacherDeleteStudentGroup(params: { id: Int }) {
+    const fullUrl = this.baseURL + "/api/prof/classrooms/groups";
+    this.startRequest();
+    try {
+      await Axios.delete(fullUrl, {
+        headers: this.getHeaders(),
+        params: { id: String(params["id"]) },
+      });
+      return true;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** GetTrivialPoursuit performs the request and handles the error */
   async GetTrivialPoursuit(params: { matiere: string }) {
     const fullUrl = this.baseURL + "/api/prof/trivial/config";
@@ -2409,6 +2746,22 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** EditorConvertLaTeX performs the request and handles the error */
+  async EditorConvertLaTeX(params: ConvertLaTeXIn) {
+    const fullUrl = this.baseURL + "/api/prof/editor/latex";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<ConvertLaTeXOut> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** EditorGetQuestionsIndex performs the request and handles the error */
   async EditorGetQuestionsIndex() {
     const fullUrl = this.baseURL + "/api/prof/editor/questiongroups";
@@ -2524,6 +2877,42 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** EditorExportQuestiongroupLMS performs the request and handles the error */
+  async EditorExportQuestiongroupLMS(params: { id: Int; format: string }) {
+    const fullUrl = this.baseURL + "/api/prof/editor/questiongroup/export-lms";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<ExportQuestiongroupLMSOut> = await Axios.get(
+        fullUrl,
+        {
+          headers: this.getHeaders(),
+          params: { id: String(params["id"]), format: params["format"] },
+        },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** EditorImportQuestiongroupsLMS performs the request and handles the error */
+  async EditorImportQuestiongroupsLMS(file: File) {
+    const fullUrl = this.baseURL + "/api/prof/editor/questiongroup/import-lms";
+    this.startRequest();
+    try {
+      const formData = new FormData();
+      formData.append("file", file, file.name);
+      const rep: AxiosResponse<ImportQuestiongroupsLMSOut> = await Axios.post(
+        fullUrl,
+        formData,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** EditorGetQuestions performs the request and handles the error */
   async EditorGetQuestions(params: { id: Int }) {
     const fullUrl = this.baseURL + "/api/prof/editor/question";
@@ -2614,6 +3003,38 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** EditorQuestionExportSVG performs the request and handles the error */
+  async EditorQuestionExportSVG(params: QuestionPage) {
+    const fullUrl = this.baseURL + "/api/prof/editor/question/export/svg";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<ExportQuestionSVGOut> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** EditorQuestionExportHTML performs the request and handles the error */
+  async EditorQuestionExportHTML(params: QuestionPage) {
+    const fullUrl = this.baseURL + "/api/prof/editor/question/export/html";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<ExportQuestionHTMLOut> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** EditorGetExercicesIndex performs the request and handles the error */
   async EditorGetExercicesIndex() {
     const fullUrl = this.baseURL + "/api/prof/editor/exercicegroups";
@@ -3032,6 +3453,114 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** HomeworkCopyTravailMany performs the request and handles the error */
+  async HomeworkCopyTravailMany(params: CopyTravailManyIn) {
+    const fullUrl = this.baseURL + "/api/prof/homework/travail/copy-many";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<CopyTravailToOut[] | null> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkGetTravailGroups performs the request and handles the error */
+  async HomeworkGetTravailGroups(params: { "id-travail": Int }) {
+    const fullUrl = this.baseURL + "/api/prof/homework/travail/groups";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<TravailGroupsOut> = await Axios.get(fullUrl, {
+        headers: this.getHeaders(),
+        params: { "id-travail": String(params["id-travail"]) },
+      });
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkSetTravailGroups performs the request and handles the error */
+  async HomeworkSetTravailGroups(params: SetTravailGroupsIn) {
+    const fullUrl = this.baseURL + "/api/prof/homework/travail/groups";
+    this.startRequest();
+    try {
+      await Axios.post(fullUrl, params, { headers: this.getHeaders() });
+      return true;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkGetSchedules performs the request and handles the error */
+  async HomeworkGetSchedules(params: { "id-classroom": Int }) {
+    const fullUrl = this.baseURL + "/api/prof/homework/schedules";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<TravailSchedule[] | null> = await Axios.get(
+        fullUrl,
+        {
+          headers: this.getHeaders(),
+          params: { "id-classroom": String(params["id-classroom"]) },
+        },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkCreateSchedule performs the request and handles the error */
+  async HomeworkCreateSchedule(params: TravailSchedule) {
+    const fullUrl = this.baseURL + "/api/prof/homework/schedules";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<TravailSchedule> = await Axios.put(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkUpdateSchedule performs the request and handles the error */
+  async HomeworkUpdateSchedule(params: TravailSchedule) {
+    const fullUrl = this.baseURL + "/api/prof/homework/schedules";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<TravailSchedule> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
+  /** HomeworkDeleteSchedule performs the request and handles the error */
+  async HomeworkDeleteSchedule(params: { id: Int }) {
+    const fullUrl = this.baseURL + "/api/prof/homework/schedules";
+    this.startRequest();
+    try {
+      await Axios.delete(fullUrl, {
+        headers: this.getHeaders(),
+        params: { id: String(params["id"]) },
+      });
+      return true;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** HomeworkRemoveTask performs the request and handles the error */
   async HomeworkRemoveTask(params: { "id-task": Int }) {
     const fullUrl = this.baseURL + "/api/prof/homework/sheet";
@@ -3166,6 +3695,20 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** HomeworkUpdateSheetTask performs the request and handles the error */
+  async HomeworkUpdateSheetTask(params: SheetTask) {
+    const fullUrl = this.baseURL + "/api/prof/homework/sheet/task";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<TaskExt> = await Axios.post(fullUrl, params, {
+        headers: this.getHeaders(),
+      });
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** HomeworkMissingTasksHint performs the request and handles the error */
   async HomeworkMissingTasksHint(params: { "id-sheet": Int }) {
     const fullUrl = this.baseURL + "/api/prof/homework/sheet/missing-hint";
@@ -3197,6 +3740,22 @@ export abstract class AbstractAPI {
     }
   }
 
+  /** HomeworkStudentAttempt performs the request and handles the error */
+  async HomeworkStudentAttempt(params: HomeworkStudentAttemptIn) {
+    const fullUrl = this.baseURL + "/api/prof/homework/marks/attempt";
+    this.startRequest();
+    try {
+      const rep: AxiosResponse<HomeworkStudentAttemptOut> = await Axios.post(
+        fullUrl,
+        params,
+        { headers: this.getHeaders() },
+      );
+      return rep.data;
+    } catch (error) {
+      this.handleError(error);
+    }
+  }
+
   /** HomeworkGetDispenses performs the request and handles the error */
   async HomeworkGetDispenses(params: { "id-travail": Int }) {
     const fullUrl = this.baseURL + "/api/prof/homework/dispences";
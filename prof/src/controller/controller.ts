@@ -20,6 +20,7 @@ class Controller extends AbstractAPI {
     Password: "",
     Contact: { Name: "", URL: "" },
     FavoriteMatiere: MatiereTag.Autre,
+    WeeklyDigest: false,
   };
 
   logout() {
@@ -44,6 +44,7 @@ import {
   Int,
   Parameters,
   Enonce,
+  Hints,
   ErrQuestionInvalid,
   SetFieldBlock,
   IdExercice,
@@ -100,6 +101,7 @@ export const sortedBlockKindLabels = [
       isAnswerField: false,
     },
   ],
+  [BlockKind.NumberLineBlock, { label: "Droite graduée", isAnswerField: false }],
   [BlockKind.NumberFieldBlock, { label: "Nombre", isAnswerField: true }],
   [
     BlockKind.ExpressionFieldBlock,
@@ -155,6 +157,16 @@ export const sortedBlockKindLabels = [
   [BlockKind.TableFieldBlock, { label: "Tableau", isAnswerField: true }],
   [BlockKind.TreeFieldBlock, { label: "Arbre", isAnswerField: true }],
   [BlockKind.SetFieldBlock, { label: "Ensembles", isAnswerField: true }],
+  [BlockKind.TextFieldBlock, { label: "Texte libre", isAnswerField: true }],
+  [BlockKind.MatchingFieldBlock, { label: "Appariement", isAnswerField: true }],
+  [
+    BlockKind.CategoriesFieldBlock,
+    { label: "Classement par catégories", isAnswerField: true },
+  ],
+  [
+    BlockKind.NumberLineFieldBlock,
+    { label: "Droite graduée (intervalles)", isAnswerField: true },
+  ],
 ] as const;
 
 export const BlockKindLabels: {
@@ -187,11 +199,15 @@ export function extractPoints(vars: Variable[]) {
 }
 
 export function saveData<T>(data: T, fileName: string) {
+  saveText(JSON.stringify(data, null, "  "), fileName);
+}
+
+/** saveText triggers the download of the given content */
+export function saveText(content: string, fileName: string) {
   const a = document.createElement("a");
   document.body.appendChild(a);
   a.setAttribute("style", "display: none");
-  const json = JSON.stringify(data, null, "  "),
-    blob = new Blob([json], { type: "octet/stream" }),
+  const blob = new Blob([content], { type: "octet/stream" }),
     url = window.URL.createObjectURL(blob);
   a.href = url;
   a.download = fileName;
@@ -388,6 +404,7 @@ export function newBlock(kind: BlockKind): Block {
           Expression: "x^2 + 2x + 1",
           ComparisonLevel: ComparisonLevel.SimpleSubstitutions,
           ShowFractionHelp: false,
+          Hints: [],
         },
       };
     }
@@ -560,6 +577,62 @@ export function newBlock(kind: BlockKind): Block {
         },
       };
     }
+    case BlockKind.TextFieldBlock: {
+      return {
+        Kind: kind,
+        Data: {
+          Answers: ["Victor Hugo"],
+          IgnoreCase: true,
+          IgnoreAccents: true,
+          IgnorePunctuation: true,
+          UseRegexp: false,
+          Correction: "",
+          MaxTypos: 1 as Int,
+        },
+      };
+    }
+    case BlockKind.MatchingFieldBlock: {
+      return {
+        Kind: kind,
+        Data: {
+          Left: ["$x^2$", "$e^x$", "$\\ln(x)$"],
+          Right: ["$2x$", "$e^x$", "$\\frac{1}{x}$"],
+          AdditionalRight: [],
+        },
+      };
+    }
+    case BlockKind.CategoriesFieldBlock: {
+      return {
+        Kind: kind,
+        Data: {
+          Categories: ["Rationnel", "Irrationnel"],
+          Items: [
+            ["$\\frac{1}{3}$", "$0.5$"],
+            ["$\\sqrt{2}$", "$\\pi$"],
+          ],
+        },
+      };
+    }
+    case BlockKind.NumberLineBlock: {
+      return {
+        Kind: kind,
+        Data: {
+          Set: "]-inf;-1[ ∪ [2;4]",
+          Ticks: ["0"],
+        },
+      };
+    }
+    case BlockKind.NumberLineFieldBlock: {
+      return {
+        Kind: kind,
+        Data: {
+          Answer: {
+            Set: "]-inf;-1[ ∪ [2;4]",
+            Ticks: ["0"],
+          },
+        },
+      };
+    }
     case BlockKind.ImageBlock: {
       return {
         Kind: kind,
@@ -784,6 +857,7 @@ export interface QuestionPage {
   sharedParameters: Parameters;
   enonce: Enonce;
   correction: Enonce;
+  hints: Hints;
 }
 
 export interface SaveQuestionOut {
@@ -7,6 +7,7 @@ export interface Variable {
 }
 
 export const BlockKind = {
+  CategoriesFieldBlock: "CategoriesFieldBlock",
   ExpressionFieldBlock: "ExpressionFieldBlock",
   FigureBlock: "FigureBlock",
   FormulaBlock: "FormulaBlock",
@@ -14,7 +15,10 @@ export const BlockKind = {
   FunctionsGraphBlock: "FunctionsGraphBlock",
   GeometricConstructionFieldBlock: "GeometricConstructionFieldBlock",
   ImageBlock: "ImageBlock",
+  MatchingFieldBlock: "MatchingFieldBlock",
   NumberFieldBlock: "NumberFieldBlock",
+  NumberLineBlock: "NumberLineBlock",
+  NumberLineFieldBlock: "NumberLineFieldBlock",
   OrderedListFieldBlock: "OrderedListFieldBlock",
   ProofFieldBlock: "ProofFieldBlock",
   RadioFieldBlock: "RadioFieldBlock",
@@ -24,6 +28,7 @@ export const BlockKind = {
   TableBlock: "TableBlock",
   TableFieldBlock: "TableFieldBlock",
   TextBlock: "TextBlock",
+  TextFieldBlock: "TextFieldBlock",
   TreeBlock: "TreeBlock",
   TreeFieldBlock: "TreeFieldBlock",
   VariationTableBlock: "VariationTableBlock",
@@ -34,6 +39,7 @@ export type BlockKind = (typeof BlockKind)[keyof typeof BlockKind];
 
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Block
 export type Block =
+  | { Kind: "CategoriesFieldBlock"; Data: CategoriesFieldBlock }
   | { Kind: "ExpressionFieldBlock"; Data: ExpressionFieldBlock }
   | { Kind: "FigureBlock"; Data: FigureBlock }
   | { Kind: "FormulaBlock"; Data: FormulaBlock }
@@ -44,7 +50,10 @@ export type Block =
       Data: GeometricConstructionFieldBlock;
     }
   | { Kind: "ImageBlock"; Data: ImageBlock }
+  | { Kind: "MatchingFieldBlock"; Data: MatchingFieldBlock }
   | { Kind: "NumberFieldBlock"; Data: NumberFieldBlock }
+  | { Kind: "NumberLineBlock"; Data: NumberLineBlock }
+  | { Kind: "NumberLineFieldBlock"; Data: NumberLineFieldBlock }
   | { Kind: "OrderedListFieldBlock"; Data: OrderedListFieldBlock }
   | { Kind: "ProofFieldBlock"; Data: ProofFieldBlock }
   | { Kind: "RadioFieldBlock"; Data: RadioFieldBlock }
@@ -54,17 +63,25 @@ export type Block =
   | { Kind: "TableBlock"; Data: TableBlock }
   | { Kind: "TableFieldBlock"; Data: TableFieldBlock }
   | { Kind: "TextBlock"; Data: TextBlock }
+  | { Kind: "TextFieldBlock"; Data: TextFieldBlock }
   | { Kind: "TreeBlock"; Data: TreeBlock }
   | { Kind: "TreeFieldBlock"; Data: TreeFieldBlock }
   | { Kind: "VariationTableBlock"; Data: VariationTableBlock }
   | { Kind: "VariationTableFieldBlock"; Data: VariationTableFieldBlock }
   | { Kind: "VectorFieldBlock"; Data: VectorFieldBlock };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.CategoriesFieldBlock
+export interface CategoriesFieldBlock {
+  Categories: Interpolated[] | null;
+  Items: (Interpolated[] | null)[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Co
 export type Co = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ComparisonLevel
 export const ComparisonLevel = {
   AsLinearEquation: 102,
+  AsSolutionSet: 103,
+  AsComplexNumber: 104,
   ExpandedSubstitutions: 2,
   SimpleSubstitutions: 1,
   Strict: 0,
@@ -74,6 +91,8 @@ export type ComparisonLevel =
 
 export const ComparisonLevelLabels: Record<ComparisonLevel, string> = {
   [ComparisonLevel.AsLinearEquation]: "",
+  [ComparisonLevel.AsSolutionSet]: "",
+  [ComparisonLevel.AsComplexNumber]: "",
   [ComparisonLevel.ExpandedSubstitutions]: "Complète",
   [ComparisonLevel.SimpleSubstitutions]: "Simple",
   [ComparisonLevel.Strict]: "Exacte",
@@ -84,6 +103,8 @@ export interface CoordExpression {
   X: string;
   Y: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Ct
+export type Ct = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Enonce
 export type Enonce = Block[] | null;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ExpressionFieldBlock
@@ -92,6 +113,7 @@ export interface ExpressionFieldBlock {
   Label: Interpolated;
   ComparisonLevel: ComparisonLevel;
   ShowFractionHelp: boolean;
+  Hints: WrongAnswerHint[] | null;
 }
 // github.com/benoitkugler/maths-online/server/src/maths/questions.FigureBlock
 export interface FigureBlock {
@@ -113,6 +135,12 @@ export type FiguresOrGraphs =
   | { Kind: "FigureBlock"; Data: FigureBlock }
   | { Kind: "FunctionsGraphBlock"; Data: FunctionsGraphBlock };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Fn
+export interface Fn {
+  function: Variable;
+  variable: Variable;
+  expression: string;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.FormulaBlock
 export interface FormulaBlock {
   Parts: Interpolated;
@@ -173,10 +201,41 @@ export interface GFAffineLine {
   A: string;
   B: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFAngle
+export interface GFAngle {
+  Measure: string;
+  Vertex: CoordExpression;
+  MustHaveVertex: boolean;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFCircle
+export interface GFCircle {
+  Center: CoordExpression;
+  Radius: string;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFLine
+export interface GFLine {
+  Label: string;
+  Through: CoordExpression;
+  A: CoordExpression;
+  B: CoordExpression;
+  Perpendicular: boolean;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GFPoint
 export interface GFPoint {
   Answer: CoordExpression;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFPointOnCurve
+export interface GFPointOnCurve {
+  Function: string;
+  Variable: Variable;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.GFSegment
+export interface GFSegment {
+  From: CoordExpression;
+  To: CoordExpression;
+  LabelFrom: string;
+  LabelTo: string;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GFVector
 export interface GFVector {
   Answer: CoordExpression;
@@ -190,7 +249,12 @@ export interface GFVectorPair {
 
 export const GeoFieldKind = {
   GFAffineLine: "GFAffineLine",
+  GFAngle: "GFAngle",
+  GFCircle: "GFCircle",
+  GFLine: "GFLine",
   GFPoint: "GFPoint",
+  GFPointOnCurve: "GFPointOnCurve",
+  GFSegment: "GFSegment",
   GFVector: "GFVector",
   GFVectorPair: "GFVectorPair",
 } as const;
@@ -199,7 +263,12 @@ export type GeoFieldKind = (typeof GeoFieldKind)[keyof typeof GeoFieldKind];
 // github.com/benoitkugler/maths-online/server/src/maths/questions.GeoField
 export type GeoField =
   | { Kind: "GFAffineLine"; Data: GFAffineLine }
+  | { Kind: "GFAngle"; Data: GFAngle }
+  | { Kind: "GFCircle"; Data: GFCircle }
+  | { Kind: "GFLine"; Data: GFLine }
   | { Kind: "GFPoint"; Data: GFPoint }
+  | { Kind: "GFPointOnCurve"; Data: GFPointOnCurve }
+  | { Kind: "GFSegment"; Data: GFSegment }
   | { Kind: "GFVector"; Data: GFVector }
   | { Kind: "GFVectorPair"; Data: GFVectorPair };
 
@@ -208,6 +277,8 @@ export interface GeometricConstructionFieldBlock {
   Field: GeoField;
   Background: FiguresOrGraphs;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.Hints
+export type Hints = Enonce[] | null;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ImageBlock
 export interface ImageBlock {
   URL: string;
@@ -217,10 +288,25 @@ export interface ImageBlock {
 export type In = string;
 // github.com/benoitkugler/maths-online/server/src/maths/questions.Interpolated
 export type Interpolated = string;
+// github.com/benoitkugler/maths-online/server/src/maths/questions.MatchingFieldBlock
+export interface MatchingFieldBlock {
+  Left: Interpolated[] | null;
+  Right: Interpolated[] | null;
+  AdditionalRight: Interpolated[] | null;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.NumberFieldBlock
 export interface NumberFieldBlock {
   Expression: string;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.NumberLineBlock
+export interface NumberLineBlock {
+  Set: string;
+  Ticks: string[] | null;
+}
+// github.com/benoitkugler/maths-online/server/src/maths/questions.NumberLineFieldBlock
+export interface NumberLineFieldBlock {
+  Answer: NumberLineBlock;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.OrderedListFieldBlock
 export interface OrderedListFieldBlock {
   Label: Interpolated;
@@ -230,6 +316,8 @@ export interface OrderedListFieldBlock {
 
 export const ParameterEntryKind = {
   Co: "Co",
+  Ct: "Ct",
+  Fn: "Fn",
   In: "In",
   Rp: "Rp",
 } as const;
@@ -239,6 +327,8 @@ export type ParameterEntryKind =
 // github.com/benoitkugler/maths-online/server/src/maths/questions.ParameterEntry
 export type ParameterEntry =
   | { Kind: "Co"; Data: Co }
+  | { Kind: "Ct"; Data: Ct }
+  | { Kind: "Fn"; Data: Fn }
   | { Kind: "In"; Data: In }
   | { Kind: "Rp"; Data: Rp };
 
@@ -294,6 +384,7 @@ export interface QuestionPage {
   enonce: Enonce;
   parameters: Parameters;
   correction: Enonce;
+  hints: Hints;
 }
 // github.com/benoitkugler/maths-online/server/src/maths/questions.RadioFieldBlock
 export interface RadioFieldBlock {
@@ -339,6 +430,16 @@ export interface TextBlock {
   Italic: boolean;
   Smaller: boolean;
 }
+// github.com/benoitkugler/maths-online/server/src/maths/questions.TextFieldBlock
+export interface TextFieldBlock {
+  Answers: string[] | null;
+  IgnoreCase: boolean;
+  IgnoreAccents: boolean;
+  IgnorePunctuation: boolean;
+  UseRegexp: boolean;
+  Correction: string;
+  MaxTypos: Int;
+}
 // github.com/benoitkugler/maths-online/server/src/maths/questions.TextKind
 export const TextKind = {
   Text: 0,
@@ -404,6 +505,12 @@ export const VectorPairCriterionLabels: Record<VectorPairCriterion, string> = {
   [VectorPairCriterion.VectorOrthogonal]: "Vecteurs orthogonaux",
 };
 
+// github.com/benoitkugler/maths-online/server/src/maths/questions.WrongAnswerHint
+export interface WrongAnswerHint {
+  Answer: string;
+  Hint: string;
+}
+
 // github.com/benoitkugler/maths-online/server/src/maths/questions/client.Binary
 export const Binary = {
   Invalid: 0,
@@ -82,8 +82,14 @@ export function taskBareme(task: TaskExt) {
   return task.Bareme?.reduce((v, qu) => v + qu, 0) || 0;
 }
 
+// optional tasks are not taken into account
 export function sheetBareme(sheet: SheetExt) {
-  return sheet.Tasks?.reduce((v, task) => v + taskBareme(task), 0) || 0;
+  return (
+    sheet.Tasks?.reduce(
+      (v, task) => v + (task.Optional ? 0 : taskBareme(task)),
+      0
+    ) || 0
+  );
 }
 
 // safer and easier access
@@ -1,6 +1,5 @@
 CREATE TABLE classrooms (
     Id serial PRIMARY KEY,
-    IdTeacher integer NOT NULL,
     Name text NOT NULL,
     MaxRankThreshold integer NOT NULL
 );
@@ -20,6 +19,17 @@ CREATE TABLE students (
     Clients jsonb NOT NULL
 );
 
+CREATE TABLE student_groups (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    Name text NOT NULL
+);
+
+CREATE TABLE student_group_members (
+    IdStudentGroup integer NOT NULL,
+    IdStudent integer NOT NULL
+);
+
 CREATE TABLE teachers (
     Id serial PRIMARY KEY,
     Mail text NOT NULL,
@@ -30,12 +40,15 @@ CREATE TABLE teachers (
     FavoriteMatiere text CHECK (FavoriteMatiere IN ('ALLEMAND', 'ANGLAIS', 'AUTRE', 'ESPAGNOL', 'FRANCAIS', 'HISTOIRE-GEO', 'ITALIEN', 'MATHS', 'PHYSIQUE', 'SES', 'SVT')) NOT NULL
 );
 
-CREATE TABLE exercices (
-    Id serial PRIMARY KEY,
-    IdGroup integer NOT NULL,
-    Subtitle text NOT NULL,
-    Parameters jsonb NOT NULL,
-    Difficulty text CHECK (Difficulty IN ('★', '★★', '★★★', '')) NOT NULL
+CREATE TABLE teacher_classrooms (
+    IdTeacher integer NOT NULL,
+    IdClassroom integer NOT NULL
+);
+
+CREATE TABLE teacher_digests (
+    IdTeacher integer NOT NULL,
+    LastSent timestamp(0) with time zone NOT NULL,
+    LastError text NOT NULL
 );
 
 CREATE TABLE exercice_questions (
@@ -66,7 +79,8 @@ CREATE TABLE questions (
     IdGroup integer,
     Enonce jsonb NOT NULL,
     Parameters jsonb NOT NULL,
-    Correction jsonb NOT NULL
+    Correction jsonb NOT NULL,
+    Hints jsonb NOT NULL
 );
 
 CREATE TABLE questiongroups (
@@ -109,7 +123,9 @@ CREATE TABLE progressions (
     IdStudent integer NOT NULL,
     IdTask integer NOT NULL,
     Index smallint NOT NULL,
-    History boolean[]
+    History boolean[],
+    HintsUsed smallint NOT NULL,
+    LastAttempt jsonb NOT NULL
 );
 
 CREATE TABLE random_monoquestions (
@@ -147,7 +163,10 @@ CREATE TABLE sheets (
 CREATE TABLE sheet_tasks (
     IdSheet integer NOT NULL,
     Index integer NOT NULL,
-    IdTask integer NOT NULL
+    IdTask integer NOT NULL,
+    Optional boolean NOT NULL,
+    Requirement smallint CHECK (Requirement IN (0, 1, 2)) NOT NULL,
+    MinMark integer NOT NULL
 );
 
 CREATE TABLE travails (
@@ -158,7 +177,8 @@ CREATE TABLE travails (
     Deadline timestamp(0) with time zone NOT NULL,
     ShowAfter timestamp(0) with time zone NOT NULL,
     QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
-    QuestionTimeLimit integer NOT NULL
+    QuestionTimeLimit integer NOT NULL,
+    HintPenalty integer NOT NULL
 );
 
 CREATE TABLE travail_exceptions (
@@ -168,9 +188,24 @@ CREATE TABLE travail_exceptions (
     IgnoreForMark boolean NOT NULL
 );
 
-CREATE TABLE reviews (
+CREATE TABLE travail_groups (
+    IdTravail integer NOT NULL,
+    IdStudentGroup integer NOT NULL,
+    IdSheet integer
+);
+
+CREATE TABLE travail_schedules (
     Id serial PRIMARY KEY,
-    Kind smallint CHECK (Kind IN (0, 1, 2, 3)) NOT NULL
+    IdClassroom integer NOT NULL,
+    IdSheet integer NOT NULL,
+    IdTeacher integer NOT NULL,
+    Noted boolean NOT NULL,
+    QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
+    QuestionTimeLimit integer NOT NULL,
+    NextShowAfter timestamp(0) with time zone NOT NULL,
+    DeadlineDelay integer NOT NULL,
+    Period integer NOT NULL,
+    Until timestamp(0) with time zone NOT NULL
 );
 
 CREATE TABLE review_exercices (
@@ -218,6 +253,7 @@ CREATE TABLE beltquestions (
     Parameters jsonb NOT NULL,
     Enonce jsonb NOT NULL,
     Correction jsonb NOT NULL,
+    Hints jsonb NOT NULL,
     Repeat integer NOT NULL,
     Title text NOT NULL
 );
@@ -195,6 +195,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_array_ques_Block (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_FunctionArea (data jsonb)
     RETURNS boolean
     AS $$
@@ -402,6 +425,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_repe_NamedRandomLabeledPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -631,7 +677,9 @@ BEGIN
     IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
         RETURN FALSE;
     END IF;
-    CASE WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
         RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'FigureBlock' THEN
         RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
@@ -645,8 +693,14 @@ BEGIN
         RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ImageBlock' THEN
         RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
         RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
         RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
@@ -665,6 +719,8 @@ BEGIN
         RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TextBlock' THEN
         RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeBlock' THEN
         RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
@@ -683,12 +739,34 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_CategoriesFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Categories', 'Items'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Categories')
+        AND gomacro_validate_json_array_array_string (data -> 'Items');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
     RETURNS boolean
     AS $$
 DECLARE
     is_valid boolean := jsonb_typeof(data) = 'number'
-    AND data::int IN (102, 2, 1, 0);
+    AND data::int IN (102, 103, 104, 2, 1, 0);
 BEGIN
     IF NOT is_valid THEN
         RAISE WARNING '% is not a ques_ComparisonLevel', data;
@@ -732,13 +810,14 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp'))
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_string (data -> 'Expression')
         AND gomacro_validate_json_string (data -> 'Label')
         AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
-        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp');
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -1001,6 +1080,76 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -1022,6 +1171,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFVector (data jsonb)
     RETURNS boolean
     AS $$
@@ -1075,8 +1270,18 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
         RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFPoint' THEN
         RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVector' THEN
         RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVectorPair' THEN
@@ -1133,6 +1338,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_MatchingFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Left', 'Right', 'AdditionalRight'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Left')
+        AND gomacro_validate_json_array_string (data -> 'Right')
+        AND gomacro_validate_json_array_string (data -> 'AdditionalRight');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1154,6 +1382,49 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_OrderedListFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1177,6 +1448,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
     RETURNS boolean
     AS $$
@@ -1186,6 +1480,10 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'Co' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
     WHEN data ->> 'Kind' = 'In' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
     WHEN data ->> 'Kind' = 'Rp' THEN
@@ -1530,6 +1828,33 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answers', 'IgnoreCase', 'IgnoreAccents', 'IgnorePunctuation', 'UseRegexp', 'Correction', 'MaxTypos'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Answers')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreCase')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreAccents')
+        AND gomacro_validate_json_boolean (data -> 'IgnorePunctuation')
+        AND gomacro_validate_json_boolean (data -> 'UseRegexp')
+        AND gomacro_validate_json_string (data -> 'Correction')
+        AND gomacro_validate_json_number (data -> 'MaxTypos');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextKind (data jsonb)
     RETURNS boolean
     AS $$
@@ -1717,6 +2042,28 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_repe_Coord (data jsonb)
     RETURNS boolean
     AS $$
@@ -2199,6 +2546,42 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_task_AttemptParam (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN data ->> 'Kind' IN ('CategoriesAnswer', 'DoublePointAnswer', 'DoublePointPairAnswer', 'ExpressionAnswer', 'FunctionPointsAnswer', 'MatchingAnswer', 'NumberAnswer', 'NumberLineAnswer', 'OrderedListAnswer', 'PointAnswer', 'ProofAnswer', 'RadioAnswer', 'SetAnswer', 'SignTableAnswer', 'TableAnswer', 'TextAnswer', 'TreeAnswer', 'VariationTableAnswer');
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_edit_DifficultyTag (data jsonb)
     RETURNS boolean
     AS $$
@@ -2215,6 +2598,122 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_expr_Variable (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Indice', 'Name'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Indice')
+        AND gomacro_validate_json_number (data -> 'Name');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_map_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN (
+        SELECT
+            coalesce(bool_and(gomacro_validate_json_clie_Answer (value)), TRUE)
+        FROM
+            jsonb_each(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_number (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'number';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a number', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'string';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a string', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Variable', 'Resolved'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable')
+        AND gomacro_validate_json_string (data -> 'Resolved');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_QuestionAttempt (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Params', 'Answers'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_task_AttemptParam (data -> 'Params')
+        AND gomacro_validate_json_map_clie_Answer (data -> 'Answers');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_revi_Comment (data jsonb)
     RETURNS boolean
     AS $$
@@ -2334,7 +2833,30 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_string (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_string (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_array_string (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_boolean (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2349,7 +2871,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_array_string (value))
+            bool_and(gomacro_validate_json_boolean (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2357,7 +2879,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_boolean (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_clie_SignSymbol (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2372,7 +2894,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_boolean (value))
+            bool_and(gomacro_validate_json_clie_SignSymbol (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2380,7 +2902,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_clie_SignSymbol (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_Block (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2395,7 +2917,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_clie_SignSymbol (value))
+            bool_and(gomacro_validate_json_ques_Block (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2403,7 +2925,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_Block (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2418,7 +2940,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_ques_Block (value))
+            bool_and(gomacro_validate_json_array_ques_Block (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2633,6 +3155,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_repe_NamedRandomLabeledPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -2797,11 +3342,12 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Success', 'Failure'))
+            bool_and(key IN ('Success', 'Failure', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_number (data -> 'Success')
-        AND gomacro_validate_json_number (data -> 'Failure');
+        AND gomacro_validate_json_number (data -> 'Failure')
+        AND gomacro_validate_json_number (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -2884,7 +3430,9 @@ BEGIN
     IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
         RETURN FALSE;
     END IF;
-    CASE WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
         RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'FigureBlock' THEN
         RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
@@ -2898,8 +3446,14 @@ BEGIN
         RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ImageBlock' THEN
         RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
         RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
         RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
@@ -2918,6 +3472,8 @@ BEGIN
         RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TextBlock' THEN
         RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeBlock' THEN
         RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
@@ -2936,12 +3492,34 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_CategoriesFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Categories', 'Items'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Categories')
+        AND gomacro_validate_json_array_array_string (data -> 'Items');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
     RETURNS boolean
     AS $$
 DECLARE
     is_valid boolean := jsonb_typeof(data) = 'number'
-    AND data::int IN (102, 2, 1, 0);
+    AND data::int IN (102, 103, 104, 2, 1, 0);
 BEGIN
     IF NOT is_valid THEN
         RAISE WARNING '% is not a ques_ComparisonLevel', data;
@@ -2985,13 +3563,14 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp'))
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_string (data -> 'Expression')
         AND gomacro_validate_json_string (data -> 'Label')
         AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
-        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp');
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -3254,6 +3833,76 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -3275,6 +3924,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFVector (data jsonb)
     RETURNS boolean
     AS $$
@@ -3328,8 +4023,18 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
         RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFPoint' THEN
         RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVector' THEN
         RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVectorPair' THEN
@@ -3386,6 +4091,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_MatchingFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Left', 'Right', 'AdditionalRight'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Left')
+        AND gomacro_validate_json_array_string (data -> 'Right')
+        AND gomacro_validate_json_array_string (data -> 'AdditionalRight');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -3407,6 +4135,49 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_OrderedListFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -3430,6 +4201,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
     RETURNS boolean
     AS $$
@@ -3439,6 +4233,10 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'Co' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
     WHEN data ->> 'Kind' = 'In' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
     WHEN data ->> 'Kind' = 'Rp' THEN
@@ -3783,6 +4581,33 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answers', 'IgnoreCase', 'IgnoreAccents', 'IgnorePunctuation', 'UseRegexp', 'Correction', 'MaxTypos'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Answers')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreCase')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreAccents')
+        AND gomacro_validate_json_boolean (data -> 'IgnorePunctuation')
+        AND gomacro_validate_json_boolean (data -> 'UseRegexp')
+        AND gomacro_validate_json_string (data -> 'Correction')
+        AND gomacro_validate_json_number (data -> 'MaxTypos');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextKind (data jsonb)
     RETURNS boolean
     AS $$
@@ -3970,6 +4795,28 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_repe_Coord (data jsonb)
     RETURNS boolean
     AS $$
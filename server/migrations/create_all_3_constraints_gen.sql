@@ -1,11 +1,14 @@
 ALTER TABLE teachers
     ADD UNIQUE (Mail);
 
-ALTER TABLE classrooms
-    ADD UNIQUE (Id, IdTeacher);
+ALTER TABLE teacher_classrooms
+    ADD UNIQUE (IdTeacher, IdClassroom);
 
-ALTER TABLE classrooms
-    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+ALTER TABLE teacher_classrooms
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers;
+
+ALTER TABLE teacher_classrooms
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms;
 
 ALTER TABLE classroom_codes
     ADD UNIQUE (Code);
@@ -16,12 +19,37 @@ ALTER TABLE classroom_codes
 ALTER TABLE students
     ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
 
+ALTER TABLE student_groups
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD UNIQUE (IdStudentGroup, IdStudent);
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudent) REFERENCES students ON DELETE CASCADE;
+
 ALTER TABLE students
     ADD CONSTRAINT Clients_gomacro CHECK (gomacro_validate_json_array_teac_Client (Clients));
 
 ALTER TABLE teachers
     ADD CONSTRAINT Contact_gomacro CHECK (gomacro_validate_json_teac_Contact (Contact));
 
+ALTER TABLE teacher_digests
+    ADD UNIQUE (IdTeacher);
+
+ALTER TABLE teacher_digests
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+CREATE TABLE exercices (
+    Id serial PRIMARY KEY,
+    IdGroup integer NOT NULL,
+    Subtitle text NOT NULL,
+    Parameters jsonb NOT NULL,
+    Difficulty text CHECK (Difficulty IN ('★', '★★', '★★★', '')) NOT NULL
+);
+
 ALTER TABLE questions
     ADD CHECK (NeedExercice IS NOT NULL
         OR IdGroup IS NOT NULL);
@@ -116,6 +144,9 @@ ALTER TABLE exercice_questions
 ALTER TABLE questions
     ADD CONSTRAINT Correction_gomacro CHECK (gomacro_validate_json_array_ques_Block (Correction));
 
+ALTER TABLE questions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+
 ALTER TABLE questions
     ADD CONSTRAINT Enonce_gomacro CHECK (gomacro_validate_json_array_ques_Block (Enonce));
 
@@ -129,7 +160,7 @@ ALTER TABLE trivials
     ADD FOREIGN KEY (IdTeacher) REFERENCES teachers;
 
 ALTER TABLE selfaccess_trivials
-    ADD FOREIGN KEY (IdClassroom, IdTeacher) REFERENCES classrooms (Id, IdTeacher) ON DELETE CASCADE;
+    ADD FOREIGN KEY (IdClassroom, IdTeacher) REFERENCES teacher_classrooms (IdClassroom, IdTeacher) ON DELETE CASCADE;
 
 ALTER TABLE selfaccess_trivials
     ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
@@ -191,6 +222,9 @@ ALTER TABLE progressions
 ALTER TABLE progressions
     ADD FOREIGN KEY (IdTask) REFERENCES tasks ON DELETE CASCADE;
 
+ALTER TABLE progressions
+    ADD CONSTRAINT LastAttempt_gomacro CHECK (gomacro_validate_json_task_QuestionAttempt (LastAttempt));
+
 ALTER TABLE random_monoquestions
     ADD CONSTRAINT Difficulty_gomacro CHECK (gomacro_validate_json_array_edit_DifficultyTag (Difficulty));
 
@@ -233,6 +267,31 @@ ALTER TABLE travail_exceptions
 ALTER TABLE travail_exceptions
     ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
 
+ALTER TABLE travail_groups
+    ADD UNIQUE (IdTravail, IdStudentGroup);
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE SET NULL;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+CREATE TABLE reviews (
+    Id serial PRIMARY KEY,
+    Kind smallint CHECK (Kind IN (0, 1, 2, 3)) NOT NULL
+);
+
 ALTER TABLE reviews
     ADD UNIQUE (Id, Kind);
 
@@ -339,6 +398,9 @@ ALTER TABLE beltevolutions
 ALTER TABLE beltquestions
     ADD CONSTRAINT Correction_gomacro CHECK (gomacro_validate_json_array_ques_Block (Correction));
 
+ALTER TABLE beltquestions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+
 ALTER TABLE beltquestions
     ADD CONSTRAINT Enonce_gomacro CHECK (gomacro_validate_json_array_ques_Block (Enonce));
 
@@ -3,7 +3,6 @@
 -- Code genererated by gomacro/generator/sql. DO NOT EDIT.
 CREATE TABLE classrooms (
     Id serial PRIMARY KEY,
-    IdTeacher integer NOT NULL,
     Name text NOT NULL,
     MaxRankThreshold integer NOT NULL
 );
@@ -23,6 +22,17 @@ CREATE TABLE students (
     Clients jsonb NOT NULL
 );
 
+CREATE TABLE student_groups (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    Name text NOT NULL
+);
+
+CREATE TABLE student_group_members (
+    IdStudentGroup integer NOT NULL,
+    IdStudent integer NOT NULL
+);
+
 CREATE TABLE teachers (
     Id serial PRIMARY KEY,
     Mail text NOT NULL,
@@ -33,15 +43,29 @@ CREATE TABLE teachers (
     FavoriteMatiere text CHECK (FavoriteMatiere IN ('ALLEMAND', 'ANGLAIS', 'AUTRE', 'ESPAGNOL', 'FRANCAIS', 'HISTOIRE-GEO', 'ITALIEN', 'MATHS', 'PHYSIQUE', 'SES', 'SVT')) NOT NULL
 );
 
+CREATE TABLE teacher_classrooms (
+    IdTeacher integer NOT NULL,
+    IdClassroom integer NOT NULL
+);
+
+CREATE TABLE teacher_digests (
+    IdTeacher integer NOT NULL,
+    LastSent timestamp(0) with time zone NOT NULL,
+    LastError text NOT NULL
+);
+
 -- constraints
 ALTER TABLE teachers
     ADD UNIQUE (Mail);
 
-ALTER TABLE classrooms
-    ADD UNIQUE (Id, IdTeacher);
+ALTER TABLE teacher_classrooms
+    ADD UNIQUE (IdTeacher, IdClassroom);
 
-ALTER TABLE classrooms
-    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+ALTER TABLE teacher_classrooms
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers;
+
+ALTER TABLE teacher_classrooms
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms;
 
 ALTER TABLE classroom_codes
     ADD UNIQUE (Code);
@@ -52,6 +76,18 @@ ALTER TABLE classroom_codes
 ALTER TABLE students
     ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
 
+ALTER TABLE student_groups
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD UNIQUE (IdStudentGroup, IdStudent);
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudent) REFERENCES students ON DELETE CASCADE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_teac_Client (data jsonb)
     RETURNS boolean
     AS $$
@@ -140,6 +176,11 @@ ALTER TABLE students
 ALTER TABLE teachers
     ADD CONSTRAINT Contact_gomacro CHECK (gomacro_validate_json_teac_Contact (Contact));
 
+ALTER TABLE teacher_digests
+    ADD UNIQUE (IdTeacher);
+
+ALTER TABLE teacher_digests
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
 -- sql/editor/gen_create.sql
 -- Code genererated by gomacro/generator/sql. DO NOT EDIT.
 CREATE TABLE exercices (
@@ -178,7 +219,8 @@ CREATE TABLE questions (
     IdGroup integer,
     Enonce jsonb NOT NULL,
     Parameters jsonb NOT NULL,
-    Correction jsonb NOT NULL
+    Correction jsonb NOT NULL,
+    Hints jsonb NOT NULL
 );
 
 CREATE TABLE questiongroups (
@@ -401,6 +443,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_array_ques_Block (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_FunctionArea (data jsonb)
     RETURNS boolean
     AS $$
@@ -608,6 +673,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_repe_NamedRandomLabeledPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -837,7 +925,9 @@ BEGIN
     IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
         RETURN FALSE;
     END IF;
-    CASE WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
         RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'FigureBlock' THEN
         RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
@@ -851,8 +941,14 @@ BEGIN
         RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ImageBlock' THEN
         RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
         RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
         RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
@@ -871,6 +967,8 @@ BEGIN
         RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TextBlock' THEN
         RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeBlock' THEN
         RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
@@ -889,12 +987,34 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_CategoriesFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Categories', 'Items'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Categories')
+        AND gomacro_validate_json_array_array_string (data -> 'Items');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
     RETURNS boolean
     AS $$
 DECLARE
     is_valid boolean := jsonb_typeof(data) = 'number'
-    AND data::int IN (102, 2, 1, 0);
+    AND data::int IN (102, 103, 104, 2, 1, 0);
 BEGIN
     IF NOT is_valid THEN
         RAISE WARNING '% is not a ques_ComparisonLevel', data;
@@ -938,13 +1058,14 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp'))
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_string (data -> 'Expression')
         AND gomacro_validate_json_string (data -> 'Label')
         AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
-        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp');
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -1207,6 +1328,76 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -1228,6 +1419,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFVector (data jsonb)
     RETURNS boolean
     AS $$
@@ -1281,8 +1518,18 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
         RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFPoint' THEN
         RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVector' THEN
         RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVectorPair' THEN
@@ -1339,6 +1586,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_MatchingFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Left', 'Right', 'AdditionalRight'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Left')
+        AND gomacro_validate_json_array_string (data -> 'Right')
+        AND gomacro_validate_json_array_string (data -> 'AdditionalRight');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1360,6 +1630,49 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_OrderedListFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1383,6 +1696,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
     RETURNS boolean
     AS $$
@@ -1392,6 +1728,10 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'Co' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
     WHEN data ->> 'Kind' = 'In' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
     WHEN data ->> 'Kind' = 'Rp' THEN
@@ -1736,6 +2076,33 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answers', 'IgnoreCase', 'IgnoreAccents', 'IgnorePunctuation', 'UseRegexp', 'Correction', 'MaxTypos'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Answers')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreCase')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreAccents')
+        AND gomacro_validate_json_boolean (data -> 'IgnorePunctuation')
+        AND gomacro_validate_json_boolean (data -> 'UseRegexp')
+        AND gomacro_validate_json_string (data -> 'Correction')
+        AND gomacro_validate_json_number (data -> 'MaxTypos');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextKind (data jsonb)
     RETURNS boolean
     AS $$
@@ -1923,6 +2290,28 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_repe_Coord (data jsonb)
     RETURNS boolean
     AS $$
@@ -2207,6 +2596,9 @@ IMMUTABLE;
 ALTER TABLE questions
     ADD CONSTRAINT Correction_gomacro CHECK (gomacro_validate_json_array_ques_Block (Correction));
 
+ALTER TABLE questions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+
 ALTER TABLE questions
     ADD CONSTRAINT Enonce_gomacro CHECK (gomacro_validate_json_array_ques_Block (Enonce));
 
@@ -2239,7 +2631,7 @@ ALTER TABLE trivials
     ADD FOREIGN KEY (IdTeacher) REFERENCES teachers;
 
 ALTER TABLE selfaccess_trivials
-    ADD FOREIGN KEY (IdClassroom, IdTeacher) REFERENCES classrooms (Id, IdTeacher) ON DELETE CASCADE;
+    ADD FOREIGN KEY (IdClassroom, IdTeacher) REFERENCES teacher_classrooms (IdClassroom, IdTeacher) ON DELETE CASCADE;
 
 ALTER TABLE selfaccess_trivials
     ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
@@ -2444,7 +2836,9 @@ CREATE TABLE progressions (
     IdStudent integer NOT NULL,
     IdTask integer NOT NULL,
     Index smallint NOT NULL,
-    History boolean[]
+    History boolean[],
+    HintsUsed smallint NOT NULL,
+    LastAttempt jsonb NOT NULL
 );
 
 CREATE TABLE random_monoquestions (
@@ -2541,6 +2935,42 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_task_AttemptParam (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN data ->> 'Kind' IN ('CategoriesAnswer', 'DoublePointAnswer', 'DoublePointPairAnswer', 'ExpressionAnswer', 'FunctionPointsAnswer', 'MatchingAnswer', 'NumberAnswer', 'NumberLineAnswer', 'OrderedListAnswer', 'PointAnswer', 'ProofAnswer', 'RadioAnswer', 'SetAnswer', 'SignTableAnswer', 'TableAnswer', 'TextAnswer', 'TreeAnswer', 'VariationTableAnswer');
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_edit_DifficultyTag (data jsonb)
     RETURNS boolean
     AS $$
@@ -2557,6 +2987,125 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_expr_Variable (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Indice', 'Name'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Indice')
+        AND gomacro_validate_json_number (data -> 'Name');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_map_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN (
+        SELECT
+            coalesce(bool_and(gomacro_validate_json_clie_Answer (value)), TRUE)
+        FROM
+            jsonb_each(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_number (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'number';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a number', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'string';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a string', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Variable', 'Resolved'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable')
+        AND gomacro_validate_json_string (data -> 'Resolved');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_QuestionAttempt (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Params', 'Answers'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_task_AttemptParam (data -> 'Params')
+        AND gomacro_validate_json_map_clie_Answer (data -> 'Answers');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+ALTER TABLE progressions
+    ADD CONSTRAINT LastAttempt_gomacro CHECK (gomacro_validate_json_task_QuestionAttempt (LastAttempt));
+
 ALTER TABLE random_monoquestions
     ADD CONSTRAINT Difficulty_gomacro CHECK (gomacro_validate_json_array_edit_DifficultyTag (Difficulty));
 
@@ -2575,7 +3124,10 @@ CREATE TABLE sheets (
 CREATE TABLE sheet_tasks (
     IdSheet integer NOT NULL,
     Index integer NOT NULL,
-    IdTask integer NOT NULL
+    IdTask integer NOT NULL,
+    Optional boolean NOT NULL,
+    Requirement smallint CHECK (Requirement IN (0, 1, 2)) NOT NULL,
+    MinMark integer NOT NULL
 );
 
 CREATE TABLE travails (
@@ -2586,7 +3138,8 @@ CREATE TABLE travails (
     Deadline timestamp(0) with time zone NOT NULL,
     ShowAfter timestamp(0) with time zone NOT NULL,
     QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
-    QuestionTimeLimit integer NOT NULL
+    QuestionTimeLimit integer NOT NULL,
+    HintPenalty integer NOT NULL
 );
 
 CREATE TABLE travail_exceptions (
@@ -2596,6 +3149,26 @@ CREATE TABLE travail_exceptions (
     IgnoreForMark boolean NOT NULL
 );
 
+CREATE TABLE travail_groups (
+    IdTravail integer NOT NULL,
+    IdStudentGroup integer NOT NULL,
+    IdSheet integer
+);
+
+CREATE TABLE travail_schedules (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    IdSheet integer NOT NULL,
+    IdTeacher integer NOT NULL,
+    Noted boolean NOT NULL,
+    QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
+    QuestionTimeLimit integer NOT NULL,
+    NextShowAfter timestamp(0) with time zone NOT NULL,
+    DeadlineDelay integer NOT NULL,
+    Period integer NOT NULL,
+    Until timestamp(0) with time zone NOT NULL
+);
+
 -- constraints
 ALTER TABLE travails
     ADD UNIQUE (Id, IdSheet);
@@ -2636,6 +3209,26 @@ ALTER TABLE travail_exceptions
 ALTER TABLE travail_exceptions
     ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
 
+ALTER TABLE travail_groups
+    ADD UNIQUE (IdTravail, IdStudentGroup);
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE SET NULL;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
 -- sql/reviews/gen_create.sql
 -- Code genererated by gomacro/generator/sql. DO NOT EDIT.
 CREATE TABLE reviews (
@@ -2842,6 +3435,7 @@ CREATE TABLE beltquestions (
     Parameters jsonb NOT NULL,
     Enonce jsonb NOT NULL,
     Correction jsonb NOT NULL,
+    Hints jsonb NOT NULL,
     Repeat integer NOT NULL,
     Title text NOT NULL
 );
@@ -2930,7 +3524,30 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_array_string (value))
+            bool_and(gomacro_validate_json_array_string (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_boolean (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_boolean (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2938,7 +3555,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_boolean (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_clie_SignSymbol (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2953,7 +3570,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_boolean (value))
+            bool_and(gomacro_validate_json_clie_SignSymbol (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2961,7 +3578,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_clie_SignSymbol (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_Block (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2976,7 +3593,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_clie_SignSymbol (value))
+            bool_and(gomacro_validate_json_ques_Block (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -2984,7 +3601,7 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
-CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_Block (data jsonb)
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
     RETURNS boolean
     AS $$
 BEGIN
@@ -2999,7 +3616,7 @@ BEGIN
     END IF;
     RETURN (
         SELECT
-            bool_and(gomacro_validate_json_ques_Block (value))
+            bool_and(gomacro_validate_json_array_ques_Block (value))
         FROM
             jsonb_array_elements(data));
 END;
@@ -3214,6 +3831,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_repe_NamedRandomLabeledPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -3378,11 +4018,12 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Success', 'Failure'))
+            bool_and(key IN ('Success', 'Failure', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_number (data -> 'Success')
-        AND gomacro_validate_json_number (data -> 'Failure');
+        AND gomacro_validate_json_number (data -> 'Failure')
+        AND gomacro_validate_json_number (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -3465,7 +4106,9 @@ BEGIN
     IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
         RETURN FALSE;
     END IF;
-    CASE WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
         RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'FigureBlock' THEN
         RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
@@ -3479,8 +4122,14 @@ BEGIN
         RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ImageBlock' THEN
         RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
         RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
         RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
@@ -3499,6 +4148,8 @@ BEGIN
         RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TextBlock' THEN
         RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeBlock' THEN
         RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
@@ -3517,12 +4168,34 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_CategoriesFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Categories', 'Items'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Categories')
+        AND gomacro_validate_json_array_array_string (data -> 'Items');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
     RETURNS boolean
     AS $$
 DECLARE
     is_valid boolean := jsonb_typeof(data) = 'number'
-    AND data::int IN (102, 2, 1, 0);
+    AND data::int IN (102, 103, 104, 2, 1, 0);
 BEGIN
     IF NOT is_valid THEN
         RAISE WARNING '% is not a ques_ComparisonLevel', data;
@@ -3566,13 +4239,14 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp'))
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_string (data -> 'Expression')
         AND gomacro_validate_json_string (data -> 'Label')
         AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
-        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp');
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -3835,6 +4509,76 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -3856,6 +4600,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFVector (data jsonb)
     RETURNS boolean
     AS $$
@@ -3909,8 +4699,18 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
         RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFPoint' THEN
         RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVector' THEN
         RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVectorPair' THEN
@@ -3967,6 +4767,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_MatchingFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Left', 'Right', 'AdditionalRight'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Left')
+        AND gomacro_validate_json_array_string (data -> 'Right')
+        AND gomacro_validate_json_array_string (data -> 'AdditionalRight');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -3988,6 +4811,49 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_OrderedListFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -4011,6 +4877,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
     RETURNS boolean
     AS $$
@@ -4020,6 +4909,10 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'Co' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
     WHEN data ->> 'Kind' = 'In' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
     WHEN data ->> 'Kind' = 'Rp' THEN
@@ -4364,6 +5257,33 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answers', 'IgnoreCase', 'IgnoreAccents', 'IgnorePunctuation', 'UseRegexp', 'Correction', 'MaxTypos'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Answers')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreCase')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreAccents')
+        AND gomacro_validate_json_boolean (data -> 'IgnorePunctuation')
+        AND gomacro_validate_json_boolean (data -> 'UseRegexp')
+        AND gomacro_validate_json_string (data -> 'Correction')
+        AND gomacro_validate_json_number (data -> 'MaxTypos');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextKind (data jsonb)
     RETURNS boolean
     AS $$
@@ -4551,6 +5471,28 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_repe_Coord (data jsonb)
     RETURNS boolean
     AS $$
@@ -4838,6 +5780,9 @@ ALTER TABLE beltevolutions
 ALTER TABLE beltquestions
     ADD CONSTRAINT Correction_gomacro CHECK (gomacro_validate_json_array_ques_Block (Correction));
 
+ALTER TABLE beltquestions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+
 ALTER TABLE beltquestions
     ADD CONSTRAINT Enonce_gomacro CHECK (gomacro_validate_json_array_ques_Block (Enonce));
 
@@ -0,0 +1,20 @@
+BEGIN;
+ALTER TABLE sheet_tasks
+    ADD COLUMN Optional boolean;
+ALTER TABLE sheet_tasks
+    ADD COLUMN Requirement smallint CHECK (Requirement IN (0, 1, 2));
+ALTER TABLE sheet_tasks
+    ADD COLUMN MinMark integer;
+UPDATE
+    sheet_tasks
+SET
+    Optional = FALSE,
+    Requirement = 0,
+    MinMark = 0;
+ALTER TABLE sheet_tasks
+    ALTER COLUMN Optional SET NOT NULL;
+ALTER TABLE sheet_tasks
+    ALTER COLUMN Requirement SET NOT NULL;
+ALTER TABLE sheet_tasks
+    ALTER COLUMN MinMark SET NOT NULL;
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+CREATE TABLE student_groups (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    Name text NOT NULL
+);
+CREATE TABLE student_group_members (
+    IdStudentGroup integer NOT NULL,
+    IdStudent integer NOT NULL
+);
+CREATE TABLE travail_groups (
+    IdTravail integer NOT NULL,
+    IdStudentGroup integer NOT NULL,
+    IdSheet integer
+);
+ALTER TABLE student_groups
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+ALTER TABLE student_group_members
+    ADD UNIQUE (IdStudentGroup, IdStudent);
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudent) REFERENCES students ON DELETE CASCADE;
+ALTER TABLE travail_groups
+    ADD UNIQUE (IdTravail, IdStudentGroup);
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE SET NULL;
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+CREATE TABLE travail_schedules (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    IdSheet integer NOT NULL,
+    IdTeacher integer NOT NULL,
+    Noted boolean NOT NULL,
+    QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
+    QuestionTimeLimit integer NOT NULL,
+    NextShowAfter timestamp(0) with time zone NOT NULL,
+    DeadlineDelay integer NOT NULL,
+    Period integer NOT NULL,
+    Until timestamp(0) with time zone NOT NULL
+);
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE CASCADE;
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+CREATE TABLE teacher_digests (
+    IdTeacher integer NOT NULL,
+    LastSent timestamp(0) with time zone NOT NULL
+);
+ALTER TABLE teacher_digests
+    ADD UNIQUE (IdTeacher);
+ALTER TABLE teacher_digests
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
+COMMIT;
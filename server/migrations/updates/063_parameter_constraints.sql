@@ -0,0 +1,24 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    CASE WHEN data ->> 'Kind' = 'Co' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'In' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Rp' THEN
+        RETURN gomacro_validate_json_ques_Rp (data -> 'Data');
+    ELSE
+        RETURN FALSE;
+    END CASE;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+COMMIT;
@@ -0,0 +1,49 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    CASE WHEN data ->> 'Kind' = 'Co' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
+    WHEN data ->> 'Kind' = 'In' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Rp' THEN
+        RETURN gomacro_validate_json_ques_Rp (data -> 'Data');
+    ELSE
+        RETURN FALSE;
+    END CASE;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+COMMIT;
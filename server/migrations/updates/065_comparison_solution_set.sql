@@ -0,0 +1,17 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'number'
+    AND data::int IN (102, 103, 2, 1, 0);
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a ques_ComparisonLevel', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+COMMIT;
@@ -0,0 +1,71 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ExpressionFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Expression')
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+COMMIT;
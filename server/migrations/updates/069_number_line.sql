@@ -0,0 +1,114 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Block (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'FigureBlock' THEN
+        RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'FormulaBlock' THEN
+        RETURN gomacro_validate_json_ques_FormulaBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'FunctionPointsFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_FunctionPointsFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'FunctionsGraphBlock' THEN
+        RETURN gomacro_validate_json_ques_FunctionsGraphBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GeometricConstructionFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ImageBlock' THEN
+        RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_ProofFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'RadioFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_RadioFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'SetFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_SetFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'SignTableBlock' THEN
+        RETURN gomacro_validate_json_ques_SignTableBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'SignTableFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_SignTableFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TableBlock' THEN
+        RETURN gomacro_validate_json_ques_TableBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TableFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextBlock' THEN
+        RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TreeBlock' THEN
+        RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TreeFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'VariationTableBlock' THEN
+        RETURN gomacro_validate_json_ques_VariationTableBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'VariationTableFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_VariationTableFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'VectorFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_VectorFieldBlock (data -> 'Data');
+    ELSE
+        RETURN FALSE;
+    END CASE;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+COMMIT;
@@ -0,0 +1,151 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GeoField (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
+        RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPoint' THEN
+        RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFVector' THEN
+        RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFVectorPair' THEN
+        RETURN gomacro_validate_json_ques_GFVectorPair (data -> 'Data');
+    ELSE
+        RETURN FALSE;
+    END CASE;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+COMMIT;
@@ -0,0 +1,64 @@
+BEGIN;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_array_ques_Block (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+--
+ALTER TABLE questions
+    ADD COLUMN Hints jsonb;
+UPDATE
+    questions
+SET
+    Hints = '[]';
+ALTER TABLE questions
+    ALTER COLUMN Hints SET NOT NULL;
+ALTER TABLE questions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+--
+ALTER TABLE beltquestions
+    ADD COLUMN Hints jsonb;
+UPDATE
+    beltquestions
+SET
+    Hints = '[]';
+ALTER TABLE beltquestions
+    ALTER COLUMN Hints SET NOT NULL;
+ALTER TABLE beltquestions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+--
+ALTER TABLE progressions
+    ADD COLUMN HintsUsed smallint;
+UPDATE
+    progressions
+SET
+    HintsUsed = 0;
+ALTER TABLE progressions
+    ALTER COLUMN HintsUsed SET NOT NULL;
+--
+ALTER TABLE travails
+    ADD COLUMN HintPenalty integer;
+UPDATE
+    travails
+SET
+    HintPenalty = 0;
+ALTER TABLE travails
+    ALTER COLUMN HintPenalty SET NOT NULL;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+ALTER TABLE teacher_digests
+    ADD COLUMN LastError text;
+UPDATE
+    teacher_digests
+SET
+    LastError = '';
+ALTER TABLE teacher_digests
+    ALTER COLUMN LastError SET NOT NULL;
+COMMIT;
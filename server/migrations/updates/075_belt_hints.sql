@@ -0,0 +1,42 @@
+-- record the hints revealed in the belts training mode
+BEGIN;
+ALTER TABLE beltevolutions
+    DROP CONSTRAINT Stats_gomacro;
+CREATE OR REPLACE FUNCTION gomacro_validate_json_cein_Stat (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Success', 'Failure', 'Hints'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_number (data -> 'Success')
+        AND gomacro_validate_json_number (data -> 'Failure')
+        AND gomacro_validate_json_number (data -> 'Hints');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+UPDATE
+    beltevolutions
+SET
+    Stats = (
+        SELECT
+            jsonb_agg((
+                SELECT
+                    jsonb_agg(stat.value || '{"Hints": 0}'::jsonb ORDER BY stat.ordinality)
+                FROM jsonb_array_elements(domain.value)
+                WITH ORDINALITY AS stat) ORDER BY domain.ordinality)
+        FROM
+            jsonb_array_elements(Stats)
+            WITH ORDINALITY AS domain);
+ALTER TABLE beltevolutions
+    ADD CONSTRAINT Stats_gomacro CHECK (gomacro_validate_json_array_12_array_11_cein_Stat (Stats));
+COMMIT;
@@ -32,7 +32,7 @@ func (pj JoinedFile) attach(e *email.Email) error {
 	return err
 }
 
-func newMail(to []string, subject, text string, creds pass.SMTP, pjs []JoinedFile) (*email.Email, error) {
+func newMail(to []string, subject, html, text string, creds pass.SMTP, pjs []JoinedFile) (*email.Email, error) {
 	e := email.NewEmail()
 
 	e.To = to
@@ -43,7 +43,7 @@ func newMail(to []string, subject, text string, creds pass.SMTP, pjs []JoinedFil
 
 	e.From = fmt.Sprintf("Isyro <%s>", creds.User)
 	e.Subject = subject
-	e.HTML = []byte(text)
+	e.HTML = []byte(html)
 	e.Text = []byte(text)
 
 	e.Headers.Set("List-Unsubscribe", fmt.Sprintf("<mailto:%s>", creds.User))
@@ -64,7 +64,7 @@ func getFromAuth(creds pass.SMTP) (string, smtp.Auth) {
 
 // SendMail one simple mail.
 func SendMail(smtp pass.SMTP, to []string, subject, text string) (err error) {
-	e, err := newMail(to, subject, text, smtp, nil)
+	e, err := newMail(to, subject, text, text, smtp, nil)
 	if err != nil {
 		return err
 	}
@@ -96,7 +96,12 @@ func NewPool(credences pass.SMTP, pjs []JoinedFile) (Pool, error) {
 }
 
 func (p Pool) SendMail(to, subject, textBody string) error {
-	mail, err := newMail([]string{to}, subject, textBody, p.creds, p.pjs)
+	return p.SendMailHTML(to, subject, textBody, textBody)
+}
+
+// SendMailHTML sends a mail with distinct HTML and plain text versions.
+func (p Pool) SendMailHTML(to, subject, htmlBody, textBody string) error {
+	mail, err := newMail([]string{to}, subject, htmlBody, textBody, p.creds, p.pjs)
 	if err != nil {
 		return err
 	}
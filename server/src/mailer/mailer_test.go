@@ -1 +1,92 @@
 package mailer
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/pass"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+// startSMTPStub starts a minimal SMTP server, without TLS nor authentication,
+// sending the received mails on the returned channel.
+func startSMTPStub(t *testing.T) (net.Listener, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	tu.AssertNoErr(t, err)
+
+	mails := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTPStub(conn, mails)
+		}
+	}()
+	return ln, mails
+}
+
+func serveSMTPStub(conn net.Conn, mails chan<- string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+	reply("220 localhost stub")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			mails <- data.String()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default: // MAIL, RCPT, RSET, NOOP
+			reply("250 OK")
+		}
+	}
+}
+
+func TestPoolSendMailHTML(t *testing.T) {
+	ln, mails := startSMTPStub(t)
+	defer ln.Close()
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	tu.AssertNoErr(t, err)
+
+	pool, err := NewPool(pass.SMTP{Host: host, Port: port, User: "contact@isyro.fr"}, nil)
+	tu.AssertNoErr(t, err)
+	defer pool.Close()
+
+	err = pool.SendMailHTML("prof@free.fr", "[Isyro] - Test", "<b>Version HTML</b>", "Version texte")
+	tu.AssertNoErr(t, err)
+
+	mail := <-mails
+	tu.Assert(t, strings.Contains(mail, "To: <prof@free.fr>"))
+	tu.Assert(t, strings.Contains(mail, "Subject: [Isyro] - Test"))
+	tu.Assert(t, strings.Contains(mail, "Content-Type: text/html"))
+	tu.Assert(t, strings.Contains(mail, "<b>Version HTML</b>"))
+	tu.Assert(t, strings.Contains(mail, "Content-Type: text/plain"))
+	tu.Assert(t, strings.Contains(mail, "Version texte"))
+}
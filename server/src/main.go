@@ -220,6 +220,8 @@ func main() {
 		return
 	} else {
 		go sanityChecks(db, *skipValidation)
+		go hwc.RunSchedules()
+		go hwc.RunDigests(smtp)
 	}
 	fmt.Println("Setup done (pending sanityChecks)")
 
@@ -347,6 +349,7 @@ func setupRoutes(e *echo.Echo, db *sql.DB,
 	e.PUT("/api/student/ceintures/stage", ce.CeinturesEvaluateAnswers)
 	e.PUT("/api/student/ceintures/training", ce.CeinturesInstantiateTraining)
 	e.POST("/api/student/ceintures/training", ce.CeinturesEvaluateTraining)
+	e.POST("/api/student/ceintures/training/hint", ce.CeinturesShowHint)
 
 	// trivial monitor
 	e.GET("/api/trivial/monitor", tvc.GetTrivialsMetrics)
@@ -385,6 +388,9 @@ func setupRoutes(e *echo.Echo, db *sql.DB,
 	e.POST("/api/exercices/evaluate", func(c echo.Context) error {
 		return evaluateExercice(db, c)
 	})
+	e.POST("/api/exercices/hint", func(c echo.Context) error {
+		return showExerciceHint(db, c)
+	})
 
 	// student homework API
 	e.GET("/api/student/homework/sheets", home.StudentGetTravaux)
@@ -392,6 +398,7 @@ func setupRoutes(e *echo.Echo, db *sql.DB,
 	e.GET("/api/student/homework/sheet", home.StudentLoadTravail)
 	e.GET("/api/student/homework/task/instantiate", home.StudentInstantiateTask)
 	e.POST("/api/student/homework/task/evaluate", home.StudentEvaluateTask)
+	e.POST("/api/student/homework/task/hint", home.StudentShowHint)
 	e.POST("/api/student/homework/task/reset", home.StudentResetTask)
 
 	// student misc API
@@ -27,6 +27,10 @@ const (
 type Expr struct {
 	left, right *Expr
 	atom        atom
+
+	// call marks the nodes which may be a call to
+	// a user defined function (see [expandCalls])
+	call callTag
 }
 
 // Serialize returns the expression as text.
@@ -96,6 +100,7 @@ func (function) lexicographicOrder() int        { return 5 }
 func (indice) lexicographicOrder() int          { return 6 }
 func (specialFunction) lexicographicOrder() int { return 7 }
 func (roundFunc) lexicographicOrder() int       { return 8 }
+func (userFunction) lexicographicOrder() int    { return 9 }
 
 type matrix [][]*Expr // with regular size, 1-indexed
 
@@ -133,6 +138,7 @@ const (
 	// the order is the precedence of operators
 	// used during parsing
 	equals operator = iota
+	notEquals
 	greater
 	strictlyGreater
 	lesser
@@ -158,6 +164,8 @@ func (op operator) String() string {
 	switch op {
 	case equals:
 		return "=="
+	case notEquals:
+		return "!="
 	case greater:
 		return ">="
 	case strictlyGreater:
@@ -0,0 +1,490 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+)
+
+// This file implements the support of complex numbers.
+//
+// To preserve existing expressions, there is no dedicated syntax for the
+// imaginary unit : the variable i is interpreted as the complex number i
+// when it is not defined by the parameters (or used as index in sum(), prod(), ...).
+// Defining a parameter i is rejected when complex functions are used (see [RandomParameters.Validate]).
+// Complex evaluation is only used as a fallback of the regular (real) evaluation,
+// and complex values are not simplified during instantiation (see algForm() and expForm()),
+// so that real valued expressions are not affected.
+
+// imaginaryUnit is the variable denoting the complex number i
+var imaginaryUnit = NewVar('i')
+
+// complexNb stores the complex number re + i*im,
+// where the real and imaginary parts are exact when possible
+type complexNb struct {
+	re, im real
+}
+
+var complexI = complexNb{re: newRealInt(0), im: newRealInt(1)}
+
+func newComplexReal(r real) complexNb { return complexNb{re: r, im: newRealInt(0)} }
+
+func (r real) isZero() bool {
+	if r.isRational {
+		return r.rat.isZero()
+	}
+	return r.val == 0
+}
+
+// isReal returns true if the imaginary part is zero,
+// up to floating point rounding errors
+func (z complexNb) isReal() bool {
+	if z.im.isRational {
+		return z.im.rat.isZero()
+	}
+	return AreFloatEqual(z.im.val, 0)
+}
+
+func (z complexNb) isZero() bool { return z.re.isZero() && z.im.isZero() }
+
+func (z complexNb) equals(other complexNb) bool {
+	return AreFloatEqual(z.re.eval(), other.re.eval()) && AreFloatEqual(z.im.eval(), other.im.eval())
+}
+
+func (z complexNb) conj() complexNb {
+	z.im.opposite()
+	return z
+}
+
+func sumComplex(z1, z2 complexNb) complexNb {
+	return complexNb{re: sumReal(z1.re, z2.re), im: sumReal(z1.im, z2.im)}
+}
+
+func minusComplex(z1, z2 complexNb) complexNb {
+	return complexNb{re: minusReal(z1.re, z2.re), im: minusReal(z1.im, z2.im)}
+}
+
+func multComplex(z1, z2 complexNb) complexNb {
+	return complexNb{
+		re: minusReal(multReal(z1.re, z2.re), multReal(z1.im, z2.im)),
+		im: sumReal(multReal(z1.re, z2.im), multReal(z1.im, z2.re)),
+	}
+}
+
+// squaredModulus returns re^2 + im^2
+func (z complexNb) squaredModulus() real {
+	return sumReal(multReal(z.re, z.re), multReal(z.im, z.im))
+}
+
+func divComplex(z1, z2 complexNb) (complexNb, error) {
+	if z2.isZero() {
+		return complexNb{}, errors.New("Division par zéro.")
+	}
+	den := z2.squaredModulus()
+	num := multComplex(z1, z2.conj())
+	return complexNb{re: divReal(num.re, den), im: divReal(num.im, den)}, nil
+}
+
+// sqrtRat returns the exact square root of r, if it is rational
+func sqrtRat(r rat) (rat, bool) {
+	v := r.toBig()
+	if v.Sign() < 0 {
+		return rat{}, false
+	}
+	num, den := new(big.Int).Sqrt(v.Num()), new(big.Int).Sqrt(v.Denom())
+	out := new(big.Rat).SetFrac(num, den)
+	if new(big.Rat).Mul(out, out).Cmp(v) != 0 {
+		return rat{}, false
+	}
+	return newRatBig(out), true
+}
+
+// modulus returns |z|, which is exact when possible
+func (z complexNb) modulus() real {
+	if z.im.isZero() {
+		abs := z.re
+		if abs.eval() < 0 {
+			abs.opposite()
+		}
+		return abs
+	}
+	sq := z.squaredModulus()
+	if sq.isRational {
+		if r, ok := sqrtRat(sq.rat); ok {
+			return real{isRational: true, rat: r}
+		}
+	}
+	return newReal(math.Sqrt(sq.eval()))
+}
+
+// argument returns the principal argument of z, in ]-pi; pi]
+func (z complexNb) argument() (real, error) {
+	if z.isZero() {
+		return real{}, errors.New("L'argument de 0 n'est pas défini.")
+	}
+	return newReal(math.Atan2(z.im.eval(), z.re.eval())), nil
+}
+
+// expComplex returns e^z
+func expComplex(z complexNb) complexNb {
+	if z.im.isZero() {
+		return newComplexReal(newReal(math.Exp(z.re.eval())))
+	}
+	r, theta := math.Exp(z.re.eval()), z.im.eval()
+	return complexNb{re: newReal(r * math.Cos(theta)), im: newReal(r * math.Sin(theta))}
+}
+
+// maxComplexPower is the maximum exponent for which
+// z^n is computed exactly
+const maxComplexPower = 100
+
+// powComplex returns z^w, which is exact for small integer exponents
+func powComplex(z, w complexNb) (complexNb, error) {
+	if w.im.isZero() {
+		if n, isInt := IsInt(w.re.eval()); isInt && -maxComplexPower <= n && n <= maxComplexPower {
+			if n < 0 {
+				if z.isZero() {
+					return complexNb{}, errors.New("Division par zéro.")
+				}
+				zn, _ := powComplex(z, newComplexReal(newRealInt(-n)))
+				return divComplex(newComplexReal(newRealInt(1)), zn)
+			}
+			out := newComplexReal(newRealInt(1))
+			for ; n > 0; n >>= 1 {
+				if n&1 == 1 {
+					out = multComplex(out, z)
+				}
+				z = multComplex(z, z)
+			}
+			return out, nil
+		}
+	}
+	if z.isZero() {
+		if w.re.eval() > 0 {
+			return newComplexReal(newRealInt(0)), nil
+		}
+		return complexNb{}, errors.New("Division par zéro.")
+	}
+	// z^w = exp(w log(z))
+	arg, _ := z.argument()
+	logZ := complexNb{re: newReal(math.Log(z.modulus().eval())), im: arg}
+	return expComplex(multComplex(w, logZ)), nil
+}
+
+// isImaginaryUnit returns true if [v] is i and is not defined in [ctx]
+func (ctx *resolver) isImaginaryUnit(v Variable) bool {
+	if v != imaginaryUnit {
+		return false
+	}
+	if v == ctx.tmpVariable {
+		return false
+	}
+	if _, has := ctx.results[v]; has {
+		return false
+	}
+	_, has := ctx.defs[v]
+	return !has
+}
+
+// usesComplexFunction returns true if [expr] calls one of
+// the functions dedicated to complex numbers
+func (expr *Expr) usesComplexFunction() bool {
+	if expr == nil {
+		return false
+	}
+	if sf, ok := expr.atom.(specialFunction); ok {
+		switch sf.kind {
+		case reFn, imFn, conjFn, argFn, algFormFn, expFormFn, randComplex:
+			return true
+		}
+		for _, arg := range sf.args {
+			if arg.usesComplexFunction() {
+				return true
+			}
+		}
+	}
+	return expr.left.usesComplexFunction() || expr.right.usesComplexFunction()
+}
+
+// validateImaginaryUnit returns an error if i is defined as a parameter
+// while complex functions are used, since the definition would silently
+// replace the imaginary unit.
+func (rv RandomParameters) validateImaginaryUnit() error {
+	if !rv.IsDefined(imaginaryUnit) {
+		return nil
+	}
+	usesComplex := false
+	for _, expr := range rv.defs {
+		usesComplex = usesComplex || expr.usesComplexFunction()
+	}
+	for _, fn := range rv.functions {
+		usesComplex = usesComplex || fn.Function.usesComplexFunction()
+	}
+	for _, ct := range rv.constraints {
+		usesComplex = usesComplex || ct.usesComplexFunction()
+	}
+	if usesComplex {
+		return ErrInvalidRandomParameters{
+			Cause:  imaginaryUnit,
+			Detail: "La variable i est réservée au nombre complexe i lorsque des fonctions complexes sont utilisées : merci de renommer ce paramètre.",
+		}
+	}
+	return nil
+}
+
+// AreComplexEquivalent is the same as [AreExpressionsEquivalent] with
+// [ExpandedSubstitutions], but also compares the expressions by value
+// as complex numbers, where i is the imaginary unit.
+// It should only be used when complex numbers are expected, since
+// a free variable i is then no longer a regular variable.
+func AreComplexEquivalent(e1, e2 *Expr) bool {
+	z1, err1 := e1.evalComplex(nil)
+	z2, err2 := e2.evalComplex(nil)
+	if err1 == nil && err2 == nil && z1.equals(z2) {
+		return true
+	}
+	return AreExpressionsEquivalent(e1, e2, ExpandedSubstitutions)
+}
+
+// evalComplex evaluates [expr] as a complex number.
+// The regular evaluation is tried first, so that
+// real valued expressions are evaluated as usual.
+// [ctx] may be nil.
+func (expr *Expr) evalComplex(ctx *resolver) (complexNb, error) {
+	if ctx == nil {
+		ctx = Vars(nil).resolver()
+	}
+	r, errReal := expr.evalReal(ctx)
+	if errReal == nil {
+		return newComplexReal(r), nil
+	}
+
+	switch atom := expr.atom.(type) {
+	case Variable:
+		if ctx.isImaginaryUnit(atom) {
+			return complexI, nil
+		}
+		value, err := ctx.resolve(atom)
+		if err != nil {
+			return complexNb{}, err
+		}
+		return value.evalComplex(ctx)
+	case operator:
+		left, right := newComplexReal(newRealInt(0)), newComplexReal(newRealInt(0))
+		var err error
+		if expr.left != nil {
+			left, err = expr.left.evalComplex(ctx)
+			if err != nil {
+				return complexNb{}, err
+			}
+		}
+		if expr.right != nil {
+			right, err = expr.right.evalComplex(ctx)
+			if err != nil {
+				return complexNb{}, err
+			}
+		}
+		switch atom {
+		case plus:
+			return sumComplex(left, right), nil
+		case minus:
+			return minusComplex(left, right), nil
+		case mult:
+			return multComplex(left, right), nil
+		case div:
+			return divComplex(left, right)
+		case pow:
+			return powComplex(left, right)
+		case equals:
+			return newComplexReal(evalBool(left.equals(right))), nil
+		case notEquals:
+			return newComplexReal(evalBool(!left.equals(right))), nil
+		default:
+			return complexNb{}, fmt.Errorf("L'opération %s n'est pas définie pour les nombres complexes.", atom)
+		}
+	case function:
+		arg, err := expr.right.evalComplex(ctx)
+		if err != nil {
+			return complexNb{}, err
+		}
+		switch atom {
+		case expFn:
+			return expComplex(arg), nil
+		case absFn:
+			return newComplexReal(arg.modulus()), nil
+		default:
+			return complexNb{}, fmt.Errorf("La fonction %s n'est pas définie pour les nombres complexes.", atom)
+		}
+	case specialFunction:
+		switch atom.kind {
+		case conjFn, algFormFn, expFormFn:
+			z, err := atom.args[0].evalComplex(ctx)
+			if err != nil {
+				return complexNb{}, err
+			}
+			if atom.kind == conjFn {
+				return z.conj(), nil
+			}
+			return z, nil
+		case randComplex:
+			return atom.randomComplex(ctx)
+		case randChoice:
+			return atom.args[rand.Intn(len(atom.args))].evalComplex(ctx)
+		case choiceFrom:
+			choice, err := choiceFromSelect(atom.args, ctx)
+			if err != nil {
+				return complexNb{}, err
+			}
+			return choice.evalComplex(ctx)
+		}
+	}
+	return complexNb{}, errReal
+}
+
+// evalComplexPart evaluates the real valued functions
+// of a complex number
+func (sf specialFunction) evalComplexPart(ctx *resolver) (real, error) {
+	z, err := sf.args[0].evalComplex(ctx)
+	if err != nil {
+		return real{}, err
+	}
+	switch sf.kind {
+	case reFn:
+		return z.re, nil
+	case imFn:
+		return z.im, nil
+	case argFn:
+		return z.argument()
+	case conjFn, algFormFn, expFormFn: // only valid for real numbers
+		if !z.isReal() {
+			return real{}, errors.New("Un nombre complexe non réel ne peut pas être évalué comme un réel.")
+		}
+		return z.re, nil
+	default:
+		panic(exhaustiveSpecialFunctionSwitch)
+	}
+}
+
+// randomComplex returns a + ib, where a and b are random integers
+// in [start, end], with b non zero
+func (sf specialFunction) randomComplex(ctx *resolver) (complexNb, error) {
+	start, end, err := startEnd(sf.args[0], sf.args[1], ctx)
+	if err != nil {
+		return complexNb{}, err
+	}
+	err = sf.kind.validateStartEnd(start, end, 0)
+	if err != nil {
+		return complexNb{}, err
+	}
+	a := randomInt(int(start), int(end))
+	b := 0
+	for b == 0 {
+		b = randomInt(int(start), int(end))
+	}
+	return complexNb{re: newRealInt(a), im: newRealInt(b)}, nil
+}
+
+// roundPart removes floating point rounding errors
+func roundPart(r real) real {
+	if r.isRational {
+		return r
+	}
+	return newReal(RoundFloat(r.val))
+}
+
+// toExpr returns the algebraic form a + bi
+func (z complexNb) toExpr() *Expr {
+	re, im := roundPart(z.re), roundPart(z.im)
+	if im.isZero() {
+		return re.toExpr()
+	}
+	isNegative := im.eval() < 0
+	if isNegative {
+		im.opposite()
+	}
+	imPart := NewVarExpr(imaginaryUnit)
+	if !(im.isRational && im.rat.eval() == 1) {
+		imPart = &Expr{atom: mult, left: im.toExpr(), right: imPart}
+	}
+	switch {
+	case re.isZero() && isNegative:
+		return &Expr{atom: minus, right: imPart}
+	case re.isZero():
+		return imPart
+	case isNegative:
+		return &Expr{atom: minus, left: re.toExpr(), right: imPart}
+	default:
+		return &Expr{atom: plus, left: re.toExpr(), right: imPart}
+	}
+}
+
+// maxPiDenominator is the maximum denominator
+// used when writing an argument as a fraction of pi
+const maxPiDenominator = 12
+
+// piFraction returns an expression for theta,
+// written as a fraction of pi when possible
+func piFraction(theta float64) *Expr {
+	for q := 1; q <= maxPiDenominator; q++ {
+		p, isInt := IsInt(RoundFloat(theta / math.Pi * float64(q)))
+		if !isInt {
+			continue
+		}
+		if p == 0 {
+			return NewNb(0)
+		}
+		isNegative := p < 0
+		if isNegative {
+			p = -p
+		}
+		num := &Expr{atom: piConstant}
+		if p != 1 {
+			num = &Expr{atom: mult, left: newNb(float64(p)), right: num}
+		}
+		if q != 1 {
+			num = &Expr{atom: div, left: num, right: newNb(float64(q))}
+		}
+		if isNegative {
+			num = &Expr{atom: minus, right: num}
+		}
+		return num
+	}
+	return newNb(RoundFloat(theta))
+}
+
+// toExponentialForm returns r e^{i theta}, where
+// r is written as a square root when possible and theta as a fraction of pi
+func (z complexNb) toExponentialForm() *Expr {
+	if z.isZero() {
+		return NewNb(0)
+	}
+	var modulus *Expr
+	sq := roundPart(z.squaredModulus())
+	if !sq.isRational {
+		modulus = newNb(RoundFloat(math.Sqrt(sq.val)))
+	} else if r, ok := sqrtRat(sq.rat); ok {
+		modulus = real{isRational: true, rat: r}.toExpr()
+	} else if n, isInt := IsInt(sq.eval()); isInt {
+		modulus = &Expr{atom: sqrtFn, right: newNb(float64(n))}
+	} else {
+		modulus = newNb(RoundFloat(math.Sqrt(sq.eval())))
+	}
+
+	arg, _ := z.argument()
+	theta := piFraction(arg.eval())
+	if theta.atom == Number(0) {
+		return modulus
+	}
+	exponent := &Expr{atom: mult, left: NewVarExpr(imaginaryUnit), right: theta}
+	if theta.atom == minus && theta.left == nil {
+		// -i * pi/2 instead of i * (-pi/2)
+		exponent = &Expr{atom: minus, right: &Expr{atom: mult, left: NewVarExpr(imaginaryUnit), right: theta.right}}
+	}
+	out := &Expr{atom: pow, left: &Expr{atom: eConstant}, right: exponent}
+	if modulus.atom == Number(1) {
+		return out
+	}
+	return &Expr{atom: mult, left: modulus, right: out}
+}
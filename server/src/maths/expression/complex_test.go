@@ -0,0 +1,204 @@
+package expression
+
+import (
+	"math"
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestEvalComplex(t *testing.T) {
+	for _, test := range []struct {
+		expr   string
+		re, im float64
+	}{
+		{"i", 0, 1},
+		{"2 + 3i", 2, 3},
+		{"i^2", -1, 0},
+		{"(1+i)^2", 0, 2},
+		{"(1+i)(1-i)", 2, 0},
+		{"1/i", 0, -1},
+		{"(3 + 4i) / (1 - 2i)", -1, 2},
+		{"(1+i)^(-2)", 0, -0.5},
+		{"conj(2 - 5i)", 2, 5},
+		{"e^(i*pi)", -1, 0},
+		{"2e^(i*pi/2)", 0, 2},
+		{"exp(i*pi/3)", 0.5, math.Sqrt(3) / 2},
+		{"i^i", math.Exp(-math.Pi / 2), 0},
+	} {
+		z, err := MustParse(test.expr).evalComplex(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, z.equals(complexNb{re: newReal(test.re), im: newReal(test.im)}))
+	}
+
+	_, err := MustParse("1 / (i - i)").evalComplex(nil)
+	tu.Assert(t, err != nil)
+	_, err = MustParse("i > 1").evalComplex(nil)
+	tu.Assert(t, err != nil)
+	_, err = MustParse("ln(i)").evalComplex(nil)
+	tu.Assert(t, err != nil)
+}
+
+func TestComplexFunctions(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		want float64
+	}{
+		{"re(2 - 3i)", 2},
+		{"im(2 - 3i)", -3},
+		{"Re((1+i)^2)", 0},
+		{"abs(3 + 4i)", 5},
+		{"abs(-2)", 2},
+		{"arg(1 + i)", math.Pi / 4},
+		{"arg(-1)", math.Pi},
+		{"arg(-2i)", -math.Pi / 2},
+		{"conj(2)", 2},
+		{"im(z)", 5},
+	} {
+		got := mustEvaluate(test.expr, Vars{NewVar('z'): MustParse("1 + 5i")})
+		tu.Assert(t, AreFloatEqual(got, test.want))
+	}
+
+	// exact parts
+	v, err := MustParse("im(1/(1+2i))").evalReal(nil)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, v.isRational && v.toExpr().String() == MustParse("-2/5").String())
+
+	for _, expr := range []string{"arg(0)", "conj(i)", "i", "2+i"} {
+		_, err := MustParse(expr).Evaluate(nil)
+		tu.Assert(t, err != nil)
+	}
+
+	// re is only a function when followed by (
+	e := MustParse("re^2")
+	tu.Assert(t, e.String() == MustParse("r*e^2").String())
+	_, err = Parse("re(1; 2)")
+	tu.Assert(t, err != nil)
+}
+
+func TestImaginaryUnitAsVariable(t *testing.T) {
+	// i defined as parameter is not the imaginary unit
+	tu.Assert(t, mustEvaluate("i^2", Vars{NewVar('i'): NewNb(3)}) == 9)
+	tu.Assert(t, mustEvaluate("sum(i; 1; 3; i)", nil) == 6)
+	tu.Assert(t, mustEvaluate("abs(sum(i; 1; 2; i^2) + i)", nil) == math.Sqrt(26))
+}
+
+func TestComplexToExpr(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		want string
+	}{
+		{"(1+i)^2", "2i"},
+		{"(2+i)(1-i)", "3 - i"},
+		{"i^3", "-i"},
+		{"1/(1+i)", "1/2 - (1/2)i"},
+		{"e^(i*pi)", "-1"},
+		{"conj(3 + 2i)", "3 - 2i"},
+	} {
+		z, err := MustParse(test.expr).evalComplex(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, z.toExpr().String() == MustParse(test.want).String())
+	}
+}
+
+func TestComplexExponentialForm(t *testing.T) {
+	for _, test := range []struct {
+		expr      string
+		wantLaTeX string
+	}{
+		{"expForm(1 + i)", `\sqrt{2} {e}^{i \frac{\pi}{4}}`},
+		{"expForm(-3)", `3 {e}^{i \pi}`},
+		{"expForm(-2i)", `2 {e}^{-i \frac{\pi}{2}}`},
+		{"expForm(i)", `{e}^{i \frac{\pi}{2}}`},
+		{"expForm(4)", `4`},
+		{"expForm(1 + sqrt(3)i)", `2 {e}^{i \frac{\pi}{3}}`},
+		{"expForm(z)", `\text{expForm(z)}`},
+		{"algForm(2e^(i*pi/2))", `2 i`},
+	} {
+		e := MustParse(test.expr)
+		tu.Assert(t, e.AsLaTeX() == test.wantLaTeX)
+		if test.expr == "expForm(z)" {
+			continue
+		}
+
+		// the exponential form has the same value
+		z, err := e.evalComplex(nil)
+		tu.AssertNoErr(t, err)
+		exp := z.toExponentialForm()
+		z2, err := exp.evalComplex(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, z.equals(z2))
+	}
+}
+
+func TestComplexParameters(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('z'), "randComplex(-5; 5)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('w'), "algForm(z * conj(z))"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "re(z)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('b'), "im(z)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('u'), "algForm((1 + i)^2)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('v'), "(1 + i)^2"))
+	tu.AssertNoErr(t, rv.Validate())
+
+	vars, err := rv.Instantiate()
+	tu.AssertNoErr(t, err)
+	a, b := vars[NewVar('a')].mustEvaluate(nil), vars[NewVar('b')].mustEvaluate(nil)
+	tu.Assert(t, -5 <= a && a <= 5 && -5 <= b && b <= 5 && b != 0)
+	// z * conj(z) is real
+	tu.Assert(t, vars[NewVar('w')].mustEvaluate(nil) == a*a+b*b)
+	tu.Assert(t, vars[NewVar('u')].String() == MustParse("2i").String())
+	// complex values are not simplified by default
+	tu.Assert(t, vars[NewVar('v')].String() == MustParse("(1 + i)^2").String())
+
+	_, err = Parse("randComplex(0; 0)")
+	tu.Assert(t, err != nil)
+	_, err = Parse("randComplex(1)")
+	tu.Assert(t, err != nil)
+}
+
+func TestComplexParametersShadowingI(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('i'), "randInt(1;4)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('z'), "randComplex(-3;3)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "re(z)"))
+	err := rv.Validate()
+	tu.Assert(t, err != nil)
+	_, ok := err.(ErrInvalidRandomParameters)
+	tu.Assert(t, ok)
+
+	// i is still a regular parameter without complex functions
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('i'), "randInt(1;4)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "2i + 1"))
+	tu.AssertNoErr(t, rv.Validate())
+}
+
+func TestComplexEquivalent(t *testing.T) {
+	for _, test := range []struct {
+		e1, e2 string
+		want   bool
+	}{
+		{"(1+i)^2", "2i", true},
+		{"3 + 2i", "2i + 3", true},
+		{"1/i", "-i", true},
+		{"e^(i*pi/2)", "i", true},
+		{"2 + 3i", "2 - 3i", false},
+		{"2 + 3i", "2", false},
+		{"x + i", "i + x", true},
+	} {
+		got := AreComplexEquivalent(MustParse(test.e1), MustParse(test.e2))
+		tu.Assert(t, got == test.want)
+	}
+
+	// without opting in, i is a regular variable
+	for _, test := range [][2]string{
+		{"i^2", "-1"},
+		{"i^4", "1"},
+		{"1/i", "-i"},
+	} {
+		tu.Assert(t, !AreExpressionsEquivalent(MustParse(test[0]), MustParse(test[1]), SimpleSubstitutions))
+		tu.Assert(t, !AreExpressionsEquivalent(MustParse(test[0]), MustParse(test[1]), ExpandedSubstitutions))
+		tu.Assert(t, AreComplexEquivalent(MustParse(test[0]), MustParse(test[1])))
+	}
+}
@@ -6,7 +6,7 @@ import (
 )
 
 // Compound is a sum type for a complex math object, built on [Expr]s,
-// such as sets, intervals, or vectors.
+// such as sets, intervals, vectors, lists or relations.
 // For compatibility, [*Expr] are also implementing [Compound].
 // Note that nested compound objects, such as sets of sets, are not supported.
 type Compound interface {
@@ -33,8 +33,36 @@ type Compound interface {
 // extending [Parse].
 // If invalid, [ErrInvalidExpr] is returned.
 func ParseCompound(expr string) (out Compound, err error) {
+	return parseCompound(expr, false)
+}
+
+// ParseEquationCompound is the same as [ParseCompound], but also
+// accepts a single = in equations, such as 2x + 1 = 3.
+// It should only be used where equations are expected.
+func ParseEquationCompound(expr string) (out Compound, err error) {
+	return parseCompound(expr, true)
+}
+
+func parseCompound(expr string, acceptSingleEqual bool) (out Compound, err error) {
+	// combination of relations, such as x > 3 et x <= 5
+	if rels, ok, err := parseRelations(expr, acceptSingleEqual); ok {
+		if err != nil {
+			errV := err.(ErrInvalidExpr)
+			errV.Input = expr
+			return nil, errV
+		}
+		return rels, nil
+	}
+
 	bytes := []byte(expr)
+	parseExpr := func() (*Expr, error) {
+		pr := newParser(bytes)
+		pr.tk.acceptSingleEqual = acceptSingleEqual
+		e, _, err := pr.parseAll()
+		return e, err
+	}
 	pr := newParser(bytes)
+	pr.tk.acceptSingleEqual = acceptSingleEqual
 	// look for a starting delimiter
 	switch tok := pr.tk.Peek().data; tok {
 	case openBracket, closeBracket: // Interval
@@ -46,14 +74,14 @@ func ParseCompound(expr string) (out Compound, err error) {
 		// to distinguish, try to parse as an expression,
 		// if it fails, try as vector
 		var parsedExpr *Expr
-		parsedExpr, _, err = parseBytes(bytes)
+		parsedExpr, err = parseExpr()
 		if err == nil { // simple expression
 			out = parsedExpr
 		} else { // it's a vector
 			out, err = pr.parseVector()
 		}
 	default: // simple expression
-		out, _, err = parseBytes(bytes)
+		out, err = parseExpr()
 	}
 
 	if err != nil {
@@ -62,6 +90,13 @@ func ParseCompound(expr string) (out Compound, err error) {
 		return nil, errV
 	}
 
+	// list literals are parsed as expressions
+	if e, ok := out.(*Expr); ok {
+		if sf, ok := e.atom.(specialFunction); ok && sf.kind == listFn {
+			out = List(sf.args)
+		}
+	}
+
 	return out, nil
 }
 
@@ -254,6 +289,8 @@ func areIntervalsEquivalent(inter1, inter2 Interval, level ComparisonLevel) bool
 //
 // See AreExpressionsEquivalent for the meaning of [level].
 func AreCompoundsEquivalent(e1, e2 Compound, level ComparisonLevel) bool {
+	// lists may be obtained after substituting parameters
+	e1, e2 = asListCompound(e1), asListCompound(e2)
 	switch e1 := e1.(type) {
 	case Vector:
 		e2, ok := e2.(Vector)
@@ -273,6 +310,18 @@ func AreCompoundsEquivalent(e1, e2 Compound, level ComparisonLevel) bool {
 			return false
 		}
 		return areIntervalsEquivalent(e1, e2, level)
+	case Relations:
+		e2, ok := e2.(Relations)
+		if !ok {
+			return false
+		}
+		return areRelationsEquivalent(e1, e2, level)
+	case List: // the order matters
+		e2, ok := e2.(List)
+		if !ok {
+			return false
+		}
+		return areVectorsEquivalent(Vector(e1), Vector(e2), level)
 	case *Expr:
 		e2, ok := e2.(*Expr)
 		if !ok {
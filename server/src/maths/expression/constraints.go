@@ -0,0 +1,108 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+)
+
+// This file implements constraints on random parameters, such as a != b
+// or b^2 - 4ac > 0. They are enforced by rejection sampling : the parameters
+// are instantiated again until all the constraints are satisfied.
+
+const (
+	// maxConstraintTries is the number of instantiations tried
+	// before giving up
+	maxConstraintTries = 1_000
+
+	// MinAcceptanceRate is the minimum proportion of instantiations
+	// satisfying the constraints. Below, the constraints are considered
+	// practically unsatisfiable.
+	MinAcceptanceRate = 0.02
+)
+
+// ErrConstraint is returned when a constraint can't be evaluated
+// or when it is never satisfied.
+type ErrConstraint struct {
+	Constraint string
+	Detail     string
+}
+
+func (ec ErrConstraint) Error() string {
+	return fmt.Sprintf("%s -> %s", ec.Constraint, ec.Detail)
+}
+
+// ParseConstraint parses the given boolean expression and adds it to the parameters.
+func (rp *RandomParameters) ParseConstraint(expr string) error {
+	e, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	rp.constraints = append(rp.constraints, e)
+	return nil
+}
+
+// HasConstraints returns true if at least one constraint is defined.
+func (rp RandomParameters) HasConstraints() bool { return len(rp.constraints) != 0 }
+
+// checkConstraints returns true if all the constraints are satisfied,
+// or an error if one of them can't be evaluated.
+// A constraint is satisfied if it evaluates to a non zero value.
+func (rp RandomParameters) checkConstraints(vars Vars) (bool, error) {
+	for _, constraint := range rp.constraints {
+		value, err := constraint.Evaluate(vars)
+		if err != nil {
+			return false, ErrConstraint{Constraint: constraint.String(), Detail: err.Error()}
+		}
+		if math.IsNaN(value) {
+			return false, ErrConstraint{Constraint: constraint.String(), Detail: "La contrainte produit une valeur invalide (NaN)."}
+		}
+		if value == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// AcceptanceRate instantiates the parameters [nbTries] times, and returns the
+// proportion of instantiations satisfying the constraints (without retrying).
+// It returns 1 when no constraints are defined.
+func (rv RandomParameters) AcceptanceRate(nbTries int) (float64, error) {
+	if !rv.HasConstraints() {
+		return 1, nil
+	}
+	inst := NewInstantiater(rv)
+	accepted := 0
+	for i := 0; i < nbTries; i++ {
+		inst.Reset()
+		vars, err := inst.instantiateOnce()
+		if err != nil {
+			return 0, err
+		}
+		ok, err := rv.checkConstraints(vars)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			accepted++
+		}
+	}
+	return float64(accepted) / float64(nbTries), nil
+}
+
+// validateConstraints returns an error if the constraints are invalid
+// or practically unsatisfiable.
+func (rv RandomParameters) validateConstraints() error {
+	const nbTries = 500
+	rate, err := rv.AcceptanceRate(nbTries)
+	if err != nil {
+		return err
+	}
+	if rate < MinAcceptanceRate {
+		return ErrConstraint{
+			Constraint: "Contraintes",
+			Detail: fmt.Sprintf("Les contraintes ne sont satisfaites que dans %.1f %% des tirages (minimum requis : %.0f %%).",
+				100*rate, 100*MinAcceptanceRate),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package expression
+
+import (
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestNotEquals(t *testing.T) {
+	tu.Assert(t, mustEvaluate("2 != 3", nil) == 1)
+	tu.Assert(t, mustEvaluate("2 ≠ 2", nil) == 0)
+	tu.Assert(t, mustEvaluate("(1+1) != 2", nil) == 0)
+
+	e := MustParse("a != b")
+	tu.Assert(t, e.String() == "a != b")
+	tu.Assert(t, e.AsLaTeX() == `a \neq b`)
+}
+
+func TestConstraints(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "randInt(1;3)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('b'), "randInt(1;3)"))
+	tu.AssertNoErr(t, rv.ParseConstraint("a != b"))
+	tu.AssertNoErr(t, rv.ParseConstraint("b^2 - 4a > -10"))
+	tu.Assert(t, rv.ParseConstraint("a != ") != nil)
+	tu.Assert(t, rv.HasConstraints())
+
+	tu.AssertNoErr(t, rv.Validate())
+
+	inst := NewInstantiater(*rv)
+	for range [200]int{} {
+		inst.Reset()
+		vars, err := inst.Instantiate()
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, vars[NewVar('a')].mustEvaluate(nil) != vars[NewVar('b')].mustEvaluate(nil))
+	}
+	// the theoretical rate is 2/3
+	rate := inst.AcceptanceRate()
+	tu.Assert(t, 0.4 < rate && rate < 0.9)
+
+	rate, err := rv.AcceptanceRate(300)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, 0.4 < rate && rate < 0.9)
+}
+
+func TestConstraintsInvalid(t *testing.T) {
+	// never satisfied
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "randInt(1;3)"))
+	tu.AssertNoErr(t, rv.ParseConstraint("a > 3"))
+	err := rv.Validate()
+	_, isConstraint := err.(ErrConstraint)
+	tu.Assert(t, isConstraint)
+	_, err = rv.Instantiate()
+	tu.Assert(t, err != nil)
+
+	// satisfied with probability 1/1000
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "randInt(1;1000)"))
+	tu.AssertNoErr(t, rv.ParseConstraint("a == 1"))
+	tu.Assert(t, rv.Validate() != nil)
+
+	// undefined variable
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "randInt(1;3)"))
+	tu.AssertNoErr(t, rv.ParseConstraint("a != c"))
+	tu.Assert(t, rv.Validate() != nil)
+
+	// no constraints
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "randInt(1;3)"))
+	rate, err := rv.AcceptanceRate(10)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, rate == 1)
+}
@@ -0,0 +1,332 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+)
+
+// asDecimalRational converts a decimal number such as 0.3
+// to the exact rational 3/10, so that probabilities computed
+// from it stay exact.
+func asDecimalRational(r real) real {
+	if r.isRational || math.IsInf(r.val, 0) || math.IsNaN(r.val) {
+		return r
+	}
+	s := strconv.FormatFloat(r.val, 'f', -1, 64)
+	if len(s) > 20 { // not a "short" decimal number
+		return r
+	}
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return r
+	}
+	return real{isRational: true, rat: newRatBig(v)}
+}
+
+// evalProbability evaluates [arg] and checks it is in [0, 1]
+func evalProbability(arg *Expr, ctx *resolver) (real, error) {
+	p, err := arg.evalReal(ctx)
+	if err != nil {
+		return real{}, err
+	}
+	if pf := p.eval(); !(0 <= pf && pf <= 1) {
+		return real{}, fmt.Errorf("Une probabilité doit être comprise entre 0 et 1 (%g reçu).", pf)
+	}
+	return asDecimalRational(p), nil
+}
+
+// maxBinomTrials is the maximum number of trials of binomial laws,
+// which bounds the cost of the evaluations and simulations
+const maxBinomTrials = 10_000
+
+// evalBinomParams returns n and p for binomial laws
+func evalBinomParams(nE, pE *Expr, ctx *resolver) (int, real, error) {
+	n, err := evalInt(nE, ctx)
+	if err != nil {
+		return 0, real{}, fmt.Errorf("Le nombre de répétitions d'une loi binomiale doit être un entier (%s).", err)
+	}
+	if n < 0 {
+		return 0, real{}, fmt.Errorf("Le nombre de répétitions d'une loi binomiale doit être positif (%d reçu).", n)
+	}
+	if n > maxBinomTrials {
+		return 0, real{}, fmt.Errorf("Le nombre de répétitions d'une loi binomiale doit être au plus %d (%d reçu).", maxBinomTrials, n)
+	}
+	p, err := evalProbability(pE, ctx)
+	return n, p, err
+}
+
+// binomialProbability returns P(X = k) for X ~ B(n, p)
+// The result is exact when possible, and is otherwise
+// computed in log space, to avoid overflows for large n.
+func binomialProbability(n, k int, p real) real {
+	if k < 0 || k > n {
+		return newRealInt(0)
+	}
+	q := minusReal(newRealInt(1), p)
+	coeff, pk, qk := binomialCoefficient(k, n), powReal(p, float64(k)), powReal(q, float64(n-k))
+	if coeff.isRational && pk.isRational && qk.isRational {
+		return multReal(coeff, multReal(pk, qk))
+	}
+	return newReal(binomialProbabilityFloat(n, k, p.eval()))
+}
+
+func binomialProbabilityFloat(n, k int, p float64) float64 {
+	switch p {
+	case 0:
+		if k == 0 {
+			return 1
+		}
+		return 0
+	case 1:
+		if k == n {
+			return 1
+		}
+		return 0
+	}
+	lgN, _ := math.Lgamma(float64(n + 1))
+	lgK, _ := math.Lgamma(float64(k + 1))
+	lgNK, _ := math.Lgamma(float64(n - k + 1))
+	return math.Exp(lgN - lgK - lgNK + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+}
+
+// geometricProbability returns P(X = k) for X ~ G(p) (first success at k)
+func geometricProbability(k int, p real) real {
+	if k < 1 {
+		return newRealInt(0)
+	}
+	q := minusReal(newRealInt(1), p)
+	return multReal(powReal(q, float64(k-1)), p)
+}
+
+// evalLaw returns the values and probabilities of a random variable,
+// given as two lists
+func (sf specialFunction) evalLaw(ctx *resolver) (values, probas []real, err error) {
+	valuesE, isList, err := sf.args[0].instantiateList(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	probasE, isList2, err := sf.args[1].instantiateList(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isList || !isList2 {
+		return nil, nil, fmt.Errorf("Les arguments de %s() doivent être des listes.", sf.kind.String())
+	}
+	if len(valuesE) != len(probasE) {
+		return nil, nil, fmt.Errorf("Les listes des valeurs et des probabilités n'ont pas la même taille (%d et %d).", len(valuesE), len(probasE))
+	}
+	values, err = evalSeries(valuesE, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	probas = make([]real, len(probasE))
+	total := newRealInt(0)
+	for i, pE := range probasE {
+		probas[i], err = evalProbability(pE, ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		total = sumReal(total, probas[i])
+	}
+	if !AreFloatEqual(total.eval(), 1) {
+		return nil, nil, fmt.Errorf("La somme des probabilités doit valoir 1 (%g reçu).", total.eval())
+	}
+	return values, probas, nil
+}
+
+func lawExpectation(values, probas []real) real {
+	out := newRealInt(0)
+	for i, v := range values {
+		out = sumReal(out, multReal(v, probas[i]))
+	}
+	return out
+}
+
+func lawVariance(values, probas []real) real {
+	e := lawExpectation(values, probas)
+	out := newRealInt(0)
+	for i, v := range values {
+		d := minusReal(v, e)
+		out = sumReal(out, multReal(probas[i], multReal(d, d)))
+	}
+	return out
+}
+
+// evalNormalParams returns mu and sigma, starting at args[start],
+// defaulting to N(0, 1)
+func (sf specialFunction) evalNormalParams(start int, ctx *resolver) (mu, sigma float64, err error) {
+	if len(sf.args) == start {
+		return 0, 1, nil
+	}
+	mu, err = sf.args[start].evalFloat(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	sigma, err = sf.args[start+1].evalFloat(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if sigma <= 0 {
+		return 0, 0, fmt.Errorf("L'écart-type d'une loi normale doit être strictement positif (%g reçu).", sigma)
+	}
+	return mu, sigma, nil
+}
+
+// drawBinom simulates a binomial variable
+func drawBinom(random func() float64, n int, p float64) int {
+	out := 0
+	for i := 0; i < n; i++ {
+		if random() < p {
+			out++
+		}
+	}
+	return out
+}
+
+// simulateBinom returns a list of N draws of B(n, p),
+// using the optional seed for reproducible simulations
+func (sf specialFunction) simulateBinom(ctx *resolver) (*Expr, error) {
+	N, err := evalInt(sf.args[0], ctx)
+	if err != nil {
+		return nil, err
+	}
+	if N <= 0 || N > maxListSize {
+		return nil, fmt.Errorf("La taille d'une liste doit être entre 1 et %d (%d reçu)", maxListSize, N)
+	}
+	n, p, err := evalBinomParams(sf.args[1], sf.args[2], ctx)
+	if err != nil {
+		return nil, err
+	}
+	random := rand.Float64
+	if len(sf.args) == 4 {
+		seed, err := evalInt(sf.args[3], ctx)
+		if err != nil {
+			return nil, fmt.Errorf("La graine de simBinom() doit être un entier (%s).", err)
+		}
+		random = rand.New(rand.NewSource(int64(seed))).Float64
+	}
+	out := make([]*Expr, N)
+	for i := range out {
+		out[i] = newRealInt(drawBinom(random, n, p.eval())).toExpr()
+	}
+	return newList(out), nil
+}
+
+// evalDistribution evaluates the probability functions
+func (sf specialFunction) evalDistribution(ctx *resolver) (real, error) {
+	switch sf.kind {
+	case binomPdf, binomCdf:
+		n, p, err := evalBinomParams(sf.args[0], sf.args[2], ctx)
+		if err != nil {
+			return real{}, err
+		}
+		k, err := evalInt(sf.args[1], ctx)
+		if err != nil {
+			return real{}, fmt.Errorf("Le deuxième argument de %s() doit être un entier (%s).", sf.kind.String(), err)
+		}
+		if sf.kind == binomPdf {
+			return binomialProbability(n, k, p), nil
+		}
+		if k >= n {
+			return newRealInt(1), nil
+		}
+		out := newRealInt(0)
+		for i := 0; i <= k; i++ {
+			out = sumReal(out, binomialProbability(n, i, p))
+		}
+		return out, nil
+	case geomPdf, geomCdf:
+		k, err := evalInt(sf.args[0], ctx)
+		if err != nil {
+			return real{}, fmt.Errorf("Le premier argument de %s() doit être un entier (%s).", sf.kind.String(), err)
+		}
+		p, err := evalProbability(sf.args[1], ctx)
+		if err != nil {
+			return real{}, err
+		}
+		if sf.kind == geomPdf {
+			return geometricProbability(k, p), nil
+		}
+		if k < 1 {
+			return newRealInt(0), nil
+		}
+		// P(X <= k) = 1 - (1-p)^k
+		q := minusReal(newRealInt(1), p)
+		return minusReal(newRealInt(1), powReal(q, float64(k))), nil
+	case unifPdf, unifCdf:
+		x, err := sf.args[0].evalReal(ctx)
+		if err != nil {
+			return real{}, err
+		}
+		a, b, err := startEnd(sf.args[1], sf.args[2], ctx)
+		if err != nil {
+			return real{}, err
+		}
+		if a >= b {
+			return real{}, fmt.Errorf("Les bornes d'une loi uniforme doivent vérifier a < b (%g et %g reçus).", a, b)
+		}
+		ar, br := asDecimalRational(newReal(a)), asDecimalRational(newReal(b))
+		x = asDecimalRational(x)
+		width := minusReal(br, ar)
+		if sf.kind == unifPdf {
+			if xf := x.eval(); xf < a || xf > b {
+				return newRealInt(0), nil
+			}
+			return divReal(newRealInt(1), width), nil
+		}
+		switch xf := x.eval(); {
+		case xf <= a:
+			return newRealInt(0), nil
+		case xf >= b:
+			return newRealInt(1), nil
+		default:
+			return divReal(minusReal(x, ar), width), nil
+		}
+	case normalCdf:
+		a, b, err := startEnd(sf.args[0], sf.args[1], ctx)
+		if err != nil {
+			return real{}, err
+		}
+		mu, sigma, err := sf.evalNormalParams(2, ctx)
+		if err != nil {
+			return real{}, err
+		}
+		// P(a <= X <= b)
+		fa := math.Erf((a - mu) / (sigma * math.Sqrt2))
+		fb := math.Erf((b - mu) / (sigma * math.Sqrt2))
+		return newReal((fb - fa) / 2), nil
+	case invNorm:
+		p, err := sf.args[0].evalFloat(ctx)
+		if err != nil {
+			return real{}, err
+		}
+		if !(0 < p && p < 1) {
+			return real{}, fmt.Errorf("Le premier argument de invNorm() doit être strictement compris entre 0 et 1 (%g reçu).", p)
+		}
+		mu, sigma, err := sf.evalNormalParams(1, ctx)
+		if err != nil {
+			return real{}, err
+		}
+		return newReal(mu + sigma*math.Sqrt2*math.Erfinv(2*p-1)), nil
+	case expectationFn, varianceFn:
+		values, probas, err := sf.evalLaw(ctx)
+		if err != nil {
+			return real{}, err
+		}
+		if sf.kind == expectationFn {
+			return lawExpectation(values, probas), nil
+		}
+		return lawVariance(values, probas), nil
+	case randBinom:
+		n, p, err := evalBinomParams(sf.args[0], sf.args[1], ctx)
+		if err != nil {
+			return real{}, err
+		}
+		return newRealInt(drawBinom(rand.Float64, n, p.eval())), nil
+	default:
+		panic(exhaustiveSpecialFunctionSwitch)
+	}
+}
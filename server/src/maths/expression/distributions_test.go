@@ -0,0 +1,143 @@
+package expression
+
+import (
+	"math"
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestEvalDistributions(t *testing.T) {
+	tests := []struct {
+		expr     string
+		bindings Vars
+		want     float64
+	}{
+		{"binomPdf(4 ; 2 ; 1/2)", nil, 6. / 16},
+		{"binomPdf(3 ; 0 ; 0.1)", nil, 0.729},
+		{"binomPdf(3 ; 4 ; 0.1)", nil, 0},
+		{"binomCdf(2 ; 1 ; 1/2)", nil, 3. / 4},
+		{"binomCdf(5 ; 5 ; 0.3)", nil, 1},
+		{"binomCdf(n ; -1 ; 0.3)", Vars{NewVar('n'): NewNb(5)}, 0},
+		{"geomPdf(3 ; 1/2)", nil, 1. / 8},
+		{"geomPdf(0 ; 1/2)", nil, 0},
+		{"geomCdf(3 ; 1/2)", nil, 7. / 8},
+		{"unifPdf(2 ; 0 ; 4)", nil, 1. / 4},
+		{"unifPdf(5 ; 0 ; 4)", nil, 0},
+		{"unifCdf(1 ; 0 ; 4)", nil, 1. / 4},
+		{"unifCdf(-1 ; 0 ; 4)", nil, 0},
+		{"unifCdf(6 ; 0 ; 4)", nil, 1},
+		{"normalCdf(-inf ; 0)", nil, 0.5},
+		{"normalCdf(-inf ; inf ; 3 ; 2)", nil, 1},
+		{"normalCdf(1 ; 5 ; 3 ; 2)", nil, math.Erf(1 / math.Sqrt2)},
+		{"invNorm(0.5)", nil, 0},
+		{"invNorm(0.5 ; 10 ; 2)", nil, 10},
+		{"expectation(list(0 ; 1 ; 2) ; list(1/4 ; 1/2 ; 1/4))", nil, 1},
+		{"variance(list(0 ; 1 ; 2) ; list(1/4 ; 1/2 ; 1/4))", nil, 1. / 2},
+		{"expectation(X ; P)", Vars{NewVar('X'): MustParse("list(-1 ; 5)"), NewVar('P'): MustParse("list(0.8 ; 0.2)")}, 0.2},
+	}
+	for _, tt := range tests {
+		got := mustEvaluate(tt.expr, tt.bindings)
+		tu.Assert(t, AreFloatEqual(got, tt.want))
+	}
+
+	tu.Assert(t, math.Abs(mustEvaluate("normalCdf(-1.96 ; 1.96)", nil)-0.95) < 1e-3)
+	tu.Assert(t, math.Abs(mustEvaluate("invNorm(0.975)", nil)-1.96) < 1e-3)
+	tu.Assert(t, AreFloatEqual(mustEvaluate("normalCdf(-inf ; invNorm(0.3 ; 2 ; 5) ; 2 ; 5)", nil), 0.3))
+
+	// large number of trials
+	tu.Assert(t, math.Abs(mustEvaluate("binomPdf(1100 ; 550 ; 0.5)", nil)-0.024054) < 1e-5)
+	tu.Assert(t, math.Abs(mustEvaluate("binomCdf(3000 ; 1500 ; 0.37)", nil)-1) < 1e-9)
+	tu.Assert(t, math.Abs(mustEvaluate("binomCdf(3000 ; 1110 ; 0.37)", nil)-0.5) < 0.02)
+}
+
+func TestDistributionsExact(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		want string
+	}{
+		{"binomPdf(3 ; 1 ; 0.1)", "243/1000"},
+		{"binomPdf(4 ; 2 ; 1/3)", "8/27"},
+		{"binomCdf(3 ; 1 ; 0.1)", "243/250"},
+		{"geomCdf(2 ; 1/3)", "5/9"},
+		{"expectation(list(0 ; 1) ; list(2/3 ; 1/3))", "1/3"},
+		{"unifCdf(1 ; 0 ; 3)", "1/3"},
+	} {
+		v, err := MustParse(test.expr).evalReal(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, v.isRational)
+		tu.Assert(t, v.toExpr().String() == MustParse(test.want).String())
+	}
+}
+
+func TestDistributionsErrors(t *testing.T) {
+	for _, expr := range []string{
+		"binomPdf(3 ; 1 ; 1.2)",
+		"binomPdf(-3 ; 1 ; 0.5)",
+		"binomPdf(2.5 ; 1 ; 0.5)",
+		"geomPdf(1.5 ; 0.5)",
+		"unifCdf(1 ; 3 ; 0)",
+		"normalCdf(0 ; 1 ; 0 ; -1)",
+		"invNorm(1)",
+		"expectation(list(0 ; 1) ; list(1/2 ; 1/4))",
+		"expectation(list(0 ; 1) ; list(1))",
+		"expectation(1 ; 1)",
+		"simBinom(10 ; 4 ; 0.5)",
+		"randBinom(20000000 ; 0.5)",
+		"binomPdf(20000 ; 1 ; 0.5)",
+	} {
+		_, err := MustParse(expr).Evaluate(nil)
+		tu.Assert(t, err != nil)
+	}
+
+	for _, expr := range []string{
+		"binomPdf(3 ; 1)",
+		"normalCdf(0 ; 1 ; 2)",
+		"invNorm(0.5 ; 1)",
+		"simBinom(10 ; 4)",
+	} {
+		_, err := Parse(expr)
+		tu.Assert(t, err != nil)
+	}
+}
+
+func TestSimulations(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('S'), "simBinom(50 ; 10 ; 0.3 ; 42)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('f'), "mean(S) / 10"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('X'), "randBinom(10 ; 0.3)"))
+	tu.AssertNoErr(t, rv.Validate())
+
+	vars1, err := rv.Instantiate()
+	tu.AssertNoErr(t, err)
+	vars2, err := rv.Instantiate()
+	tu.AssertNoErr(t, err)
+
+	// the seed makes the simulation reproducible
+	tu.Assert(t, vars1[NewVar('S')].String() == vars2[NewVar('S')].String())
+	S := vars1[NewVar('S')].atom.(specialFunction)
+	tu.Assert(t, S.kind == listFn && len(S.args) == 50)
+	for _, v := range S.args {
+		vf := v.mustEvaluate(nil)
+		tu.Assert(t, 0 <= vf && vf <= 10)
+	}
+	f := vars1[NewVar('f')].mustEvaluate(nil)
+	tu.Assert(t, 0 <= f && f <= 1)
+	X := vars1[NewVar('X')].mustEvaluate(nil)
+	tu.Assert(t, 0 <= X && X <= 10)
+
+	tu.Assert(t, mustEvaluate("randBinom(10 ; 0)", nil) == 0)
+	tu.Assert(t, mustEvaluate("randBinom(10 ; 1)", nil) == 10)
+}
+
+func TestDistributionsLaTeX(t *testing.T) {
+	e := MustParse("binomPdf(n ; 2 ; 1/3)")
+	tu.Assert(t, e.AsLaTeX() == `\operatorname{binomPdf}\left(n ; 2 ; \frac{1}{3}\right)`)
+
+	back, err := ParseLaTeX(e.AsLaTeX())
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, back.String() == e.String())
+
+	e = MustParse("expectation(list(1;2;3);list(0.2;0.3;0.5))")
+	tu.Assert(t, e.AsLaTeX() == `\operatorname{expectation}\left(\left[1 ; 2 ; 3\right] ; \left[0,2 ; 0,3 ; 0,5\right]\right)`)
+}
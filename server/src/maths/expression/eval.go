@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
 	"math/rand"
 	"strconv"
 )
@@ -93,6 +95,12 @@ func (vrs Vars) resolver() *resolver {
 // If a variable is in a cycle and can't be resolved, ErrCycleVariable` is returned.
 // If the expression is not valid, like in randInt(2; -2), `ErrInvalidExpr` is returned
 func (expr *Expr) Evaluate(vars Vars) (float64, error) {
+	if functions := vars.functions(); len(functions) != 0 {
+		expr = expr.Copy()
+		if err := expr.expandCalls(functions, nil); err != nil {
+			return 0, err
+		}
+	}
 	resolver := vars.resolver()
 	return expr.evalFloat(resolver)
 }
@@ -260,6 +268,8 @@ func (op operator) evaluate(left, right real) real {
 	switch op {
 	case equals:
 		return evalBool(AreFloatEqual(left.eval(), right.eval()))
+	case notEquals:
+		return evalBool(!AreFloatEqual(left.eval(), right.eval()))
 	case greater:
 		return evalBool(left.eval() >= right.eval())
 	case strictlyGreater:
@@ -276,20 +286,12 @@ func (op operator) evaluate(left, right real) real {
 		return multReal(left, right)
 	case div:
 		return divReal(left, right)
-	case mod:
-		leftInt, leftIsInt := IsInt(left.eval())
-		rightInt, rightIsInt := IsInt(right.eval())
-		if !(leftIsInt && rightIsInt) {
-			return newRealInt(0)
-		}
-		return newRealInt(leftInt % rightInt)
-	case rem:
-		leftInt, leftIsInt := IsInt(left.eval())
-		rightInt, rightIsInt := IsInt(right.eval())
-		if !(leftIsInt && rightIsInt) {
+	case mod, rem:
+		res, ok := modReal(left, right, op == rem)
+		if !ok {
 			return newRealInt(0)
 		}
-		return newRealInt(leftInt / rightInt)
+		return res
 	case pow:
 		return powReal(left, right.eval())
 	case factorial:
@@ -297,8 +299,7 @@ func (op operator) evaluate(left, right real) real {
 		if !argIsInt {
 			return newRealInt(0)
 		}
-		f := evalFactorial(argInt)
-		return newRealInt(f)
+		return evalFactorial(argInt)
 	case union, intersection, complement:
 		// should not append
 		return real{}
@@ -363,6 +364,11 @@ func (round roundFunc) eval(_, right *Expr, b *resolver) (real, error) {
 func (fn function) eval(_, right *Expr, b *resolver) (real, error) {
 	arg, err := right.evalFloat(b)
 	if err != nil {
+		if fn == absFn { // modulus of a complex number
+			if z, errComplex := right.evalComplex(b); errComplex == nil {
+				return z.modulus(), nil
+			}
+		}
 		return real{}, err
 	}
 	switch fn {
@@ -596,8 +602,15 @@ func (r specialFunction) evalRat(ctx *resolver) (real, error) {
 		if err != nil {
 			return real{}, fmt.Errorf("Le second argument de binom() doit être un entier (%s).", err)
 		}
-		return newRealInt(binomialCoefficient(k, n)), nil
-	case randMatrixInt, unionFn, interFn:
+		return binomialCoefficient(k, n), nil
+	case meanFn, medianFn, q1Fn, q3Fn, varFn, stdevFn, countFn:
+		return r.evalStatistic(ctx)
+	case binomPdf, binomCdf, geomPdf, geomCdf, unifPdf, unifCdf, normalCdf, invNorm,
+		expectationFn, varianceFn, randBinom:
+		return r.evalDistribution(ctx)
+	case reFn, imFn, conjFn, argFn, algFormFn, expFormFn:
+		return r.evalComplexPart(ctx)
+	case randMatrixInt, unionFn, interFn, listFn, randList, sortFn, simBinom, randComplex:
 		return real{}, fmt.Errorf("La fonction %s() ne peut pas être évaluée.", r.kind.String())
 	default:
 		panic(exhaustiveSpecialFunctionSwitch)
@@ -646,8 +659,18 @@ func (r specialFunction) eval(_, _ *Expr, ctx *resolver) (real, error) {
 
 // --------------------------- numbers computations ---------------------------
 
+// rat stores an exact rational number p/q.
+// Small values are stored as int64, and [big.Rat] is used
+// when needed, so that computations never overflow.
+// The underlying value is never mutated : operations always
+// allocate a new value.
+type rat struct {
+	p, q int64    // in irreductible form, with q > 0 ; meaningful only if big is nil
+	big  *big.Rat // non nil for values not fitting in int64
+}
+
 // greatest common divisor (GCD) via Euclidean algorithm
-func gcd(a, b int) int {
+func gcd(a, b int64) int64 {
 	for b != 0 {
 		t := b
 		b = a % b
@@ -656,86 +679,213 @@ func gcd(a, b int) int {
 	return a
 }
 
-// rat stores a rational number p/q, with q != 0
-type rat struct {
-	p, q int
+// newRat returns p/q, with q != 0
+func newRat(p, q int) rat {
+	return newRatInt64(int64(p), int64(q))
+}
+
+// newRatInt64 returns p/q, in irreductible form, with q != 0
+func newRatInt64(p, q int64) rat {
+	if p == math.MinInt64 || q == math.MinInt64 { // -p would overflow
+		return newRatBig(new(big.Rat).SetFrac(big.NewInt(p), big.NewInt(q)))
+	}
+	if p == 0 {
+		return rat{p: 0, q: 1}
+	}
+	g := gcd(p, q)
+	p, q = p/g, q/g
+	// simplify the minus
+	if q < 0 {
+		p, q = -p, -q
+	}
+	return rat{p: p, q: q}
 }
 
-func (r rat) eval() float64 { return float64(r.p) / float64(r.q) }
+// newRatBig uses the small representation if possible
+func newRatBig(r *big.Rat) rat {
+	if num, den := r.Num(), r.Denom(); num.IsInt64() && den.IsInt64() &&
+		num.Int64() != math.MinInt64 {
+		return rat{p: num.Int64(), q: den.Int64()}
+	}
+	return rat{big: r}
+}
 
-// update `r` to be in irreductible form
-func (r *rat) reduce() {
-	// special case for 0 / -5 : avoid spurious -0
-	if r.p == 0 {
-		r.p = 0
-		r.q = 1
-		return
+// toBig returns a [big.Rat] which must not be mutated
+func (r rat) toBig() *big.Rat {
+	if r.big != nil {
+		return r.big
 	}
+	return big.NewRat(r.p, r.q)
+}
 
-	// simplify integer denominators
-	// commonDen = den1 * den2 / gcd()
-	g := gcd(r.p, r.q)
-	r.p /= g
-	r.q /= g
+func (r rat) eval() float64 {
+	if r.big != nil {
+		f, _ := r.big.Float64()
+		return f
+	}
+	return float64(r.p) / float64(r.q)
+}
 
-	// simplify the minus
-	if r.q < 0 {
-		r.p = -r.p
-		r.q = -r.q
+func (r rat) isZero() bool { return r.big == nil && r.p == 0 }
+
+// isInt returns the integer value of [r], if any
+func (r rat) isInt() (*big.Int, bool) {
+	if r.big != nil {
+		if !r.big.IsInt() {
+			return nil, false
+		}
+		return r.big.Num(), true
+	}
+	if r.q != 1 {
+		return nil, false
 	}
+	return big.NewInt(r.p), true
 }
 
+// maxExactFloat is the biggest integer exactly
+// representable by a float64
+const maxExactFloat = 1 << 53
+
+func isExactFloat(i int64) bool { return -maxExactFloat <= i && i <= maxExactFloat }
+
+// toExpr returns the irreductible fraction, or a float
+// approximation if the numerator or denominator are too big to
+// be stored in a [Number]
 func (r rat) toExpr() *Expr {
-	r.reduce()
+	if r.big != nil || !isExactFloat(r.p) || !isExactFloat(r.q) {
+		return newNb(r.eval())
+	}
 
 	// avoid useless 4 / 1 or 0 / 1 fractions
-	if r.q == 1 || r.p == 0 {
+	if r.q == 1 {
 		return newNb(float64(r.p))
 	}
 
 	return &Expr{atom: div, left: newNb(float64(r.p)), right: newNb(float64(r.q))}
 }
 
+// mulInt64 returns a*b, or false on overflow
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return c, true
+}
+
+// addInt64 returns a+b, or false on overflow
+func addInt64(a, b int64) (int64, bool) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, false
+	}
+	return c, true
+}
+
 func sumRat(r1, r2 rat) rat {
-	// den1, ok1 := IsInt(r1.q)
-	// den2, ok2 := IsInt(r2.q)
-	// if ok1 && ok2 {
-	// 	// simplify integer denominators
-	// 	// commonDen = den1 * den2 / gcd()
-	// 	commonDen := float64(lcm(den1, den2))
-	// 	factor1 := commonDen / r1.q
-	// 	factor2 := commonDen / r2.q
-	// 	return real{p: r1.p*factor1 + r2.p*factor2, q: commonDen}
-	// }
-	// // general case: do not simplify
-	return rat{p: r1.p*r2.q + r2.p*r1.q, q: r1.q * r2.q}
+	if r1.big == nil && r2.big == nil {
+		n1, ok1 := mulInt64(r1.p, r2.q)
+		n2, ok2 := mulInt64(r2.p, r1.q)
+		den, ok3 := mulInt64(r1.q, r2.q)
+		if ok1 && ok2 && ok3 {
+			if num, ok := addInt64(n1, n2); ok {
+				return newRatInt64(num, den)
+			}
+		}
+	}
+	return newRatBig(new(big.Rat).Add(r1.toBig(), r2.toBig()))
+}
+
+func (r rat) opposite() rat {
+	if r.big != nil || r.p == math.MinInt64 {
+		return newRatBig(new(big.Rat).Neg(r.toBig()))
+	}
+	return rat{p: -r.p, q: r.q}
 }
 
 // return r1 - r2
 func minusRat(r1, r2 rat) rat {
-	return sumRat(r1, rat{p: -r2.p, q: r2.q})
+	return sumRat(r1, r2.opposite())
 }
 
 func multRat(r1, r2 rat) rat {
-	return rat{p: r1.p * r2.p, q: r1.q * r2.q}
+	if r1.big == nil && r2.big == nil {
+		num, ok1 := mulInt64(r1.p, r2.p)
+		den, ok2 := mulInt64(r1.q, r2.q)
+		if ok1 && ok2 {
+			return newRatInt64(num, den)
+		}
+	}
+	return newRatBig(new(big.Rat).Mul(r1.toBig(), r2.toBig()))
 }
 
-// return r1 / r2
+// return r1 / r2, which must not be zero
 func divRat(r1, r2 rat) rat {
-	return rat{p: r1.p * r2.q, q: r1.q * r2.p}
+	if r1.big == nil && r2.big == nil {
+		num, ok1 := mulInt64(r1.p, r2.q)
+		den, ok2 := mulInt64(r1.q, r2.p)
+		if ok1 && ok2 {
+			return newRatInt64(num, den)
+		}
+	}
+	return newRatBig(new(big.Rat).Quo(r1.toBig(), r2.toBig()))
 }
 
-func powRat(r rat, pow int) rat {
-	powF := float64(pow)
-	pF, qF := float64(r.p), float64(r.q)
-	var pPow, qPow float64
-	if powF < 0 { // invert the fraction
-		pPow, qPow = math.Pow(qF, powF), math.Pow(pF, powF)
-	} else {
-		pPow, qPow = math.Pow(pF, powF), math.Pow(qF, powF)
+// maxRatBits is the maximum size of the numerator and denominator
+// of the power of a rational, above which floats are used instead,
+// to avoid huge memory usage for inputs like 2^1000000
+const maxRatBits = 4096
+
+// powRat returns r^pow, or false if the result is not
+// defined (0^-1) or too big
+func powRat(r rat, pow int) (rat, bool) {
+	if r.isZero() && pow < 0 {
+		return rat{}, false
 	}
+	absPow := pow
+	if absPow < 0 {
+		absPow = -absPow
+	}
+	if r.bitLen()*absPow > maxRatBits {
+		return rat{}, false
+	}
+	if pow < 0 { // invert the fraction
+		r = divRat(newRat(1, 1), r)
+	}
+
+	if r.big == nil { // exponentiation by squaring, which promotes to big.Rat if needed
+		out, square := newRat(1, 1), r
+		for ; absPow > 0; absPow >>= 1 {
+			if absPow&1 == 1 {
+				out = multRat(out, square)
+			}
+			if absPow > 1 {
+				square = multRat(square, square)
+			}
+		}
+		return out, true
+	}
+
+	b := r.big
+	exp := big.NewInt(int64(absPow))
+	p := new(big.Int).Exp(b.Num(), exp, nil)
+	q := new(big.Int).Exp(b.Denom(), exp, nil)
+	return newRatBig(new(big.Rat).SetFrac(p, q)), true
+}
 
-	return rat{p: int(pPow), q: int(qPow)}
+// bitLen returns the size of the numerator and denominator
+func (r rat) bitLen() int {
+	if r.big != nil {
+		return r.big.Num().BitLen() + r.big.Denom().BitLen()
+	}
+	p := r.p
+	if p < 0 {
+		p = -p
+	}
+	return bits.Len64(uint64(p)) + bits.Len64(uint64(r.q))
 }
 
 // real store a real number, which may be represented as
@@ -747,7 +897,11 @@ type real struct {
 }
 
 func newRealInt(p int) real {
-	return real{isRational: true, rat: rat{p: p, q: 1}}
+	return real{isRational: true, rat: newRat(p, 1)}
+}
+
+func newRealBig(p *big.Int) real {
+	return real{isRational: true, rat: newRatBig(new(big.Rat).SetInt(p))}
 }
 
 // returns a rational number if v is an integer
@@ -797,7 +951,9 @@ func (r1 *real) add(r2 real) {
 // transforms r to -r
 func (r *real) opposite() {
 	r.val = -r.val
-	r.rat.p = -r.rat.p
+	if r.isRational {
+		r.rat = r.rat.opposite()
+	}
 }
 
 // return r1 - r2
@@ -819,21 +975,50 @@ func multReal(r1, r2 real) real {
 
 // return r1 / r2
 func divReal(r1, r2 real) real {
-	if r1.isRational && r2.isRational {
+	if r1.isRational && r2.isRational && !r2.rat.isZero() {
 		return real{isRational: true, rat: divRat(r1.rat, r2.rat)}
 	}
-	// use eval to handle the case where r1 or r2 is rational
+	// use eval to handle the case where r1 or r2 is rational,
+	// and the division by zero
 	return real{isRational: false, val: r1.eval() / r2.eval()}
 }
 
 func powReal(r real, pow float64) real {
 	if powInt, isPowInt := IsInt(pow); r.isRational && isPowInt {
-		return real{isRational: true, rat: powRat(r.rat, powInt)}
+		if res, ok := powRat(r.rat, powInt); ok {
+			return real{isRational: true, rat: res}
+		}
 	}
 	// use eval to handle the case where r is rational
 	return real{isRational: false, val: math.Pow(r.eval(), pow)}
 }
 
+// modReal returns left % right, or false if left and right are not integers
+// If [isQuotient] is true, the (truncated) quotient is returned instead.
+func modReal(left, right real, isQuotient bool) (real, bool) {
+	if left.isRational && right.isRational {
+		leftInt, leftIsInt := left.rat.isInt()
+		rightInt, rightIsInt := right.rat.isInt()
+		if !(leftIsInt && rightIsInt) || rightInt.Sign() == 0 {
+			return real{}, false
+		}
+		if isQuotient {
+			return newRealBig(new(big.Int).Quo(leftInt, rightInt)), true
+		}
+		return newRealBig(new(big.Int).Rem(leftInt, rightInt)), true
+	}
+
+	leftInt, leftIsInt := IsInt(left.eval())
+	rightInt, rightIsInt := IsInt(right.eval())
+	if !(leftIsInt && rightIsInt) || rightInt == 0 {
+		return real{}, false
+	}
+	if isQuotient {
+		return newRealInt(leftInt / rightInt), true
+	}
+	return newRealInt(leftInt % rightInt), true
+}
+
 // performs some basic simplifications to convert expressions to numbers
 // examples :
 //
@@ -880,10 +1065,16 @@ func (expr *Expr) IsFraction() bool {
 	return expr.atom == div
 }
 
-func evalFactorial(n int) int {
-	f := 1
-	for k := 2; k <= n; k++ {
-		f *= k
+// maxFactorial is the biggest integer for which
+// n! is computed exactly
+const maxFactorial = 1000
+
+func evalFactorial(n int) real {
+	if n < 2 {
+		return newRealInt(1)
+	}
+	if n > maxFactorial { // way bigger than the float range
+		return newReal(math.Inf(1))
 	}
-	return f
+	return newRealBig(new(big.Int).MulRange(2, int64(n)))
 }
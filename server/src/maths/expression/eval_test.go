@@ -2,6 +2,7 @@ package expression
 
 import (
 	"math"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -460,17 +461,17 @@ func Test_sumRat(t *testing.T) {
 		want rat
 	}{
 		{
-			rat{1, 2}, rat{3, 2}, rat{8, 4},
+			newRat(1, 2), newRat(3, 2), newRat(8, 4),
 		},
 		{
-			rat{-2, 2}, rat{5, 2}, rat{6, 4},
+			newRat(-2, 2), newRat(5, 2), newRat(6, 4),
 		},
 		{
-			rat{3, 2}, rat{7, 9}, rat{3*9 + 7*2, 18},
+			newRat(3, 2), newRat(7, 9), newRat(3*9+7*2, 18),
 		},
 	}
 	for _, tt := range tests {
-		if got := sumRat(tt.r1, tt.r2); !reflect.DeepEqual(got, tt.want) {
+		if got := sumRat(tt.r1, tt.r2); got != tt.want {
 			t.Errorf("sumRat() = %v, want %v", got, tt.want)
 		}
 	}
@@ -482,16 +483,12 @@ func Test_rat_reduce(t *testing.T) {
 		q    int
 		want rat
 	}{
-		{8, 4, rat{2, 1}},
-		{12, 8, rat{3, 2}},
-		{6, -3, rat{-2, 1}},
+		{8, 4, newRat(2, 1)},
+		{12, 8, newRat(3, 2)},
+		{6, -3, newRat(-2, 1)},
 	}
 	for _, tt := range tests {
-		r := rat{
-			p: tt.p,
-			q: tt.q,
-		}
-		r.reduce()
+		r := newRat(tt.p, tt.q)
 		if r != tt.want {
 			t.Errorf("sumRat() = %v, want %v", r, tt.want)
 		}
@@ -503,9 +500,9 @@ func TestReal_toExpr(t *testing.T) {
 		rat  real
 		expr string
 	}{
-		{rat: real{rat: rat{3, 49}, isRational: true}, expr: "3 / 49"},
-		{rat: real{rat: rat{6, 49}, isRational: true}, expr: "6 / 49"},
-		{rat: real{rat: rat{2, 8}, isRational: true}, expr: "1 / 4"},
+		{rat: real{rat: newRat(3, 49), isRational: true}, expr: "3 / 49"},
+		{rat: real{rat: newRat(6, 49), isRational: true}, expr: "6 / 49"},
+		{rat: real{rat: newRat(2, 8), isRational: true}, expr: "1 / 4"},
 	} {
 		expected := mustParse(t, input.expr)
 		tu.Assert(t, input.rat.toExpr().equals(expected))
@@ -555,3 +552,58 @@ func TestExpr_reduce(t *testing.T) {
 		tu.Assert(t, expr.String() == tt.want)
 	}
 }
+
+func TestEvalBigRationals(t *testing.T) {
+	for _, test := range []struct {
+		expr string
+		want float64
+	}{
+		{"21! / 20!", 21},
+		{"30! / (28! * 2)", 435},
+		{"2^70 + 1 - 2^70", 1},
+		{"(2^64 + 1) % 2", 1},
+		{"(2^64 + 3) // 2 - 2^63", 1},
+		{"binom(30; 60) - binom(30; 60) + 1", 1},
+		{"binom(30; 60) / binom(29; 60)", 31. / 30},
+		{"(2/3)^40 * (3/2)^40", 1},
+		{"(1/3)^(-2)", 9},
+		{"sum(k; 1; 30; k!) - sum(k; 1; 30; k!) + 1", 1},
+	} {
+		got, err := MustParse(test.expr).Evaluate(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, AreFloatEqual(got, test.want))
+	}
+
+	// irrational operations and invalid operations use floats
+	tu.Assert(t, math.IsInf(mustEvaluate("0^(-1)", nil), 1))
+	tu.Assert(t, math.IsInf(mustEvaluate("1/0", nil), 1))
+	tu.Assert(t, mustEvaluate("3 % 0", nil) == 0)
+	tu.Assert(t, AreFloatEqual(mustEvaluate("2^(1/2)", nil), math.Sqrt2))
+	tu.Assert(t, math.IsInf(mustEvaluate("2000!", nil), 1))
+
+	// huge powers are not computed exactly
+	v, err := MustParse("2^1000000").Evaluate(nil)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, math.IsInf(v, 1))
+}
+
+func TestBigRationalsToExpr(t *testing.T) {
+	// exact fractions are kept
+	e := MustParse("(2/3)^5")
+	e.reduce()
+	tu.Assert(t, e.String() == "32/243")
+
+	e = MustParse("20! / 18!")
+	e.reduce()
+	tu.Assert(t, e.String() == "380")
+
+	// big values are demoted when possible
+	r1 := multRat(newRatInt64(math.MaxInt64, 1), newRat(4, 1))
+	tu.Assert(t, r1.big != nil)
+	r1 = divRat(r1, newRat(8, 1))
+	tu.Assert(t, r1 == newRatInt64(math.MaxInt64, 2))
+
+	// too big to be stored exactly : use an approximation
+	r := newRealBig(new(big.Int).Lsh(big.NewInt(1), 80))
+	tu.Assert(t, r.toExpr().atom == Number(math.Pow(2, 80)))
+}
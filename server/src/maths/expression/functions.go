@@ -0,0 +1,199 @@
+package expression
+
+import (
+	"fmt"
+)
+
+// This file implements user defined functions, such as f(x) = ax^2 + bx + c,
+// which may then be used as f(2), f(a+1) or f(x+h) in other expressions.
+//
+// Since f(2) is also a valid implicit multiplication, the parser keeps the usual tree
+// but marks the multiplications between a variable and a parenthesis (see [callTag]).
+// These nodes are expanded (that is, replaced by the body of the function) only when a function
+// with this name is defined : in [RandomParameters], or in the [Vars] resulting
+// from their instantiation.
+
+// callTag marks the nodes involved in f(...)
+type callTag uint8
+
+const (
+	callMult callTag = 1 << iota // the implicit multiplication between the name and the parenthesis
+	callArg                      // the content of the parenthesis
+)
+
+// functionArgument is used to avoid conflicts between the argument
+// of a function and the random parameters
+var functionArgument = Variable{Name: '\uF8FE'}
+
+// userFunction is the instantiated form of a user defined function,
+// as stored in [Vars] : its right member is the body of the function.
+type userFunction struct {
+	arg Variable
+}
+
+func (fn userFunction) String() string { return fmt.Sprintf("%s ↦", fn.arg) }
+
+func (fn userFunction) serialize(_, right *Expr) string {
+	return fmt.Sprintf("%s ↦ %s", fn.arg, right.Serialize())
+}
+
+func (fn userFunction) asLaTeX(_, right *Expr) string {
+	return fmt.Sprintf(`%s \mapsto %s`, fn.arg.asLaTeX(nil, nil), right.AsLaTeX())
+}
+
+func (fn userFunction) eval(_, _ *Expr, _ *resolver) (real, error) {
+	return real{}, ErrInvalidExpr{Reason: "une fonction ne peut être évaluée qu'avec un argument, comme dans f(2)"}
+}
+
+// ErrCycleFunction is returned when user defined functions
+// call each other recursively.
+type ErrCycleFunction struct {
+	InCycle Variable
+}
+
+func (cf ErrCycleFunction) Error() string {
+	return fmt.Sprintf("La fonction %s est présente dans un cycle.", cf.InCycle)
+}
+
+// ParseFunction parses the given expression as the definition of the function
+// [name], with argument [arg], and adds it to the parameters.
+func (rp *RandomParameters) ParseFunction(name, arg Variable, expr string) error {
+	e, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	if rp.IsDefined(name) {
+		return ErrDuplicateParameter{Duplicate: name}
+	}
+	if rp.functions == nil {
+		rp.functions = make(map[Variable]FunctionExpr)
+	}
+	rp.functions[name] = FunctionExpr{Function: e, Variable: arg}
+	return nil
+}
+
+// functions returns the user defined functions stored in [vs]
+func (vs Vars) functions() map[Variable]FunctionExpr {
+	var out map[Variable]FunctionExpr
+	for name, value := range vs {
+		if fn, ok := value.atom.(userFunction); ok {
+			if out == nil {
+				out = make(map[Variable]FunctionExpr)
+			}
+			out[name] = FunctionExpr{Function: value.right, Variable: fn.arg}
+		}
+	}
+	return out
+}
+
+// instantiateFunctions resolves the random parameters used in the
+// functions, and adds them to the results
+func (ctx *resolver) instantiateFunctions(functions map[Variable]FunctionExpr) error {
+	for name, fn := range functions {
+		body := fn.Function.Copy()
+		if err := body.expandCalls(functions, []Variable{name}); err != nil {
+			return ErrInvalidRandomParameters{Cause: name, Detail: err.Error()}
+		}
+		// protect the argument from the parameters with the same name
+		body.substitute(Vars{fn.Variable: NewVarExpr(functionArgument)})
+
+		ctx.currentVariable = Variable{}
+		value, err := body.instantiate(ctx)
+		if err != nil {
+			return ErrInvalidRandomParameters{Cause: name, Detail: err.Error()}
+		}
+		value.DefaultSimplify()
+		value.substitute(Vars{functionArgument: NewVarExpr(fn.Variable)})
+
+		ctx.results[name] = &Expr{atom: userFunction{arg: fn.Variable}, right: value}
+	}
+	return nil
+}
+
+// rightmostLeaf returns the last node of [expr], in reading order
+func (expr *Expr) rightmostLeaf() *Expr {
+	for expr.right != nil {
+		expr = expr.right
+	}
+	return expr
+}
+
+// callArgument returns the node tagged as argument,
+// or nil if not found
+func (expr *Expr) callArgument() *Expr {
+	for ; expr != nil; expr = expr.left {
+		if expr.call&callArg != 0 {
+			return expr
+		}
+	}
+	return nil
+}
+
+// expandCalls replaces the calls to the given functions by the body of
+// the functions, where the argument is substituted.
+// [stack] contains the functions being expanded, and is used to detect cycles.
+func (expr *Expr) expandCalls(functions map[Variable]FunctionExpr, stack []Variable) error {
+	if expr == nil {
+		return nil
+	}
+
+	if err := expr.left.expandCalls(functions, stack); err != nil {
+		return err
+	}
+	if err := expr.right.expandCalls(functions, stack); err != nil {
+		return err
+	}
+	switch atom := expr.atom.(type) {
+	case specialFunction:
+		for _, arg := range atom.args {
+			if err := arg.expandCalls(functions, stack); err != nil {
+				return err
+			}
+		}
+	case matrix:
+		for i := range atom {
+			for j := range atom[i] {
+				if err := atom[i][j].expandCalls(functions, stack); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if expr.call&callMult == 0 || expr.left == nil {
+		return nil
+	}
+	leaf := expr.left.rightmostLeaf()
+	name, isVariable := leaf.atom.(Variable)
+	fn, isFunction := functions[name]
+	if !isVariable || !isFunction {
+		return nil // regular multiplication
+	}
+	argNode := expr.right.callArgument()
+	if argNode == nil {
+		return nil
+	}
+
+	for _, v := range stack {
+		if v == name {
+			return ErrCycleFunction{InCycle: name}
+		}
+	}
+	body := fn.Function.Copy()
+	if err := body.expandCalls(functions, append(stack, name)); err != nil {
+		return err
+	}
+	arg := *argNode
+	arg.call = 0
+	body.substitute(Vars{fn.Variable: &arg})
+
+	// f(X)^2 is parsed as f * (X)^2 : replace X by the body,
+	// and f by the right member
+	*argNode = *body
+	*leaf = *expr.right
+	tag := expr.call & callArg // expr may itself be an argument, as in g(f(2))
+	*expr = *expr.left
+	expr.call |= tag
+
+	return nil
+}
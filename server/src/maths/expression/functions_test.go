@@ -0,0 +1,74 @@
+package expression
+
+import (
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestUserFunctions(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "2"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('b'), "randInt(3;3)"))
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('f'), NewVar('x'), "ax^2 + b"))
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('g'), NewVar('t'), "f(t) - 1"))
+	tu.Assert(t, rv.ParseFunction(NewVar('f'), NewVar('x'), "x") != nil)    // duplicate
+	tu.Assert(t, rv.ParseVariable(NewVar('g'), "2") != nil)                 // duplicate
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('c'), "f(2)"))                // 11
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('d'), "2f(a+1)"))             // 42
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('e'), "f(1)^2"))              // 25
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('k'), "g(f(0))"))             // 20
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('h'), "f(1) + sqrt(f(0)+1)")) // 7
+
+	tu.Assert(t, rv.IsDefined(NewVar('f')))
+	tu.AssertNoErr(t, rv.Validate())
+
+	vars, err := rv.Instantiate()
+	tu.AssertNoErr(t, err)
+	for v, exp := range map[rune]float64{'c': 11, 'd': 42, 'e': 25, 'k': 20, 'h': 7} {
+		tu.Assert(t, vars[NewVar(v)].mustEvaluate(nil) == exp)
+	}
+
+	// the functions are available in the instantiated parameters
+	tu.Assert(t, vars[NewVar('f')].right.String() == "2x ^ 2 + 3")
+	tu.Assert(t, mustEvaluate("f(-1) + g(1)", vars) == 9)
+
+	e := MustParse("f(x+h)")
+	e.Substitute(Vars{NewVar('f'): vars[NewVar('f')]})
+	tu.Assert(t, e.String() == "2(x + h) ^ 2 + 3")
+
+	// the argument shadows the parameters
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('x'), "4"))
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('f'), NewVar('x'), "x + 1"))
+	vars, err = rv.Instantiate()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, mustEvaluate("f(2)", vars) == 3)
+}
+
+func TestUserFunctionsCycle(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('f'), NewVar('x'), "g(x) + 1"))
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('g'), NewVar('x'), "2f(x)"))
+	tu.Assert(t, rv.Validate() != nil)
+
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseFunction(NewVar('f'), NewVar('x'), "x + 1"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "f(f(2))"))
+	vars, err := rv.Instantiate()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, vars[NewVar('a')].mustEvaluate(nil) == 4)
+}
+
+func TestImplicitMultNotFunction(t *testing.T) {
+	// without functions, the usual implicit multiplication is used
+	e := MustParse("a(x+1)")
+	tu.Assert(t, e.String() == "a(x + 1)")
+
+	e.Substitute(Vars{NewVar('a'): NewNb(2)})
+	tu.Assert(t, e.String() == "2(x + 1)")
+
+	v, err := MustParse("a(b+1)").Evaluate(Vars{NewVar('a'): NewNb(2), NewVar('b'): NewNb(1)})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, v == 4)
+}
@@ -16,15 +16,21 @@ import (
 // Validate calls `Instantiate` many times to make sure the parameters are always
 // valid regardless of the random value chosen.
 // If not, it returns the first error encountered.
+// It also checks that the constraints, if any, are satisfied often enough
+// (see [MinAcceptanceRate]), and that the imaginary unit i is not shadowed
+// by a parameter when complex numbers are used.
 func (rv RandomParameters) Validate() error {
+	if err := rv.validateImaginaryUnit(); err != nil {
+		return err
+	}
 	const nbTries = 200
 	for i := 0; i < nbTries; i++ {
-		_, err := rv.Instantiate()
+		_, err := NewInstantiater(rv).instantiateOnce()
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return rv.validateConstraints()
 }
 
 // ErrInvalidRandomParameters is returned when instantiating
@@ -47,6 +53,12 @@ func (rv RandomParameters) consumeIntrinsics(dst Vars) error {
 		}
 	}
 	for k, v := range rv.defs {
+		if len(rv.functions) != 0 {
+			v = v.Copy()
+			if err := v.expandCalls(rv.functions, nil); err != nil {
+				return ErrInvalidRandomParameters{Cause: k, Detail: err.Error()}
+			}
+		}
 		dst[k] = v
 	}
 	return nil
@@ -56,6 +68,9 @@ type Instantiater struct {
 	origin RandomParameters
 
 	ctx resolver
+
+	// statistics about the constraints, not affected by [Reset]
+	nbDraws, nbAccepted int
 }
 
 func NewInstantiater(rv RandomParameters) *Instantiater {
@@ -77,12 +92,57 @@ func (inst *Instantiater) Reset() {
 	}
 }
 
+// Instantiate generates a random version of the variables,
+// retrying (up to a limit) until the constraints are satisfied.
+// [Reset] must be called between two calls.
 func (inst *Instantiater) Instantiate() (Vars, error) {
+	for try := 0; try < maxConstraintTries; try++ {
+		if try != 0 {
+			inst.Reset()
+		}
+		vars, err := inst.instantiateOnce()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := inst.origin.checkConstraints(vars)
+		if err != nil {
+			return nil, err
+		}
+		inst.nbDraws++
+		if ok {
+			inst.nbAccepted++
+			return vars, nil
+		}
+	}
+	return nil, ErrConstraint{
+		Constraint: "Contraintes",
+		Detail:     fmt.Sprintf("Les contraintes n'ont pas été satisfaites après %d tirages.", maxConstraintTries),
+	}
+}
+
+// AcceptanceRate returns the proportion of the draws made by [Instantiate]
+// which satisfied the constraints, or 1 if no draws have been made.
+func (inst *Instantiater) AcceptanceRate() float64 {
+	if inst.nbDraws == 0 {
+		return 1
+	}
+	return float64(inst.nbAccepted) / float64(inst.nbDraws)
+}
+
+// instantiateOnce ignores the constraints
+func (inst *Instantiater) instantiateOnce() (Vars, error) {
 	err := inst.origin.consumeIntrinsics(inst.ctx.defs)
 	if err != nil {
 		return nil, err
 	}
-	return inst.ctx.instantiateAll()
+	vars, err := inst.ctx.instantiateAll()
+	if err != nil {
+		return nil, err
+	}
+	if err = inst.ctx.instantiateFunctions(inst.origin.functions); err != nil {
+		return nil, err
+	}
+	return vars, nil
 }
 
 // Instantiate generates a random version of the variables, resolving possible dependencies.
@@ -95,6 +155,8 @@ func (inst *Instantiater) Instantiate() (Vars, error) {
 //
 // It returns an `ErrInvalidRandomParameters` error for invalid cycles, like a = a + 1
 // or a = b + 1; b = a.
+// Constraints are enforced by rejection sampling, and an `ErrConstraint`
+// is returned if they are not satisfied after many tries.
 //
 // See `Validate` to statistically check for invalid parameters.
 func (rv RandomParameters) Instantiate() (Vars, error) {
@@ -309,7 +371,7 @@ func (expr *Expr) instantiate(ctx *resolver) (*Expr, error) {
 	case specialFunction:
 		// generate random numbers
 		switch atom.kind {
-		case randInt, randPrime, randDenominator:
+		case randInt, randPrime, randDenominator, randBinom:
 			v, err := atom.evalRat(ctx)
 			return v.toExpr(), err
 		case randMatrixInt:
@@ -392,7 +454,28 @@ func (expr *Expr) instantiate(ctx *resolver) (*Expr, error) {
 			out := matV.copy()
 			out[in-1][jn-1] = value // adjust to computer convention
 			return &Expr{atom: out}, nil
-		case minFn, maxFn, matCoeff, binomial, sumFn, prodFn, unionFn, interFn: // no-op, simply recurse
+		case randList:
+			return atom.randomList(ctx)
+		case sortFn:
+			return atom.sortedList(ctx)
+		case simBinom:
+			return atom.simulateBinom(ctx)
+		case randComplex:
+			z, err := atom.randomComplex(ctx)
+			return z.toExpr(), err
+		case algFormFn, expFormFn:
+			z, err := atom.args[0].evalComplex(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if atom.kind == algFormFn {
+				return z.toExpr(), nil
+			}
+			return z.toExponentialForm(), nil
+		case minFn, maxFn, matCoeff, binomial, sumFn, prodFn, unionFn, interFn,
+			listFn, meanFn, medianFn, q1Fn, q3Fn, varFn, stdevFn, countFn,
+			binomPdf, binomCdf, geomPdf, geomCdf, unifPdf, unifCdf, normalCdf, invNorm,
+			expectationFn, varianceFn, reFn, imFn, conjFn, argFn: // no-op, simply recurse
 			inst := specialFunction{
 				kind: atom.kind,
 				args: make([]*Expr, len(atom.args)),
@@ -410,6 +493,8 @@ func (expr *Expr) instantiate(ctx *resolver) (*Expr, error) {
 		default:
 			panic(exhaustiveSpecialFunctionSwitch)
 		}
+	case userFunction: // already instantiated
+		return expr.Copy(), nil
 	default:
 		panic(exhaustiveAtomSwitch)
 	}
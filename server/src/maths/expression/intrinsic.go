@@ -59,9 +59,9 @@ func (pt pythagorianTriplet) instantiateTo(target Vars) error {
 	c := (p*q*q + p) / 2
 	b := c - p
 
-	target[pt.a] = rat{a, 1}.toExpr()
-	target[pt.b] = rat{b, 1}.toExpr()
-	target[pt.c] = rat{c, 1}.toExpr()
+	target[pt.a] = newRealInt(a).toExpr()
+	target[pt.b] = newRealInt(b).toExpr()
+	target[pt.c] = newRealInt(c).toExpr()
 
 	return nil
 }
@@ -120,8 +120,8 @@ func (np numberPair) instantiateTo(target Vars) error {
 	a := randomInt(selectedRange.a[0], selectedRange.a[1])
 	b := randomInt(selectedRange.b[0], selectedRange.b[1])
 
-	target[np.a] = rat{a, 1}.toExpr()
-	target[np.b] = rat{b, 1}.toExpr()
+	target[np.a] = newRealInt(a).toExpr()
+	target[np.b] = newRealInt(b).toExpr()
 
 	return nil
 }
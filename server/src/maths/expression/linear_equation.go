@@ -96,7 +96,7 @@ func (expr *Expr) isLinearTerm() (coeff float64, variable Variable, ok bool) {
 			}
 		}
 		return 0, Variable{}, false
-	case Number, constant, function, specialFunction, roundFunc, indice, matrix:
+	case Number, constant, function, specialFunction, roundFunc, indice, matrix, userFunction:
 		return 0, Variable{}, false
 	default:
 		panic(exhaustiveAtomSwitch)
@@ -0,0 +1,261 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// maxListSize is the maximum number of elements generated by randList
+const maxListSize = 1000
+
+// newList returns the list literal list(e1 ; e2 ; ...)
+func newList(elements []*Expr) *Expr {
+	return &Expr{atom: specialFunction{kind: listFn, args: elements}}
+}
+
+// instantiateList instantiates [expr], returning
+// the elements of the resulting value if it is a list.
+// For other values, [isList] is false and the instantiated value is returned
+// as only element.
+// [ctx] may be nil.
+func (expr *Expr) instantiateList(ctx *resolver) (elements []*Expr, isList bool, err error) {
+	if ctx == nil {
+		ctx = Vars(nil).resolver()
+	}
+	value, err := expr.instantiate(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if l, ok := value.atom.(specialFunction); ok && l.kind == listFn {
+		return l.args, true, nil
+	}
+	return []*Expr{value}, false, nil
+}
+
+// dataSeries returns the values given as argument to a statistic function :
+// list arguments are flattened, so that
+// mean(L), mean(1 ; 2 ; 3) and mean(L ; 4) are all valid.
+func (sf specialFunction) dataSeries(ctx *resolver) ([]*Expr, error) {
+	var out []*Expr
+	for _, arg := range sf.args {
+		elements, _, err := arg.instantiateList(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, elements...)
+	}
+	return out, nil
+}
+
+// evalSeries evaluates each element of the data series
+func evalSeries(elements []*Expr, ctx *resolver) ([]real, error) {
+	out := make([]real, len(elements))
+	for i, element := range elements {
+		var err error
+		out[i], err = element.evalReal(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("Les éléments d'une liste doivent être des nombres (%s).", err)
+		}
+	}
+	return out, nil
+}
+
+func sortReals(values []real) {
+	sort.SliceStable(values, func(i, j int) bool { return values[i].eval() < values[j].eval() })
+}
+
+// sortedList instantiates the arguments and returns them
+// as a list sorted in increasing order
+func (sf specialFunction) sortedList(ctx *resolver) (*Expr, error) {
+	elements, err := sf.dataSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values, err := evalSeries(elements, ctx)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]int, len(elements))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return values[indices[i]].eval() < values[indices[j]].eval() })
+	out := make([]*Expr, len(elements))
+	for i, index := range indices {
+		out[i] = elements[index]
+	}
+	return newList(out), nil
+}
+
+// randomList returns a list of n random integers in [start, end]
+func (sf specialFunction) randomList(ctx *resolver) (*Expr, error) {
+	n, err := evalInt(sf.args[0], ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 || n > maxListSize {
+		return nil, fmt.Errorf("La taille d'une liste doit être entre 1 et %d (%d reçu)", maxListSize, n)
+	}
+	start, end, err := startEnd(sf.args[1], sf.args[2], ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = sf.kind.validateStartEnd(start, end, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Expr, n)
+	for i := range out {
+		out[i] = newRealInt(randomInt(int(start), int(end))).toExpr()
+	}
+	return newList(out), nil
+}
+
+// evalStatistic computes the statistic [kind] of the data series
+func (sf specialFunction) evalStatistic(ctx *resolver) (real, error) {
+	if sf.kind == countFn && len(sf.args) == 2 {
+		return sf.evalFrequency(ctx)
+	}
+
+	elements, err := sf.dataSeries(ctx)
+	if err != nil {
+		return real{}, err
+	}
+	if sf.kind == countFn {
+		return newRealInt(len(elements)), nil
+	}
+
+	values, err := evalSeries(elements, ctx)
+	if err != nil {
+		return real{}, err
+	}
+	n := len(values)
+	if n == 0 {
+		return real{}, fmt.Errorf("La fonction %s() ne peut pas être calculée sur une liste vide.", sf.kind.String())
+	}
+
+	switch sf.kind {
+	case meanFn:
+		return mean(values), nil
+	case medianFn:
+		sortReals(values)
+		if n%2 == 1 {
+			return values[n/2], nil
+		}
+		return divReal(sumReal(values[n/2-1], values[n/2]), newRealInt(2)), nil
+	case q1Fn: // smallest value such that at least 25% of the values are lower or equal
+		sortReals(values)
+		return values[(n+3)/4-1], nil
+	case q3Fn: // smallest value such that at least 75% of the values are lower or equal
+		sortReals(values)
+		return values[(3*n+3)/4-1], nil
+	case varFn:
+		return variance(values), nil
+	case stdevFn:
+		return newReal(math.Sqrt(variance(values).eval())), nil
+	default:
+		panic(exhaustiveSpecialFunctionSwitch)
+	}
+}
+
+// evalFrequency returns the number of occurences
+// of the second argument in the first
+func (sf specialFunction) evalFrequency(ctx *resolver) (real, error) {
+	elements, isList, err := sf.args[0].instantiateList(ctx)
+	if err != nil {
+		return real{}, err
+	}
+	if !isList {
+		return real{}, errors.New("Le premier argument de count() doit être une liste.")
+	}
+	values, err := evalSeries(elements, ctx)
+	if err != nil {
+		return real{}, err
+	}
+	value, err := sf.args[1].evalFloat(ctx)
+	if err != nil {
+		return real{}, err
+	}
+	count := 0
+	for _, v := range values {
+		if v.eval() == value {
+			count++
+		}
+	}
+	return newRealInt(count), nil
+}
+
+func mean(values []real) real {
+	sum := newRealInt(0)
+	for _, v := range values {
+		sum = sumReal(sum, v)
+	}
+	return divReal(sum, newRealInt(len(values)))
+}
+
+// variance returns the population variance,
+// as used in high school
+func variance(values []real) real {
+	m := mean(values)
+	sum := newRealInt(0)
+	for _, v := range values {
+		d := minusReal(v, m)
+		sum = sumReal(sum, multReal(d, d))
+	}
+	return divReal(sum, newRealInt(len(values)))
+}
+
+func listLaTeX(elements []*Expr) string {
+	chunks := make([]string, len(elements))
+	for i, expr := range elements {
+		chunks[i] = expr.AsLaTeX()
+	}
+	return strings.Join(chunks, " ; ")
+}
+
+// listArgLaTeX returns the LaTeX code for a function argument,
+// adding delimiters around lists so that several list arguments
+// are not merged
+func listArgLaTeX(arg *Expr) string {
+	if sf, ok := arg.atom.(specialFunction); ok && (sf.kind == listFn || sf.kind == sortFn) {
+		return `\left[` + arg.AsLaTeX() + `\right]`
+	}
+	return arg.AsLaTeX()
+}
+
+// List is an ordered data series, such as list(12 ; 15 ; 8)
+type List []*Expr
+
+func (List) isCompound() {}
+
+func (l List) Expressions() []*Expr { return l }
+
+func (l List) String() string { return newList(l).String() }
+
+func (l List) AsLaTeX() string { return listLaTeX(l) }
+
+func (l List) Substitute(vars Vars) {
+	for _, expr := range l {
+		expr.Substitute(vars)
+	}
+}
+
+// asListCompound converts list literals (or sorted lists) to [List]
+func asListCompound(c Compound) Compound {
+	e, ok := c.(*Expr)
+	if !ok {
+		return c
+	}
+	sf, ok := e.atom.(specialFunction)
+	if !ok || (sf.kind != listFn && sf.kind != sortFn) {
+		return c
+	}
+	elements, isList, err := e.instantiateList(nil)
+	if err != nil || !isList {
+		return c
+	}
+	return List(elements)
+}
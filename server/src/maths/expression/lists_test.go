@@ -0,0 +1,124 @@
+package expression
+
+import (
+	"math"
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestStatistics(t *testing.T) {
+	for _, test := range []struct {
+		expr     string
+		expected float64
+	}{
+		{"mean(list(1 ; 2 ; 3 ; 6))", 3},
+		{"mean(1 ; 2 ; 3 ; 6)", 3},
+		{"median(list(5 ; 1 ; 3))", 3},
+		{"median(list(5 ; 1 ; 3 ; 4))", 3.5},
+		{"q1(list(1 ; 2 ; 3 ; 4 ; 5 ; 6 ; 7 ; 8))", 2},
+		{"q3(list(1 ; 2 ; 3 ; 4 ; 5 ; 6 ; 7 ; 8))", 6},
+		{"Q1(list(8 ; 1 ; 5 ; 3 ; 2))", 2},
+		{"q3(list(8 ; 1 ; 5 ; 3 ; 2))", 5},
+		{"var(list(2 ; 4 ; 4 ; 4 ; 5 ; 5 ; 7 ; 9))", 4},
+		{"stdev(list(2 ; 4 ; 4 ; 4 ; 5 ; 5 ; 7 ; 9))", 2},
+		{"count(list(2 ; 4 ; 4 ; 4 ; 5))", 5},
+		{"count(list(2 ; 4 ; 4 ; 4 ; 5) ; 4)", 3},
+		{"mean(sort(3 ; 1 ; 2)) + 1", 3},
+		{"median(sort(3 ; 1 ; 2) ; 10)", 2.5},
+	} {
+		tu.Assert(t, mustEvaluate(test.expr, nil) == test.expected)
+	}
+
+	// exact rationals
+	e := MustParse("mean(list(1 ; 2 ; 2))")
+	tu.Assert(t, e.AsLaTeX() == MustParse("5/3").AsLaTeX())
+
+	for _, expr := range []string{
+		"mean(list())",
+		"count(2 ; 1)",
+		"mean(list(x ; 2))",
+		"list(1 ; 2)",
+		"sort(1 ; 2)",
+	} {
+		_, err := MustParse(expr).Evaluate(nil)
+		tu.Assert(t, err != nil)
+	}
+
+	for _, expr := range []string{
+		"randList(2 ; 1)",
+		"randList(2 ; 3 ; 1)",
+		"count()",
+		"mean()",
+	} {
+		_, err := Parse(expr)
+		tu.Assert(t, err != nil)
+	}
+}
+
+func TestQuartileTokens(t *testing.T) {
+	// q1 and q3 are only functions when called
+	tu.Assert(t, MustParse("q1(list(1 ; 2))").String() == "q1(list(1 ; 2))")
+	tu.Assert(t, mustEvaluate("q(1+1)", Vars{NewVar('q'): NewNb(3)}) == 6)
+}
+
+func TestListParameters(t *testing.T) {
+	rv := NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('L'), "randList(20 ; 1 ; 6)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('S'), "sort(L)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('m'), "mean(L)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('a'), "median(S)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('n'), "count(L)"))
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('s'), "stdev(L)"))
+	tu.AssertNoErr(t, rv.Validate())
+
+	for range [10]int{} {
+		vars, err := rv.Instantiate()
+		tu.AssertNoErr(t, err)
+
+		L := vars[NewVar('L')].atom.(specialFunction)
+		tu.Assert(t, L.kind == listFn && len(L.args) == 20)
+		S := vars[NewVar('S')].atom.(specialFunction)
+		tu.Assert(t, S.kind == listFn && len(S.args) == 20)
+		var sum float64
+		for i, v := range S.args {
+			vf := v.mustEvaluate(nil)
+			tu.Assert(t, 1 <= vf && vf <= 6)
+			if i > 0 {
+				tu.Assert(t, S.args[i-1].mustEvaluate(nil) <= vf)
+			}
+			sum += vf
+		}
+		tu.Assert(t, vars[NewVar('n')].mustEvaluate(nil) == 20)
+		tu.Assert(t, math.Abs(vars[NewVar('m')].mustEvaluate(nil)-sum/20) < 1e-9)
+
+		// the answer expressions may refer to the list
+		tu.Assert(t, math.Abs(mustEvaluate("mean(L)", vars)-sum/20) < 1e-9)
+		tu.Assert(t, mustEvaluate("sum(k ; 1 ; 6 ; count(L ; k))", vars) == 20)
+		tu.Assert(t, mustEvaluate("median(L)", vars) == vars[NewVar('a')].mustEvaluate(nil))
+	}
+
+	rv = NewRandomParameters()
+	tu.AssertNoErr(t, rv.ParseVariable(NewVar('L'), "randList(0 ; 1 ; 6)"))
+	_, err := rv.Instantiate()
+	tu.Assert(t, err != nil)
+}
+
+func TestListCompound(t *testing.T) {
+	c, err := ParseCompound("list(3 ; 1 ; 2)")
+	tu.AssertNoErr(t, err)
+	l, ok := c.(List)
+	tu.Assert(t, ok && len(l) == 3)
+	tu.Assert(t, l.AsLaTeX() == "3 ; 1 ; 2")
+	tu.Assert(t, l.String() == "list(3 ; 1 ; 2)")
+
+	sorted, err := ParseCompound("sort(L)")
+	tu.AssertNoErr(t, err)
+	sorted.Substitute(Vars{NewVar('L'): MustParse("list(2 ; 3 ; 1)")})
+	tu.Assert(t, sorted.AsLaTeX() == "1 ; 2 ; 3")
+
+	exp, _ := ParseCompound("list(1 ; 2 ; 3)")
+	tu.Assert(t, AreCompoundsEquivalent(exp, sorted, SimpleSubstitutions))
+	tu.Assert(t, !AreCompoundsEquivalent(exp, c, SimpleSubstitutions)) // order matters
+	tu.Assert(t, !AreCompoundsEquivalent(exp, Vector(l), SimpleSubstitutions))
+}
@@ -33,7 +33,7 @@ func Test_matricesOperations(t *testing.T) {
 		{"-A", matrix{{newNb(-1), newNb(-2)}, {newNb(-3), newNb(-4)}}},
 		{"trans(A)", matrix{{newNb(1), newNb(3)}, {newNb(2), newNb(4)}}},
 		{"transpose(A)", matrix{{newNb(1), newNb(3)}, {newNb(2), newNb(4)}}},
-		{"inv(D)", matrix{{rat{1, 2}.toExpr(), newNb(0)}, {newNb(0), rat{1, 5}.toExpr()}}},
+		{"inv(D)", matrix{{newRat(1, 2).toExpr(), newNb(0)}, {newNb(0), newRat(1, 5).toExpr()}}},
 	}
 
 	for i, op := range ops {
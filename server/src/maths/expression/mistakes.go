@@ -0,0 +1,226 @@
+package expression
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Mistake identifies a classic error made by students,
+// detected by comparing a wrong answer to the expected one.
+type Mistake uint8
+
+const (
+	NoMistake        Mistake = iota // no known mistake has been detected
+	FormMistake                     // the value is correct, but not in the expected form
+	SignMistake                     // the opposite of the expected answer
+	InvertedFraction                // the inverse of the expected answer
+	MissingFactor                   // a factor of the expected product is missing
+	IdentityMistake                 // the double product of (a+b)^2 has been forgotten
+	PowerMistake                    // an exponent is off by one
+	RoundingMistake                 // the value is close, but not correctly rounded
+)
+
+// Hint returns a short text, displayed to the student.
+func (m Mistake) Hint() string {
+	switch m {
+	case FormMistake:
+		return "Ta réponse a la bonne valeur, mais n'est pas écrite sous la forme attendue."
+	case SignMistake:
+		return "Vérifie le signe de ta réponse."
+	case InvertedFraction:
+		return "Il semble que tu aies inversé le numérateur et le dénominateur."
+	case MissingFactor:
+		return "Il semble qu'il manque un facteur dans ta réponse."
+	case IdentityMistake:
+		return "Attention au double produit : (a + b)² = a² + 2ab + b²."
+	case PowerMistake:
+		return "Vérifie les exposants de ta réponse."
+	case RoundingMistake:
+		return "Ta réponse est proche : vérifie l'arrondi."
+	default:
+		return ""
+	}
+}
+
+// DiagnoseMistake tries to explain why [answer] is not equal to [expected],
+// by testing transformations of [expected] corresponding to classic errors.
+// It returns [NoMistake] if no explanation is found.
+func DiagnoseMistake(expected, answer *Expr) Mistake {
+	isEqual := func(candidate *Expr) bool {
+		return AreExpressionsEquivalent(candidate, answer, ExpandedSubstitutions) ||
+			areNumericallyEqual(candidate, answer)
+	}
+
+	if isEqual(expected) {
+		return FormMistake
+	}
+
+	if isEqual(&Expr{atom: minus, right: expected.Copy()}) {
+		return SignMistake
+	}
+
+	if isEqual(&Expr{atom: div, left: newNb(1), right: expected.Copy()}) {
+		return InvertedFraction
+	}
+
+	if factors := expected.extractOperator(mult); len(factors) >= 2 {
+		for i := range factors {
+			if isEqual(productWithout(factors, i)) {
+				return MissingFactor
+			}
+		}
+	}
+
+	// the candidates are built by modifying a copy in place
+	candidate := expected.Copy()
+	for _, node := range candidate.findNodes(isSquaredSum) {
+		original := *node
+		a, b := node.left.left, node.left.right
+		for _, op := range [2]operator{plus, minus} {
+			*node = Expr{
+				atom:  op,
+				left:  &Expr{atom: pow, left: a, right: newNb(2)},
+				right: &Expr{atom: pow, left: b, right: newNb(2)},
+			}
+			if isEqual(candidate) {
+				return IdentityMistake
+			}
+		}
+		*node = original
+	}
+
+	for _, node := range candidate.findNodes(hasNumberExponent) {
+		exponent := node.right
+		n := float64(exponent.atom.(Number))
+		for _, other := range [2]float64{n - 1, n + 1} {
+			node.right = newNb(other)
+			if isEqual(candidate) {
+				return PowerMistake
+			}
+		}
+		node.right = exponent
+	}
+
+	if isRoundingMistake(expected, answer) {
+		return RoundingMistake
+	}
+
+	return NoMistake
+}
+
+// sampleValues are arbitrary values used to compare expressions
+// with variables
+var sampleValues = [...]float64{0.7, -1.9, 2.3, 5.1}
+
+// areNumericallyEqual compares the values of [e1] and [e2] for
+// a few values of their variables
+func areNumericallyEqual(e1, e2 *Expr) bool {
+	variables := map[Variable]bool{}
+	e1.collectVariables(variables)
+	e2.collectVariables(variables)
+	sorted := make([]Variable, 0, len(variables))
+	for v := range variables {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+
+	for try := range sampleValues {
+		bindings := make(Vars, len(sorted))
+		for i, v := range sorted {
+			bindings[v] = newNb(sampleValues[(try+i)%len(sampleValues)] + float64(i))
+		}
+		v1, err1 := e1.Evaluate(bindings)
+		v2, err2 := e2.Evaluate(bindings)
+		if err1 != nil || err2 != nil || math.IsNaN(v1) || math.IsNaN(v2) {
+			return false
+		}
+		if math.Abs(v1-v2) > floatPrec*math.Max(1, math.Max(math.Abs(v1), math.Abs(v2))) {
+			return false
+		}
+	}
+	return true
+}
+
+// productWithout returns the product of [factors], except the one at [index]
+func productWithout(factors []*Expr, index int) *Expr {
+	var out *Expr
+	for i, factor := range factors {
+		if i == index {
+			continue
+		}
+		if out == nil {
+			out = factor.Copy()
+		} else {
+			out = &Expr{atom: mult, left: out, right: factor.Copy()}
+		}
+	}
+	return out
+}
+
+// findNodes returns the nodes of [expr] for which [match] is true
+func (expr *Expr) findNodes(match func(*Expr) bool) []*Expr {
+	if expr == nil {
+		return nil
+	}
+	var out []*Expr
+	if match(expr) {
+		out = append(out, expr)
+	}
+	out = append(out, expr.left.findNodes(match)...)
+	out = append(out, expr.right.findNodes(match)...)
+	return out
+}
+
+// isSquaredSum matches (a + b)^2 and (a - b)^2
+func isSquaredSum(expr *Expr) bool {
+	if expr.atom != pow || expr.right.atom != Number(2) {
+		return false
+	}
+	base := expr.left
+	return (base.atom == plus || base.atom == minus) && base.left != nil
+}
+
+func hasNumberExponent(expr *Expr) bool {
+	if expr.atom != pow {
+		return false
+	}
+	_, ok := expr.right.atom.(Number)
+	return ok
+}
+
+// isRoundingMistake returns true if [answer] is a number
+// close to [expected], but with the wrong last digit.
+// Only expected values which may not be written exactly
+// as a decimal number (such as pi or 2/3) are concerned : otherwise,
+// the exact value is expected and no rounding is involved.
+func isRoundingMistake(expected, answer *Expr) bool {
+	ve, err := expected.Evaluate(nil)
+	if err != nil {
+		return false
+	}
+	va, err := answer.Evaluate(nil)
+	if err != nil || AreFloatEqual(ve, va) {
+		return false
+	}
+	if decimalDigits(ve) < maxDecimalDigits {
+		return false
+	}
+	// the precision is given by the number of digits of the answer
+	digits := decimalDigits(va)
+	return math.Abs(ve-va) < math.Pow10(-digits)
+}
+
+// maxDecimalDigits is the number of digits kept by [RoundFloat]
+const maxDecimalDigits = 10
+
+// decimalDigits returns the number of digits after the decimal point
+// needed to write [v], up to the precision of [RoundFloat]
+func decimalDigits(v float64) int {
+	s := strconv.FormatFloat(RoundFloat(v), 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		return 0
+	}
+	return len(s) - strings.Index(s, ".") - 1
+}
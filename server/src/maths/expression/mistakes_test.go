@@ -0,0 +1,49 @@
+package expression
+
+import (
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestDiagnoseMistake(t *testing.T) {
+	for _, test := range []struct {
+		expected, answer string
+		want             Mistake
+	}{
+		{"2x + 1", "1 + 2x", FormMistake},
+		{"3/4", "0.75", FormMistake},
+		{"x - 3", "3 - x", SignMistake},
+		{"-5", "5", SignMistake},
+		{"3/4", "4/3", InvertedFraction},
+		{"(x+1)/(x-1)", "(x-1)/(x+1)", InvertedFraction},
+		{"2x(x+1)", "x(x+1)", MissingFactor},
+		{"3(x-2)", "x - 2", MissingFactor},
+		{"(x+3)^2", "x^2 + 9", IdentityMistake},
+		{"(2x-1)^2", "4x^2 - 1", IdentityMistake},
+		{"(x-1)^2", "x^2 + 1", IdentityMistake},
+		{"3x^2", "3x^3", PowerMistake},
+		{"5x^4", "5x^3", PowerMistake},
+		{"pi", "3.15", RoundingMistake},
+		{"2/3", "0.66", RoundingMistake},
+		{"2/3", "0.67", RoundingMistake},
+		{"2/3", "0.6", RoundingMistake},
+		{"2/3", "0.5", NoMistake},
+		{"2.5", "2", NoMistake},
+		{"2.5", "3", NoMistake},
+		{"1/8", "0.13", NoMistake},
+		{"sqrt(2)", "1", RoundingMistake},
+		{"x + 2", "x^2 + 5", NoMistake},
+		{"12", "7", NoMistake},
+	} {
+		got := DiagnoseMistake(MustParse(test.expected), MustParse(test.answer))
+		tu.Assert(t, got == test.want)
+	}
+}
+
+func TestMistakeHint(t *testing.T) {
+	tu.Assert(t, NoMistake.Hint() == "")
+	for m := FormMistake; m <= RoundingMistake; m++ {
+		tu.Assert(t, m.Hint() != "")
+	}
+}
@@ -63,7 +63,11 @@ func MustParse(s string) *Expr {
 
 // parseBytes parses a mathematical expression. If invalid, an `InvalidExpr` is returned.
 func parseBytes(text []byte) (*Expr, varMap, error) {
-	pr := newParser(text)
+	return newParser(text).parseAll()
+}
+
+// parseAll parses the whole input as one expression
+func (pr *parser) parseAll() (*Expr, varMap, error) {
 	e, err := pr.parseExpression(false)
 	if err != nil {
 		return nil, nil, err
@@ -142,7 +146,11 @@ func (pr *parser) parseOneNode(acceptSemiColon bool) (*Expr, error) {
 	case symbol:
 		switch data {
 		case openPar:
-			return pr.parseParenthesisBlock(tok.pos)
+			out, err := pr.parseParenthesisBlock(tok.pos)
+			if err == nil && out != nil && tok.call {
+				out.call |= callArg
+			}
+			return out, err
 		case closePar:
 			panic("internal error")
 		case openMatrix: // matrix/vector start
@@ -159,7 +167,11 @@ func (pr *parser) parseOneNode(acceptSemiColon bool) (*Expr, error) {
 			panic(exhaustiveSymbolSwitch)
 		}
 	case operator:
-		return pr.parseOperator(data, tok.pos, acceptSemiColon)
+		out, err := pr.parseOperator(data, tok.pos, acceptSemiColon)
+		if err == nil && tok.call {
+			out.call |= callMult
+		}
+		return out, err
 	case roundFunc:
 		return pr.parseRoundFunction(tok.pos)
 	case specialFunctionKind:
@@ -657,6 +669,94 @@ func (rd specialFunction) validate(pos int) error {
 				Pos:    pos,
 			}
 		}
+	case listFn: // empty lists are allowed
+	case randList:
+		if len(rd.args) != 3 {
+			return ErrInvalidExpr{
+				Reason: "randList requiert exactement 3 arguments",
+				Pos:    pos,
+			}
+		}
+
+		// eagerly try to eval start and end in case their are constant,
+		// so that the error is detected during parameter setup
+		start, end, err := startEnd(rd.args[1], rd.args[2], nil)
+		if err == nil {
+			return rd.kind.validateStartEnd(start, end, pos)
+		}
+	case meanFn, medianFn, q1Fn, q3Fn, varFn, stdevFn, sortFn:
+		if len(rd.args) == 0 {
+			return ErrInvalidExpr{
+				Reason: fmt.Sprintf("%s() requiert au moins un argument", rd.kind.String()),
+				Pos:    pos,
+			}
+		}
+	case countFn:
+		if len(rd.args) != 1 && len(rd.args) != 2 {
+			return ErrInvalidExpr{
+				Reason: "count requiert exactement 1 ou 2 arguments",
+				Pos:    pos,
+			}
+		}
+	case binomPdf, binomCdf, unifPdf, unifCdf:
+		if len(rd.args) != 3 {
+			return ErrInvalidExpr{
+				Reason: fmt.Sprintf("%s() requiert exactement 3 arguments", rd.kind.String()),
+				Pos:    pos,
+			}
+		}
+	case geomPdf, geomCdf, expectationFn, varianceFn, randBinom:
+		if len(rd.args) != 2 {
+			return ErrInvalidExpr{
+				Reason: fmt.Sprintf("%s() requiert exactement 2 arguments", rd.kind.String()),
+				Pos:    pos,
+			}
+		}
+	case normalCdf:
+		// the default law is N(0, 1)
+		if len(rd.args) != 2 && len(rd.args) != 4 {
+			return ErrInvalidExpr{
+				Reason: "normalCdf requiert exactement 2 ou 4 arguments",
+				Pos:    pos,
+			}
+		}
+	case invNorm:
+		// the default law is N(0, 1)
+		if len(rd.args) != 1 && len(rd.args) != 3 {
+			return ErrInvalidExpr{
+				Reason: "invNorm requiert exactement 1 ou 3 arguments",
+				Pos:    pos,
+			}
+		}
+	case simBinom:
+		// the last argument is an optional seed
+		if len(rd.args) != 3 && len(rd.args) != 4 {
+			return ErrInvalidExpr{
+				Reason: "simBinom requiert exactement 3 ou 4 arguments",
+				Pos:    pos,
+			}
+		}
+	case reFn, imFn, conjFn, argFn, algFormFn, expFormFn:
+		if len(rd.args) != 1 {
+			return ErrInvalidExpr{
+				Reason: fmt.Sprintf("%s() requiert exactement 1 argument", rd.kind.String()),
+				Pos:    pos,
+			}
+		}
+	case randComplex:
+		if len(rd.args) != 2 {
+			return ErrInvalidExpr{
+				Reason: "randComplex attend deux paramètres",
+				Pos:    pos,
+			}
+		}
+
+		// eagerly try to eval start and end in case their are constant,
+		// so that the error is detected during parameter setup
+		start, end, err := startEnd(rd.args[0], rd.args[1], nil)
+		if err == nil {
+			return rd.kind.validateStartEnd(start, end, pos)
+		}
 	default:
 		panic(exhaustiveSpecialFunctionSwitch)
 	}
@@ -0,0 +1,542 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// This file implements a LaTeX input for expressions, so that
+// formulas may be pasted from existing LaTeX documents, or sent by
+// a LaTeX math keyboard.
+// The LaTeX source is translated to the plain syntax, which is then
+// parsed as usual. The positions in the translated string are tracked,
+// so that errors refer to the LaTeX input.
+
+// ParseLaTeX parses a mathematical expression written in LaTeX,
+// such as \frac{1}{2}x^{2} + \sqrt{3}.
+// If invalid, [ErrInvalidExpr] is returned.
+func ParseLaTeX(latex string) (*Expr, error) {
+	tr, err := translateLaTeX(latex)
+	if err != nil {
+		return nil, err
+	}
+	expr, _, err := parseBytes(tr.out)
+	if err != nil {
+		return nil, tr.mapError(err.(ErrInvalidExpr))
+	}
+	return expr, nil
+}
+
+// ParseLaTeXCompound is the same as [ParseLaTeX], but also accepts
+// sets and intervals, such as \left] -\infty ; 3 \right] (see [ParseCompound]).
+func ParseLaTeXCompound(latex string) (Compound, error) {
+	return parseLaTeXCompound(latex, false)
+}
+
+// ParseLaTeXEquationCompound is the same as [ParseLaTeXCompound], but also
+// accepts a single = in equations (see [ParseEquationCompound]).
+func ParseLaTeXEquationCompound(latex string) (Compound, error) {
+	return parseLaTeXCompound(latex, true)
+}
+
+func parseLaTeXCompound(latex string, acceptSingleEqual bool) (Compound, error) {
+	tr, err := translateLaTeX(latex)
+	if err != nil {
+		return nil, err
+	}
+	out, err := parseCompound(string(tr.out), acceptSingleEqual)
+	if err != nil {
+		return nil, tr.mapError(err.(ErrInvalidExpr))
+	}
+	return out, nil
+}
+
+// IsLaTeX returns true if [s] uses LaTeX commands,
+// which are never valid in the plain syntax.
+func IsLaTeX(s string) bool { return strings.ContainsRune(s, '\\') }
+
+// latexFunctions maps the LaTeX commands to
+// the functions names of the plain syntax
+var latexFunctions = map[string]string{
+	"ln":     "ln",
+	"log":    "log",
+	"exp":    "exp",
+	"sin":    "sin",
+	"cos":    "cos",
+	"tan":    "tan",
+	"arcsin": "asin",
+	"arccos": "acos",
+	"arctan": "atan",
+	"det":    "det",
+	"arg":    "arg",
+	"Re":     "re",
+	"Im":     "im",
+}
+
+// latexSymbols maps the LaTeX commands (without arguments)
+// to their plain syntax equivalent
+var latexSymbols = map[string]string{
+	"cdot":       "*",
+	"times":      "*",
+	"div":        "/",
+	"pi":         string(piRune),
+	"infty":      " inf ",
+	"le":         "<=",
+	"leq":        "<=",
+	"leqslant":   "<=",
+	"ge":         ">=",
+	"geq":        ">=",
+	"geqslant":   ">=",
+	"ne":         "!=",
+	"neq":        "!=",
+	"cup":        "∪",
+	"cap":        "∩",
+	"emptyset":   "{}",
+	"varnothing": "{}",
+	"lbrace":     "{",
+	"rbrace":     "}",
+	"lfloor":     " floor(",
+	"rfloor":     ")",
+	// spacing and style commands are ignored
+	"displaystyle": "",
+	"quad":         "",
+	"qquad":        "",
+	"limits":       "",
+}
+
+var (
+	latexToUnicodeOnce sync.Once
+	latexToUnicode     map[string]rune
+)
+
+// reverse the printer table, favoring the smallest rune
+// (so that \alpha is α and not the mathematical italic variant)
+func latexToUnicodeTable() map[string]rune {
+	latexToUnicodeOnce.Do(func() {
+		latexToUnicode = make(map[string]rune, len(unicodeToLaTeX))
+		for r, command := range unicodeToLaTeX {
+			if !strings.HasPrefix(command, "\\") {
+				continue
+			}
+			if other, has := latexToUnicode[command]; has && other < r {
+				continue
+			}
+			latexToUnicode[command] = r
+		}
+	})
+	return latexToUnicode
+}
+
+type latexTranslater struct {
+	src []rune
+	pos int // in src
+
+	out    []byte
+	outPos []int // for each byte of out, the rune index in src
+
+	absDepth int // number of | not closed yet
+}
+
+func translateLaTeX(latex string) (*latexTranslater, error) {
+	tr := &latexTranslater{src: []rune(latex)}
+	if err := tr.translate(func() bool { return false }); err != nil {
+		err.Input = latex
+		return nil, *err
+	}
+	if tr.absDepth != 0 {
+		return nil, ErrInvalidExpr{Input: latex, Reason: "valeur absolue non fermée", Pos: len(tr.src)}
+	}
+	return tr, nil
+}
+
+// mapError uses the original LaTeX input and position
+func (tr *latexTranslater) mapError(err ErrInvalidExpr) ErrInvalidExpr {
+	err.Input = string(tr.src)
+	// [Pos] is a rune index in the translated string
+	runes := 0
+	pos := len(tr.src)
+	for i := range string(tr.out) {
+		if runes == err.Pos {
+			pos = tr.outPos[i]
+			break
+		}
+		runes++
+	}
+	err.Pos = pos
+	return err
+}
+
+func (tr *latexTranslater) errorf(format string, args ...any) *ErrInvalidExpr {
+	return &ErrInvalidExpr{Reason: fmt.Sprintf(format, args...), Pos: tr.pos}
+}
+
+// emit writes [s] to the output, pointing to the current position
+func (tr *latexTranslater) emit(s string) {
+	pos := tr.pos
+	if pos >= len(tr.src) {
+		pos = len(tr.src) - 1
+	}
+	for range []byte(s) {
+		tr.outPos = append(tr.outPos, pos)
+	}
+	tr.out = append(tr.out, s...)
+}
+
+func (tr *latexTranslater) skipSpaces() {
+	for tr.pos < len(tr.src) && unicode.IsSpace(tr.src[tr.pos]) {
+		tr.pos++
+	}
+}
+
+// peekCommand returns the name of the command starting at the current position,
+// or an empty string
+func (tr *latexTranslater) peekCommand() string {
+	if tr.pos >= len(tr.src) || tr.src[tr.pos] != '\\' {
+		return ""
+	}
+	end := tr.pos + 1
+	for end < len(tr.src) && unicode.IsLetter(tr.src[end]) {
+		end++
+	}
+	if end == tr.pos+1 && end < len(tr.src) { // one char command, like \{
+		end++
+	}
+	return string(tr.src[tr.pos+1 : end])
+}
+
+// translate reads until EOF or [stop] returns true
+func (tr *latexTranslater) translate(stop func() bool) *ErrInvalidExpr {
+	for tr.pos < len(tr.src) && !stop() {
+		if err := tr.translateElement(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateGroup reads a {...} group, or a single element,
+// and returns its translation, without writing it to the output
+func (tr *latexTranslater) translateGroup() (string, []int, *ErrInvalidExpr) {
+	start := len(tr.out)
+	tr.skipSpaces()
+	if tr.pos >= len(tr.src) {
+		return "", nil, tr.errorf("argument manquant")
+	}
+	if tr.src[tr.pos] == '{' {
+		tr.pos++
+		if err := tr.translate(func() bool { return tr.src[tr.pos] == '}' }); err != nil {
+			return "", nil, err
+		}
+		if tr.pos >= len(tr.src) {
+			return "", nil, tr.errorf("accolade fermante manquante")
+		}
+		tr.pos++ // consume }
+	} else if err := tr.translateElement(); err != nil {
+		return "", nil, err
+	}
+	out, outPos := string(tr.out[start:]), append([]int(nil), tr.outPos[start:]...)
+	tr.out, tr.outPos = tr.out[:start], tr.outPos[:start]
+	return out, outPos, nil
+}
+
+// emitGroup reads a group and writes it to the output,
+// enclosed in [open] and [close]
+func (tr *latexTranslater) emitGroup(open, close string) *ErrInvalidExpr {
+	tr.emit(open)
+	content, contentPos, err := tr.translateGroup()
+	if err != nil {
+		return err
+	}
+	tr.out = append(tr.out, content...)
+	tr.outPos = append(tr.outPos, contentPos...)
+	tr.emit(close)
+	return nil
+}
+
+// readRawGroup reads a {...} group as text, or a single letter
+func (tr *latexTranslater) readRawGroup() (string, *ErrInvalidExpr) {
+	tr.skipSpaces()
+	if tr.pos >= len(tr.src) {
+		return "", tr.errorf("argument manquant")
+	}
+	if tr.src[tr.pos] != '{' {
+		tr.pos++
+		return string(tr.src[tr.pos-1]), nil
+	}
+	end := tr.pos + 1
+	for end < len(tr.src) && tr.src[end] != '}' {
+		end++
+	}
+	if end >= len(tr.src) {
+		return "", tr.errorf("accolade fermante manquante")
+	}
+	out := string(tr.src[tr.pos+1 : end])
+	tr.pos = end + 1
+	return out, nil
+}
+
+func isSimpleIndice(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isVariableChar(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func (tr *latexTranslater) translateElement() *ErrInvalidExpr {
+	c := tr.src[tr.pos]
+	switch c {
+	case '\\':
+		return tr.translateCommand()
+	case '{':
+		content, contentPos, err := tr.translateGroup()
+		if err != nil {
+			return err
+		}
+		if content == "," || content == "." { // decimal separator, as in 3{,}5
+			tr.emit(content)
+			return nil
+		}
+		tr.emit("(")
+		tr.out = append(tr.out, content...)
+		tr.outPos = append(tr.outPos, contentPos...)
+		tr.emit(")")
+		return nil
+	case '}':
+		return tr.errorf("accolade fermante inattendue")
+	case '^':
+		tr.emit("^")
+		tr.pos++
+		return tr.emitGroup("(", ")")
+	case '_':
+		tr.pos++
+		save := tr.pos
+		indice, err := tr.readRawGroup()
+		if err != nil {
+			return err
+		}
+		if isSimpleIndice(indice) { // x_{1} is the variable x_1
+			tr.emit("_" + indice)
+			return nil
+		}
+		tr.pos = save
+		tr.emit("_")
+		return tr.emitGroup("{", "}")
+	case '|':
+		tr.toggleAbs()
+		tr.pos++
+		return nil
+	case '~':
+		tr.pos++
+		return nil
+	default:
+		tr.emit(string(c))
+		tr.pos++
+		return nil
+	}
+}
+
+// translateCommand assumes the current position is on a \
+func (tr *latexTranslater) translateCommand() *ErrInvalidExpr {
+	name := tr.peekCommand()
+	start := tr.pos
+	tr.pos += 1 + len([]rune(name))
+
+	switch name {
+	case "", "\\":
+		tr.pos = start
+		return tr.errorf("commande LaTeX invalide")
+	case ",", ";", ":", "!", " ":
+		return nil
+	case "{", "}":
+		tr.emit(name)
+		return nil
+	case "frac", "dfrac", "tfrac":
+		if err := tr.emitGroup("((", ")"); err != nil {
+			return err
+		}
+		return tr.emitGroup("/(", "))")
+	case "sqrt":
+		tr.skipSpaces()
+		if tr.pos < len(tr.src) && tr.src[tr.pos] == '[' { // n-th root
+			end := tr.pos + 1
+			for end < len(tr.src) && tr.src[end] != ']' {
+				end++
+			}
+			if end >= len(tr.src) {
+				return tr.errorf("crochet fermant manquant")
+			}
+			sub := &latexTranslater{src: tr.src[:end], pos: tr.pos + 1}
+			if err := sub.translate(func() bool { return false }); err != nil {
+				return err
+			}
+			tr.pos = end + 1
+			if err := tr.emitGroup("((", ")^(1/("); err != nil {
+				return err
+			}
+			tr.out = append(tr.out, sub.out...)
+			tr.outPos = append(tr.outPos, sub.outPos...)
+			tr.emit(")))")
+			return nil
+		}
+		return tr.emitGroup(" sqrt(", ")")
+	case "binom", "dbinom", "tbinom": // \binom{n}{k} is binom(k; n)
+		n, nPos, err := tr.translateGroup()
+		if err != nil {
+			return err
+		}
+		if err := tr.emitGroup(" binom((", ")"); err != nil {
+			return err
+		}
+		tr.emit(";(")
+		tr.out = append(tr.out, n...)
+		tr.outPos = append(tr.outPos, nPos...)
+		tr.emit("))")
+		return nil
+	case "overline", "bar":
+		return tr.emitGroup(" ¬(", ")")
+	case "mathrm", "text", "textrm", "operatorname", "mathit":
+		content, err := tr.readRawGroup()
+		if err != nil {
+			return err
+		}
+		content = strings.TrimSpace(content)
+		if fn, isFunction := latexFunctions[content]; isFunction {
+			return tr.emitFunction(fn)
+		}
+		tr.emit(" " + content + " ")
+		return nil
+	case "left", "bigl", "Bigl", "biggl":
+		return tr.translateDelimiter(true, false)
+	case "right", "bigr", "Bigr", "biggr":
+		return tr.translateDelimiter(false, true)
+	case "big", "Big", "bigg":
+		return tr.translateDelimiter(false, false)
+	case "vert", "lvert", "rvert":
+		tr.toggleAbs()
+		return nil
+	}
+
+	if fn, isFunction := latexFunctions[name]; isFunction {
+		return tr.emitFunction(fn)
+	}
+	if symbol, isSymbol := latexSymbols[name]; isSymbol {
+		tr.emit(symbol)
+		return nil
+	}
+
+	// use the printer table, to support greek letters and other symbols
+	table := latexToUnicodeTable()
+	if r, has := table["\\"+name]; has {
+		tr.emit(string(r))
+		return nil
+	}
+	// commands with one argument, like \mathbb{R}
+	if arg, err := tr.readRawGroup(); err == nil {
+		if r, has := table[fmt.Sprintf("\\%s{%s}", name, arg)]; has {
+			tr.emit(string(r))
+			return nil
+		}
+	}
+
+	tr.pos = start
+	return tr.errorf("commande LaTeX \\%s non supportée", name)
+}
+
+// toggleAbs opens or closes an absolute value, for | delimiters
+func (tr *latexTranslater) toggleAbs() {
+	if tr.absDepth > 0 {
+		tr.absDepth--
+		tr.emit(")")
+	} else {
+		tr.absDepth++
+		tr.emit(" abs(")
+	}
+}
+
+// translateDelimiter handles \left( or \right), and similar.
+// For sizing commands like \big, both [isOpening] and [isClosing] are false.
+func (tr *latexTranslater) translateDelimiter(isOpening, isClosing bool) *ErrInvalidExpr {
+	tr.skipSpaces()
+	if tr.pos >= len(tr.src) {
+		return tr.errorf("délimiteur manquant")
+	}
+	delimiter := string(tr.src[tr.pos])
+	if delimiter == "\\" {
+		delimiter = "\\" + tr.peekCommand()
+	}
+	tr.pos += len([]rune(delimiter))
+	switch delimiter {
+	case "(", ")", "[", "]":
+		tr.emit(delimiter)
+	case ".":
+	case "\\{", "\\lbrace":
+		tr.emit("{")
+	case "\\}", "\\rbrace":
+		tr.emit("}")
+	case "|", "\\vert", "\\lvert", "\\rvert":
+		switch {
+		case isOpening:
+			tr.absDepth++
+			tr.emit(" abs(")
+		case isClosing:
+			tr.absDepth--
+			tr.emit(")")
+		default:
+			tr.toggleAbs()
+		}
+	case "\\lfloor":
+		tr.emit(" floor(")
+	case "\\rfloor":
+		tr.emit(")")
+	default:
+		return tr.errorf("délimiteur %s non supporté", delimiter)
+	}
+	return nil
+}
+
+// isFunctionArgumentEnd returns true if the current position
+// ends an argument given without parenthesis, as in \ln x + 1
+func (tr *latexTranslater) isFunctionArgumentEnd() bool {
+	switch tr.src[tr.pos] {
+	case '+', '-', '=', '<', '>', ';', ')', ']', '}', '|':
+		return true
+	case '\\':
+		command := tr.peekCommand()
+		if _, isFunction := latexFunctions[command]; isFunction {
+			return true
+		}
+		switch command {
+		case "right", "cdot", "times", "div", "le", "leq", "leqslant", "ge", "geq", "geqslant",
+			"ne", "neq", "cup", "cap", "}", "rbrace", "rfloor", "bigr", "Bigr", "biggr":
+			return true
+		}
+	}
+	return false
+}
+
+// emitFunction writes a function call, adding the parenthesis
+// if required, as in \ln x
+func (tr *latexTranslater) emitFunction(fn string) *ErrInvalidExpr {
+	tr.emit(" " + fn)
+	tr.skipSpaces()
+	if tr.pos >= len(tr.src) {
+		return nil // let the parser report the error
+	}
+	switch command := tr.peekCommand(); {
+	case tr.src[tr.pos] == '(', command == "left", command == "bigl", command == "Bigl", command == "biggl":
+		return nil
+	}
+	tr.emit("(")
+	if err := tr.translate(tr.isFunctionArgumentEnd); err != nil {
+		return err
+	}
+	tr.emit(")")
+	return nil
+}
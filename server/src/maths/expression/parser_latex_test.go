@@ -0,0 +1,92 @@
+package expression
+
+import (
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestParseLaTeX(t *testing.T) {
+	for _, test := range []struct {
+		latex string
+		plain string
+	}{
+		{`\frac{1}{2}x^{2} + \sqrt{3}`, "(1/2)x^2 + sqrt(3)"},
+		{`\dfrac{a+1}{b} \neq 0`, "(a+1)/b != 0"},
+		{`\sqrt[3]{x+1}`, "(x+1)^(1/3)"},
+		{`2\cdot 3 \times x`, "2 * 3 * x"},
+		{`\left(x+1\right)^{2}`, "(x+1)^2"},
+		{`{x}^{2}`, "x^2"},
+		{`\mathrm{e}^{-x}`, "e^(-x)"},
+		{`\ln x + 1`, "ln(x) + 1"},
+		{`x\ln\left(x\right)`, "x ln(x)"},
+		{`\sin 2x \cdot \cos x`, "sin(2x) * cos(x)"},
+		{`\pi r^2`, "πr^2"},
+		{`-\infty`, "-inf"},
+		{`x_{1} + x_2 + u_{n+1}`, "x_1 + x_2 + u_{n+1}"},
+		{`\left|x-1\right| + |y|`, "abs(x-1) + abs(y)"},
+		{`3{,}5`, "3,5"},
+		{`\alpha\beta`, "αβ"},
+		{`\binom{5}{2}`, "binom(2;5)"},
+		{`\overline{A} \cup B \cap C`, "¬A ∪ B ∩ C"},
+		{`x \leqslant 2`, "x <= 2"},
+		{`1\,000 \times 2`, "1000 * 2"},
+	} {
+		got, err := ParseLaTeX(test.latex)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, got.String() == MustParse(test.plain).String())
+	}
+}
+
+func TestParseLaTeXCompound(t *testing.T) {
+	for _, test := range []struct {
+		latex string
+		plain string
+	}{
+		{`\left] -\infty ; 3 \right]`, "]-inf;3]"},
+		{`\left[ \frac{1}{2} ; \pi \right[`, "[1/2;π["},
+		{`\left\{ 1 ; \sqrt{2} \right\}`, "{1;sqrt(2)}"},
+		{`\{ a \}`, "{a}"},
+		{`\emptyset`, "{}"},
+		{`\frac{1}{2}`, "1/2"},
+	} {
+		got, err := ParseLaTeXCompound(test.latex)
+		tu.AssertNoErr(t, err)
+		exp, err := ParseCompound(test.plain)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, got.String() == exp.String())
+	}
+}
+
+func TestParseLaTeXErrors(t *testing.T) {
+	for _, test := range []struct {
+		latex   string
+		portion string
+	}{
+		{`\frac{1}{`, `\frac{1}{`},
+		{`2 + \foo x`, `2 + `},
+		{`\frac{1}{2} + )`, `\frac{1}{2} `},
+		{`|x`, `|x`},
+		{`x}`, `x`},
+	} {
+		_, err := ParseLaTeX(test.latex)
+		tu.Assert(t, err != nil)
+		errV, ok := err.(ErrInvalidExpr)
+		tu.Assert(t, ok)
+		tu.Assert(t, errV.Input == test.latex)
+		tu.Assert(t, errV.Portion() == test.portion)
+	}
+}
+
+func TestIsLaTeX(t *testing.T) {
+	tu.Assert(t, IsLaTeX(`\frac{1}{2}`))
+	tu.Assert(t, !IsLaTeX(`1/2`))
+	tu.Assert(t, !IsLaTeX(`x^{2}`)) // also valid in the plain syntax
+}
+
+func TestParseLaTeXBinom(t *testing.T) {
+	e, err := ParseLaTeX(`\binom{5}{2}`)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, mustEvaluate(e.String(), nil) == 10)
+	tu.Assert(t, e.AsLaTeX() == `\binom{5}{2}`)
+}
@@ -443,6 +443,9 @@ var expressions = [...]struct {
 	{"n - !", nil, true},
 	{"n!", &Expr{atom: factorial, left: newVarExpr('n'), right: nil}, false},
 	{"(2 + n)!", &Expr{atom: factorial, left: &Expr{atom: plus, left: NewNb(2), right: newVarExpr('n')}, right: nil}, false},
+	{"3!==6", &Expr{atom: equals, left: &Expr{atom: factorial, left: NewNb(3)}, right: NewNb(6)}, false},
+	{"n!==m", &Expr{atom: equals, left: &Expr{atom: factorial, left: newVarExpr('n')}, right: newVarExpr('m')}, false},
+	{"n!=m", &Expr{atom: notEquals, left: newVarExpr('n'), right: newVarExpr('m')}, false},
 
 	{"randInt(-a, )", nil, true},
 	{"randInt(1.5; )", nil, true},
@@ -580,6 +583,7 @@ var expressions = [...]struct {
 	// comparison
 	{"1 <", nil, true},
 	{">= 4", nil, true},
+	{" 2 =< 4 ", nil, true},
 	{" 2 = 4 ", nil, true},
 	{
 		" 2 == 4 ",
@@ -105,6 +105,8 @@ func (op operator) asLaTeX(left, right *Expr) string {
 	switch op {
 	case equals:
 		return fmt.Sprintf("%s = %s", leftCode, rightCode)
+	case notEquals:
+		return fmt.Sprintf("%s \\neq %s", leftCode, rightCode)
 	case greater:
 		return fmt.Sprintf("%s \\ge %s", leftCode, rightCode)
 	case strictlyGreater:
@@ -287,6 +289,40 @@ func (r specialFunction) asLaTeX(_, _ *Expr) string {
 	case binomial:
 		k, n := r.args[0], r.args[1]
 		return fmt.Sprintf(`\binom{%s}{%s}`, n.AsLaTeX(), k.AsLaTeX())
+	case listFn:
+		return listLaTeX(r.args)
+	case sortFn:
+		if l, err := r.sortedList(nil); err == nil {
+			return listLaTeX(l.atom.(specialFunction).args)
+		}
+	case binomPdf, binomCdf, geomPdf, geomCdf, unifPdf, unifCdf, normalCdf, invNorm,
+		expectationFn, varianceFn:
+		args := make([]string, len(r.args))
+		for i, arg := range r.args {
+			args[i] = listArgLaTeX(arg)
+		}
+		return fmt.Sprintf(`\operatorname{%s}\left(%s\right)`, r.kind.String(), strings.Join(args, " ; "))
+	case reFn:
+		return fmt.Sprintf(`\operatorname{Re}\left(%s\right)`, r.args[0].AsLaTeX())
+	case imFn:
+		return fmt.Sprintf(`\operatorname{Im}\left(%s\right)`, r.args[0].AsLaTeX())
+	case argFn:
+		return fmt.Sprintf(`\arg\left(%s\right)`, r.args[0].AsLaTeX())
+	case conjFn:
+		return fmt.Sprintf(`\overline{%s}`, r.args[0].AsLaTeX())
+	case algFormFn, expFormFn:
+		// display the form when the number is known
+		if z, err := r.args[0].evalComplex(nil); err == nil {
+			if r.kind == algFormFn {
+				return z.toExpr().AsLaTeX()
+			}
+			return z.toExponentialForm().AsLaTeX()
+		}
+	case meanFn, medianFn, q1Fn, q3Fn, varFn, stdevFn, countFn:
+		// display the value when the data series is known
+		if v, err := r.evalStatistic(nil); err == nil {
+			return v.toExpr().AsLaTeX()
+		}
 	case sumFn, prodFn, unionFn, interFn:
 		k, start, end, expr := r.args[0], r.args[1], r.args[2], r.args[3]
 		if len(r.args) == 5 {
@@ -319,9 +355,8 @@ func (r specialFunction) asLaTeX(_, _ *Expr) string {
 			latexOp = "bigcap"
 		}
 		return fmt.Sprintf(`\%s_{%s=%s}^{%s} %s`, latexOp, k.AsLaTeX(), start.AsLaTeX(), end.AsLaTeX(), expr.AsLaTeX())
-	default:
-		return fmt.Sprintf(`\text{%s}`, r.String())
 	}
+	return fmt.Sprintf(`\text{%s}`, r.String())
 }
 
 func (v Variable) asLaTeX(_, _ *Expr) string {
@@ -391,7 +426,7 @@ func (op operator) needParenthesis(expr *Expr, isLeftArg, isLaTex bool) bool {
 	}
 
 	switch atom := expr.atom.(type) {
-	case Number, constant, function, Variable, roundFunc, specialFunction, indice, matrix:
+	case Number, constant, function, Variable, roundFunc, specialFunction, indice, matrix, userFunction:
 		return false
 	case operator:
 		if isLaTex && atom == complement {
@@ -475,7 +510,7 @@ func (op operator) serialize(left, right *Expr) string {
 	case div:
 		// compact fractions
 		return leftCode + op.String() + rightCode
-	case equals, greater, strictlyGreater, lesser, strictlyLesser, union, intersection, complement,
+	case equals, notEquals, greater, strictlyGreater, lesser, strictlyLesser, union, intersection, complement,
 		mod, rem, pow:
 		return fmt.Sprintf(`%s %s %s`, leftCode, op.String(), rightCode)
 	default:
@@ -493,7 +528,7 @@ func shouldOmitTimes(left *Expr, rightHasParenthesis bool, right *Expr) bool {
 	}
 
 	switch right.atom.(type) {
-	case Variable, constant, function, specialFunction, roundFunc, indice:
+	case Variable, constant, function, specialFunction, roundFunc, indice, userFunction:
 		return true
 	case Number:
 		return false
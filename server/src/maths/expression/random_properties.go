@@ -3,6 +3,7 @@ package expression
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"sort"
 	"strings"
@@ -15,6 +16,13 @@ type RandomParameters struct {
 	specials []intrinsic
 
 	defs map[Variable]*Expr
+
+	// boolean expressions which must be satisfied
+	// by the instantiated parameters
+	constraints []*Expr
+
+	// user defined functions, such as f(x) = ax + b
+	functions map[Variable]FunctionExpr
 }
 
 func NewRandomParameters() *RandomParameters {
@@ -29,6 +37,9 @@ func (rp RandomParameters) IsDefined(v Variable) bool {
 			return true
 		}
 	}
+	if _, has := rp.functions[v]; has {
+		return true
+	}
 	_, has := rp.defs[v]
 	return has
 }
@@ -41,6 +52,9 @@ func (rp RandomParameters) DefinedVariables() []Variable {
 	for v := range rp.defs {
 		out = append(out, v)
 	}
+	for v := range rp.functions {
+		out = append(out, v)
+	}
 	return out
 }
 
@@ -53,6 +67,9 @@ func (rp RandomParameters) ParseVariable(v Variable, expr string) error {
 	if _, has := rp.defs[v]; has {
 		return ErrDuplicateParameter{Duplicate: v}
 	}
+	if _, has := rp.functions[v]; has {
+		return ErrDuplicateParameter{Duplicate: v}
+	}
 	rp.defs[v] = e
 	return nil
 }
@@ -123,31 +140,23 @@ func generateDecDenominator(min, max int) []int {
 	return out
 }
 
-func binomialCoefficient(k, n int) int {
+func binomialCoefficient(k, n int) real {
 	if n < 0 || k < 0 || k > n {
-		return 0
-	}
-
-	// Since C(n, k) = C(n, n-k)
-	if k > n-k {
-		k = n - k
+		return newRealInt(0)
 	}
-
-	res := 1
-	// Calculate value of
-	// [n * (n-1) *---* (n-k+1)] / [k * (k-1) *----* 1]
-	for i := 0; i < k; i++ {
-		res *= (n - i)
-		res /= (i + 1)
+	if n > maxFactorial { // use an approximation
+		lgN, _ := math.Lgamma(float64(n + 1))
+		lgK, _ := math.Lgamma(float64(k + 1))
+		lgNK, _ := math.Lgamma(float64(n - k + 1))
+		return newReal(math.Round(math.Exp(lgN - lgK - lgNK)))
 	}
-
-	return res
+	return newRealBig(new(big.Int).Binomial(int64(n), int64(k)))
 }
 
 func (kind specialFunctionKind) validateStartEnd(start, end float64, pos int) error {
 	_ = exhaustiveSpecialFunctionSwitch
 	switch kind {
-	case randInt, randPrime, randDenominator, randMatrixInt:
+	case randInt, randPrime, randDenominator, randMatrixInt, randList, randComplex:
 		start, okStart := IsInt(start)
 		end, okEnd := IsInt(end)
 		if !(okStart && okEnd) {
@@ -178,6 +187,13 @@ func (kind specialFunctionKind) validateStartEnd(start, end float64, pos int) er
 			}
 		}
 
+		if kind == randComplex && start == 0 && end == 0 {
+			return ErrInvalidExpr{
+				Reason: "randComplex requiert un intervalle contenant un entier non nul",
+				Pos:    pos,
+			}
+		}
+
 		if kind == randDenominator && len(generateDecDenominator(start, end)) == 0 {
 			return ErrInvalidExpr{
 				Reason: fmt.Sprintf("aucun diviseur d'un nombre décimal n'existe entre %d et %d", start, end),
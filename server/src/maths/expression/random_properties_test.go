@@ -2,6 +2,7 @@ package expression
 
 import (
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -382,9 +383,10 @@ func Test_binomialCoefficient(t *testing.T) {
 		{2, 4, 6},
 		{2, 3, 3},
 		{2, 5, 10},
+		{30, 60, 118264581564861424},
 	}
 	for _, tt := range tests {
-		if got := binomialCoefficient(tt.k, tt.n); got != tt.want {
+		if got := binomialCoefficient(tt.k, tt.n); got.rat.toBig().Cmp(big.NewRat(int64(tt.want), 1)) != 0 {
 			t.Errorf("binomialCoefficient() = %v, want %v", got, tt.want)
 		}
 	}
@@ -0,0 +1,718 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// This file implements the comparison of equations and inequations
+// through their solution sets over ℝ.
+
+// Relations is a combination of equations or inequations,
+// such as "x > 3 et x <= 5" or "x = -2 ou x = 2".
+// It is stored in disjunctive normal form : the outer slice is
+// joined by "ou", the inner slices by "et".
+type Relations [][]*Expr
+
+func (Relations) isCompound() {}
+
+func (rs Relations) Expressions() []*Expr {
+	var out []*Expr
+	for _, conj := range rs {
+		out = append(out, conj...)
+	}
+	return out
+}
+
+func (rs Relations) join(print func(*Expr) string, and, or string) string {
+	chunks := make([]string, len(rs))
+	for i, conj := range rs {
+		terms := make([]string, len(conj))
+		for j, rel := range conj {
+			terms[j] = print(rel)
+		}
+		chunks[i] = strings.Join(terms, and)
+	}
+	return strings.Join(chunks, or)
+}
+
+func (rs Relations) String() string {
+	return rs.join((*Expr).String, " et ", " ou ")
+}
+
+func (rs Relations) AsLaTeX() string {
+	return rs.join((*Expr).AsLaTeX, ` \text{ et } `, ` \text{ ou } `)
+}
+
+func (rs Relations) Substitute(vars Vars) {
+	for _, rel := range rs.Expressions() {
+		rel.Substitute(vars)
+	}
+}
+
+func areRelationsEquivalent(rs1, rs2 Relations, level ComparisonLevel) bool {
+	if len(rs1) != len(rs2) {
+		return false
+	}
+	for i, conj := range rs1 {
+		if !areVectorsEquivalent(Vector(conj), Vector(rs2[i]), level) {
+			return false
+		}
+	}
+	return true
+}
+
+var reRelationsConnector = regexp.MustCompile(`(?i)\s+(et|and|ou|or)\s+`)
+
+// parseRelations handles expressions combining relations with connectors.
+// If [expr] does not start with a relation, [ok] is false.
+func parseRelations(expr string, acceptSingleEqual bool) (out Relations, ok bool, err error) {
+	matches := reRelationsConnector.FindAllStringSubmatchIndex(expr, -1)
+	if len(matches) == 0 {
+		return nil, false, nil
+	}
+
+	var conj []*Expr
+	start := 0
+	for i := 0; i <= len(matches); i++ {
+		end := len(expr)
+		if i < len(matches) {
+			end = matches[i][0]
+		}
+		pr := newParser([]byte(expr[start:end]))
+		pr.tk.acceptSingleEqual = acceptSingleEqual
+		rel, _, err := pr.parseAll()
+		if i == 0 && (err != nil || !rel.isRelation()) {
+			return nil, false, nil // not a combination of relations
+		}
+		if err != nil {
+			errV := err.(ErrInvalidExpr)
+			errV.Pos += len([]rune(expr[:start]))
+			return nil, true, errV
+		}
+		if !rel.isRelation() {
+			return nil, true, ErrInvalidExpr{
+				Reason: "une équation ou une inéquation est attendue",
+				Pos:    len([]rune(expr[:start])),
+			}
+		}
+		conj = append(conj, rel)
+
+		if i < len(matches) {
+			connector := strings.ToLower(expr[matches[i][2]:matches[i][3]])
+			if connector == "ou" || connector == "or" {
+				out = append(out, conj)
+				conj = nil
+			}
+			start = matches[i][1]
+		}
+	}
+	out = append(out, conj)
+	return out, true, nil
+}
+
+func isRelationOperator(op operator) bool {
+	switch op {
+	case equals, notEquals, greater, strictlyGreater, lesser, strictlyLesser:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRelation returns true if the root of [expr] is an equality or an inequality
+func (expr *Expr) isRelation() bool {
+	op, ok := expr.atom.(operator)
+	return ok && isRelationOperator(op)
+}
+
+// polynomial stores its coefficients by increasing degree
+type polynomial []float64
+
+// trim removes the (almost) zero leading coefficients
+func (p polynomial) trim() polynomial {
+	var largest float64
+	for _, c := range p {
+		largest = math.Max(largest, math.Abs(c))
+	}
+	for len(p) > 0 && math.Abs(p[len(p)-1]) <= 1e-12*largest {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+func (p polynomial) eval(x float64) float64 {
+	var out float64
+	for i := len(p) - 1; i >= 0; i-- {
+		out = out*x + p[i]
+	}
+	return out
+}
+
+func (p polynomial) scale(f float64) polynomial {
+	out := make(polynomial, len(p))
+	for i, c := range p {
+		out[i] = f * c
+	}
+	return out
+}
+
+func addPolynomials(p1, p2 polynomial) polynomial {
+	out := make(polynomial, max(len(p1), len(p2)))
+	copy(out, p1)
+	for i, c := range p2 {
+		out[i] += c
+	}
+	return out
+}
+
+func multPolynomials(p1, p2 polynomial) polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		return nil
+	}
+	out := make(polynomial, len(p1)+len(p2)-1)
+	for i, c1 := range p1 {
+		for j, c2 := range p2 {
+			out[i+j] += c1 * c2
+		}
+	}
+	return out
+}
+
+// roots returns the real roots of polynomials of degree <= 2, sorted
+func (p polynomial) roots() ([]float64, error) {
+	p = p.trim()
+	switch len(p) - 1 {
+	case -1, 0:
+		return nil, nil
+	case 1:
+		return []float64{-p[0] / p[1]}, nil
+	case 2:
+		a, b, c := p[2], p[1], p[0]
+		delta := b*b - 4*a*c
+		if math.Abs(delta) <= 1e-12*(b*b+math.Abs(4*a*c)) {
+			return []float64{-b / (2 * a)}, nil
+		} else if delta < 0 {
+			return nil, nil
+		}
+		sq := math.Sqrt(delta)
+		x1, x2 := (-b-sq)/(2*a), (-b+sq)/(2*a)
+		if x1 > x2 {
+			x1, x2 = x2, x1
+		}
+		return []float64{x1, x2}, nil
+	default:
+		return nil, errors.New("Seules les équations et inéquations de degré au plus 2 sont supportées.")
+	}
+}
+
+// rationalFunction is num / den
+type rationalFunction struct {
+	num, den polynomial
+}
+
+// maxRationalDegree avoids degree blow up
+const maxRationalDegree = 8
+
+// toRationalFunction returns [expr] as a rational function of [x],
+// or false if [expr] contains other variables or functions
+// applied to [x].
+func (expr *Expr) toRationalFunction(x Variable) (rationalFunction, bool) {
+	if expr == nil {
+		return rationalFunction{num: polynomial{0}, den: polynomial{1}}, true
+	}
+	// constant terms
+	if v, err := expr.evalFloat(nil); err == nil {
+		return rationalFunction{num: polynomial{v}, den: polynomial{1}}, true
+	}
+	switch atom := expr.atom.(type) {
+	case Variable:
+		if atom != x {
+			return rationalFunction{}, false
+		}
+		return rationalFunction{num: polynomial{0, 1}, den: polynomial{1}}, true
+	case operator:
+		left, okLeft := expr.left.toRationalFunction(x)
+		if !okLeft {
+			return rationalFunction{}, false
+		}
+		var out rationalFunction
+		switch atom {
+		case pow:
+			n, ok := expr.right.isConstantTermInt()
+			if !ok || n < -maxRationalDegree || n > maxRationalDegree {
+				return rationalFunction{}, false
+			}
+			if n < 0 {
+				left.num, left.den = left.den, left.num
+				n = -n
+			}
+			out = rationalFunction{num: polynomial{1}, den: polynomial{1}}
+			for i := 0; i < n; i++ {
+				out.num = multPolynomials(out.num, left.num)
+				out.den = multPolynomials(out.den, left.den)
+			}
+		case plus, minus, mult, div:
+			right, okRight := expr.right.toRationalFunction(x)
+			if !okRight {
+				return rationalFunction{}, false
+			}
+			switch atom {
+			case plus:
+				out.num = addPolynomials(multPolynomials(left.num, right.den), multPolynomials(right.num, left.den))
+				out.den = multPolynomials(left.den, right.den)
+			case minus:
+				out.num = addPolynomials(multPolynomials(left.num, right.den), multPolynomials(right.num, left.den).scale(-1))
+				out.den = multPolynomials(left.den, right.den)
+			case mult:
+				out.num = multPolynomials(left.num, right.num)
+				out.den = multPolynomials(left.den, right.den)
+			case div:
+				out.num = multPolynomials(left.num, right.den)
+				out.den = multPolynomials(left.den, right.num)
+			}
+		default:
+			return rationalFunction{}, false
+		}
+		out.num, out.den = out.num.trim(), out.den.trim()
+		if len(out.den) == 0 || len(out.num) > maxRationalDegree+1 || len(out.den) > maxRationalDegree+1 {
+			return rationalFunction{}, false
+		}
+		return out, true
+	default:
+		return rationalFunction{}, false
+	}
+}
+
+// realInterval is an interval of ℝ, with possibly infinite bounds
+type realInterval struct {
+	left, right         float64
+	leftOpen, rightOpen bool
+}
+
+func (ri realInterval) contains(x float64) bool {
+	okLeft := ri.left < x || (!ri.leftOpen && AreFloatEqual(ri.left, x))
+	okRight := x < ri.right || (!ri.rightOpen && AreFloatEqual(ri.right, x))
+	return okLeft && okRight
+}
+
+func areBoundsEqual(b1, b2 float64) bool {
+	if math.IsInf(b1, 0) || math.IsInf(b2, 0) {
+		return b1 == b2
+	}
+	return AreFloatEqual(b1, b2)
+}
+
+// SolutionSet is a subset of ℝ, written as a union of disjoint
+// intervals, sorted in increasing order.
+// Isolated points are represented by closed intervals [a;a].
+type SolutionSet []realInterval
+
+func (set SolutionSet) contains(x float64) bool {
+	for _, ri := range set {
+		if ri.contains(x) {
+			return true
+		}
+	}
+	return false
+}
+
+func (set SolutionSet) String() string {
+	if len(set) == 0 {
+		return "∅"
+	}
+	chunks := make([]string, len(set))
+	for i, ri := range set {
+		if ri.left == ri.right {
+			chunks[i] = fmt.Sprintf("{%g}", ri.left)
+			continue
+		}
+		leftSymbol, rightSymbol := "[", "]"
+		if ri.leftOpen {
+			leftSymbol = "]"
+		}
+		if ri.rightOpen {
+			rightSymbol = "["
+		}
+		chunks[i] = fmt.Sprintf("%s%g;%g%s", leftSymbol, ri.left, ri.right, rightSymbol)
+	}
+	return strings.Join(chunks, " ∪ ")
+}
+
+// IsEquivalent returns true if the two sets are equal.
+func (set SolutionSet) IsEquivalent(other SolutionSet) bool {
+	if len(set) != len(other) {
+		return false
+	}
+	for i, ri := range set {
+		o := other[i]
+		if !areBoundsEqual(ri.left, o.left) || !areBoundsEqual(ri.right, o.right) ||
+			ri.leftOpen != o.leftOpen || ri.rightOpen != o.rightOpen {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the union of the two sets.
+func (set SolutionSet) Union(other SolutionSet) SolutionSet {
+	return set.combine(other, true)
+}
+
+// Bounds returns the finite bounds of the intervals composing the set,
+// sorted in increasing order.
+func (set SolutionSet) Bounds() []float64 {
+	var points []float64
+	for _, ri := range set {
+		for _, b := range [2]float64{ri.left, ri.right} {
+			if !math.IsInf(b, 0) {
+				points = append(points, b)
+			}
+		}
+	}
+	return sortedPoints(points)
+}
+
+// Pieces returns the membership of each of the 2n+1 pieces defined
+// by the sorted [points] p_0 < ... < p_{n-1} :
+// the piece 2k is the open interval ]p_{k-1}; p_k[ (with p_{-1} = -inf and p_n = +inf),
+// the piece 2k+1 is the point p_k.
+// The set is fully described by its pieces if [points] includes its [Bounds].
+func (set SolutionSet) Pieces(points []float64) []bool {
+	out := make([]bool, 2*len(points)+1)
+	for piece := range out {
+		if piece%2 == 1 {
+			out[piece] = set.contains(points[piece/2])
+		} else {
+			out[piece] = set.contains(samplePiece(points, piece))
+		}
+	}
+	return out
+}
+
+// NewSolutionSetFromPieces is the inverse of [SolutionSet.Pieces] :
+// it returns the set described by the membership of each piece.
+// [pieces] must have length 2*len(points)+1.
+func NewSolutionSetFromPieces(points []float64, pieces []bool) SolutionSet {
+	return newSolutionSet(points, func(piece int) bool { return pieces[piece] })
+}
+
+// sortedPoints sorts and removes duplicates
+func sortedPoints(points []float64) []float64 {
+	sort.Float64s(points)
+	var out []float64
+	for _, p := range points {
+		if len(out) != 0 && AreFloatEqual(out[len(out)-1], p) {
+			continue
+		}
+		out = append(out, p+0) // avoid -0
+	}
+	return out
+}
+
+// newSolutionSet builds a set from the sorted [points] p_0 < ... < p_{n-1},
+// and a membership function [member] for each of the 2n+1 pieces :
+// the piece 2k is the open interval ]p_{k-1}; p_k[ (with p_{-1} = -inf and p_n = +inf),
+// the piece 2k+1 is the point p_k.
+func newSolutionSet(points []float64, member func(piece int) bool) SolutionSet {
+	n := len(points)
+	point := func(k int) float64 {
+		if k < 0 {
+			return math.Inf(-1)
+		} else if k >= n {
+			return math.Inf(1)
+		}
+		return points[k]
+	}
+
+	var (
+		out     SolutionSet
+		current *realInterval
+	)
+	for piece := 0; piece <= 2*n; piece++ {
+		k := piece / 2
+		isPoint := piece%2 == 1
+		if member(piece) {
+			if current == nil { // start a new interval
+				if isPoint {
+					current = &realInterval{left: point(k)}
+				} else {
+					current = &realInterval{left: point(k - 1), leftOpen: true}
+				}
+			}
+			// update the end
+			if isPoint {
+				current.right, current.rightOpen = point(k), false
+			} else {
+				current.right, current.rightOpen = point(k), true
+			}
+		} else if current != nil {
+			out = append(out, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		out = append(out, *current)
+	}
+	return out
+}
+
+// samplePiece returns a number inside the given (non point) piece
+func samplePiece(points []float64, piece int) float64 {
+	n, k := len(points), piece/2
+	switch {
+	case n == 0:
+		return 0
+	case k == 0:
+		return points[0] - 1
+	case k == n:
+		return points[n-1] + 1
+	default:
+		return (points[k-1] + points[k]) / 2
+	}
+}
+
+// combine returns the union (or the intersection) of the two sets
+func (set SolutionSet) combine(other SolutionSet, isUnion bool) SolutionSet {
+	var points []float64
+	for _, ri := range append(append(SolutionSet(nil), set...), other...) {
+		for _, b := range [2]float64{ri.left, ri.right} {
+			if !math.IsInf(b, 0) {
+				points = append(points, b)
+			}
+		}
+	}
+	points = sortedPoints(points)
+	return newSolutionSet(points, func(piece int) bool {
+		x := samplePiece(points, piece)
+		if piece%2 == 1 {
+			x = points[piece/2]
+		}
+		if isUnion {
+			return set.contains(x) || other.contains(x)
+		}
+		return set.contains(x) && other.contains(x)
+	})
+}
+
+func compareToZero(op operator, v float64) bool {
+	switch op {
+	case equals:
+		return v == 0
+	case notEquals:
+		return v != 0
+	case greater:
+		return v >= 0
+	case strictlyGreater:
+		return v > 0
+	case lesser:
+		return v <= 0
+	case strictlyLesser:
+		return v < 0
+	default:
+		panic(exhaustiveOperatorSwitch)
+	}
+}
+
+// collectVariables adds the variables used in [expr]
+func (expr *Expr) collectVariables(out map[Variable]bool) {
+	if expr == nil {
+		return
+	}
+	expr.left.collectVariables(out)
+	expr.right.collectVariables(out)
+	switch atom := expr.atom.(type) {
+	case Variable:
+		out[atom] = true
+	case specialFunction:
+		for _, arg := range atom.args {
+			arg.collectVariables(out)
+		}
+	}
+}
+
+// unknown returns the only variable of the relations, or
+// an error if there are more
+func unknown(relations ...*Expr) (Variable, error) {
+	vars := map[Variable]bool{}
+	for _, rel := range relations {
+		rel.collectVariables(vars)
+	}
+	if len(vars) > 1 {
+		return Variable{}, errors.New("Une seule inconnue est attendue.")
+	}
+	for v := range vars {
+		return v, nil
+	}
+	return Variable{}, nil
+}
+
+// solveRelation returns the solution set of the (in)equation [rel],
+// whose unknown is [x].
+// Chained inequalities, such as 3 < x <= 5, are supported.
+func (rel *Expr) solveRelation(x Variable) (SolutionSet, error) {
+	op := rel.atom.(operator)
+	if rel.left.isRelation() { // a < b < c
+		first, err := rel.left.solveRelation(x)
+		if err != nil {
+			return nil, err
+		}
+		second := &Expr{atom: op, left: rel.left.right, right: rel.right}
+		if second.left.isRelation() {
+			return nil, fmt.Errorf("La relation %s n'est pas supportée.", rel)
+		}
+		secondSet, err := second.solveRelation(x)
+		if err != nil {
+			return nil, err
+		}
+		return first.combine(secondSet, false), nil
+	}
+
+	// move everything on the left
+	diff := &Expr{atom: minus, left: rel.left, right: rel.right}
+	f, ok := diff.toRationalFunction(x)
+	if !ok {
+		return nil, fmt.Errorf("L'expression %s n'est pas une fraction rationnelle.", diff)
+	}
+
+	numRoots, err := f.num.roots()
+	if err != nil {
+		return nil, err
+	}
+	denRoots, err := f.den.roots()
+	if err != nil {
+		return nil, err
+	}
+	isRoot := func(roots []float64, v float64) bool {
+		for _, r := range roots {
+			if AreFloatEqual(r, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	points := sortedPoints(append(append([]float64(nil), numRoots...), denRoots...))
+	return newSolutionSet(points, func(piece int) bool {
+		if piece%2 == 1 { // critical point
+			p := points[piece/2]
+			if isRoot(denRoots, p) { // forbidden value
+				return false
+			}
+			return compareToZero(op, 0)
+		}
+		t := samplePiece(points, piece)
+		return compareToZero(op, f.num.eval(t)/f.den.eval(t))
+	}), nil
+}
+
+// SolutionSetOf returns the set of real numbers described by [c] :
+// equations and inequations (of degree at most 2, or quotients of such polynomials)
+// are solved, and intervals and sets of numbers are converted.
+func SolutionSetOf(c Compound) (SolutionSet, error) {
+	set, _, err := solutionSetOf(c)
+	return set, err
+}
+
+// ExactBounds returns the expressions explicitly used as bounds in [c] :
+// the bounds of intervals, the elements of sets, and the constant side
+// of relations such as x > a.
+// Bounds only found by solving (such as 3/2 for 2x > 3) are not returned.
+func ExactBounds(c Compound) (out []*Expr) {
+	switch c := c.(type) {
+	case *Expr:
+		return ExactBounds(Relations{{c}})
+	case Relations:
+		for _, rel := range c.Expressions() {
+			if !rel.isRelation() {
+				continue
+			}
+			if _, isVar := rel.left.atom.(Variable); isVar && rel.left.left == nil && rel.left.right == nil {
+				out = append(out, rel.right)
+			} else if _, isVar := rel.right.atom.(Variable); isVar && rel.right.left == nil && rel.right.right == nil {
+				out = append(out, rel.left)
+			}
+		}
+	case Interval:
+		out = append(out, c.Left, c.Right)
+	case Set:
+		out = append(out, c...)
+	}
+	return out
+}
+
+func solutionSetOf(c Compound) (SolutionSet, Variable, error) {
+	switch c := c.(type) {
+	case *Expr:
+		if !c.isRelation() {
+			return nil, Variable{}, fmt.Errorf("L'expression %s n'est pas une équation ou une inéquation.", c)
+		}
+		return solutionSetOf(Relations{{c}})
+	case Relations:
+		x, err := unknown(c.Expressions()...)
+		if err != nil {
+			return nil, Variable{}, err
+		}
+		var out SolutionSet
+		for _, conj := range c {
+			current := SolutionSet{{left: math.Inf(-1), right: math.Inf(1), leftOpen: true, rightOpen: true}}
+			for _, rel := range conj {
+				set, err := rel.solveRelation(x)
+				if err != nil {
+					return nil, Variable{}, err
+				}
+				current = current.combine(set, false)
+			}
+			out = out.combine(current, true)
+		}
+		return out, x, nil
+	case Interval:
+		left, right, err := startEnd(c.Left, c.Right, nil)
+		if err != nil {
+			return nil, Variable{}, err
+		}
+		if left > right {
+			return nil, Variable{}, errors.New("Les bornes de l'intervalle sont dans le mauvais ordre.")
+		}
+		ri := realInterval{
+			left: left, right: right,
+			leftOpen: c.LeftOpen || math.IsInf(left, 0), rightOpen: c.RightOpen || math.IsInf(right, 0),
+		}
+		if left == right && (ri.leftOpen || ri.rightOpen) {
+			return nil, Variable{}, nil
+		}
+		return SolutionSet{ri}, Variable{}, nil
+	case Set:
+		points := make([]float64, len(c))
+		for i, e := range c {
+			var err error
+			points[i], err = e.evalFloat(nil)
+			if err != nil {
+				return nil, Variable{}, err
+			}
+		}
+		points = sortedPoints(points)
+		return newSolutionSet(points, func(piece int) bool { return piece%2 == 1 }), Variable{}, nil
+	default:
+		return nil, Variable{}, errors.New("Cet objet ne définit pas un ensemble de réels.")
+	}
+}
+
+// AreSolutionSetsEquivalent returns true if [e1] and [e2] describe
+// the same subset of ℝ (see [SolutionSetOf]).
+// Relations using different unknowns are never equivalent.
+func AreSolutionSetsEquivalent(e1, e2 Compound) bool {
+	set1, x1, err1 := solutionSetOf(e1)
+	set2, x2, err2 := solutionSetOf(e2)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	if x1 != (Variable{}) && x2 != (Variable{}) && x1 != x2 {
+		return false
+	}
+	return set1.IsEquivalent(set2)
+}
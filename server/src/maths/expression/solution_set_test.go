@@ -0,0 +1,165 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func mustParseCompound(t *testing.T, s string) Compound {
+	t.Helper()
+	c, err := ParseEquationCompound(s)
+	tu.AssertNoErr(t, err)
+	return c
+}
+
+func TestSingleEqualSign(t *testing.T) {
+	e := mustParseCompound(t, "2x + 4 = 0")
+	tu.Assert(t, e.String() == MustParse("2x + 4 == 0").String())
+	tu.Assert(t, mustParseCompound(t, "x <= 2").(*Expr).atom == lesser)
+	tu.Assert(t, mustParseCompound(t, "x = 2 ou x = 3").String() == mustParseCompound(t, "x == 2 ou x == 3").String())
+	_, err := ParseLaTeXEquationCompound(`x^{2} = 4`)
+	tu.AssertNoErr(t, err)
+
+	// only accepted where equations are expected
+	_, err = Parse("2x + 4 = 0")
+	tu.Assert(t, err != nil)
+	_, err = ParseCompound("x = 2 ou x = 3")
+	tu.Assert(t, err != nil)
+	_, err = ParseLaTeXCompound(`x^{2} = 4`)
+	tu.Assert(t, err != nil)
+}
+
+func TestParseRelations(t *testing.T) {
+	c := mustParseCompound(t, "x > 3 et x <= 5")
+	rs, ok := c.(Relations)
+	tu.Assert(t, ok && len(rs) == 1 && len(rs[0]) == 2)
+
+	c = mustParseCompound(t, "x = -2 OU x = 2 and x > 0")
+	rs, ok = c.(Relations)
+	tu.Assert(t, ok && len(rs) == 2 && len(rs[0]) == 1 && len(rs[1]) == 2)
+	tu.Assert(t, rs.String() == "x == -2 ou x == 2 et x > 0")
+	tu.Assert(t, rs.AsLaTeX() == `x = -2 \text{ ou } x = 2 \text{ et } x > 0`)
+
+	rs.Substitute(Vars{NewVar('x'): NewNb(1)})
+	tu.Assert(t, rs.String() == "1 == -2 ou 1 == 2 et 1 > 0")
+
+	// not relations : regular parsing is used
+	_, err := ParseCompound("x et y")
+	tu.AssertNoErr(t, err)
+
+	_, err = ParseCompound("x > 2 et 3 +")
+	tu.Assert(t, err != nil)
+	_, err = ParseCompound("x > 2 ou 3")
+	tu.Assert(t, err != nil)
+}
+
+func TestSolutionSet(t *testing.T) {
+	for _, test := range []struct {
+		expr     string
+		expected string
+	}{
+		{"2x + 4 = 0", "{-2}"},
+		{"x = -2", "{-2}"},
+		{"x^2 = 4", "{-2} ∪ {2}"},
+		{"x^2 + 1 = 0", "∅"},
+		{"(x-1)^2 = 0", "{1}"},
+		{"x^2 != 4", "]-Inf;-2[ ∪ ]-2;2[ ∪ ]2;+Inf["},
+		{"x > 3", "]3;+Inf["},
+		{"2x - 6 <= 0", "]-Inf;3]"},
+		{"x^2 >= 4", "]-Inf;-2] ∪ [2;+Inf["},
+		{"x^2 + 1 > 0", "]-Inf;+Inf["},
+		{"3 < x <= 5", "]3;5]"},
+		{"x > 3 et x <= 5", "]3;5]"},
+		{"x < -1 ou x > 1", "]-Inf;-1[ ∪ ]1;+Inf["},
+		{"x = -2 ou x = 2", "{-2} ∪ {2}"},
+		{"1/x > 0", "]0;+Inf["},
+		{"(x+1)/(x-2) <= 0", "[-1;2["},
+		{"x/x = 1", "]-Inf;0[ ∪ ]0;+Inf["},
+		{"2 = 2", "]-Inf;+Inf["},
+		{"]-inf ; 3]", "]-Inf;3]"},
+		{"[2;2]", "{2}"},
+		{"{2 ; -1 ; 2}", "{-1} ∪ {2}"},
+	} {
+		set, err := SolutionSetOf(mustParseCompound(t, test.expr))
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, set.String() == test.expected)
+	}
+
+	for _, expr := range []string{
+		"x + 1",
+		"x^3 = 8",
+		"sqrt(x) = 2",
+		"x + y = 2",
+		"x > 0 et y > 0",
+		"(1;2)",
+	} {
+		_, err := SolutionSetOf(mustParseCompound(t, expr))
+		tu.Assert(t, err != nil)
+	}
+}
+
+func TestAreSolutionSetsEquivalent(t *testing.T) {
+	for _, test := range []struct {
+		e1, e2 string
+		want   bool
+	}{
+		{"2x + 4 = 0", "x = -2", true},
+		{"2x + 4 = 0", "-x = 2", true},
+		{"2x + 4 = 0", "x = 2", false},
+		{"x^2 = 4", "x = -2 ou x = 2", true},
+		{"x^2 = 4", "{-2 ; 2}", true},
+		{"x^2 = 4", "x = 2", false},
+		{"x > 3 et x <= 5", "]3 ; 5]", true},
+		{"x > 3 et x <= 5", "3 < x <= 5", true},
+		{"x > 3 et x <= 5", "-2x >= -10 and x - 3 > 0", true},
+		{"x > 3 et x <= 5", "[3 ; 5]", false},
+		{"x^2 - 1 > 0", "x < -1 ou x > 1", true},
+		{"x^2 - 1 > 0", "x > 1", false},
+		{"x/(x-1) >= 2", "1 < x <= 2", true},
+		{"x > 2", "y > 2", false},
+		{"x > 2", "x + 1", false},
+	} {
+		got := AreSolutionSetsEquivalent(mustParseCompound(t, test.e1), mustParseCompound(t, test.e2))
+		tu.Assert(t, got == test.want)
+	}
+}
+
+func TestSolutionSetPieces(t *testing.T) {
+	set1, err := SolutionSetOf(mustParseCompound(t, "]-inf ; 3]"))
+	tu.AssertNoErr(t, err)
+	set2, err := SolutionSetOf(mustParseCompound(t, "{5}"))
+	tu.AssertNoErr(t, err)
+	set := set1.Union(set2)
+	tu.Assert(t, set.String() == "]-Inf;3] ∪ {5}")
+	tu.Assert(t, reflect.DeepEqual(set.Bounds(), []float64{3, 5}))
+
+	points := []float64{0, 3, 5}
+	pieces := set.Pieces(points)
+	tu.Assert(t, reflect.DeepEqual(pieces, []bool{true, true, true, true, false, true, false}))
+	tu.Assert(t, NewSolutionSetFromPieces(points, pieces).IsEquivalent(set))
+}
+
+func TestExactBounds(t *testing.T) {
+	for _, test := range []struct {
+		compound string
+		want     []string
+	}{
+		{"[1/3 ; sqrt(2)[", []string{"1/3", "sqrt(2)"}},
+		{"{2 ; 1/2}", []string{"2", "1/2"}},
+		{"x > 7/2", []string{"7/2"}},
+		{"-1 <= x et x < 2", []string{"-1", "2"}},
+		{"2x > 3", nil},
+	} {
+		var got []string
+		for _, bound := range ExactBounds(mustParseCompound(t, test.compound)) {
+			got = append(got, bound.String())
+		}
+		var want []string
+		for _, s := range test.want {
+			want = append(want, MustParse(s).String())
+		}
+		tu.Assert(t, reflect.DeepEqual(got, want))
+	}
+}
@@ -10,6 +10,11 @@ import (
 type token struct {
 	data tokenData // a nil data field means EOF
 	pos  int
+
+	// call is true for the implicit multiplication between a variable
+	// and an opening parenthesis, and for the parenthesis itself,
+	// since it may actually be a call to a user defined function
+	call bool
 }
 
 type tokenData interface {
@@ -45,6 +50,35 @@ const (
 	matCoeff
 	matSet   // update a matrix
 	binomial // coefficient binomial (n, k)
+	listFn   // list (data series) literal
+	randList // random list of integers
+	meanFn
+	medianFn
+	q1Fn // first quartile
+	q3Fn // third quartile
+	varFn
+	stdevFn
+	sortFn
+	countFn // count the elements of a list, or the occurences of a value
+	binomPdf
+	binomCdf
+	geomPdf
+	geomCdf
+	unifPdf
+	unifCdf
+	normalCdf
+	invNorm
+	expectationFn // expectation of a random variable given by its law
+	varianceFn    // variance of a random variable given by its law
+	randBinom     // random draw of a binomial variable
+	simBinom      // list of random draws of a binomial variable
+	reFn          // real part of a complex number
+	imFn          // imaginary part of a complex number
+	conjFn        // conjugate of a complex number
+	argFn         // principal argument of a complex number
+	algFormFn     // algebraic form of a complex number
+	expFormFn     // exponential form of a complex number
+	randComplex   // random complex number with integer parts
 
 	invalidSpecialFunction
 )
@@ -81,6 +115,64 @@ func (sf specialFunctionKind) String() string {
 		return "set"
 	case binomial:
 		return "binom"
+	case listFn:
+		return "list"
+	case randList:
+		return "randList"
+	case meanFn:
+		return "mean"
+	case medianFn:
+		return "median"
+	case q1Fn:
+		return "q1"
+	case q3Fn:
+		return "q3"
+	case varFn:
+		return "var"
+	case stdevFn:
+		return "stdev"
+	case sortFn:
+		return "sort"
+	case countFn:
+		return "count"
+	case binomPdf:
+		return "binomPdf"
+	case binomCdf:
+		return "binomCdf"
+	case geomPdf:
+		return "geomPdf"
+	case geomCdf:
+		return "geomCdf"
+	case unifPdf:
+		return "unifPdf"
+	case unifCdf:
+		return "unifCdf"
+	case normalCdf:
+		return "normalCdf"
+	case invNorm:
+		return "invNorm"
+	case expectationFn:
+		return "expectation"
+	case varianceFn:
+		return "variance"
+	case randBinom:
+		return "randBinom"
+	case simBinom:
+		return "simBinom"
+	case reFn:
+		return "re"
+	case imFn:
+		return "im"
+	case conjFn:
+		return "conj"
+	case argFn:
+		return "arg"
+	case algFormFn:
+		return "algForm"
+	case expFormFn:
+		return "expForm"
+	case randComplex:
+		return "randComplex"
 	default:
 		panic(exhaustiveSpecialFunctionSwitch)
 	}
@@ -137,6 +229,10 @@ type tokenizer struct {
 
 	src []rune
 	pos int
+
+	// acceptSingleEqual is true if a single = is read as [equals],
+	// which is only enabled where equations are expected
+	acceptSingleEqual bool
 }
 
 func newTokenizer(text []byte) *tokenizer { return &tokenizer{src: bytes.Runes(text)} }
@@ -189,6 +285,10 @@ func (tk *tokenizer) readToken() (current, next token) {
 	} else if isImplicitMult(tk.lastToken, nextToken) { // insert a mult
 		current = token{data: mult, pos: nextToken.pos}
 		next = nextToken
+		if _, isVar := tk.lastToken.data.(Variable); isVar && nextToken.data == openPar { // f(...)
+			current.call = true
+			next.call = true
+		}
 	} else {
 		current = nextToken
 		next = token{}
@@ -221,6 +321,12 @@ func isOperator(src []rune) (op operator, n int) {
 		switch s {
 		case "==":
 			return equals, 2
+		case "!=":
+			// n!==m is read as a factorial followed by ==
+			if len(src) >= 3 && src[2] == '=' {
+				break
+			}
+			return notEquals, 2
 		case ">=":
 			return greater, 2
 		case "<=":
@@ -248,6 +354,8 @@ func isOperator(src []rune) (op operator, n int) {
 		return pow, 1
 	case '!':
 		return factorial, 1
+	case '\u2260':
+		return notEquals, 1
 	case '\u222A':
 		return union, 1
 	case '\u2229':
@@ -347,6 +455,9 @@ func (tk *tokenizer) readRawToken() (out token) {
 	case isOpRunes != 0:
 		out.data = op
 		tk.pos += isOpRunes
+	case c == '=' && tk.acceptSingleEqual: // equations are usually written with a single =
+		out.data = equals
+		tk.pos++
 
 	case c == '"': // custom symbol
 		out.data = tk.readCustomSymbol()
@@ -470,16 +581,104 @@ func (tk *tokenizer) tryReadSpecialFunction() (specialFunctionKind, bool) {
 		fn = matSet
 	case "binom":
 		fn = binomial
+	case "list":
+		fn = listFn
+	case "randlist":
+		fn = randList
+	case "mean":
+		fn = meanFn
+	case "median":
+		fn = medianFn
+	case "var":
+		fn = varFn
+	case "stdev":
+		fn = stdevFn
+	case "sort":
+		fn = sortFn
+	case "count":
+		fn = countFn
+	case "binompdf":
+		fn = binomPdf
+	case "binomcdf":
+		fn = binomCdf
+	case "geompdf":
+		fn = geomPdf
+	case "geomcdf":
+		fn = geomCdf
+	case "unifpdf":
+		fn = unifPdf
+	case "unifcdf":
+		fn = unifCdf
+	case "normalcdf":
+		fn = normalCdf
+	case "invnorm":
+		fn = invNorm
+	case "expectation":
+		fn = expectationFn
+	case "variance":
+		fn = varianceFn
+	case "randbinom":
+		fn = randBinom
+	case "simbinom":
+		fn = simBinom
+	case "re":
+		fn = reFn
+	case "im":
+		fn = imFn
+	case "conj":
+		fn = conjFn
+	case "arg":
+		fn = argFn
+	case "algform":
+		fn = algFormFn
+	case "expform":
+		fn = expFormFn
+	case "randcomplex":
+		fn = randComplex
+	case "q":
+		// quartiles are only recognized as function calls : q1( and q3(
+		if fn, ok := tk.tryReadQuartile(); ok {
+			return fn, true
+		}
+		return 0, false
 	default:
 		_ = exhaustiveSpecialFunctionSwitch
 		return 0, false
 	}
 
+	// complex functions have short names, and are only recognized
+	// as function calls, so that re is still the product r*e
+	switch fn {
+	case reFn, imFn, conjFn, argFn:
+		if end := tk.pos + len(letters); end >= len(tk.src) || tk.src[end] != '(' {
+			return 0, false
+		}
+	}
+
 	// found a function, advance the position
 	tk.pos += len(letters)
 	return fn, true
 }
 
+// tryReadQuartile handles the special case of q1( and q3(,
+// which are not only made of letters
+func (tk *tokenizer) tryReadQuartile() (specialFunctionKind, bool) {
+	if tk.pos+2 >= len(tk.src) || tk.src[tk.pos+2] != '(' {
+		return 0, false
+	}
+	var fn specialFunctionKind
+	switch tk.src[tk.pos+1] {
+	case '1':
+		fn = q1Fn
+	case '3':
+		fn = q3Fn
+	default:
+		return 0, false
+	}
+	tk.pos += 2
+	return fn, true
+}
+
 // return the next letters; without advancing
 func (tk *tokenizer) peekLetters() []rune {
 	L := len(tk.src)
@@ -88,6 +88,12 @@ func compareNodes(n1, n2 *Expr) int {
 			} else {
 				return compareNodes(n1.right, n2.right)
 			}
+		case userFunction:
+			a2 := a2.(userFunction)
+			if c := strings.Compare(a1.arg.String(), a2.arg.String()); c != 0 {
+				return c
+			}
+			return compareNodes(n1.right, n2.right)
 		case specialFunction:
 			a2 := a2.(specialFunction)
 			if a1.kind < a2.kind {
@@ -455,7 +461,7 @@ func (expr *Expr) simplify0And1() {
 		if left.atom == Number(1) || left.atom == Number(0) { // 0! = 1, 1! = 1
 			*expr = Expr{atom: Number(1)}
 		}
-	case mod, rem, equals, lesser, strictlyLesser, greater, strictlyGreater, union, intersection, complement:
+	case mod, rem, equals, notEquals, lesser, strictlyLesser, greater, strictlyGreater, union, intersection, complement:
 		// nothing to do
 	default:
 		panic(exhaustiveOperatorSwitch)
@@ -743,29 +749,41 @@ func AreExpressionsEquivalent(e1, e2 *Expr, level ComparisonLevel) bool {
 // partial evaluation a.k.a substitution
 
 // Substitute replaces variables contained in `vars`, updating `expr` in place.
+// The calls to the user defined functions contained in `vars` are expanded.
 func (expr *Expr) Substitute(vars Vars) {
+	if functions := vars.functions(); len(functions) != 0 {
+		// the bodies are already expanded, so that no cycle may happen
+		_ = expr.expandCalls(functions, nil)
+	}
+	expr.substitute(vars)
+}
+
+func (expr *Expr) substitute(vars Vars) {
 	if expr == nil {
 		return
 	}
 
-	expr.left.Substitute(vars)
-	expr.right.Substitute(vars)
+	expr.left.substitute(vars)
+	expr.right.substitute(vars)
 
 	_ = exhaustiveAtomSwitch
 	switch atom := expr.atom.(type) {
 	case Variable:
 		value, has := vars[atom]
-		if has {
+		if !has {
+			return
+		}
+		if _, isFunction := value.atom.(userFunction); !isFunction {
 			*expr = *value.Copy()
 		}
 	case specialFunction:
 		for _, e := range atom.args {
-			e.Substitute(vars)
+			e.substitute(vars)
 		}
 	case matrix:
 		for i := range atom {
 			for j := range atom[i] {
-				atom[i][j].Substitute(vars)
+				atom[i][j].substitute(vars)
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package questions
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	ex "github.com/benoitkugler/maths-online/server/src/maths/expression"
@@ -23,6 +24,7 @@ var (
 	_ Block = TableBlock{}
 	_ Block = TreeBlock{}
 	_ Block = ImageBlock{}
+	_ Block = NumberLineBlock{}
 )
 
 // Block form the actual content of a question
@@ -41,7 +43,7 @@ type Block interface {
 }
 
 // ParameterEntry is either a single variable definition,
-// a special function or a (possibly multiline) comment.
+// a special function, a user defined function, a constraint or a (possibly multiline) comment.
 type ParameterEntry interface {
 	// Return a user friendly description
 	String() string
@@ -55,6 +57,10 @@ func (rp Rp) String() string {
 }
 func (it In) String() string { return string(it) }
 func (cm Co) String() string { return string(cm) }
+func (ct Ct) String() string { return string(ct) }
+func (fn Fn) String() string {
+	return fmt.Sprintf("%s(%s) = %s", fn.Function, fn.Variable, fn.Expression)
+}
 
 func (rp Rp) mergeTo(vars *ex.RandomParameters) error {
 	return vars.ParseVariable(rp.Variable, rp.Expression)
@@ -64,6 +70,14 @@ func (it In) mergeTo(vars *ex.RandomParameters) error {
 	return vars.ParseIntrinsic(string(it))
 }
 
+func (ct Ct) mergeTo(vars *ex.RandomParameters) error {
+	return vars.ParseConstraint(string(ct))
+}
+
+func (fn Fn) mergeTo(vars *ex.RandomParameters) error {
+	return vars.ParseFunction(fn.Function, fn.Variable, fn.Expression)
+}
+
 // Comment are ignored
 func (Co) mergeTo(vars *ex.RandomParameters) error { return nil }
 
@@ -100,6 +114,19 @@ type In string
 
 type Co string
 
+// Ct is a boolean expression (like a != b or b^2 - 4*a*c > 0)
+// which must be satisfied by the random parameters.
+// Parameters are drawn again until all the constraints are satisfied.
+type Ct string
+
+// Fn is a user defined function, such as f(x) = ax^2 + b,
+// which may then be used as f(2) or f(a+1) in the parameters and the question content.
+type Fn struct {
+	Function   ex.Variable `json:"function"`   // the name of the function, like f
+	Variable   ex.Variable `json:"variable"`   // the argument, like x
+	Expression string      `json:"expression"` // the body, like ax^2 + b
+}
+
 // QuestionPage is the fundamental object to build exercices.
 // It is mainly consituted of a list of content blocks, which
 // describes the question (description, question, field answer),
@@ -108,6 +135,7 @@ type QuestionPage struct {
 	Enonce     Enonce     `json:"enonce" gomacro-opaque:"dart"`
 	Parameters Parameters `json:"parameters" gomacro-opaque:"dart"` // random parameters shared by the all the blocks
 	Correction Enonce     `json:"correction" gomacro-opaque:"dart"`
+	Hints      Hints      `json:"hints" gomacro-opaque:"dart"`
 }
 
 // Instantiate returns a deep copy of `qu`, where all random parameters
@@ -142,6 +170,23 @@ func (qu QuestionPage) InstantiateWith(params ex.Vars) (QuestionInstance, error)
 	return QuestionInstance{enonce, correction}, err
 }
 
+// InstantiateHint instantiates the hint at [index] with the given
+// parameters, which should be the ones used for the [Enonce].
+func (qu QuestionPage) InstantiateHint(params ex.Vars, index int) (client.Enonce, error) {
+	if index < 0 || index >= len(qu.Hints) {
+		return nil, fmt.Errorf("invalid hint index %d (for %d hints)", index, len(qu.Hints))
+	}
+	hint, err := qu.Hints[index].InstantiateWith(params)
+	if err != nil {
+		return nil, err
+	}
+	out := make(client.Enonce, len(hint))
+	for i, block := range hint {
+		out[i] = block.toClient()
+	}
+	return out, nil
+}
+
 // InstantiateErr is a shortcut to :
 //   - instantiate [Parameters]
 //   - instantiate [Enonce] with these parameters
@@ -286,7 +331,8 @@ type TextBlock struct {
 	Smaller bool
 }
 
-// return TextBlock, FormulaBlock or NumberFieldBlock
+// return TextBlock, FormulaBlock, NumberFieldBlock or one of the fields
+// defined by blanks
 func (t TextBlock) expandFormulas() []Block {
 	blocks := t.Parts.parseFormula()
 	out := make([]Block, len(blocks))
@@ -298,6 +344,12 @@ func (t TextBlock) expandFormulas() []Block {
 			out[i] = FormulaBlock{Parts: Interpolated(b.s)}
 		case iNumberField:
 			out[i] = NumberFieldBlock{Expression: b.s}
+		case iTextField:
+			out[i] = TextFieldBlock{Answers: splitBlankOptions(b.s), IgnoreCase: true}
+		case iDropDownField:
+			out[i] = blankDropDown(b.s)
+		case iExpressionField:
+			out[i] = ExpressionFieldBlock{Expression: b.s, ComparisonLevel: SimpleSubstitutions}
 		}
 	}
 	return out
@@ -1233,3 +1285,111 @@ func (img ImageBlock) setupValidator(*ex.RandomParameters) (validator, error) {
 	}
 	return noOpValidator{}, nil
 }
+
+// NumberLineBlock displays a subset of ℝ (union of intervals
+// and isolated points) on a graduated line.
+type NumberLineBlock struct {
+	// Set is the subset to display, written as a union (separated by ∪)
+	// of intervals, sets of numbers and inequations,
+	// such as ]-inf;a] ∪ {3} ∪ x > 5
+	Set string
+	// Ticks are optional additional values (expressions) displayed on the line.
+	// The finite bounds of [Set] are always displayed.
+	Ticks []string
+}
+
+// maxNumberLineTicks limits the number of values
+// displayed on a number line
+const maxNumberLineTicks = 10
+
+// parseNumberLineSet splits the union and parses each part
+func parseNumberLineSet(set string) ([]ex.Compound, error) {
+	var out []ex.Compound
+	for _, part := range strings.Split(set, "∪") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, errors.New("L'ensemble à représenter comporte une partie vide.")
+		}
+		c, err := ex.ParseCompound(part)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (nl NumberLineBlock) instantiateNL(params ex.Vars) (NumberLineInstance, error) {
+	parts, err := parseNumberLineSet(nl.Set)
+	if err != nil {
+		return NumberLineInstance{}, err
+	}
+	var (
+		set    ex.SolutionSet
+		bounds []evaluatedExpression // exact expressions of the bounds, when available
+	)
+	for _, part := range parts {
+		part.Substitute(params)
+		partSet, err := ex.SolutionSetOf(part)
+		if err != nil {
+			return NumberLineInstance{}, err
+		}
+		set = set.Union(partSet)
+		for _, bound := range ex.ExactBounds(part) {
+			if v, err := bound.Evaluate(nil); err == nil {
+				bounds = append(bounds, evaluatedExpression{Expr: bound, Value: v})
+			}
+		}
+	}
+
+	ticks := make([]evaluatedExpression, 0, len(nl.Ticks))
+	addTick := func(tick evaluatedExpression) {
+		for _, other := range ticks {
+			if ex.AreFloatEqual(other.Value, tick.Value) {
+				return
+			}
+		}
+		ticks = append(ticks, tick)
+	}
+	for _, s := range nl.Ticks {
+		tick, err := newEvaluatedExpression(s, params)
+		if err != nil {
+			return NumberLineInstance{}, err
+		}
+		addTick(tick)
+	}
+	for _, bound := range set.Bounds() {
+		tick := evaluatedExpression{Expr: ex.NewNb(bound), Value: bound}
+		for _, exact := range bounds {
+			if ex.AreFloatEqual(exact.Value, bound) {
+				tick = exact
+				break
+			}
+		}
+		addTick(tick)
+	}
+	sort.SliceStable(ticks, func(i, j int) bool { return ticks[i].Value < ticks[j].Value })
+
+	if len(ticks) > maxNumberLineTicks {
+		return NumberLineInstance{}, fmt.Errorf("La droite graduée comporte trop de valeurs (%d, maximum %d).", len(ticks), maxNumberLineTicks)
+	}
+
+	return NumberLineInstance{Ticks: ticks, Set: set}, nil
+}
+
+func (nl NumberLineBlock) instantiate(params ex.Vars, _ int) (instance, error) {
+	return nl.instantiateNL(params)
+}
+
+func (nl NumberLineBlock) setupValidator(*ex.RandomParameters) (validator, error) {
+	// check the syntax
+	if _, err := parseNumberLineSet(nl.Set); err != nil {
+		return nil, err
+	}
+	for _, tick := range nl.Ticks {
+		if _, err := ex.Parse(tick); err != nil {
+			return nil, err
+		}
+	}
+	return numberLineValidator{data: nl}, nil
+}
@@ -2,8 +2,11 @@ package questions
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
+	"regexp"
 	"sort"
+	"strings"
 
 	ex "github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
@@ -24,6 +27,10 @@ var (
 	_ Block = VectorFieldBlock{}
 	_ Block = ProofFieldBlock{}
 	_ Block = SetFieldBlock{}
+	_ Block = TextFieldBlock{}
+	_ Block = MatchingFieldBlock{}
+	_ Block = CategoriesFieldBlock{}
+	_ Block = NumberLineFieldBlock{}
 )
 
 type NumberFieldBlock struct {
@@ -51,10 +58,35 @@ type ExpressionFieldBlock struct {
 	Label            Interpolated // optional
 	ComparisonLevel  ComparisonLevel
 	ShowFractionHelp bool // if true an hint for fraction is displayed when applicable
+	// Hints are displayed when the student answer matches
+	// a wrong answer anticipated by the teacher (optional)
+	Hints []WrongAnswerHint
+}
+
+// WrongAnswerHint is a custom hint, displayed when
+// the student answer is equivalent to [Answer]
+type WrongAnswerHint struct {
+	Answer string // a valid expression, which may use the random parameters
+	Hint   string // plain text
+}
+
+// expectsEquations returns true if the expressions compared are equations,
+// which may then be written with a single =
+func (cl ComparisonLevel) expectsEquations() bool {
+	return cl == AsLinearEquation || cl == AsSolutionSet
+}
+
+// parseCompound parses [expr], accepting a single = when
+// equations are expected
+func (cl ComparisonLevel) parseCompound(expr string) (ex.Compound, error) {
+	if cl.expectsEquations() {
+		return ex.ParseEquationCompound(expr)
+	}
+	return ex.ParseCompound(expr)
 }
 
 func (f ExpressionFieldBlock) SyntaxHint(params Parameters) (TextBlock, error) {
-	answer, err := ex.ParseCompound(f.Expression)
+	answer, err := f.ComparisonLevel.parseCompound(f.Expression)
 	if err != nil {
 		return TextBlock{}, err
 	}
@@ -87,7 +119,7 @@ func (f ExpressionFieldBlock) SyntaxHint(params Parameters) (TextBlock, error) {
 }
 
 func (f ExpressionFieldBlock) instantiate(params ex.Vars, ID int) (instance, error) {
-	answer, err := ex.ParseCompound(f.Expression)
+	answer, err := f.ComparisonLevel.parseCompound(f.Expression)
 	if err != nil {
 		return nil, err
 	}
@@ -105,17 +137,28 @@ func (f ExpressionFieldBlock) instantiate(params ex.Vars, ID int) (instance, err
 		return nil, err
 	}
 
+	hints := make([]ExpressionHint, len(f.Hints))
+	for i, hint := range f.Hints {
+		wrongAnswer, err := f.ComparisonLevel.parseCompound(hint.Answer)
+		if err != nil {
+			return nil, err
+		}
+		wrongAnswer.Substitute(params)
+		hints[i] = ExpressionHint{Answer: wrongAnswer, Hint: hint.Hint}
+	}
+
 	return ExpressionFieldInstance{
 		LabelLaTeX:       label,
 		Answer:           answer,
 		ComparisonLevel:  f.ComparisonLevel,
 		ShowFractionHelp: showFractionHelp,
+		Hints:            hints,
 		ID:               ID,
 	}, nil
 }
 
 func (f ExpressionFieldBlock) setupValidator(*ex.RandomParameters) (validator, error) {
-	expr, err := ex.ParseCompound(f.Expression)
+	expr, err := f.ComparisonLevel.parseCompound(f.Expression)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +167,15 @@ func (f ExpressionFieldBlock) setupValidator(*ex.RandomParameters) (validator, e
 		return nil, err
 	}
 
+	for _, hint := range f.Hints {
+		if _, err := f.ComparisonLevel.parseCompound(hint.Answer); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(hint.Hint) == "" {
+			return nil, errors.New("Le texte d'un indice ne peut pas être vide.")
+		}
+	}
+
 	asExpr, isExpr := expr.(*ex.Expr)
 
 	if f.ShowFractionHelp && !isExpr {
@@ -136,6 +188,13 @@ func (f ExpressionFieldBlock) setupValidator(*ex.RandomParameters) (validator, e
 			return nil, errors.New("Une expression simple est attendue pour une équation cartésienne.")
 		}
 		return linearEquationValidator{expr: asExpr}, nil
+	case AsSolutionSet:
+		return solutionSetValidator{expression: f.Expression}, nil
+	case AsComplexNumber:
+		if !isExpr {
+			return nil, errors.New("Une expression simple est attendue pour un nombre complexe.")
+		}
+		return noOpValidator{}, nil
 	default:
 		return noOpValidator{}, nil
 	}
@@ -336,6 +395,46 @@ type GFAffineLine struct {
 	B     string // valid expression.Expression
 }
 
+// GFCircle asks for a circle, given by its center
+// and one of its points.
+type GFCircle struct {
+	Center CoordExpression
+	// Radius is a valid expression.Expression, which must be the length
+	// of a vector with integer coordinates (such as 5 or sqrt(2))
+	Radius string
+}
+
+// GFSegment asks for the segment between two points,
+// whose labels are displayed.
+type GFSegment struct {
+	From, To           CoordExpression
+	LabelFrom, LabelTo string // optional
+}
+
+// GFLine asks for the line passing through [Through],
+// and parallel (or perpendicular) to the line (AB).
+type GFLine struct {
+	Label         string
+	Through       CoordExpression
+	A, B          CoordExpression
+	Perpendicular bool // if false, the line is parallel to (AB)
+}
+
+// GFPointOnCurve asks for a point on the curve
+// of the given function.
+type GFPointOnCurve struct {
+	Function string      // valid expression.Expression
+	Variable ex.Variable // usually x
+}
+
+// GFAngle asks for an angle, given by two half-lines
+// with a common vertex.
+type GFAngle struct {
+	Measure        string          // in degrees, valid expression.Expression
+	Vertex         CoordExpression // optionnal, used when MustHaveVertex is true
+	MustHaveVertex bool
+}
+
 func (f FigureBlock) instantiateFG(params ex.Vars) (client.FigureOrGraph, error) {
 	fig, err := f.instantiateF(params)
 	return client.FigureBlock(fig), err
@@ -438,6 +537,138 @@ func (fa GFAffineLine) setupValidator(params *ex.RandomParameters) (validator, e
 	return gfAffineLineValidator{a: a, b: b}, nil
 }
 
+func (fc GFCircle) instantiate(params ex.Vars) (geoFieldInstance, error) {
+	center, err := fc.Center.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	radius, err := newEvaluatedExpression(fc.Radius, params)
+	if err != nil {
+		return nil, err
+	}
+	return gfCircle{Center: center, Radius: radius}, nil
+}
+
+func (fc GFCircle) setupValidator(params *ex.RandomParameters) (validator, error) {
+	center, err := fc.Center.parse()
+	if err != nil {
+		return nil, err
+	}
+	radius, err := ex.Parse(fc.Radius)
+	if err != nil {
+		return nil, err
+	}
+	return gfCircleValidator{center: center, radius: radius}, nil
+}
+
+func (fs GFSegment) instantiate(params ex.Vars) (geoFieldInstance, error) {
+	from, err := fs.From.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	to, err := fs.To.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	return gfSegment{From: from, To: to, LabelFrom: fs.LabelFrom, LabelTo: fs.LabelTo}, nil
+}
+
+func (fs GFSegment) setupValidator(params *ex.RandomParameters) (validator, error) {
+	from, err := fs.From.parse()
+	if err != nil {
+		return nil, err
+	}
+	to, err := fs.To.parse()
+	if err != nil {
+		return nil, err
+	}
+	return gfSegmentValidator{from: from, to: to}, nil
+}
+
+func (fl GFLine) instantiate(params ex.Vars) (geoFieldInstance, error) {
+	through, err := fl.Through.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fl.A.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	b, err := fl.B.instantiate(params)
+	if err != nil {
+		return nil, err
+	}
+	direction := repere.IntCoord{X: b.X - a.X, Y: b.Y - a.Y}
+	if fl.Perpendicular {
+		direction = repere.IntCoord{X: -direction.Y, Y: direction.X}
+	}
+	return gfLine{Label: fl.Label, Through: through, Direction: direction}, nil
+}
+
+func (fl GFLine) setupValidator(params *ex.RandomParameters) (validator, error) {
+	through, err := fl.Through.parse()
+	if err != nil {
+		return nil, err
+	}
+	a, err := fl.A.parse()
+	if err != nil {
+		return nil, err
+	}
+	b, err := fl.B.parse()
+	if err != nil {
+		return nil, err
+	}
+	return gfLineValidator{through: through, a: a, b: b}, nil
+}
+
+func (fp GFPointOnCurve) instantiate(params ex.Vars) (geoFieldInstance, error) {
+	fn, err := ex.Parse(fp.Function)
+	if err != nil {
+		return nil, err
+	}
+	fn.Substitute(params)
+	return gfPointOnCurve{Function: fn, Variable: fp.Variable}, nil
+}
+
+func (fp GFPointOnCurve) setupValidator(params *ex.RandomParameters) (validator, error) {
+	fn, err := ex.Parse(fp.Function)
+	if err != nil {
+		return nil, err
+	}
+	return gfPointOnCurveValidator{Function: fn, Variable: fp.Variable}, nil
+}
+
+func (fa GFAngle) instantiate(params ex.Vars) (geoFieldInstance, error) {
+	measure, err := evaluateExpr(fa.Measure, params)
+	if err != nil {
+		return nil, err
+	}
+	out := gfAngle{Measure: measure, MustHaveVertex: fa.MustHaveVertex}
+	if fa.MustHaveVertex {
+		out.Vertex, err = fa.Vertex.instantiate(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (fa GFAngle) setupValidator(params *ex.RandomParameters) (validator, error) {
+	measure, err := ex.Parse(fa.Measure)
+	if err != nil {
+		return nil, err
+	}
+	out := gfAngleValidator{measure: measure}
+	if fa.MustHaveVertex {
+		vertex, err := fa.Vertex.parse()
+		if err != nil {
+			return nil, err
+		}
+		out.vertex = &vertex
+	}
+	return out, nil
+}
+
 type VariationTableFieldBlock struct {
 	Answer VariationTableBlock
 }
@@ -705,3 +936,215 @@ func (v SetFieldBlock) setupValidator(*ex.RandomParameters) (validator, error) {
 
 	return setValidator{answer: answer}, nil
 }
+
+// TextFieldBlock is an answer field for a free text,
+// mainly used outside of mathematics (languages, history, ...)
+type TextFieldBlock struct {
+	// Answers is the list of accepted answers.
+	// When [UseRegexp] is true, each answer is a regular expression
+	// which must match the whole student answer.
+	Answers           []string
+	IgnoreCase        bool
+	IgnoreAccents     bool
+	IgnorePunctuation bool
+	UseRegexp         bool
+	// Correction is the answer displayed as correction.
+	// It is required when [UseRegexp] is true (a regular expression
+	// is not a valid answer), and defaults to the first answer otherwise.
+	Correction string
+	// MaxTypos is the number of typing mistakes tolerated,
+	// measured with the edit distance.
+	// It is ignored when [UseRegexp] is true.
+	MaxTypos int
+}
+
+func (tf TextFieldBlock) instantiate(params ex.Vars, ID int) (instance, error) {
+	out := TextFieldInstance{
+		Answers:           append([]string(nil), tf.Answers...),
+		IgnoreCase:        tf.IgnoreCase,
+		IgnoreAccents:     tf.IgnoreAccents,
+		IgnorePunctuation: tf.IgnorePunctuation,
+		MaxTypos:          tf.MaxTypos,
+		Correction:        tf.Correction,
+		ID:                ID,
+	}
+	if tf.UseRegexp {
+		out.MaxTypos = 0
+		out.Patterns = make([]*regexp.Regexp, len(tf.Answers))
+		for i, answer := range tf.Answers {
+			re, err := out.compile(answer)
+			if err != nil {
+				return nil, err
+			}
+			out.Patterns[i] = re
+		}
+	}
+	return out, nil
+}
+
+func (tf TextFieldBlock) setupValidator(*ex.RandomParameters) (validator, error) {
+	if len(tf.Answers) == 0 {
+		return nil, errors.New("Au moins une réponse doit être acceptée.")
+	}
+	if tf.MaxTypos < 0 {
+		return nil, errors.New("Le nombre d'erreurs de frappe tolérées doit être positif.")
+	}
+	tmp := TextFieldInstance{
+		IgnoreCase:        tf.IgnoreCase,
+		IgnoreAccents:     tf.IgnoreAccents,
+		IgnorePunctuation: tf.IgnorePunctuation,
+	}
+	for _, answer := range tf.Answers {
+		if strings.TrimSpace(answer) == "" {
+			return nil, errors.New("Une réponse acceptée ne peut pas être vide.")
+		}
+		if tf.UseRegexp {
+			re, err := tmp.compile(answer)
+			if err != nil {
+				return nil, fmt.Errorf("L'expression régulière %s est invalide : %s", answer, err)
+			}
+			tmp.Patterns = append(tmp.Patterns, re)
+		}
+	}
+	if tf.UseRegexp {
+		if strings.TrimSpace(tf.Correction) == "" {
+			return nil, errors.New("Avec les expressions régulières, une réponse (en texte simple) à afficher en correction est requise.")
+		}
+		if !tmp.evaluateAnswer(client.TextAnswer{Text: tf.Correction}) {
+			return nil, fmt.Errorf("La correction %s n'est acceptée par aucune des expressions régulières.", tf.Correction)
+		}
+	}
+	return noOpValidator{}, nil
+}
+
+// instantiateLines instantiates each item of [list]
+func instantiateLines(list []Interpolated, params ex.Vars) ([]client.TextLine, error) {
+	out := make([]client.TextLine, len(list))
+	for i, item := range list {
+		var err error
+		out[i], err = item.instantiate(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// parseLines checks the syntax of each item of [list]
+func parseLines(list []Interpolated) error {
+	for _, item := range list {
+		if _, err := item.parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchingFieldBlock asks the student to associate each item
+// of a first column to one item of a second column,
+// such as functions and their derivatives.
+type MatchingFieldBlock struct {
+	Left  []Interpolated
+	Right []Interpolated // Right[i] is the item expected for Left[i]
+	// AdditionalRight are added to the second column
+	// as distractors (optional)
+	AdditionalRight []Interpolated
+}
+
+func (mf MatchingFieldBlock) instantiate(params ex.Vars, ID int) (instance, error) {
+	out := MatchingFieldInstance{ID: ID}
+	var err error
+	out.Left, err = instantiateLines(mf.Left, params)
+	if err != nil {
+		return nil, err
+	}
+	out.Right, err = instantiateLines(mf.Right, params)
+	if err != nil {
+		return nil, err
+	}
+	out.AdditionalRight, err = instantiateLines(mf.AdditionalRight, params)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (mf MatchingFieldBlock) setupValidator(*ex.RandomParameters) (validator, error) {
+	if len(mf.Left) < 2 {
+		return nil, errors.New("Au moins deux associations sont requises.")
+	}
+	if len(mf.Left) != len(mf.Right) {
+		return nil, errors.New("Chaque élément de la première colonne doit être associé à un élément de la seconde colonne.")
+	}
+	for _, list := range [][]Interpolated{mf.Left, mf.Right, mf.AdditionalRight} {
+		if err := parseLines(list); err != nil {
+			return nil, err
+		}
+	}
+	return noOpValidator{}, nil
+}
+
+// CategoriesFieldBlock asks the student to sort items
+// into labelled categories, such as "rationnel" and "irrationnel".
+type CategoriesFieldBlock struct {
+	Categories []Interpolated
+	// Items[i] are the items belonging to Categories[i]
+	Items [][]Interpolated
+}
+
+func (cf CategoriesFieldBlock) instantiate(params ex.Vars, ID int) (instance, error) {
+	out := CategoriesFieldInstance{ID: ID, Items: make([][]client.TextLine, len(cf.Items))}
+	var err error
+	out.Categories, err = instantiateLines(cf.Categories, params)
+	if err != nil {
+		return nil, err
+	}
+	for i, items := range cf.Items {
+		out.Items[i], err = instantiateLines(items, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (cf CategoriesFieldBlock) setupValidator(*ex.RandomParameters) (validator, error) {
+	if len(cf.Categories) < 2 {
+		return nil, errors.New("Au moins deux catégories sont requises.")
+	}
+	if len(cf.Categories) != len(cf.Items) {
+		return nil, errors.New("Les éléments de chaque catégorie doivent être définis.")
+	}
+	if err := parseLines(cf.Categories); err != nil {
+		return nil, err
+	}
+	nbItems := 0
+	for _, items := range cf.Items {
+		if err := parseLines(items); err != nil {
+			return nil, err
+		}
+		nbItems += len(items)
+	}
+	if nbItems == 0 {
+		return nil, errors.New("Au moins un élément à classer est requis.")
+	}
+	return noOpValidator{}, nil
+}
+
+// NumberLineFieldBlock asks the student to represent
+// a subset of ℝ on a graduated line.
+type NumberLineFieldBlock struct {
+	Answer NumberLineBlock
+}
+
+func (nl NumberLineFieldBlock) instantiate(params ex.Vars, ID int) (instance, error) {
+	ans, err := nl.Answer.instantiateNL(params)
+	return NumberLineFieldInstance{
+		ID:     ID,
+		Answer: ans,
+	}, err
+}
+
+func (nl NumberLineFieldBlock) setupValidator(params *ex.RandomParameters) (validator, error) {
+	return nl.Answer.setupValidator(params)
+}
@@ -26,6 +26,7 @@ func (FunctionsGraphBlock) isBlock() {}
 func (TableBlock) isBlock()          {}
 func (TreeBlock) isBlock()           {}
 func (ImageBlock) isBlock()          {}
+func (NumberLineBlock) isBlock()     {}
 
 func (NumberFieldBlock) isBlock()                {}
 func (ExpressionFieldBlock) isBlock()            {}
@@ -41,6 +42,10 @@ func (TableFieldBlock) isBlock()                 {}
 func (VectorFieldBlock) isBlock()                {}
 func (ProofFieldBlock) isBlock()                 {}
 func (SetFieldBlock) isBlock()                   {}
+func (TextFieldBlock) isBlock()                  {}
+func (MatchingFieldBlock) isBlock()              {}
+func (CategoriesFieldBlock) isBlock()            {}
+func (NumberLineFieldBlock) isBlock()            {}
 
 // TextOrMath is a part of a text line, rendered
 // either as plain text or using LaTeX in text mode.
@@ -188,6 +193,9 @@ type GeoField interface {
 func (GFPoint) isGF()      {}
 func (GFVector) isGF()     {}
 func (GFVectorPair) isGF() {}
+func (GFCircle) isGF()     {}
+func (GFSegment) isGF()    {}
+func (GFAngle) isGF()      {}
 
 // FigurePointFieldBlock asks for one 2D point
 type GFPoint struct{}
@@ -207,6 +215,23 @@ type GFVector struct {
 // is not allowed
 type GFVectorPair struct{}
 
+// GFCircle asks for a circle, represented by its center
+// (first point) and one of its points (second point),
+// and answered with a [DoublePointAnswer].
+type GFCircle struct{}
+
+// GFSegment asks for a segment, represented by its
+// two (labelled) extremities,
+// and answered with a [DoublePointAnswer].
+type GFSegment struct {
+	LabelFrom, LabelTo string // optional
+}
+
+// GFAngle asks for an angle, represented by two half-lines
+// with a common vertex, and answered with a [DoublePointPairAnswer]
+// where From1 and From2 are the vertex.
+type GFAngle struct{}
+
 // VariationTableFieldBlock asks to complete a
 // variation table (with fixed length)
 type VariationTableFieldBlock struct {
@@ -246,6 +271,15 @@ type ImageBlock struct {
 	Scale int // in percent
 }
 
+// NumberLineBlock displays a subset of ℝ on a number line.
+// The n [Ticks] define 2n+1 pieces : the piece 2k is the open segment
+// before the tick k (or after the last tick for k = n), the piece 2k+1
+// is the tick k itself.
+type NumberLineBlock struct {
+	Ticks  []string // LaTeX code, sorted in increasing order
+	Pieces []bool   // membership of each piece, with length 2*len(Ticks)+1
+}
+
 // TreeShape defines the shape of a "regular" tree,
 // specifying the number of children for each level
 type TreeShape []int
@@ -310,6 +344,35 @@ type SetFieldBlock struct {
 	ID   int
 }
 
+// TextFieldBlock is a free text answer field,
+// mainly used outside of mathematics.
+type TextFieldBlock struct {
+	SizeHint int // approximate number of characters of the expected answer
+	ID       int
+}
+
+// MatchingFieldBlock asks to associate each item of [Left]
+// to one item of [Right].
+type MatchingFieldBlock struct {
+	Left  []TextLine
+	Right []TextLine // shuffled, may be longer than [Left]
+	ID    int
+}
+
+// CategoriesFieldBlock asks to sort [Items] into [Categories].
+type CategoriesFieldBlock struct {
+	Categories []TextLine
+	Items      []TextLine // shuffled
+	ID         int
+}
+
+// NumberLineFieldBlock asks to select the pieces (segments and points)
+// of a number line, see [NumberLineBlock].
+type NumberLineFieldBlock struct {
+	Ticks []string // LaTeX code, sorted in increasing order
+	ID    int
+}
+
 // Answer is a sum type for the possible answers
 // of question fields
 type Answer interface {
@@ -331,6 +394,10 @@ func (TableAnswer) isAnswer()           {}
 func (VectorNumberAnswer) isAnswer()    {}
 func (ProofAnswer) isAnswer()           {}
 func (SetAnswer) isAnswer()             {}
+func (TextAnswer) isAnswer()            {}
+func (MatchingAnswer) isAnswer()        {}
+func (CategoriesAnswer) isAnswer()      {}
+func (NumberLineAnswer) isAnswer()      {}
 
 // NumberAnswer is compared with float equality, with a fixed
 // precision of 8 digits
@@ -338,6 +405,8 @@ type NumberAnswer struct {
 	Value float64
 }
 
+// ExpressionAnswer is either written in the plain syntax,
+// or in LaTeX (as sent by math keyboards)
 type ExpressionAnswer struct {
 	Expression string
 }
@@ -412,6 +481,30 @@ type SetAnswer struct {
 	Root sets.ListNode
 }
 
+// TextAnswer is a free text, compared
+// according to the options of the field
+type TextAnswer struct {
+	Text string
+}
+
+// MatchingAnswer stores, for each left item,
+// the index of the associated right item (as displayed).
+type MatchingAnswer struct {
+	Indices []int
+}
+
+// CategoriesAnswer stores, for each item (as displayed),
+// the index of its category.
+type CategoriesAnswer struct {
+	Categories []int
+}
+
+// NumberLineAnswer stores the membership of each piece
+// of the number line, see [NumberLineBlock].
+type NumberLineAnswer struct {
+	Pieces []bool
+}
+
 // QuestionAnswersIn map the field ids to their answer
 type QuestionAnswersIn struct {
 	Data Answers
@@ -422,6 +515,13 @@ type Answers map[int]Answer
 type QuestionAnswersOut struct {
 	Results         map[int]bool
 	ExpectedAnswers Answers
+	// Hints optionnaly stores a short text for wrong answers,
+	// explaining the likely mistake
+	Hints map[int]string
+	// ItemResults optionnaly stores, for wrong answers of fields made
+	// of several items (matching, categories), the result of each item,
+	// in the order used by the answer.
+	ItemResults map[int][]bool
 }
 
 // IsCorrect returns `true` if all the fields are correct.
@@ -20,6 +20,10 @@ func (out *AnswerWrapper) UnmarshalJSON(src []byte) error {
 		return err
 	}
 	switch wr.Kind {
+	case "CategoriesAnswer":
+		var data CategoriesAnswer
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "DoublePointAnswer":
 		var data DoublePointAnswer
 		err = json.Unmarshal(wr.Data, &data)
@@ -36,10 +40,18 @@ func (out *AnswerWrapper) UnmarshalJSON(src []byte) error {
 		var data FunctionPointsAnswer
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "MatchingAnswer":
+		var data MatchingAnswer
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "NumberAnswer":
 		var data NumberAnswer
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "NumberLineAnswer":
+		var data NumberLineAnswer
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "OrderedListAnswer":
 		var data OrderedListAnswer
 		err = json.Unmarshal(wr.Data, &data)
@@ -68,6 +80,10 @@ func (out *AnswerWrapper) UnmarshalJSON(src []byte) error {
 		var data TableAnswer
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "TextAnswer":
+		var data TextAnswer
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "TreeAnswer":
 		var data TreeAnswer
 		err = json.Unmarshal(wr.Data, &data)
@@ -94,6 +110,8 @@ func (item AnswerWrapper) MarshalJSON() ([]byte, error) {
 	}
 	var wr wrapper
 	switch data := item.Data.(type) {
+	case CategoriesAnswer:
+		wr = wrapper{Kind: "CategoriesAnswer", Data: data}
 	case DoublePointAnswer:
 		wr = wrapper{Kind: "DoublePointAnswer", Data: data}
 	case DoublePointPairAnswer:
@@ -102,8 +120,12 @@ func (item AnswerWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "ExpressionAnswer", Data: data}
 	case FunctionPointsAnswer:
 		wr = wrapper{Kind: "FunctionPointsAnswer", Data: data}
+	case MatchingAnswer:
+		wr = wrapper{Kind: "MatchingAnswer", Data: data}
 	case NumberAnswer:
 		wr = wrapper{Kind: "NumberAnswer", Data: data}
+	case NumberLineAnswer:
+		wr = wrapper{Kind: "NumberLineAnswer", Data: data}
 	case OrderedListAnswer:
 		wr = wrapper{Kind: "OrderedListAnswer", Data: data}
 	case PointAnswer:
@@ -118,6 +140,8 @@ func (item AnswerWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "SignTableAnswer", Data: data}
 	case TableAnswer:
 		wr = wrapper{Kind: "TableAnswer", Data: data}
+	case TextAnswer:
+		wr = wrapper{Kind: "TextAnswer", Data: data}
 	case TreeAnswer:
 		wr = wrapper{Kind: "TreeAnswer", Data: data}
 	case VariationTableAnswer:
@@ -132,11 +156,14 @@ func (item AnswerWrapper) MarshalJSON() ([]byte, error) {
 }
 
 const (
+	CategoriesAnswerAnKind      = "CategoriesAnswer"
 	DoublePointAnswerAnKind     = "DoublePointAnswer"
 	DoublePointPairAnswerAnKind = "DoublePointPairAnswer"
 	ExpressionAnswerAnKind      = "ExpressionAnswer"
 	FunctionPointsAnswerAnKind  = "FunctionPointsAnswer"
+	MatchingAnswerAnKind        = "MatchingAnswer"
 	NumberAnswerAnKind          = "NumberAnswer"
+	NumberLineAnswerAnKind      = "NumberLineAnswer"
 	OrderedListAnswerAnKind     = "OrderedListAnswer"
 	PointAnswerAnKind           = "PointAnswer"
 	ProofAnswerAnKind           = "ProofAnswer"
@@ -144,6 +171,7 @@ const (
 	SetAnswerAnKind             = "SetAnswer"
 	SignTableAnswerAnKind       = "SignTableAnswer"
 	TableAnswerAnKind           = "TableAnswer"
+	TextAnswerAnKind            = "TextAnswer"
 	TreeAnswerAnKind            = "TreeAnswer"
 	VariationTableAnswerAnKind  = "VariationTableAnswer"
 	VectorNumberAnswerAnKind    = "VectorNumberAnswer"
@@ -277,6 +305,10 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		var data DropDownFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "CategoriesFieldBlock":
+		var data CategoriesFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "ExpressionFieldBlock":
 		var data ExpressionFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -305,10 +337,22 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		var data ImageBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "MatchingFieldBlock":
+		var data MatchingFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "NumberFieldBlock":
 		var data NumberFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "NumberLineBlock":
+		var data NumberLineBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "NumberLineFieldBlock":
+		var data NumberLineFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "OrderedListFieldBlock":
 		var data OrderedListFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -345,6 +389,10 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		var data TextBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "TextFieldBlock":
+		var data TextFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "TreeBlock":
 		var data TreeBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -381,6 +429,8 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 	switch data := item.Data.(type) {
 	case DropDownFieldBlock:
 		wr = wrapper{Kind: "DropDownFieldBlock", Data: data}
+	case CategoriesFieldBlock:
+		wr = wrapper{Kind: "CategoriesFieldBlock", Data: data}
 	case ExpressionFieldBlock:
 		wr = wrapper{Kind: "ExpressionFieldBlock", Data: data}
 	case FigureBlock:
@@ -395,8 +445,14 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "GeometricConstructionFieldBlock", Data: data}
 	case ImageBlock:
 		wr = wrapper{Kind: "ImageBlock", Data: data}
+	case MatchingFieldBlock:
+		wr = wrapper{Kind: "MatchingFieldBlock", Data: data}
 	case NumberFieldBlock:
 		wr = wrapper{Kind: "NumberFieldBlock", Data: data}
+	case NumberLineBlock:
+		wr = wrapper{Kind: "NumberLineBlock", Data: data}
+	case NumberLineFieldBlock:
+		wr = wrapper{Kind: "NumberLineFieldBlock", Data: data}
 	case OrderedListFieldBlock:
 		wr = wrapper{Kind: "OrderedListFieldBlock", Data: data}
 	case ProofFieldBlock:
@@ -415,6 +471,8 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "TableFieldBlock", Data: data}
 	case TextBlock:
 		wr = wrapper{Kind: "TextBlock", Data: data}
+	case TextFieldBlock:
+		wr = wrapper{Kind: "TextFieldBlock", Data: data}
 	case TreeBlock:
 		wr = wrapper{Kind: "TreeBlock", Data: data}
 	case TreeFieldBlock:
@@ -434,6 +492,7 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 
 const (
 	DropDownFieldBlockBlKind              = "DropDownFieldBlock"
+	CategoriesFieldBlockBlKind            = "CategoriesFieldBlock"
 	ExpressionFieldBlockBlKind            = "ExpressionFieldBlock"
 	FigureBlockBlKind                     = "FigureBlock"
 	FormulaBlockBlKind                    = "FormulaBlock"
@@ -441,7 +500,10 @@ const (
 	FunctionsGraphBlockBlKind             = "FunctionsGraphBlock"
 	GeometricConstructionFieldBlockBlKind = "GeometricConstructionFieldBlock"
 	ImageBlockBlKind                      = "ImageBlock"
+	MatchingFieldBlockBlKind              = "MatchingFieldBlock"
 	NumberFieldBlockBlKind                = "NumberFieldBlock"
+	NumberLineBlockBlKind                 = "NumberLineBlock"
+	NumberLineFieldBlockBlKind            = "NumberLineFieldBlock"
 	OrderedListFieldBlockBlKind           = "OrderedListFieldBlock"
 	ProofFieldBlockBlKind                 = "ProofFieldBlock"
 	RadioFieldBlockBlKind                 = "RadioFieldBlock"
@@ -451,6 +513,7 @@ const (
 	TableBlockBlKind                      = "TableBlock"
 	TableFieldBlockBlKind                 = "TableFieldBlock"
 	TextBlockBlKind                       = "TextBlock"
+	TextFieldBlockBlKind                  = "TextFieldBlock"
 	TreeBlockBlKind                       = "TreeBlock"
 	TreeFieldBlockBlKind                  = "TreeFieldBlock"
 	VariationTableBlockBlKind             = "VariationTableBlock"
@@ -546,10 +609,22 @@ func (out *GeoFieldWrapper) UnmarshalJSON(src []byte) error {
 		return err
 	}
 	switch wr.Kind {
+	case "GFAngle":
+		var data GFAngle
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "GFCircle":
+		var data GFCircle
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "GFPoint":
 		var data GFPoint
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "GFSegment":
+		var data GFSegment
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "GFVector":
 		var data GFVector
 		err = json.Unmarshal(wr.Data, &data)
@@ -572,8 +647,14 @@ func (item GeoFieldWrapper) MarshalJSON() ([]byte, error) {
 	}
 	var wr wrapper
 	switch data := item.Data.(type) {
+	case GFAngle:
+		wr = wrapper{Kind: "GFAngle", Data: data}
+	case GFCircle:
+		wr = wrapper{Kind: "GFCircle", Data: data}
 	case GFPoint:
 		wr = wrapper{Kind: "GFPoint", Data: data}
+	case GFSegment:
+		wr = wrapper{Kind: "GFSegment", Data: data}
 	case GFVector:
 		wr = wrapper{Kind: "GFVector", Data: data}
 	case GFVectorPair:
@@ -586,7 +667,10 @@ func (item GeoFieldWrapper) MarshalJSON() ([]byte, error) {
 }
 
 const (
+	GFAngleGeKind      = "GFAngle"
+	GFCircleGeKind     = "GFCircle"
 	GFPointGeKind      = "GFPoint"
+	GFSegmentGeKind    = "GFSegment"
 	GFVectorGeKind     = "GFVector"
 	GFVectorPairGeKind = "GFVectorPair"
 )
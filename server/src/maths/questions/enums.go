@@ -33,6 +33,8 @@ const (
 	SimpleSubstitutions                   = ComparisonLevel(expression.SimpleSubstitutions)   // Simple
 	ExpandedSubstitutions                 = ComparisonLevel(expression.ExpandedSubstitutions) // Complète
 	AsLinearEquation      ComparisonLevel = ExpandedSubstitutions + 100
+	AsSolutionSet         ComparisonLevel = ExpandedSubstitutions + 101
+	AsComplexNumber       ComparisonLevel = ExpandedSubstitutions + 102
 )
 
 type VectorPairCriterion uint8
@@ -80,6 +80,92 @@ var BlockList = [...]que.Block{
 			},
 		},
 	},
+	que.GeometricConstructionFieldBlock{
+		Field: que.GFCircle{
+			Center: que.CoordExpression{X: "1", Y: "2"},
+			Radius: "sqrt(5)",
+		},
+		Background: que.FigureBlock{
+			Bounds: repere.RepereBounds{
+				Width:  10,
+				Height: 10,
+				Origin: repere.Coord{
+					X: 3,
+					Y: 3,
+				},
+			},
+		},
+	},
+	que.GeometricConstructionFieldBlock{
+		Field: que.GFSegment{
+			From:      que.CoordExpression{X: "-1", Y: "0"},
+			To:        que.CoordExpression{X: "2", Y: "3"},
+			LabelFrom: "A",
+			LabelTo:   "B",
+		},
+		Background: que.FigureBlock{
+			Bounds: repere.RepereBounds{
+				Width:  10,
+				Height: 10,
+				Origin: repere.Coord{
+					X: 3,
+					Y: 3,
+				},
+			},
+		},
+	},
+	que.GeometricConstructionFieldBlock{
+		Field: que.GFLine{
+			Label:         "d",
+			Through:       que.CoordExpression{X: "1", Y: "1"},
+			A:             que.CoordExpression{X: "0", Y: "0"},
+			B:             que.CoordExpression{X: "2", Y: "1"},
+			Perpendicular: true,
+		},
+		Background: que.FigureBlock{
+			Bounds: repere.RepereBounds{
+				Width:  10,
+				Height: 10,
+				Origin: repere.Coord{
+					X: 3,
+					Y: 3,
+				},
+			},
+		},
+	},
+	que.GeometricConstructionFieldBlock{
+		Field: que.GFPointOnCurve{
+			Function: "x^2 - 2",
+			Variable: expression.NewVar('x'),
+		},
+		Background: que.FigureBlock{
+			Bounds: repere.RepereBounds{
+				Width:  10,
+				Height: 10,
+				Origin: repere.Coord{
+					X: 3,
+					Y: 3,
+				},
+			},
+		},
+	},
+	que.GeometricConstructionFieldBlock{
+		Field: que.GFAngle{
+			Measure:        "45",
+			Vertex:         que.CoordExpression{X: "0", Y: "0"},
+			MustHaveVertex: true,
+		},
+		Background: que.FigureBlock{
+			Bounds: repere.RepereBounds{
+				Width:  10,
+				Height: 10,
+				Origin: repere.Coord{
+					X: 3,
+					Y: 3,
+				},
+			},
+		},
+	},
 	que.FigureBlock{
 		Drawings: repere.RandomDrawings{
 			Points: []repere.NamedRandomLabeledPoint{
@@ -281,4 +367,26 @@ var BlockList = [...]que.Block{
 		AcceptColinear: true,
 		DisplayColumn:  true,
 	},
+	que.TextFieldBlock{
+		Answers:           []string{"Victor Hugo", "Hugo"},
+		IgnoreCase:        true,
+		IgnoreAccents:     true,
+		IgnorePunctuation: true,
+		MaxTypos:          1,
+	},
+	que.MatchingFieldBlock{
+		Left:            []que.Interpolated{"$x^2$", "$e^x$", "$\\ln(x)$"},
+		Right:           []que.Interpolated{"$2x$", "$e^x$", "$\\frac{1}{x}$"},
+		AdditionalRight: []que.Interpolated{"$x$"},
+	},
+	que.CategoriesFieldBlock{
+		Categories: []que.Interpolated{"Rationnel", "Irrationnel"},
+		Items: [][]que.Interpolated{
+			{"$\\frac{1}{3}$", "$0.5$", "$\\sqrt{4}$"},
+			{"$\\sqrt{2}$", "$\\pi$"},
+		},
+	},
+	que.NumberLineFieldBlock{
+		Answer: que.NumberLineBlock{Set: "]-inf;-1[ ∪ [2;4] ∪ {5}", Ticks: []string{"0"}},
+	},
 }
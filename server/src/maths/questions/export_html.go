@@ -0,0 +1,588 @@
+package questions
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/svg"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+)
+
+// this file implements a static HTML rendering of (instantiated) questions,
+// used for print views and screen readers.
+// Math content is kept as LaTeX, between \( \) and \[ \] delimiters,
+// and rendered in the browser by KaTeX.
+
+const htmlHeader = `<!DOCTYPE html>
+<html lang="fr">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/katex@0.16.32/dist/katex.min.css">
+<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.32/dist/katex.min.js"></script>
+<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.32/dist/contrib/auto-render.min.js" onload="renderMathInElement(document.body)"></script>
+<style>
+body { font-family: sans-serif; font-size: 1.1rem; line-height: 1.6; max-width: 50rem; margin: auto; padding: 1rem; }
+.row { margin: 0.5rem 0; }
+.center { text-align: center; }
+table { border-collapse: collapse; margin: 0.5rem auto; }
+th, td { border: 1px solid black; padding: 0.25rem 0.75rem; text-align: center; }
+fieldset { border: none; margin: 0.5rem 0; padding: 0; }
+input[type="text"] { font-size: 1rem; }
+.sr-only { position: absolute; width: 1px; height: 1px; overflow: hidden; clip: rect(0 0 0 0); white-space: nowrap; }
+.field-note { border: 1px dashed gray; padding: 0.5rem; font-style: italic; }
+@media print { .field-note { border-color: black; } }
+</style>
+</head>
+<body>
+`
+
+// HTMLOptions configures [QuestionToHTML].
+type HTMLOptions struct {
+	Title string // defaults to "Question"
+	// If not nil, Answers are displayed in the (read only) fields,
+	// for instance to show the answers of a student.
+	Answers client.Answers
+	// If true, the correction is added after the statement
+	WithCorrection bool
+}
+
+// QuestionToHTML returns a standalone HTML page displaying [question].
+// Figures are embedded as SVG images, tables as HTML tables,
+// and answer fields as labeled form controls.
+func QuestionToHTML(question client.Question, options HTMLOptions) string {
+	title := options.Title
+	if title == "" {
+		title = "Question"
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, htmlHeader, html.EscapeString(title))
+	fmt.Fprintf(&out, "<main>\n<h1>%s</h1>\n", html.EscapeString(title))
+	out.WriteString("<section aria-label=\"Énoncé\">\n")
+	out.WriteString(EnonceToHTML(question.Enonce, options.Answers))
+	out.WriteString("</section>\n")
+	if options.WithCorrection && len(question.Correction) != 0 {
+		out.WriteString("<section>\n<h2>Correction</h2>\n")
+		out.WriteString(EnonceToHTML(question.Correction, nil))
+		out.WriteString("</section>\n")
+	}
+	out.WriteString("</main>\n</body>\n</html>\n")
+	return out.String()
+}
+
+// EnonceToHTML returns the HTML fragment for [enonce].
+// If [answers] is not nil, it is used to fill the fields, which are then read only.
+// Text blocks and inline fields are grouped in rows, as in the student app.
+func EnonceToHTML(enonce client.Enonce, answers client.Answers) string {
+	hb := htmlBuilder{answers: answers}
+	lastIsText := false
+	for _, block := range enonce {
+		_, isText := block.(client.TextBlock)
+		if isText && lastIsText { // start a new line between two text blocks
+			hb.flushRow()
+		}
+		hb.addBlock(block)
+		lastIsText = isText
+	}
+	hb.flushRow()
+	return strings.Join(hb.rows, "\n") + "\n"
+}
+
+type htmlBuilder struct {
+	answers    client.Answers
+	rows       []string
+	currentRow []string
+}
+
+func (hb *htmlBuilder) flushRow() {
+	if len(hb.currentRow) == 0 {
+		return
+	}
+	hb.rows = append(hb.rows, `<p class="row">`+strings.Join(hb.currentRow, " ")+`</p>`)
+	hb.currentRow = nil
+}
+
+// addRow adds a block element, on its own line
+func (hb *htmlBuilder) addRow(content string) {
+	hb.flushRow()
+	hb.rows = append(hb.rows, content)
+}
+
+// answer returns the answer for the field [id], or nil
+func (hb *htmlBuilder) answer(id int) client.Answer {
+	if hb.answers == nil {
+		return nil
+	}
+	return hb.answers[id]
+}
+
+// readonly returns the attribute to add to fields when answers are displayed
+func (hb *htmlBuilder) readonly(isSelect bool) string {
+	if hb.answers == nil {
+		return ""
+	}
+	if isSelect { // readonly is not supported by select and checkboxes
+		return " disabled"
+	}
+	return " readonly"
+}
+
+func (hb *htmlBuilder) addBlock(block client.Block) {
+	switch block := block.(type) {
+	case client.TextBlock:
+		content := textLineToHTML(block.Parts)
+		if block.Bold {
+			content = "<strong>" + content + "</strong>"
+		}
+		if block.Italic {
+			content = "<em>" + content + "</em>"
+		}
+		if block.Smaller {
+			content = "<small>" + content + "</small>"
+		}
+		hb.currentRow = append(hb.currentRow, content)
+	case client.FormulaBlock:
+		hb.addRow(`<div class="center">` + displayMath(block.Formula) + `</div>`)
+	case client.TableBlock:
+		hb.addRow(tableToHTML(block.HorizontalHeaders, block.VerticalHeaders, func(i, j int) string {
+			return textOrMathToHTML(block.Values[i][j])
+		}, len(block.Values), nbColumns(block.Values)))
+	case client.VariationTableBlock:
+		hb.addRow(variationTableToHTML(block))
+	case client.SignTableBlock:
+		hb.addRow(signTableToHTML(block))
+	case client.ImageBlock:
+		hb.addRow(fmt.Sprintf(`<div class="center"><img src="%s" alt="Image" style="width: %d%%"></div>`, html.EscapeString(block.URL), block.Scale))
+	case client.FigureBlock:
+		hb.addRow(svgImage(block, "Figure"))
+	case client.FunctionsGraphBlock:
+		hb.addRow(svgImage(block, "Représentation graphique de fonctions"))
+	case client.TreeBlock:
+		hb.addRow(svgImage(block, "Arbre de probabilités"))
+	case client.NumberLineBlock:
+		hb.addRow(svgImage(block, "Droite graduée"))
+
+	case client.NumberFieldBlock:
+		value := ""
+		if ans, ok := hb.answer(block.ID).(client.NumberAnswer); ok {
+			value = strconv.FormatFloat(ans.Value, 'f', -1, 64)
+		}
+		hb.currentRow = append(hb.currentRow, hb.input(fieldID(block.ID), "Réponse (nombre)", value, block.SizeHint, `inputmode="decimal"`))
+	case client.ExpressionFieldBlock:
+		value := ""
+		if ans, ok := hb.answer(block.ID).(client.ExpressionAnswer); ok {
+			value = ans.Expression
+		}
+		if block.Label == "" && block.Suffix == "" {
+			hb.currentRow = append(hb.currentRow, hb.input(fieldID(block.ID), "Réponse (expression)", value, block.SizeHint, ""))
+			break
+		}
+		// the label (and suffix) are displayed, the field is on its own line
+		var content string
+		if block.Label != "" {
+			content = fmt.Sprintf(`<label for="%s">%s</label> `, fieldID(block.ID), inlineMath(block.Label)) +
+				hb.input(fieldID(block.ID), "", value, block.SizeHint, "")
+		} else {
+			content = hb.input(fieldID(block.ID), "Réponse (expression)", value, block.SizeHint, "")
+		}
+		if block.Suffix != "" {
+			content += " " + inlineMath(block.Suffix)
+		}
+		hb.addRow(`<div class="center">` + content + `</div>`)
+	case client.TextFieldBlock:
+		value := ""
+		if ans, ok := hb.answer(block.ID).(client.TextAnswer); ok {
+			value = ans.Text
+		}
+		hb.currentRow = append(hb.currentRow, hb.input(fieldID(block.ID), "Réponse (texte)", value, block.SizeHint, ""))
+	case client.DropDownFieldBlock:
+		selected := -1
+		if ans, ok := hb.answer(block.ID).(client.RadioAnswer); ok {
+			selected = ans.Index
+		}
+		hb.currentRow = append(hb.currentRow, hb.selectField(fieldID(block.ID), `aria-label="Choix de la réponse"`, block.Proposals, selected))
+	case client.RadioFieldBlock:
+		hb.addRow(hb.radioField(block))
+	case client.OrderedListFieldBlock:
+		hb.addRow(hb.orderedListField(block))
+	case client.VectorFieldBlock:
+		var x, y string
+		if ans, ok := hb.answer(block.ID).(client.VectorNumberAnswer); ok {
+			x, y = strconv.FormatFloat(ans.X, 'f', -1, 64), strconv.FormatFloat(ans.Y, 'f', -1, 64)
+		}
+		hb.currentRow = append(hb.currentRow, fmt.Sprintf(`( %s ; %s )`,
+			hb.input(fieldID(block.ID)+"-x", "Abscisse du vecteur", x, block.SizeHintX, `inputmode="decimal"`),
+			hb.input(fieldID(block.ID)+"-y", "Ordonnée du vecteur", y, block.SizeHintY, `inputmode="decimal"`)))
+	case client.TableFieldBlock:
+		hb.addRow(hb.tableField(block))
+	case client.MatchingFieldBlock:
+		var indices []int
+		if ans, ok := hb.answer(block.ID).(client.MatchingAnswer); ok {
+			indices = ans.Indices
+		}
+		hb.addRow(hb.associationField(block.ID, "Associer chaque élément à une proposition", block.Left, block.Right, indices))
+	case client.CategoriesFieldBlock:
+		var indices []int
+		if ans, ok := hb.answer(block.ID).(client.CategoriesAnswer); ok {
+			indices = ans.Categories
+		}
+		hb.addRow(hb.associationField(block.ID, "Classer chaque élément dans une catégorie", block.Items, block.Categories, indices))
+	case client.NumberLineFieldBlock:
+		hb.addRow(hb.numberLineField(block))
+	case client.GeometricConstructionFieldBlock:
+		image := ""
+		if content, ok := svg.Render(block); ok {
+			image = svgDataImage(content, "Repère")
+		}
+		hb.addRow(`<figure class="center">` + image +
+			`<figcaption class="field-note">` + geoFieldNote(block.Field, hb.answer(block.ID)) + `</figcaption></figure>`)
+	case client.VariationTableFieldBlock:
+		hb.addRow(fieldNote(fmt.Sprintf("Compléter le tableau de variations de %s.", inlineMath(block.Label))))
+	case client.SignTableFieldBlock:
+		labels := make([]string, len(block.Labels))
+		for i, label := range block.Labels {
+			labels[i] = inlineMath(label)
+		}
+		hb.addRow(fieldNote(fmt.Sprintf("Compléter le tableau de signes de %s.", strings.Join(labels, ", "))))
+	case client.FunctionPointsFieldBlock:
+		hb.addRow(fieldNote(fmt.Sprintf("Placer les points de la représentation graphique de %s.", inlineMath(block.Label))))
+	case client.TreeFieldBlock:
+		hb.addRow(fieldNote("Compléter l'arbre de probabilités."))
+	case client.ProofFieldBlock:
+		hb.addRow(fieldNote("Compléter la démonstration."))
+	case client.SetFieldBlock:
+		sets := make([]string, len(block.Sets))
+		for i, set := range block.Sets {
+			sets[i] = inlineMath(set)
+		}
+		hb.addRow(fieldNote(fmt.Sprintf("Écrire l'ensemble demandé, à l'aide de %s.", strings.Join(sets, ", "))))
+	}
+}
+
+// ------------------------------- static content -------------------------------
+
+func inlineMath(latex string) string { return `\(` + html.EscapeString(latex) + `\)` }
+
+func displayMath(latex string) string { return `\[` + html.EscapeString(latex) + `\]` }
+
+func textOrMathToHTML(part client.TextOrMath) string {
+	if part.IsMath {
+		return inlineMath(part.Text)
+	}
+	return strings.ReplaceAll(html.EscapeString(part.Text), "\n", "<br>")
+}
+
+func textLineToHTML(line client.TextLine) string {
+	var out strings.Builder
+	for _, part := range line {
+		out.WriteString(textOrMathToHTML(part))
+	}
+	return out.String()
+}
+
+// textLineToPlain returns [line] without markup, as
+// required inside <option> elements
+func textLineToPlain(line client.TextLine) string {
+	var out strings.Builder
+	for _, part := range line {
+		out.WriteString(part.Text)
+	}
+	return html.EscapeString(out.String())
+}
+
+func nbColumns(values [][]client.TextOrMath) int {
+	if len(values) == 0 {
+		return 0
+	}
+	return len(values[0])
+}
+
+// tableToHTML builds a table with optional headers, and [nbRows] x [nbColumns] cells
+func tableToHTML(horizontalHeaders, verticalHeaders []client.TextOrMath, cell func(i, j int) string, nbRows, nbColumns int) string {
+	var out strings.Builder
+	out.WriteString("<table>\n")
+	if len(horizontalHeaders) != 0 {
+		out.WriteString("<tr>")
+		if len(verticalHeaders) != 0 {
+			out.WriteString("<td></td>")
+		}
+		for _, header := range horizontalHeaders {
+			fmt.Fprintf(&out, `<th scope="col">%s</th>`, textOrMathToHTML(header))
+		}
+		out.WriteString("</tr>\n")
+	}
+	for i := 0; i < nbRows; i++ {
+		out.WriteString("<tr>")
+		if i < len(verticalHeaders) {
+			fmt.Fprintf(&out, `<th scope="row">%s</th>`, textOrMathToHTML(verticalHeaders[i]))
+		}
+		for j := 0; j < nbColumns; j++ {
+			fmt.Fprintf(&out, "<td>%s</td>", cell(i, j))
+		}
+		out.WriteString("</tr>\n")
+	}
+	out.WriteString("</table>")
+	return out.String()
+}
+
+func variationTableToHTML(vt client.VariationTableBlock) string {
+	xs, fxs := []string{`<th scope="row">\(x\)</th>`}, []string{`<th scope="row">` + inlineMath(vt.Label) + `</th>`}
+	for i, column := range vt.Columns {
+		if i > 0 && i-1 < len(vt.Arrows) {
+			arrow := `<td aria-label="décroissante">↘</td>`
+			if vt.Arrows[i-1] {
+				arrow = `<td aria-label="croissante">↗</td>`
+			}
+			xs, fxs = append(xs, "<td></td>"), append(fxs, arrow)
+		}
+		xs = append(xs, "<td>"+inlineMath(column.X)+"</td>")
+		fxs = append(fxs, "<td>"+inlineMath(column.Y)+"</td>")
+	}
+	return fmt.Sprintf("<table>\n<caption class=\"sr-only\">Tableau de variations</caption>\n<tr>%s</tr>\n<tr>%s</tr>\n</table>",
+		strings.Join(xs, ""), strings.Join(fxs, ""))
+}
+
+func signTableToHTML(st client.SignTableBlock) string {
+	var out strings.Builder
+	out.WriteString("<table>\n<caption class=\"sr-only\">Tableau de signes</caption>\n<tr><th scope=\"row\">\\(x\\)</th>")
+	for i, x := range st.Xs {
+		if i > 0 {
+			out.WriteString("<td></td>")
+		}
+		out.WriteString("<td>" + inlineMath(x) + "</td>")
+	}
+	out.WriteString("</tr>\n")
+	for _, fn := range st.Functions {
+		out.WriteString(`<tr><th scope="row">` + inlineMath(fn.Label) + "</th>")
+		for i, symbol := range fn.FxSymbols {
+			if i > 0 && i-1 < len(fn.Signs) {
+				if fn.Signs[i-1] {
+					out.WriteString("<td>+</td>")
+				} else {
+					out.WriteString("<td>−</td>")
+				}
+			}
+			switch symbol {
+			case client.Zero:
+				out.WriteString("<td>0</td>")
+			case client.ForbiddenValue:
+				out.WriteString(`<td aria-label="valeur interdite">||</td>`)
+			default:
+				out.WriteString("<td></td>")
+			}
+		}
+		out.WriteString("</tr>\n")
+	}
+	out.WriteString("</table>")
+	return out.String()
+}
+
+// svgImage embeds the SVG rendering of [block] as an image,
+// so that the ids used inside the images do not clash
+func svgImage(block client.Block, alt string) string {
+	content, _ := svg.Render(block)
+	return `<div class="center">` + svgDataImage(content, alt) + `</div>`
+}
+
+func svgDataImage(content, alt string) string {
+	return fmt.Sprintf(`<img src="data:image/svg+xml;base64,%s" alt="%s" style="max-width: 100%%">`,
+		base64.StdEncoding.EncodeToString([]byte(content)), html.EscapeString(alt))
+}
+
+// ------------------------------- fields -------------------------------
+
+func fieldID(id int) string { return fmt.Sprintf("field-%d", id) }
+
+// input returns a text input, labeled by [label] for screen readers.
+// An empty [label] is used when the input has a visible <label>.
+func (hb *htmlBuilder) input(id, label, value string, sizeHint int, attrs string) string {
+	size := max(sizeHint, 3)
+	if attrs != "" {
+		attrs = " " + attrs
+	}
+	if label != "" {
+		attrs = fmt.Sprintf(` aria-label="%s"`, label) + attrs
+	}
+	return fmt.Sprintf(`<input type="text" id="%s" size="%d" value="%s"%s%s>`,
+		id, size, html.EscapeString(value), attrs, hb.readonly(false))
+}
+
+// selectField returns a <select> element with the given proposals,
+// where [selected] is the index of the selected option, or -1
+func (hb *htmlBuilder) selectField(id, attrs string, proposals []client.TextLine, selected int) string {
+	if attrs != "" {
+		attrs = " " + attrs
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, `<select id="%s"%s%s>`, id, attrs, hb.readonly(true))
+	out.WriteString(`<option value="">—</option>`)
+	for i, proposal := range proposals {
+		isSelected := ""
+		if i == selected {
+			isSelected = " selected"
+		}
+		fmt.Fprintf(&out, `<option value="%d"%s>%s</option>`, i, isSelected, textLineToPlain(proposal))
+	}
+	out.WriteString("</select>")
+	return out.String()
+}
+
+func (hb *htmlBuilder) radioField(block client.RadioFieldBlock) string {
+	selected := -1
+	if ans, ok := hb.answer(block.ID).(client.RadioAnswer); ok {
+		selected = ans.Index
+	}
+	var out strings.Builder
+	out.WriteString("<fieldset>\n<legend class=\"sr-only\">Choisir une réponse</legend>\n")
+	for i, proposal := range block.Proposals {
+		checked := ""
+		if i == selected {
+			checked = " checked"
+		}
+		fmt.Fprintf(&out, `<div><label><input type="radio" name="%s" value="%d"%s%s> %s</label></div>`+"\n",
+			fieldID(block.ID), i, checked, hb.readonly(true), textLineToHTML(proposal))
+	}
+	out.WriteString("</fieldset>")
+	return out.String()
+}
+
+func (hb *htmlBuilder) orderedListField(block client.OrderedListFieldBlock) string {
+	var indices []int
+	if ans, ok := hb.answer(block.ID).(client.OrderedListAnswer); ok {
+		indices = ans.Indices
+	}
+	var out strings.Builder
+	out.WriteString("<fieldset>\n<legend>Ranger les propositions dans l'ordre")
+	if block.Label != "" {
+		out.WriteString(" : " + inlineMath(block.Label))
+	}
+	out.WriteString("</legend>\n<ol>\n")
+	for k := 0; k < block.AnswerLength; k++ {
+		selected := -1
+		if k < len(indices) {
+			selected = indices[k]
+		}
+		id := fmt.Sprintf("%s-%d", fieldID(block.ID), k)
+		fmt.Fprintf(&out, "<li>%s</li>\n", hb.selectField(id, fmt.Sprintf(`aria-label="Position %d"`, k+1), block.Proposals, selected))
+	}
+	out.WriteString("</ol>\n</fieldset>")
+	return out.String()
+}
+
+func (hb *htmlBuilder) tableField(block client.TableFieldBlock) string {
+	var rows [][]float64
+	if ans, ok := hb.answer(block.ID).(client.TableAnswer); ok {
+		rows = ans.Rows
+	}
+	return tableToHTML(block.HorizontalHeaders, block.VerticalHeaders, func(i, j int) string {
+		value := ""
+		if i < len(rows) && j < len(rows[i]) {
+			value = strconv.FormatFloat(rows[i][j], 'f', -1, 64)
+		}
+		label := fmt.Sprintf("Ligne %d, colonne %d", i+1, j+1)
+		return hb.input(fmt.Sprintf("%s-%d-%d", fieldID(block.ID), i, j), label, value, 4, `inputmode="decimal"`)
+	}, len(block.VerticalHeaders), len(block.HorizontalHeaders))
+}
+
+// associationField asks to associate each item with one of the [targets]
+func (hb *htmlBuilder) associationField(id int, legend string, items, targets []client.TextLine, selected []int) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "<fieldset>\n<legend>%s</legend>\n", legend)
+	for i, item := range items {
+		index := -1
+		if i < len(selected) {
+			index = selected[i]
+		}
+		itemID := fmt.Sprintf("%s-%d", fieldID(id), i)
+		fmt.Fprintf(&out, `<div class="row"><label for="%s">%s</label> %s</div>`+"\n",
+			itemID, textLineToHTML(item), hb.selectField(itemID, "", targets, index))
+	}
+	out.WriteString("</fieldset>")
+	return out.String()
+}
+
+// numberLineField displays one checkbox for each piece of the number line
+func (hb *htmlBuilder) numberLineField(block client.NumberLineFieldBlock) string {
+	var pieces []bool
+	if ans, ok := hb.answer(block.ID).(client.NumberLineAnswer); ok {
+		pieces = ans.Pieces
+	}
+	var out strings.Builder
+	out.WriteString("<fieldset>\n<legend>Sélectionner les intervalles et les valeurs de l'ensemble</legend>\n")
+	out.WriteString(svgImage(block, "Droite graduée") + "\n")
+	for piece := 0; piece < 2*len(block.Ticks)+1; piece++ {
+		checked := ""
+		if piece < len(pieces) && pieces[piece] {
+			checked = " checked"
+		}
+		fmt.Fprintf(&out, `<label><input type="checkbox" name="%s" value="%d"%s%s> %s</label>`+"\n",
+			fieldID(block.ID), piece, checked, hb.readonly(true), inlineMath(numberLinePiece(block.Ticks, piece)))
+	}
+	out.WriteString("</fieldset>")
+	return out.String()
+}
+
+// numberLinePiece returns the LaTeX code for the given piece,
+// see [client.NumberLineBlock]
+func numberLinePiece(ticks []string, piece int) string {
+	k := piece / 2
+	if piece%2 == 1 {
+		return `\{` + ticks[k] + `\}`
+	}
+	left, right := `-\infty`, `+\infty`
+	if k > 0 {
+		left = ticks[k-1]
+	}
+	if k < len(ticks) {
+		right = ticks[k]
+	}
+	return `]` + left + ` ; ` + right + `[`
+}
+
+// fieldNote is used for the fields requiring the app to be answered
+func fieldNote(description string) string {
+	return fmt.Sprintf(`<p class="field-note" role="note">%s (Champ interactif, à compléter dans l'application.)</p>`, description)
+}
+
+func coordToHTML(c repere.IntCoord) string {
+	return fmt.Sprintf("(%d ; %d)", c.X, c.Y)
+}
+
+// geoFieldNote describes the construction expected by [field],
+// and the given answer, if any
+func geoFieldNote(field client.GeoField, answer client.Answer) string {
+	var description string
+	switch field := field.(type) {
+	case client.GFPoint:
+		description = "Placer un point sur la figure."
+	case client.GFVector:
+		if field.AsLine {
+			description = fmt.Sprintf("Tracer la droite %s sur la figure.", inlineMath(field.LineLabel))
+		} else {
+			description = "Construire un vecteur sur la figure."
+		}
+	case client.GFVectorPair:
+		description = "Construire deux vecteurs sur la figure."
+	case client.GFCircle:
+		description = "Tracer un cercle sur la figure, en plaçant son centre puis un de ses points."
+	case client.GFSegment:
+		description = "Tracer un segment sur la figure."
+	case client.GFAngle:
+		description = "Construire un angle sur la figure, en plaçant son sommet puis ses deux côtés."
+	}
+	switch answer := answer.(type) {
+	case client.PointAnswer:
+		description += " Réponse : " + coordToHTML(answer.Point) + "."
+	case client.DoublePointAnswer:
+		description += fmt.Sprintf(" Réponse : %s, %s.", coordToHTML(answer.From), coordToHTML(answer.To))
+	case client.DoublePointPairAnswer:
+		description += fmt.Sprintf(" Réponse : %s, %s et %s, %s.", coordToHTML(answer.From1), coordToHTML(answer.To1),
+			coordToHTML(answer.From2), coordToHTML(answer.To2))
+	}
+	return description
+}
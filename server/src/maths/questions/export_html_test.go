@@ -0,0 +1,113 @@
+package questions
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestExportHTMLGolden(t *testing.T) {
+	question := client.Question{
+		Enonce: client.Enonce{
+			client.TextBlock{Parts: client.TextLine{{Text: "Soit "}, {Text: `f(x) = \frac{1}{x}`, IsMath: true}, {Text: ". Calculer "}}, Bold: true},
+			client.NumberFieldBlock{ID: 0, SizeHint: 5},
+			client.TextBlock{Parts: tl("Le mot manquant est")},
+			client.TextFieldBlock{ID: 1, SizeHint: 8},
+			client.DropDownFieldBlock{ID: 2, Proposals: []client.TextLine{tl("pair"), tl("impair")}},
+			client.FormulaBlock{Formula: `x^2 < 4`},
+			client.ExpressionFieldBlock{ID: 3, Label: "f'(x) =", SizeHint: 10},
+			client.RadioFieldBlock{ID: 4, Proposals: []client.TextLine{tl("Vrai"), tl("Faux")}},
+			client.OrderedListFieldBlock{ID: 5, Label: "x_1 < x_2", Proposals: []client.TextLine{tl("1"), tl("2")}, AnswerLength: 2},
+			client.VectorFieldBlock{ID: 6, SizeHintX: 3, SizeHintY: 3},
+			client.TableBlock{
+				HorizontalHeaders: []client.TextOrMath{{Text: "A"}, {Text: "B"}},
+				Values:            [][]client.TextOrMath{{{Text: "1"}, {Text: `\pi`, IsMath: true}}},
+			},
+			client.TableFieldBlock{ID: 7, HorizontalHeaders: []client.TextOrMath{{Text: "H"}, {Text: "F"}}, VerticalHeaders: []client.TextOrMath{{Text: "Oui"}}},
+			client.VariationTableBlock{Label: "f", Columns: []client.VariationColumnNumber{{X: "0", Y: "1", IsUp: true}, {X: "2", Y: "-1"}}, Arrows: []bool{false}},
+			client.SignTableBlock{Xs: []string{`-\infty`, "1", `+\infty`}, Functions: []client.FunctionSign{
+				{Label: "g(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.Nothing}, Signs: []bool{true, false}},
+			}},
+			client.MatchingFieldBlock{ID: 8, Left: []client.TextLine{tl("a")}, Right: []client.TextLine{tl("b"), tl("c")}},
+			client.CategoriesFieldBlock{ID: 9, Categories: []client.TextLine{tl("Pair"), tl("Impair")}, Items: []client.TextLine{tl("3"), tl("4")}},
+			client.NumberLineFieldBlock{ID: 10, Ticks: []string{"1"}},
+			client.GeometricConstructionFieldBlock{ID: 11, Field: client.GFPoint{}, Background: client.FigureBlock{
+				Figure: repere.Figure{Bounds: repere.RepereBounds{Width: 4, Height: 4, Origin: repere.Coord{X: 2, Y: 2}}},
+			}},
+			client.SetFieldBlock{ID: 12, Sets: []string{"A", "B"}},
+		},
+		Correction: client.Enonce{client.TextBlock{Parts: tl("On a <f> & g.")}},
+	}
+	answers := client.Answers{
+		0:  client.NumberAnswer{Value: 0.5},
+		1:  client.TextAnswer{Text: `"réponse"`},
+		2:  client.RadioAnswer{Index: 1},
+		3:  client.ExpressionAnswer{Expression: "-1/x^2"},
+		4:  client.RadioAnswer{Index: 0},
+		5:  client.OrderedListAnswer{Indices: []int{1, 0}},
+		6:  client.VectorNumberAnswer{X: 1, Y: -2},
+		7:  client.TableAnswer{Rows: [][]float64{{3, 4}}},
+		8:  client.MatchingAnswer{Indices: []int{1}},
+		9:  client.CategoriesAnswer{Categories: []int{1, 0}},
+		10: client.NumberLineAnswer{Pieces: []bool{true, false, false}},
+		11: client.PointAnswer{Point: repere.IntCoord{X: 1, Y: 2}},
+	}
+
+	for _, test := range []struct {
+		name    string
+		options HTMLOptions
+	}{
+		{"question", HTMLOptions{}},
+		{"question_answers", HTMLOptions{Title: "Réponses de l'élève", Answers: answers, WithCorrection: true}},
+	} {
+		got := QuestionToHTML(question, test.options)
+		tu.Assert(t, strings.HasPrefix(got, "<!DOCTYPE html>"))
+		tu.Assert(t, strings.Contains(got, "data:image/svg+xml;base64,"))
+
+		path := filepath.Join("testdata", "html", test.name+".html")
+		if *updateGolden {
+			tu.AssertNoErr(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+			tu.AssertNoErr(t, os.WriteFile(path, []byte(got), 0o644))
+			continue
+		}
+		want, err := os.ReadFile(path)
+		tu.AssertNoErr(t, err)
+		if got != string(want) {
+			t.Errorf("%s: unexpected HTML (run with -update to regenerate the golden files):\n%s", test.name, got)
+		}
+	}
+}
+
+// the fragments are well balanced and escaped
+func TestEnonceToHTML(t *testing.T) {
+	page := QuestionPage{Enonce: Enonce{
+		TextBlock{Parts: "Résoudre $x < 2$ & [[texte: oui | non]]"},
+		NumberFieldBlock{Expression: "2"},
+		RadioFieldBlock{Proposals: []Interpolated{"$a < b$", "b"}, Answer: "1"},
+		FigureBlock{Bounds: repere.RepereBounds{Width: 4, Height: 4}},
+	}}
+	instance, _, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+
+	// void elements are not closed in HTML
+	fragment := strings.NewReplacer("<br>", "<br/>").Replace(EnonceToHTML(instance.ToClient().Enonce, nil))
+	dec := xml.NewDecoder(strings.NewReader("<div>" + fragment + "</div>"))
+	dec.Strict = false
+	dec.AutoClose = []string{"input", "img"}
+	for {
+		_, err := dec.Token()
+		if err != nil {
+			tu.Assert(t, err.Error() == "EOF")
+			break
+		}
+	}
+	tu.Assert(t, strings.Contains(fragment, `\(x &lt; 2\)`))
+	tu.Assert(t, strings.Contains(fragment, `type="radio"`))
+	tu.Assert(t, strings.Count(fragment, `<input type="text"`) == 2)
+}
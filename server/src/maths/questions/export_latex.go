@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/functiongrapher"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/svg"
 	"github.com/benoitkugler/maths-online/server/src/maths/repere"
 )
 
@@ -16,7 +18,9 @@ const latexHeader = `
 \usepackage{amssymb}
 \usepackage[table]{xcolor}
 \usepackage{tikz}
+\usepackage{tkz-tab}
 \usepackage{environ}
+\usepackage{url}
 
 \definecolor{isyroPropColor}{gray}{0.9}
 
@@ -33,6 +37,11 @@ const latexHeader = `
 
 \newcommand{\isyroQCMSquare}{\raisebox{-.25\height}{\huge$\square$}}
 
+% Empty zone, used for answers which must be drawn
+\newcommand{\isyroDrawingField}[1]{
+	\fbox{\begin{minipage}[t][#1 cm]{0.9\textwidth} ~ \end{minipage}}
+}
+
 \newcommand{\R}{\mathbb{R}}
 \newcommand{\Q}{\mathbb{Q}}
 \newcommand{\D}{\mathbb{D}}
@@ -57,8 +66,50 @@ const latexHeader = `
 % ------------------- HEADER END ---------------------------- %
 `
 
+// LatexFigures collects the SVG images referenced by LaTeX code
+// built with [LatexFigures.enonceToLatex], so that they may be written
+// alongside the .tex files.
+// The documents must then be compiled with the --shell-escape option,
+// Inkscape being used by the svg package to convert the images.
+type LatexFigures struct {
+	Files map[string]string // file path -> SVG content
+
+	names map[string]string // SVG content -> file path, to share identical images
+}
+
+func NewLatexFigures() *LatexFigures {
+	return &LatexFigures{Files: map[string]string{}, names: map[string]string{}}
+}
+
+// blockToLatex uses the SVG renderer for graphical blocks,
+// and falls back to [instance.toLatex] for the others (or if [figures] is nil).
+func (figures *LatexFigures) blockToLatex(block instance) string {
+	if figures == nil {
+		return block.toLatex()
+	}
+	content, ok := svg.Render(block.toClient())
+	if !ok {
+		return block.toLatex()
+	}
+	path, has := figures.names[content]
+	if !has {
+		path = fmt.Sprintf("figures/figure-%d.svg", len(figures.Files)+1)
+		figures.names[content] = path
+		figures.Files[path] = content
+	}
+	return fmt.Sprintf(`
+	\begin{center}
+		\adjustbox{max width=0.9\textwidth}{\includesvg[inkscapelatex=false]{%s}}
+	\end{center}`, strings.TrimSuffix(path, ".svg"))
+}
+
 // If [standalone] is true, it includes a latex header
 func (qu EnonceInstance) ToLatex(standalone bool) string {
+	var figures *LatexFigures // graphical blocks are drawn with TikZ
+	return figures.enonceToLatex(qu, standalone)
+}
+
+func (figures *LatexFigures) enonceToLatex(qu EnonceInstance, standalone bool) string {
 	chunks := make([]string, len(qu))
 	// we add an extra new line between two text blocks
 	isPreviousText := false
@@ -66,9 +117,9 @@ func (qu EnonceInstance) ToLatex(standalone bool) string {
 		_, isText := p.(TextInstance)
 
 		if isPreviousText && isText {
-			chunks[i] = "\n \\vspace{0.3cm} \n" + p.toLatex()
+			chunks[i] = "\n \\vspace{0.3cm} \n" + figures.blockToLatex(p)
 		} else {
-			chunks[i] = p.toLatex()
+			chunks[i] = figures.blockToLatex(p)
 		}
 
 		isPreviousText = isText
@@ -193,8 +244,84 @@ func (ti TableInstance) toLatex() string {
 `, colDeclaration, header, strings.Join(rows, `\hline`+"\n"))
 }
 
-func (vi VariationTableInstance) toLatex() string { return "TODO" }
-func (si SignTableInstance) toLatex() string      { return "TODO" }
+// tkzTabEntry wraps [s] in braces, so that
+// the decimal commas are not read as separators by tkz-tab
+func tkzTabEntry(s string) string { return "{" + s + "}" }
+
+// requires the tkz-tab package
+func (vi VariationTableInstance) toLatex() string {
+	xs := make([]string, len(vi.Xs))
+	fxs := make([]string, len(vi.Xs))
+	for i := range vi.Xs {
+		xs[i] = tkzTabEntry("$" + vi.Xs[i].Expr.AsLaTeX() + "$")
+		position := "-"
+		if vi.inferNumberAlignment(i) {
+			position = "+"
+		}
+		fxs[i] = fmt.Sprintf("%s/ %s", position, tkzTabEntry("$"+vi.Fxs[i].Expr.AsLaTeX()+"$"))
+	}
+	return fmt.Sprintf(`
+	\begin{center}
+	\begin{tikzpicture}
+		\tkzTabInit[espcl=2]{{$x$} / 1, %s / 2}{%s}
+		\tkzTabVar{%s}
+	\end{tikzpicture}
+	\end{center}
+	`, tkzTabEntry(mathOrEmpty(vi.Label)), strings.Join(xs, ", "), strings.Join(fxs, ", "))
+}
+
+// mathOrEmpty returns [s] in inline math mode,
+// or an empty string
+func mathOrEmpty(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "$" + s + "$"
+}
+
+func signSymbolToLatex(s client.SignSymbol) string {
+	switch s {
+	case client.Zero:
+		return "z"
+	case client.ForbiddenValue:
+		return "d"
+	default:
+		return ""
+	}
+}
+
+// requires the tkz-tab package
+func (si SignTableInstance) toLatex() string {
+	xs := make([]string, len(si.Xs))
+	for i, x := range si.Xs {
+		xs[i] = tkzTabEntry("$" + x.AsLaTeX() + "$")
+	}
+	labels := []string{"{$x$} / 1"}
+	var lines []string
+	for _, fn := range si.Functions {
+		labels = append(labels, tkzTabEntry(mathOrEmpty(fn.Label))+" / 1")
+		var cells []string
+		for j, symbol := range fn.FxSymbols {
+			cells = append(cells, signSymbolToLatex(symbol))
+			if j < len(fn.Signs) {
+				sign := "-"
+				if fn.Signs[j] {
+					sign = "+"
+				}
+				cells = append(cells, sign)
+			}
+		}
+		lines = append(lines, fmt.Sprintf(`\tkzTabLine{%s}`, strings.Join(cells, ", ")))
+	}
+	return fmt.Sprintf(`
+	\begin{center}
+	\begin{tikzpicture}
+		\tkzTabInit[espcl=2]{%s}{%s}
+		%s
+	\end{tikzpicture}
+	\end{center}
+	`, strings.Join(labels, ", "), strings.Join(xs, ", "), strings.Join(lines, "\n\t\t"))
+}
 
 // return color and opacity
 func tikzColorArg(c repere.ColorHex) (string, string) {
@@ -280,6 +407,12 @@ func (fi FigureInstance) toLatex() string {
 		drawings = append(drawings, code)
 	}
 
+	return tikzRepere(fi.Figure.Bounds, gridColor, origin, strings.Join(drawings, "\n"))
+}
+
+// tikzRepere returns a picture scaled to the page width, with a grid and axes.
+// [content] must use coordinates translated by the origin of [bounds].
+func tikzRepere(bounds repere.RepereBounds, gridColor, origin, content string) string {
 	return fmt.Sprintf(`
 	\begin{scaletikzpicturetowidth}{\textwidth}
 	\begin{tikzpicture}[scale=\tikzscale]
@@ -292,14 +425,86 @@ func (fi FigureInstance) toLatex() string {
 	\end{tikzpicture}
 	\end{scaletikzpicturetowidth}
 	`, gridColor,
-		fi.Figure.Bounds.Width, fi.Figure.Bounds.Height,
-		fi.Figure.Bounds.Origin.X, fi.Figure.Bounds.Origin.Y,
+		bounds.Width, bounds.Height,
+		bounds.Origin.X, bounds.Origin.Y,
 		origin,
-		strings.Join(drawings, "\n"),
+		content,
 	)
 }
 
-func (fi FunctionsGraphInstance) toLatex() string { return "TODO" }
+// curveColor returns the TikZ color and opacity for a function,
+// defaulting to black
+func curveColor(color string) (string, string) {
+	if color == "" {
+		return "black", "1.00"
+	}
+	return tikzColorArg(repere.ColorHex(color))
+}
+
+// bezierPath returns a TikZ path following [curves], which are
+// assumed to be consecutive.
+// Quadratic curves are converted to the cubic ones used by TikZ.
+func bezierPath(curves []functiongrapher.BezierCurve, origin repere.Coord) string {
+	if len(curves) == 0 {
+		return ""
+	}
+	point := func(p repere.Coord) string {
+		return fmt.Sprintf("(%.02f,%.02f)", p.X+origin.X, p.Y+origin.Y)
+	}
+	chunks := []string{point(curves[0].P0)}
+	for _, curve := range curves {
+		c1 := repere.Coord{X: curve.P0.X + 2./3*(curve.P1.X-curve.P0.X), Y: curve.P0.Y + 2./3*(curve.P1.Y-curve.P0.Y)}
+		c2 := repere.Coord{X: curve.P2.X + 2./3*(curve.P1.X-curve.P2.X), Y: curve.P2.Y + 2./3*(curve.P1.Y-curve.P2.Y)}
+		chunks = append(chunks, fmt.Sprintf(".. controls %s and %s .. %s", point(c1), point(c2), point(curve.P2)))
+	}
+	return strings.Join(chunks, " ")
+}
+
+func functionsGraphToLatex(fg client.FunctionsGraphBlock) string {
+	origin := fg.Bounds.Origin
+	var drawings []string
+	for _, area := range fg.Areas {
+		color, opacity := tikzColorArg(area.Color)
+		drawings = append(drawings, fmt.Sprintf(`\fill[color=%s, opacity=%s] %s -- cycle;`,
+			color, opacity, bezierPath(area.Path, origin)))
+	}
+	for _, fn := range fg.Functions {
+		if len(fn.Segments) == 0 {
+			continue
+		}
+		color, opacity := curveColor(fn.Decoration.Color)
+		// the label is placed at the end of the curve
+		drawings = append(drawings, fmt.Sprintf(`\draw[thick, color=%s, opacity=%s] %s node[anchor=south west] {$%s$};`,
+			color, opacity, bezierPath(fn.Segments, origin), fn.Decoration.Label))
+	}
+	for _, seq := range fg.Sequences {
+		color, opacity := curveColor(seq.Decoration.Color)
+		for i, p := range seq.Points {
+			label := ""
+			if i == len(seq.Points)-1 {
+				label = "$" + seq.Decoration.Label + "$"
+			}
+			drawings = append(drawings, fmt.Sprintf(`\filldraw[color=%s, opacity=%s] (%.02f,%.02f) circle (2pt) node[anchor=south west] {%s};`,
+				color, opacity, p.X+origin.X, p.Y+origin.Y, label))
+		}
+	}
+	for _, point := range fg.Points {
+		color, opacity := tikzColorArg(point.Color)
+		drawings = append(drawings, fmt.Sprintf(`\filldraw[color=%s, opacity=%s] (%.02f,%.02f) circle (3pt) node[anchor=south west] {$%s$};`,
+			color, opacity, point.Coord.X+origin.X, point.Coord.Y+origin.Y, point.Legend))
+	}
+
+	// curves may go far outside the figure (asymptotes)
+	content := fmt.Sprintf(`\begin{scope}
+		\clip (-0.25,-0.25) rectangle (%d.25,%d.25);
+		%s
+		\end{scope}`, fg.Bounds.Width, fg.Bounds.Height, strings.Join(drawings, "\n\t\t"))
+	return tikzRepere(fg.Bounds, "gray", "", content)
+}
+
+func (fi FunctionsGraphInstance) toLatex() string {
+	return functionsGraphToLatex(fi.toClientG())
+}
 
 func (ni NumberFieldInstance) toLatex() string { return `\isyroNumberField` }
 
@@ -313,6 +518,12 @@ func (ei ExpressionFieldInstance) toLatex() string {
 	return fmt.Sprintf(`\isyroExpressionField{%.2f}`, cm)
 }
 
+func (tf TextFieldInstance) toLatex() string {
+	// map from 5 - 40 to 3cm - 13cm
+	cm := 3. + (13-3)*float64(tf.sizeHint()-5)/(40-5)
+	return fmt.Sprintf(`\isyroExpressionField{%.2f}`, cm)
+}
+
 // requires the following latex packages
 //   - \usepackage[inline]{enumitem}
 //   - \usepackage{amssymb}
@@ -367,13 +578,323 @@ func (pi VectorFieldInstance) toLatex() string {
 	}
 }
 
-func (fi GeometricConstructionFieldInstance) toLatex() string { return "TODO" }
-func (vi VariationTableFieldInstance) toLatex() string        { return "TODO" }
-func (si SignTableFieldInstance) toLatex() string             { return "TODO" }
-func (fi FunctionPointsFieldInstance) toLatex() string        { return "TODO" }
-func (ti TreeInstance) toLatex() string                       { return "TODO" }
-func (ti TreeFieldInstance) toLatex() string                  { return "TODO" }
-func (pi ProofFieldInstance) toLatex() string                 { return "TODO" }
-func (pi TableFieldInstance) toLatex() string                 { return "TODO" }
-func (pi SetFieldInstance) toLatex() string                   { return "TODO" }
-func (pi ImageInstance) toLatex() string                      { return "TODO" }
+func (fi GeometricConstructionFieldInstance) toLatex() string {
+	// the student draws on the background
+	switch bg := fi.Background.(type) {
+	case client.FigureBlock:
+		return FigureInstance(bg).toLatex()
+	case client.FunctionsGraphBlock:
+		return functionsGraphToLatex(bg)
+	default:
+		return `\isyroDrawingField{6}`
+	}
+}
+
+// emptyRows returns table rows with a label and an empty cell of the given height
+func emptyRows(labels []string, height string) string {
+	rows := make([]string, len(labels))
+	for i, label := range labels {
+		rows[i] = fmt.Sprintf(`%s & \rule{0pt}{%s} \\`, label, height)
+	}
+	return strings.Join(rows, "\n\t\t\\hline\n\t\t")
+}
+
+func (vi VariationTableFieldInstance) toLatex() string {
+	return fmt.Sprintf(`
+	\begin{center}
+		\begin{tabular}{| c | p{0.7\textwidth} |}
+		\hline
+		%s
+		\hline
+		\end{tabular}
+	\end{center}
+	`, emptyRows([]string{"$x$", mathOrEmpty(vi.Answer.Label)}, "1cm"))
+}
+
+func (si SignTableFieldInstance) toLatex() string {
+	labels := []string{"$x$"}
+	for _, fn := range si.Answer.Functions {
+		labels = append(labels, mathOrEmpty(fn.Label))
+	}
+	return fmt.Sprintf(`
+	\begin{center}
+		\begin{tabular}{| c | p{0.7\textwidth} |}
+		\hline
+		%s
+		\hline
+		\end{tabular}
+	\end{center}
+	`, emptyRows(labels, "0.7cm"))
+}
+
+func (fi FunctionPointsFieldInstance) toLatex() string {
+	block := fi.toClient().(client.FunctionPointsFieldBlock)
+	origin := block.Bounds.Origin
+	// highlight the abscissas of the points to place
+	lines := make([]string, len(block.Xs))
+	for i, x := range block.Xs {
+		lines[i] = fmt.Sprintf(`\draw[dashed, blue] (%.02f,-0.25) -- (%.02f,%d.25) node[anchor=south] {\small $%d$};`,
+			float64(x)+origin.X, float64(x)+origin.X, block.Bounds.Height, x)
+	}
+	return tikzRepere(block.Bounds, "gray", "", strings.Join(lines, "\n\t\t"))
+}
+
+// treeNodeToLatex returns the TikZ children of [node]
+func (ti TreeInstance) treeNodeToLatex(node TreeNodeInstance) string {
+	children := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		event := ""
+		if child.Value >= 0 && child.Value < len(ti.EventsProposals) {
+			event = lineToLatexCode(ti.EventsProposals[child.Value])
+		}
+		children[i] = fmt.Sprintf(`child { node {%s} %s edge from parent node[midway, fill=white] {$%s$} }`,
+			event, ti.treeNodeToLatex(child), node.Probabilities[i].AsLaTeX())
+	}
+	return strings.Join(children, " ")
+}
+
+// requires the tikz package
+func (ti TreeInstance) toLatex() string {
+	// adjust the space between siblings to the number of leaves
+	treeShape := shape(ti.AnswerRoot)
+	levels := make([]string, len(treeShape))
+	for level := range treeShape {
+		leaves := 1
+		for _, width := range treeShape[level+1:] {
+			leaves *= width
+		}
+		levels[level] = fmt.Sprintf(`level %d/.style={sibling distance=%dcm}`, level+1, leaves)
+	}
+	return fmt.Sprintf(`
+	\begin{center}
+	\begin{tikzpicture}[grow=right, level distance=3cm, %s]
+		\node {$\bullet$} %s;
+	\end{tikzpicture}
+	\end{center}
+	`, strings.Join(levels, ", "), ti.treeNodeToLatex(ti.AnswerRoot))
+}
+
+func proposalsToLatex(props []client.TextLine) string {
+	choices := make([]string, len(props))
+	for i, p := range props {
+		choices[i] = fmt.Sprintf(`\colorbox{isyroPropColor}{%s}`, lineToLatexCode(p))
+	}
+	return strings.Join(choices, " , ")
+}
+
+func (ti TreeFieldInstance) toLatex() string {
+	return fmt.Sprintf(`~\\ \begin{center} \isyroDrawingField{6} \\
+	\vspace{0.5em}
+	\textit{\small \'Evénements :} %s
+	\end{center}
+	`, proposalsToLatex(ti.Answer.EventsProposals))
+}
+
+// proofToLatex returns the LaTeX code for [assertion] : if [showContent] is false,
+// the terms are replaced by empty fields.
+func proofToLatex(assertion client.Assertion, showContent bool) string {
+	term := func(line client.TextLine, width string) string {
+		if showContent {
+			return lineToLatexCode(line)
+		}
+		return `\isyroExpressionField{` + width + `}`
+	}
+	switch assertion := assertion.(type) {
+	case client.Statement:
+		return term(assertion.Content, "6")
+	case client.Equality:
+		terms := make([]string, len(assertion.Terms))
+		for i, t := range assertion.Terms {
+			terms[i] = term(t, "2.5")
+		}
+		out := strings.Join(terms, " $=$ ")
+		if assertion.WithDef {
+			out += ` avec ` + term(assertion.Def, "2.5")
+		}
+		return out
+	case client.Node:
+		op := "et"
+		if assertion.Op == client.Or {
+			op = "ou"
+		}
+		return fmt.Sprintf(`%s \textbf{%s} %s`, proofToLatex(assertion.Left, showContent), op, proofToLatex(assertion.Right, showContent))
+	case client.Sequence:
+		parts := make([]string, len(assertion.Parts))
+		for i, part := range assertion.Parts {
+			parts[i] = `\item ` + proofToLatex(part, showContent)
+			if i != 0 {
+				parts[i] = `\item \textbf{donc} ` + proofToLatex(part, showContent)
+			}
+		}
+		return fmt.Sprintf(`\begin{itemize}[label={}]
+		%s
+	\end{itemize}`, strings.Join(parts, "\n\t\t"))
+	default:
+		return ""
+	}
+}
+
+// requires the following latex packages
+//   - \usepackage[inline]{enumitem}
+func (pi ProofFieldInstance) toLatex() string {
+	return fmt.Sprintf(`%s
+	\textit{\small \'Eléments à utiliser :} %s
+	`, proofToLatex(pi.shape().Root, false), proposalsToLatex(pi.termProposals()))
+}
+
+func (pi TableFieldInstance) toLatex() string {
+	values := make([][]client.TextOrMath, len(pi.Answer.Rows))
+	for i, row := range pi.Answer.Rows {
+		values[i] = make([]client.TextOrMath, len(row))
+		for j := range row {
+			values[i][j] = client.TextOrMath{Text: `\isyroFieldHeight \hspace{1.5cm}`}
+		}
+	}
+	return TableInstance{
+		HorizontalHeaders: pi.HorizontalHeaders,
+		VerticalHeaders:   pi.VerticalHeaders,
+		Values:            values,
+	}.toLatex()
+}
+
+func (pi SetFieldInstance) toLatex() string {
+	sets := make([]string, len(pi.Answer.Sets))
+	for i, set := range pi.Answer.Sets {
+		sets[i] = mathOrEmpty(set)
+	}
+	return fmt.Sprintf(`~\\ \begin{center} \isyroExpressionField{10} \\
+	\vspace{0.5em}
+	\textit{\small Ensembles :} %s \textit{\small (opérations : $\cup$, $\cap$, $\overline{A}$)}
+	\end{center}
+	`, strings.Join(sets, " , "))
+}
+
+// requires the url package
+// Images are not downloaded, a link is displayed instead.
+func (pi ImageInstance) toLatex() string {
+	return fmt.Sprintf(`
+	\begin{center}
+		\fbox{Image : \url{%s}}
+	\end{center}
+	`, pi.URL)
+}
+
+// the student draws lines between the two columns
+func (mi MatchingFieldInstance) toLatex() string {
+	left, right := mi.Left, mi.rightItems()
+	rows := make([]string, max(len(left), len(right)))
+	for i := range rows {
+		var l, r string
+		if i < len(left) {
+			l = lineToLatexCode(left[i]) + ` \hspace{0.5em} $\bullet$`
+		}
+		if i < len(right) {
+			r = `$\bullet$ \hspace{0.5em} ` + lineToLatexCode(right[i])
+		}
+		rows[i] = l + " & " + r + ` \\`
+	}
+	return fmt.Sprintf(`~\\ \begin{center}
+	\begin{tabular}{r@{\hspace{3cm}}l}
+	%s
+	\end{tabular}
+	\end{center}
+	`, strings.Join(rows, "\n\t"))
+}
+
+// the student writes the items in the columns
+func (ci CategoriesFieldInstance) toLatex() string {
+	items, _ := ci.shuffledItems()
+	headers := make([]string, len(ci.Categories))
+	for i, category := range ci.Categories {
+		headers[i] = lineToLatexCode(category)
+	}
+	cells := strings.Repeat(" & ", len(ci.Categories)-1)
+	return fmt.Sprintf(`~\\ \begin{center}
+	\begin{tabular}{|%s}
+	\hline
+	%s \\
+	\hline
+	%s \\[3cm]
+	\hline
+	\end{tabular} \\
+	\vspace{0.5em}
+	\textit{\small \'Eléments à classer :} %s
+	\end{center}
+	`, strings.Repeat("p{3cm}|", len(ci.Categories)), strings.Join(headers, " & "), cells, proposalsToLatex(items))
+}
+
+// numberLineToLatex draws an axis with equally spaced [ticks],
+// and, if [pieces] is not nil, the corresponding subset
+// using the french brackets notation.
+func numberLineToLatex(ticks []string, pieces []bool) string {
+	const step = 1.5 // cm
+	n := len(ticks)
+	width := step * float64(n+1)
+	tickX := func(k int) float64 { return step * float64(k+1) }
+
+	var lines []string
+	for piece, isIn := range pieces {
+		if !isIn {
+			continue
+		}
+		k := piece / 2
+		if piece%2 == 0 { // segment before the tick k
+			start, end := 0., width
+			if k > 0 {
+				start = tickX(k - 1)
+			}
+			if k < n {
+				end = tickX(k)
+			}
+			lines = append(lines, fmt.Sprintf(`\draw[line width=3pt, blue, opacity=0.5] (%.02f,0) -- (%.02f,0);`, start, end))
+		}
+	}
+	for k := 0; pieces != nil && k < n; k++ {
+		isIn, before, after := pieces[2*k+1], pieces[2*k], pieces[2*k+2]
+		x := tickX(k)
+		var bracket string
+		switch {
+		case before && after:
+			if !isIn { // excluded point
+				lines = append(lines, fmt.Sprintf(`\draw[blue, fill=white] (%.02f,0) circle (3pt);`, x))
+			}
+		case after: // start of an interval
+			bracket = "["
+			if !isIn {
+				bracket = "]"
+			}
+		case before: // end of an interval
+			bracket = "]"
+			if !isIn {
+				bracket = "["
+			}
+		case isIn: // isolated point
+			lines = append(lines, fmt.Sprintf(`\fill[blue] (%.02f,0) circle (3pt);`, x))
+		}
+		if bracket != "" {
+			lines = append(lines, fmt.Sprintf(`\node[blue] at (%.02f,0) {\Large $%s$};`, x, bracket))
+		}
+	}
+	for k, tick := range ticks {
+		x := tickX(k)
+		lines = append(lines, fmt.Sprintf(`\draw (%.02f,0.1) -- (%.02f,-0.1) node[below=2pt] {$%s$};`, x, x, tick))
+	}
+
+	return fmt.Sprintf(`
+	\begin{center}
+	\begin{tikzpicture}
+		\draw[->] (0,0) -- (%.02f,0);
+		%s
+	\end{tikzpicture}
+	\end{center}
+	`, width, strings.Join(lines, "\n\t\t"))
+}
+
+func (ni NumberLineInstance) toLatex() string {
+	return numberLineToLatex(ni.tickLabels(), ni.pieces())
+}
+
+// the student draws the set on an empty line
+func (nf NumberLineFieldInstance) toLatex() string {
+	return numberLineToLatex(nf.Answer.tickLabels(), nil)
+}
@@ -0,0 +1,254 @@
+package questions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/expression/sets"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+)
+
+// this file implements the LaTeX export of the expected answers,
+// used to build answer keys for printed papers
+
+// AnswerKeyToLatex returns LaTeX code listing the expected answer of each
+// field (in display order), followed by the correction, if any.
+// It requires the same header as [EnonceInstance.ToLatex].
+func (qu QuestionInstance) AnswerKeyToLatex() string {
+	var figures *LatexFigures // graphical blocks are drawn with TikZ
+	return figures.answerKeyToLatex(qu)
+}
+
+func (figures *LatexFigures) answerKeyToLatex(qu QuestionInstance) string {
+	var fields []fieldInstance
+	for _, block := range qu.Enonce {
+		if field, isField := block.(fieldInstance); isField {
+			fields = append(fields, field)
+		}
+	}
+
+	var chunks []string
+	if len(fields) != 0 {
+		items := make([]string, len(fields))
+		for i, field := range fields {
+			items[i] = `\item ` + correctAnswerToLatex(field)
+		}
+		chunks = append(chunks, fmt.Sprintf(`\textbf{Réponses attendues :}
+	\begin{enumerate}[label=\alph*)]
+	%s
+	\end{enumerate}`, strings.Join(items, "\n\t")))
+	}
+	if len(qu.Correction) != 0 {
+		chunks = append(chunks, `\textbf{Correction :} `+figures.enonceToLatex(qu.Correction, false))
+	}
+	if len(chunks) == 0 {
+		return `\textit{Aucune réponse attendue.}`
+	}
+	return strings.Join(chunks, "\n\n\t")
+}
+
+// correctAnswerToLatex returns LaTeX code displaying the
+// expected answer of [field].
+func correctAnswerToLatex(field fieldInstance) string {
+	switch field := field.(type) {
+	case NumberFieldInstance:
+		return numberToLatex(field.Answer)
+	case ExpressionFieldInstance:
+		return fmt.Sprintf("$%s %s$", field.LabelLaTeX, field.Answer.AsLaTeX())
+	case RadioFieldInstance:
+		return lineToLatexCode(field.Proposals[field.Answer-1])
+	case DropDownFieldInstance:
+		return lineToLatexCode(field.Proposals[field.Answer-1])
+	case OrderedListFieldInstance:
+		items := make([]string, len(field.Answer))
+		for i, item := range field.Answer {
+			items[i] = fmt.Sprintf(`\colorbox{isyroPropColor}{%s}`, lineToLatexCode(item))
+		}
+		return strings.TrimSpace(mathOrEmpty(field.Label) + " " + strings.Join(items, " "))
+	case GeometricConstructionFieldInstance:
+		return geoFieldToLatex(field.Field)
+	case VariationTableFieldInstance:
+		return field.Answer.toLatex()
+	case SignTableFieldInstance:
+		return field.Answer.toLatex()
+	case FunctionPointsFieldInstance:
+		fxs := field.correctAnswer().(client.FunctionPointsAnswer).Fxs
+		points := make([]string, len(fxs))
+		for i, fx := range fxs {
+			points[i] = fmt.Sprintf("$%s(%d) = %d$", field.Label, field.XGrid[i], fx)
+		}
+		return strings.Join(points, " ; ")
+	case TreeFieldInstance:
+		return field.Answer.toLatex()
+	case ProofFieldInstance:
+		return proofToLatex(field.Answer.toClient(), true)
+	case TableFieldInstance:
+		values := make([][]client.TextOrMath, len(field.Answer.Rows))
+		for i, row := range field.Answer.Rows {
+			values[i] = make([]client.TextOrMath, len(row))
+			for j, v := range row {
+				values[i][j] = client.TextOrMath{Text: expression.NewNb(v).AsLaTeX(), IsMath: true}
+			}
+		}
+		return TableInstance{
+			HorizontalHeaders: field.HorizontalHeaders,
+			VerticalHeaders:   field.VerticalHeaders,
+			Values:            values,
+		}.toLatex()
+	case VectorFieldInstance:
+		x, y := expression.NewNb(field.Answer.X).AsLaTeX(), expression.NewNb(field.Answer.Y).AsLaTeX()
+		if field.DisplayColumn {
+			return fmt.Sprintf(`$\begin{pmatrix} %s \\ %s \end{pmatrix}$`, x, y)
+		}
+		return fmt.Sprintf("$(%s ; %s)$", x, y)
+	case SetFieldInstance:
+		return "$" + setToLatex(field.Answer.Root, field.Answer.Sets) + "$"
+	case TextFieldInstance:
+		return field.Answers[0]
+	case MatchingFieldInstance:
+		pairs := make([]string, len(field.Left))
+		for i, left := range field.Left {
+			pairs[i] = lineToLatexCode(left) + ` $\longrightarrow$ ` + lineToLatexCode(field.Right[i])
+		}
+		return strings.Join(pairs, " ; ")
+	case CategoriesFieldInstance:
+		categories := make([]string, len(field.Categories))
+		for i, category := range field.Categories {
+			categories[i] = fmt.Sprintf(`\textbf{%s} : %s`, lineToLatexCode(category), proposalsToLatex(field.Items[i]))
+		}
+		return strings.Join(categories, " ; ")
+	case NumberLineFieldInstance:
+		return field.Answer.toLatex()
+	default:
+		return ""
+	}
+}
+
+func numberToLatex(v float64) string { return "$" + expression.NewNb(v).AsLaTeX() + "$" }
+
+func coordToLatex(c repere.IntCoord) string { return fmt.Sprintf("$(%d ; %d)$", c.X, c.Y) }
+
+func geoFieldToLatex(field geoFieldInstance) string {
+	switch field := field.(type) {
+	case gfPoint:
+		return "Point " + coordToLatex(repere.IntCoord(field))
+	case gfVector:
+		out := "Vecteur de coordonnées " + coordToLatex(field.Answer)
+		if field.MustHaveOrigin {
+			out += ", d'origine " + coordToLatex(field.AnswerOrigin)
+		}
+		return out
+	case gfAffineLine:
+		if field.isAnswerVertical() {
+			return fmt.Sprintf("Droite d'équation $x = %d$", field.AnswerB)
+		}
+		expr := expression.NewNb(field.AnswerA)
+		expr = expression.MustParse(fmt.Sprintf("(%s)x + (%d)", expr, field.AnswerB))
+		expr.DefaultSimplify()
+		return fmt.Sprintf("Droite d'équation $y = %s$", expr.AsLaTeX())
+	case gfVectorPair:
+		switch VectorPairCriterion(field) {
+		case VectorEquals:
+			return "Deux vecteurs égaux"
+		case VectorColinear:
+			return "Deux vecteurs colinéaires"
+		case VectorOrthogonal:
+			return "Deux vecteurs orthogonaux"
+		}
+	case gfCircle:
+		return fmt.Sprintf("Cercle de centre %s et de rayon %s", coordToLatex(field.Center), "$"+field.Radius.Expr.AsLaTeX()+"$")
+	case gfSegment:
+		from, to := coordToLatex(field.From), coordToLatex(field.To)
+		if field.LabelFrom != "" {
+			from = fmt.Sprintf("$%s$%s", field.LabelFrom, from)
+		}
+		if field.LabelTo != "" {
+			to = fmt.Sprintf("$%s$%s", field.LabelTo, to)
+		}
+		return fmt.Sprintf("Segment d'extrémités %s et %s", from, to)
+	case gfLine:
+		return fmt.Sprintf("Droite passant par %s, de vecteur directeur %s", coordToLatex(field.Through), coordToLatex(field.Direction))
+	case gfPointOnCurve:
+		point := field.correctAnswer(repere.RepereBounds{}).(client.PointAnswer).Point
+		return fmt.Sprintf("Point de la courbe de $%s$, par exemple %s", field.Function.AsLaTeX(), coordToLatex(point))
+	case gfAngle:
+		out := fmt.Sprintf(`Angle de mesure %s$^{\circ}$`, numberToLatex(field.Measure))
+		if field.MustHaveVertex {
+			out += ", de sommet " + coordToLatex(field.Vertex)
+		}
+		return out
+	}
+	return ""
+}
+
+// setToLatex returns the LaTeX code for [node], whose leaves
+// are indices into [names]
+func setToLatex(node sets.BinNode, names []string) string {
+	// wrap the binary operations in parenthesis
+	arg := func(n sets.BinNode) string {
+		switch n.(type) {
+		case sets.Union, sets.Inter:
+			return `\left(` + setToLatex(n, names) + `\right)`
+		default:
+			return setToLatex(n, names)
+		}
+	}
+	switch node := node.(type) {
+	case sets.Set:
+		return names[node]
+	case sets.Union:
+		return arg(node.Left) + ` \cup ` + arg(node.Right)
+	case sets.Inter:
+		return arg(node.Left) + ` \cap ` + arg(node.Right)
+	case sets.Complement:
+		return `\overline{` + setToLatex(node.Right, names) + `}`
+	default:
+		return ""
+	}
+}
+
+// PaperCopyLabel returns the name of the [index]-th copy
+// of a paper test : A, B, ..., Z, AA, AB, ...
+func PaperCopyLabel(index int) string {
+	label := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		label = string(rune('A'+(index-1)%26)) + label
+	}
+	return label
+}
+
+// PaperDocument returns a standalone LaTeX document, with the given [title],
+// listing the [questions] in a numbered list.
+// If [answerKey] is true, the expected answers and corrections are displayed
+// after each question.
+// The graphical blocks are rendered as SVG images, added to [figures].
+func PaperDocument(title string, questions []QuestionInstance, answerKey bool, figures *LatexFigures) string {
+	items := make([]string, len(questions))
+	for i, qu := range questions {
+		items[i] = `\item ` + figures.enonceToLatex(qu.Enonce, false)
+		if answerKey {
+			items[i] += "\n\n\t\\vspace{0.3cm}\n\t" + figures.answerKeyToLatex(qu)
+		}
+	}
+	return fmt.Sprintf(`%% À compiler avec l'option --shell-escape (Inkscape est requis pour les figures)
+\documentclass{article}
+
+\usepackage{fullpage}
+\usepackage[utf8]{inputenc}
+\usepackage{svg}
+\usepackage{adjustbox}
+%s
+
+\begin{document}
+	\begin{center}
+		\Large{\textbf{%s}}
+	\end{center}
+
+	\begin{enumerate}
+	%s
+	\end{enumerate}
+\end{document}
+`, latexHeader, title, strings.Join(items, "\n\n\t\\vspace{0.5cm}\n\t"))
+}
@@ -0,0 +1,123 @@
+package questions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestAnswerKeyToLatex(t *testing.T) {
+	page := QuestionPage{
+		Enonce: Enonce{
+			TextBlock{Parts: "Calculer N = "},
+			NumberFieldBlock{Expression: "2.5"},
+			ExpressionFieldBlock{Label: "A = ", Expression: "2x + 7"},
+			RadioFieldBlock{Answer: "2", Proposals: []Interpolated{"Vrai", "Faux"}},
+			RadioFieldBlock{Answer: "1", Proposals: []Interpolated{"oui", "non"}, AsDropDown: true},
+			OrderedListFieldBlock{Answer: []Interpolated{"$1$", "$2$"}, AdditionalProposals: []Interpolated{"$3$"}},
+			VectorFieldBlock{Answer: CoordExpression{X: "1", Y: "-2"}},
+			GeometricConstructionFieldBlock{Field: GFAffineLine{Label: "d", A: "-2", B: "3"}, Background: FigureBlock{ShowGrid: true}},
+			GeometricConstructionFieldBlock{Field: GFPoint{Answer: CoordExpression{X: "2", Y: "-1"}}, Background: FigureBlock{ShowGrid: true}},
+			GeometricConstructionFieldBlock{Field: GFCircle{Center: CoordExpression{X: "1", Y: "1"}, Radius: "sqrt(2)"}, Background: FigureBlock{ShowGrid: true}},
+			GeometricConstructionFieldBlock{Field: GFAngle{Measure: "45"}, Background: FigureBlock{ShowGrid: true}},
+			SetFieldBlock{Answer: "(A ∪ B) ∩ ¬C"},
+			TextFieldBlock{Answers: []string{"Paris", "paris"}},
+			MatchingFieldBlock{Left: []Interpolated{"A", "B"}, Right: []Interpolated{"1", "2"}},
+			CategoriesFieldBlock{Categories: []Interpolated{"Pair", "Impair"}, Items: [][]Interpolated{{"$2$"}, {"$3$"}}},
+			NumberLineFieldBlock{Answer: NumberLineBlock{Set: "[1;3["}},
+			ProofFieldBlock{Answer: ProofSequence{Parts: ProofAssertions{
+				ProofStatement{Content: "$n$ est pair"},
+				ProofEquality{Terms: "n^2 = 4k^2"},
+			}}},
+			TableFieldBlock{
+				HorizontalHeaders: []TextPart{tt("A"), tt("B")},
+				Answer:            [][]string{{"1", "2.5"}},
+			},
+		},
+		Correction: Enonce{TextBlock{Parts: "On calcule $N = 2.5$."}},
+	}
+	instance, _, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+
+	code := instance.AnswerKeyToLatex()
+	for _, expected := range []string{
+		"Réponses attendues",
+		"$2,5$",
+		"A = ",
+		`\item Faux`,
+		`\item oui`,
+		`\colorbox{isyroPropColor}{$ 1 $} \colorbox{isyroPropColor}{$ 2 $}`,
+		"$(1 ; -2)$",
+		"Droite d'équation $y = -2 x + 3$",
+		"Point $(2 ; -1)$",
+		`Cercle de centre $(1 ; 1)$ et de rayon $\sqrt{2}$`,
+		`Angle de mesure $45$$^{\circ}$`,
+		`\left(A \cup B\right) \cap \overline{C}`,
+		`\item Paris`,
+		`A $\longrightarrow$ 1 ; B $\longrightarrow$ 2`,
+		`\textbf{Pair} : \colorbox{isyroPropColor}{$ 2 $}`,
+		`\node[blue] at (1.50,0) {\Large $[$};`,
+		`\textbf{donc}`,
+		`$ n^2 $ $=$ $ 4k^2 $`,
+		"Correction :",
+	} {
+		tu.Assert(t, strings.Contains(code, expected))
+	}
+
+	// no fields nor correction
+	empty := QuestionInstance{Enonce: EnonceInstance{TextInstance{Parts: client.TextLine{{Text: "Lire"}}}}}
+	tu.Assert(t, strings.Contains(empty.AnswerKeyToLatex(), "Aucune réponse"))
+}
+
+func TestPaperCopyLabel(t *testing.T) {
+	for i, expected := range map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 52: "BA"} {
+		tu.Assert(t, PaperCopyLabel(i) == expected)
+	}
+}
+
+func TestPaperDocument(t *testing.T) {
+	page := QuestionPage{Enonce: Enonce{NumberFieldBlock{Expression: "4"}}}
+	instance, _, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+
+	figures := NewLatexFigures()
+	paper := PaperDocument("Sujet A", []QuestionInstance{instance, instance}, false, figures)
+	tu.Assert(t, strings.Contains(paper, `\documentclass{article}`))
+	tu.Assert(t, strings.Count(paper, "-- Question --") == 2)
+	tu.Assert(t, !strings.Contains(paper, "Réponses attendues"))
+	tu.Assert(t, len(figures.Files) == 0)
+
+	key := PaperDocument("Corrigé A", []QuestionInstance{instance}, true, figures)
+	tu.Assert(t, strings.Contains(key, "Réponses attendues"))
+}
+
+func TestPaperDocumentFigures(t *testing.T) {
+	page := QuestionPage{
+		Enonce: Enonce{
+			FigureBlock{ShowGrid: true, Bounds: repere.RepereBounds{Width: 10, Height: 10}},
+			NumberFieldBlock{Expression: "4"},
+		},
+		Correction: Enonce{FigureBlock{ShowGrid: true, Bounds: repere.RepereBounds{Width: 10, Height: 10}}},
+	}
+	instance, _, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+
+	figures := NewLatexFigures()
+	paper := PaperDocument("Sujet A", []QuestionInstance{instance}, false, figures)
+	tu.Assert(t, strings.Contains(paper, `\usepackage{svg}`))
+	tu.Assert(t, strings.Contains(paper, `\includesvg[inkscapelatex=false]{figures/figure-1}`))
+	tu.Assert(t, !strings.Contains(paper, `\begin{tikzpicture}`))
+	tu.Assert(t, len(figures.Files) == 1)
+	tu.Assert(t, strings.HasPrefix(figures.Files["figures/figure-1.svg"], "<svg"))
+
+	// identical figures are shared
+	key := PaperDocument("Corrigé A", []QuestionInstance{instance}, true, figures)
+	tu.Assert(t, strings.Count(key, "figures/figure-1") == 2)
+	tu.Assert(t, len(figures.Files) == 1)
+
+	// the plain LaTeX export still uses TikZ
+	tu.Assert(t, strings.Contains(instance.Enonce.ToLatex(false), `\begin{tikzpicture}`))
+}
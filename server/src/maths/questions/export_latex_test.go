@@ -1,8 +1,14 @@
 package questions
 
 import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	"github.com/benoitkugler/maths-online/server/src/maths/repere"
 	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
 )
@@ -53,6 +59,15 @@ func TestExportLatex(t *testing.T) {
 		OrderedListFieldBlock{Label: `$x \in $`, Answer: []Interpolated{"A", "$x+2$", "B"}, AdditionalProposals: []Interpolated{"C"}},
 		VectorFieldBlock{DisplayColumn: true, Answer: dummyCoord},
 		VectorFieldBlock{DisplayColumn: false, Answer: dummyCoord},
+		TextBlock{Parts: "Qui a écrit Les Misérables ?"},
+		TextFieldBlock{Answers: []string{"Victor Hugo"}},
+		TextBlock{Parts: "Associer chaque fonction à sa dérivée."},
+		MatchingFieldBlock{Left: []Interpolated{"$x^2$", "$e^x$"}, Right: []Interpolated{"$2x$", "$e^x$"}, AdditionalRight: []Interpolated{"$x$"}},
+		TextBlock{Parts: "Classer les nombres suivants."},
+		CategoriesFieldBlock{Categories: []Interpolated{"Rationnel", "Irrationnel"}, Items: [][]Interpolated{{"$0.5$"}, {"$\\sqrt{2}$", "$\\pi$"}}},
+		TextBlock{Parts: "Représenter l'ensemble des solutions."},
+		NumberLineBlock{Set: "]-inf;-1[ ∪ [2;4] ∪ {5}", Ticks: []string{"0"}},
+		NumberLineFieldBlock{Answer: NumberLineBlock{Set: "x >= 1/2"}},
 		// tables
 		TableBlock{ // no headers
 			Values: [][]TextPart{
@@ -116,3 +131,107 @@ func TestExportLatex(t *testing.T) {
 	latexEx := instancesToLatex(ques)
 	tu.GenerateLatex(t, latexHeader, latexEx, "export-exercice.pdf")
 }
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata/latex")
+
+func TestExportLatexGolden(t *testing.T) {
+	bounds := repere.RepereBounds{Width: 10, Height: 10, Origin: repere.Coord{X: 3, Y: 3}}
+	x := expression.NewVar('x')
+	for _, test := range []struct {
+		name  string
+		block Block
+	}{
+		{"variation_table", VariationTableBlock{Label: "g(x)", Xs: []string{"-5", "0", "2/3"}, Fxs: []string{"4.5", "2/9", "-12"}}},
+		{"sign_table", SignTableBlock{
+			Xs: []string{"-inf", "1/2", "3"},
+			Functions: []FunctionSign{
+				{Label: "g(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.ForbiddenValue}, Signs: []bool{true, false}},
+				{Label: "h(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.Nothing}, Signs: []bool{false, true}},
+			},
+		}},
+		{"functions_graph", FunctionsGraphBlock{
+			FunctionExprs: []FunctionDefinition{
+				{Function: "x^2 - 5", Decoration: FunctionDecoration{Label: "C_f", Color: "#FF0000"}, Variable: x, From: "-3", To: "3"},
+				{Function: "2x", Decoration: FunctionDecoration{Label: "C_g"}, Variable: x, From: "-2", To: "2"},
+			},
+			SequenceExprs: []FunctionDefinition{
+				{Function: "x/2", Decoration: FunctionDecoration{Label: "u", Color: "#0000FF"}, Variable: x, From: "0", To: "3"},
+			},
+			Areas:  []FunctionArea{{Bottom: "C_f", Top: "C_g", Left: "-1", Right: "1", Color: "#8800FF00"}},
+			Points: []FunctionPoint{{Function: "C_f", X: "1", Color: "#000000", Legend: "A"}},
+		}},
+		{"tree", TreeBlock{
+			EventsProposals: []Interpolated{"A", "$\\overline{A}$"},
+			AnswerRoot: TreeNodeAnswer{
+				Probabilities: []string{"1/3", "2/3"},
+				Children: []TreeNodeAnswer{
+					{Value: 0, Probabilities: []string{"0.5", "0.5"}, Children: []TreeNodeAnswer{{Value: 0}, {Value: 1}}},
+					{Value: 1, Probabilities: []string{"0.1", "0.9"}, Children: []TreeNodeAnswer{{Value: 0}, {Value: 1}}},
+				},
+			},
+		}},
+		{"image", ImageBlock{URL: "https://isyro.fr/static/prof/logo.png", Scale: 50}},
+		{"number_line", NumberLineBlock{Set: "]-inf;-1[ ∪ [0;2[ ∪ ]2;4] ∪ {5}", Ticks: []string{"1"}}},
+		{"geometric_construction_figure", GeometricConstructionFieldBlock{
+			Field:      GFPoint{Answer: CoordExpression{X: "2", Y: "-1"}},
+			Background: FigureBlock{Bounds: bounds, ShowGrid: true, ShowOrigin: true},
+		}},
+		{"geometric_construction_graph", GeometricConstructionFieldBlock{
+			Field: GFAffineLine{Label: "d", A: "1", B: "3"},
+			Background: FunctionsGraphBlock{FunctionExprs: []FunctionDefinition{
+				{Function: "-x + 1", Decoration: FunctionDecoration{Label: "C_f"}, Variable: x, From: "-3", To: "3"},
+			}},
+		}},
+		{"variation_table_field", VariationTableFieldBlock{Answer: VariationTableBlock{Label: "f(x)", Xs: []string{"-5", "0", "2"}, Fxs: []string{"4", "2", "3"}}}},
+		{"sign_table_field", SignTableFieldBlock{Answer: SignTableBlock{
+			Xs: []string{"-inf", "1", "+inf"},
+			Functions: []FunctionSign{
+				{Label: "f(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.Nothing}, Signs: []bool{true, false}},
+				{Label: "g(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.Nothing}, Signs: []bool{false, true}},
+			},
+		}}},
+		{"function_points_field", FunctionPointsFieldBlock{Function: "2x - 1", Label: "f", Variable: x, XGrid: []string{"-2", "-1", "0", "1", "2"}}},
+		{"tree_field", TreeFieldBlock{Answer: TreeBlock{
+			EventsProposals: []Interpolated{"P", "F"},
+			AnswerRoot: TreeNodeAnswer{
+				Probabilities: []string{"1/5", "4/5"},
+				Children:      []TreeNodeAnswer{{Value: 0}, {Value: 1}},
+			},
+		}}},
+		{"proof_field", ProofFieldBlock{Answer: ProofSequence{Parts: ProofAssertions{
+			ProofStatement{Content: "$n$ est pair"},
+			ProofNode{Left: ProofStatement{Content: "$n = 2k$"}, Right: ProofEquality{Terms: "n^2 = 4k^2 = 2(2k^2)"}, Op: client.And},
+			ProofStatement{Content: "$n^2$ est pair"},
+		}}}},
+		{"table_field", TableFieldBlock{
+			HorizontalHeaders: []TextPart{tt("Homme"), tt("Femme")},
+			VerticalHeaders:   []TextPart{tt("Salarié"), tt("Chômeur")},
+			Answer:            [][]string{{"899", "253"}, {"520", "50"}},
+		}},
+		{"set_field", SetFieldBlock{Answer: "A ∩ ¬B", AdditionalSets: []Interpolated{"C"}}},
+		{"matching_field", MatchingFieldBlock{Left: []Interpolated{"$x^2$", "$e^x$"}, Right: []Interpolated{"$2x$", "$e^x$"}, AdditionalRight: []Interpolated{"$x$"}}},
+		{"categories_field", CategoriesFieldBlock{Categories: []Interpolated{"Rationnel", "Irrationnel"}, Items: [][]Interpolated{{"$0.5$"}, {"$\\sqrt{2}$", "$\\pi$"}}}},
+		{"number_line_field", NumberLineFieldBlock{Answer: NumberLineBlock{Set: "]-inf;-1[ ∪ [2;4]", Ticks: []string{"0"}}}},
+	} {
+		instance, err := test.block.instantiate(nil, 0)
+		tu.AssertNoErr(t, err)
+		if fp, ok := instance.(FunctionPointsFieldInstance); ok {
+			fp.offsetHeight = 0 // remove the randomness
+			instance = fp
+		}
+		got := instance.toLatex()
+		tu.Assert(t, got != "" && !strings.Contains(got, "TODO"))
+
+		path := filepath.Join("testdata", "latex", test.name+".tex")
+		if *updateGolden {
+			tu.AssertNoErr(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+			tu.AssertNoErr(t, os.WriteFile(path, []byte(got), 0o644))
+			continue
+		}
+		want, err := os.ReadFile(path)
+		tu.AssertNoErr(t, err)
+		if got != string(want) {
+			t.Errorf("%s: unexpected LaTeX code (run with -update to regenerate the golden files):\n%s", test.name, got)
+		}
+	}
+}
@@ -20,6 +20,10 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		return err
 	}
 	switch wr.Kind {
+	case "CategoriesFieldBlock":
+		var data CategoriesFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "ExpressionFieldBlock":
 		var data ExpressionFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -48,10 +52,22 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		var data ImageBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "MatchingFieldBlock":
+		var data MatchingFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "NumberFieldBlock":
 		var data NumberFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "NumberLineBlock":
+		var data NumberLineBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "NumberLineFieldBlock":
+		var data NumberLineFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "OrderedListFieldBlock":
 		var data OrderedListFieldBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -88,6 +104,10 @@ func (out *BlockWrapper) UnmarshalJSON(src []byte) error {
 		var data TextBlock
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "TextFieldBlock":
+		var data TextFieldBlock
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "TreeBlock":
 		var data TreeBlock
 		err = json.Unmarshal(wr.Data, &data)
@@ -122,6 +142,8 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 	}
 	var wr wrapper
 	switch data := item.Data.(type) {
+	case CategoriesFieldBlock:
+		wr = wrapper{Kind: "CategoriesFieldBlock", Data: data}
 	case ExpressionFieldBlock:
 		wr = wrapper{Kind: "ExpressionFieldBlock", Data: data}
 	case FigureBlock:
@@ -136,8 +158,14 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "GeometricConstructionFieldBlock", Data: data}
 	case ImageBlock:
 		wr = wrapper{Kind: "ImageBlock", Data: data}
+	case MatchingFieldBlock:
+		wr = wrapper{Kind: "MatchingFieldBlock", Data: data}
 	case NumberFieldBlock:
 		wr = wrapper{Kind: "NumberFieldBlock", Data: data}
+	case NumberLineBlock:
+		wr = wrapper{Kind: "NumberLineBlock", Data: data}
+	case NumberLineFieldBlock:
+		wr = wrapper{Kind: "NumberLineFieldBlock", Data: data}
 	case OrderedListFieldBlock:
 		wr = wrapper{Kind: "OrderedListFieldBlock", Data: data}
 	case ProofFieldBlock:
@@ -156,6 +184,8 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 		wr = wrapper{Kind: "TableFieldBlock", Data: data}
 	case TextBlock:
 		wr = wrapper{Kind: "TextBlock", Data: data}
+	case TextFieldBlock:
+		wr = wrapper{Kind: "TextFieldBlock", Data: data}
 	case TreeBlock:
 		wr = wrapper{Kind: "TreeBlock", Data: data}
 	case TreeFieldBlock:
@@ -174,6 +204,7 @@ func (item BlockWrapper) MarshalJSON() ([]byte, error) {
 }
 
 const (
+	CategoriesFieldBlockBlKind            = "CategoriesFieldBlock"
 	ExpressionFieldBlockBlKind            = "ExpressionFieldBlock"
 	FigureBlockBlKind                     = "FigureBlock"
 	FormulaBlockBlKind                    = "FormulaBlock"
@@ -181,7 +212,10 @@ const (
 	FunctionsGraphBlockBlKind             = "FunctionsGraphBlock"
 	GeometricConstructionFieldBlockBlKind = "GeometricConstructionFieldBlock"
 	ImageBlockBlKind                      = "ImageBlock"
+	MatchingFieldBlockBlKind              = "MatchingFieldBlock"
 	NumberFieldBlockBlKind                = "NumberFieldBlock"
+	NumberLineBlockBlKind                 = "NumberLineBlock"
+	NumberLineFieldBlockBlKind            = "NumberLineFieldBlock"
 	OrderedListFieldBlockBlKind           = "OrderedListFieldBlock"
 	ProofFieldBlockBlKind                 = "ProofFieldBlock"
 	RadioFieldBlockBlKind                 = "RadioFieldBlock"
@@ -191,6 +225,7 @@ const (
 	TableBlockBlKind                      = "TableBlock"
 	TableFieldBlockBlKind                 = "TableFieldBlock"
 	TextBlockBlKind                       = "TextBlock"
+	TextFieldBlockBlKind                  = "TextFieldBlock"
 	TreeBlockBlKind                       = "TreeBlock"
 	TreeFieldBlockBlKind                  = "TreeFieldBlock"
 	VariationTableBlockBlKind             = "VariationTableBlock"
@@ -290,10 +325,30 @@ func (out *GeoFieldWrapper) UnmarshalJSON(src []byte) error {
 		var data GFAffineLine
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "GFAngle":
+		var data GFAngle
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "GFCircle":
+		var data GFCircle
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "GFLine":
+		var data GFLine
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "GFPoint":
 		var data GFPoint
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "GFPointOnCurve":
+		var data GFPointOnCurve
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "GFSegment":
+		var data GFSegment
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "GFVector":
 		var data GFVector
 		err = json.Unmarshal(wr.Data, &data)
@@ -318,8 +373,18 @@ func (item GeoFieldWrapper) MarshalJSON() ([]byte, error) {
 	switch data := item.Data.(type) {
 	case GFAffineLine:
 		wr = wrapper{Kind: "GFAffineLine", Data: data}
+	case GFAngle:
+		wr = wrapper{Kind: "GFAngle", Data: data}
+	case GFCircle:
+		wr = wrapper{Kind: "GFCircle", Data: data}
+	case GFLine:
+		wr = wrapper{Kind: "GFLine", Data: data}
 	case GFPoint:
 		wr = wrapper{Kind: "GFPoint", Data: data}
+	case GFPointOnCurve:
+		wr = wrapper{Kind: "GFPointOnCurve", Data: data}
+	case GFSegment:
+		wr = wrapper{Kind: "GFSegment", Data: data}
 	case GFVector:
 		wr = wrapper{Kind: "GFVector", Data: data}
 	case GFVectorPair:
@@ -332,10 +397,15 @@ func (item GeoFieldWrapper) MarshalJSON() ([]byte, error) {
 }
 
 const (
-	GFAffineLineGeKind = "GFAffineLine"
-	GFPointGeKind      = "GFPoint"
-	GFVectorGeKind     = "GFVector"
-	GFVectorPairGeKind = "GFVectorPair"
+	GFAffineLineGeKind   = "GFAffineLine"
+	GFAngleGeKind        = "GFAngle"
+	GFCircleGeKind       = "GFCircle"
+	GFLineGeKind         = "GFLine"
+	GFPointGeKind        = "GFPoint"
+	GFPointOnCurveGeKind = "GFPointOnCurve"
+	GFSegmentGeKind      = "GFSegment"
+	GFVectorGeKind       = "GFVector"
+	GFVectorPairGeKind   = "GFVectorPair"
 )
 
 func (item GeometricConstructionFieldBlock) MarshalJSON() ([]byte, error) {
@@ -385,6 +455,14 @@ func (out *ParameterEntryWrapper) UnmarshalJSON(src []byte) error {
 		var data Co
 		err = json.Unmarshal(wr.Data, &data)
 		out.Data = data
+	case "Ct":
+		var data Ct
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
+	case "Fn":
+		var data Fn
+		err = json.Unmarshal(wr.Data, &data)
+		out.Data = data
 	case "In":
 		var data In
 		err = json.Unmarshal(wr.Data, &data)
@@ -409,6 +487,10 @@ func (item ParameterEntryWrapper) MarshalJSON() ([]byte, error) {
 	switch data := item.Data.(type) {
 	case Co:
 		wr = wrapper{Kind: "Co", Data: data}
+	case Ct:
+		wr = wrapper{Kind: "Ct", Data: data}
+	case Fn:
+		wr = wrapper{Kind: "Fn", Data: data}
 	case In:
 		wr = wrapper{Kind: "In", Data: data}
 	case Rp:
@@ -422,6 +504,8 @@ func (item ParameterEntryWrapper) MarshalJSON() ([]byte, error) {
 
 const (
 	CoPaKind = "Co"
+	CtPaKind = "Ct"
+	FnPaKind = "Fn"
 	InPaKind = "In"
 	RpPaKind = "Rp"
 )
@@ -26,6 +26,7 @@ var (
 	_ instance = TableInstance{}
 	_ instance = FunctionsGraphInstance{}
 	_ instance = ImageInstance{}
+	_ instance = NumberLineInstance{}
 )
 
 // ExerciceInstance is an in memory version of an Exercice,
@@ -102,6 +103,22 @@ func (qu EnonceInstance) EvaluateAnswer(answers client.QuestionAnswersIn) client
 		}
 
 		out.Results[id] = reference.evaluateAnswer(answer)
+
+		if diagnoser, ok := reference.(fieldDiagnoser); ok && !out.Results[id] {
+			if hint := diagnoser.diagnoseAnswer(answer); hint != "" {
+				if out.Hints == nil {
+					out.Hints = make(map[int]string)
+				}
+				out.Hints[id] = hint
+			}
+		}
+
+		if evaluator, ok := reference.(fieldItemsEvaluator); ok && !out.Results[id] {
+			if out.ItemResults == nil {
+				out.ItemResults = make(map[int][]bool)
+			}
+			out.ItemResults[id] = evaluator.evaluateItems(answer)
+		}
 	}
 
 	return out
@@ -312,3 +329,32 @@ func (ti TreeInstance) toClient() client.Block {
 type ImageInstance ImageBlock
 
 func (img ImageInstance) toClient() client.Block { return client.ImageBlock(img) }
+
+type NumberLineInstance struct {
+	Ticks []evaluatedExpression // sorted values, including the bounds of [Set]
+	Set   expression.SolutionSet
+}
+
+func (nl NumberLineInstance) tickLabels() []string {
+	out := make([]string, len(nl.Ticks))
+	for i, tick := range nl.Ticks {
+		out[i] = tick.Expr.AsLaTeX()
+	}
+	return out
+}
+
+func (nl NumberLineInstance) tickValues() []float64 {
+	out := make([]float64, len(nl.Ticks))
+	for i, tick := range nl.Ticks {
+		out[i] = tick.Value
+	}
+	return out
+}
+
+// pieces returns the membership of the segments and points
+// defined by the ticks
+func (nl NumberLineInstance) pieces() []bool { return nl.Set.Pieces(nl.tickValues()) }
+
+func (nl NumberLineInstance) toClient() client.Block {
+	return client.NumberLineBlock{Ticks: nl.tickLabels(), Pieces: nl.pieces()}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/expression/sets"
@@ -48,6 +51,43 @@ type fieldInstance interface {
 	validateAnswerSyntax(answer client.Answer) error
 }
 
+// fieldDiagnoser is implemented by the fields
+// able to explain a wrong answer
+type fieldDiagnoser interface {
+	// diagnoseAnswer returns a short hint for the wrong [answer],
+	// or an empty string if no explanation is found.
+	// validateAnswerSyntax is assumed to have already been called on `answer`
+	diagnoseAnswer(answer client.Answer) string
+}
+
+var (
+	_ fieldDiagnoser = ExpressionFieldInstance{}
+	_ fieldDiagnoser = NumberLineFieldInstance{}
+)
+
+// fieldItemsEvaluator is implemented by the fields
+// made of several items, which may be partially correct
+type fieldItemsEvaluator interface {
+	// evaluateItems returns the result of each item of [answer].
+	// validateAnswerSyntax is assumed to have already been called on `answer`
+	evaluateItems(answer client.Answer) []bool
+}
+
+var (
+	_ fieldItemsEvaluator = MatchingFieldInstance{}
+	_ fieldItemsEvaluator = CategoriesFieldInstance{}
+)
+
+// allTrue returns true if all the items are correct
+func allTrue(items []bool) bool {
+	for _, ok := range items {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
 var (
 	_ fieldInstance = NumberFieldInstance{}
 	_ fieldInstance = ExpressionFieldInstance{}
@@ -62,6 +102,10 @@ var (
 	_ fieldInstance = ProofFieldInstance{}
 	_ fieldInstance = TableFieldInstance{}
 	_ fieldInstance = VectorFieldInstance{}
+	_ fieldInstance = TextFieldInstance{}
+	_ fieldInstance = MatchingFieldInstance{}
+	_ fieldInstance = CategoriesFieldInstance{}
+	_ fieldInstance = NumberLineFieldInstance{}
 )
 
 // NumberFieldInstance is an answer field where only
@@ -114,9 +158,18 @@ type ExpressionFieldInstance struct {
 	// If true an hint for fraction is displayed
 	ShowFractionHelp bool
 
+	// Hints are the custom hints provided by the teacher
+	Hints []ExpressionHint
+
 	ID int
 }
 
+// ExpressionHint is an instantiated [WrongAnswerHint]
+type ExpressionHint struct {
+	Answer expression.Compound
+	Hint   string
+}
+
 func (f ExpressionFieldInstance) fieldID() int { return f.ID }
 
 // add some random padding to avoid leaking to much info about
@@ -136,6 +189,18 @@ func (f ExpressionFieldInstance) toClient() client.Block {
 	return out
 }
 
+// parseExpressionAnswer accepts both the plain syntax and LaTeX,
+// as sent by math keyboards
+func (f ExpressionFieldInstance) parseExpressionAnswer(answer string) (expression.Compound, error) {
+	if expression.IsLaTeX(answer) {
+		if f.ComparisonLevel.expectsEquations() {
+			return expression.ParseLaTeXEquationCompound(answer)
+		}
+		return expression.ParseLaTeXCompound(answer)
+	}
+	return f.ComparisonLevel.parseCompound(answer)
+}
+
 func (f ExpressionFieldInstance) validateAnswerSyntax(answer client.Answer) error {
 	expr, ok := answer.(client.ExpressionAnswer)
 	if !ok {
@@ -145,7 +210,7 @@ func (f ExpressionFieldInstance) validateAnswerSyntax(answer client.Answer) erro
 		}
 	}
 
-	_, err := expression.ParseCompound(expr.Expression)
+	_, err := f.parseExpressionAnswer(expr.Expression)
 	if err != nil {
 		err := err.(expression.ErrInvalidExpr)
 		return InvalidFieldAnswer{
@@ -157,11 +222,47 @@ func (f ExpressionFieldInstance) validateAnswerSyntax(answer client.Answer) erro
 }
 
 func (f ExpressionFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool) {
-	expr, _ := expression.ParseCompound(answer.(client.ExpressionAnswer).Expression)
-	if f.ComparisonLevel == AsLinearEquation {
-		return expression.AreLinearEquationsEquivalent(f.Answer, expr)
+	expr, _ := f.parseExpressionAnswer(answer.(client.ExpressionAnswer).Expression)
+	return f.areEquivalent(f.Answer, expr)
+}
+
+// areEquivalent compares [reference] and [answer], using the field comparison level
+func (f ExpressionFieldInstance) areEquivalent(reference, answer expression.Compound) bool {
+	switch f.ComparisonLevel {
+	case AsLinearEquation:
+		return expression.AreLinearEquationsEquivalent(reference, answer)
+	case AsSolutionSet:
+		return expression.AreSolutionSetsEquivalent(reference, answer)
+	case AsComplexNumber:
+		e1, ok1 := reference.(*expression.Expr)
+		e2, ok2 := answer.(*expression.Expr)
+		return ok1 && ok2 && expression.AreComplexEquivalent(e1, e2)
+	}
+	return expression.AreCompoundsEquivalent(reference, answer, expression.ComparisonLevel(f.ComparisonLevel))
+}
+
+func (f ExpressionFieldInstance) diagnoseAnswer(answer client.Answer) string {
+	expr, err := f.parseExpressionAnswer(answer.(client.ExpressionAnswer).Expression)
+	if err != nil {
+		return ""
+	}
+
+	// the hints provided by the teacher have priority
+	for _, hint := range f.Hints {
+		if f.areEquivalent(hint.Answer, expr) {
+			return hint.Hint
+		}
+	}
+
+	if f.ComparisonLevel == AsLinearEquation || f.ComparisonLevel == AsSolutionSet || f.ComparisonLevel == AsComplexNumber {
+		return ""
 	}
-	return expression.AreCompoundsEquivalent(f.Answer, expr, expression.ComparisonLevel(f.ComparisonLevel))
+	expected, ok1 := f.Answer.(*expression.Expr)
+	got, ok2 := expr.(*expression.Expr)
+	if !(ok1 && ok2) {
+		return ""
+	}
+	return expression.DiagnoseMistake(expected, got).Hint()
 }
 
 func (f ExpressionFieldInstance) correctAnswer() client.Answer {
@@ -374,7 +475,7 @@ func (f GeometricConstructionFieldInstance) toClient() client.Block {
 
 func (f GeometricConstructionFieldInstance) validateAnswerSyntax(answer client.Answer) error {
 	switch f.Field.(type) {
-	case gfPoint:
+	case gfPoint, gfPointOnCurve:
 		_, ok := answer.(client.PointAnswer)
 		if !ok {
 			return InvalidFieldAnswer{
@@ -382,7 +483,7 @@ func (f GeometricConstructionFieldInstance) validateAnswerSyntax(answer client.A
 				Reason: fmt.Sprintf("expected PointAnswer, got %T", answer),
 			}
 		}
-	case gfVector, gfAffineLine:
+	case gfVector, gfAffineLine, gfCircle, gfSegment, gfLine:
 		_, ok := answer.(client.DoublePointAnswer)
 		if !ok {
 			return InvalidFieldAnswer{
@@ -390,7 +491,7 @@ func (f GeometricConstructionFieldInstance) validateAnswerSyntax(answer client.A
 				Reason: fmt.Sprintf("expected DoublePointAnswer, got %T", answer),
 			}
 		}
-	case gfVectorPair:
+	case gfVectorPair, gfAngle:
 		_, ok := answer.(client.DoublePointPairAnswer)
 		if !ok {
 			return InvalidFieldAnswer{
@@ -569,6 +670,266 @@ func (f gfVectorPair) correctAnswer(repere.RepereBounds) client.Answer {
 	}
 }
 
+const (
+	// geoLengthTolerance is used to compare lengths
+	// computed from grid points, in grid units
+	geoLengthTolerance = 0.05
+	// geoAngleTolerance is used to compare angles, in degrees
+	geoAngleTolerance = 1.
+	// maxGeoGrid bounds the coordinates used when searching
+	// for grid points
+	maxGeoGrid = 10
+)
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// gcd returns the greatest common divisor of two
+// non negative integers, not both zero
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func gridDistance(from, to repere.IntCoord) float64 {
+	return math.Hypot(float64(to.X-from.X), float64(to.Y-from.Y))
+}
+
+// gridVectorOfLength returns a vector with integer coordinates
+// and length [length], or false if there is none.
+func gridVectorOfLength(length float64) (repere.IntCoord, bool) {
+	for x := 0; float64(x) <= length; x++ {
+		y := int(math.Round(math.Sqrt(length*length - float64(x*x))))
+		v := repere.IntCoord{X: x, Y: y}
+		if math.Abs(gridDistance(repere.IntCoord{}, v)-length) <= geoLengthTolerance {
+			return v, true
+		}
+	}
+	return repere.IntCoord{}, false
+}
+
+type gfCircle struct {
+	Center repere.IntCoord
+	Radius evaluatedExpression
+}
+
+func (f gfCircle) toClient() client.GeoField { return client.GFCircle{} }
+
+// the answer is the center and a point of the circle
+func (f gfCircle) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	ans := answer.(client.DoublePointAnswer)
+	return ans.From == f.Center && math.Abs(gridDistance(ans.From, ans.To)-f.Radius.Value) <= geoLengthTolerance
+}
+
+func (f gfCircle) correctAnswer(repere.RepereBounds) client.Answer {
+	v, _ := gridVectorOfLength(f.Radius.Value)
+	return client.DoublePointAnswer{From: f.Center, To: repere.IntCoord{X: f.Center.X + v.X, Y: f.Center.Y + v.Y}}
+}
+
+type gfSegment struct {
+	From, To           repere.IntCoord
+	LabelFrom, LabelTo string
+}
+
+func (f gfSegment) toClient() client.GeoField {
+	return client.GFSegment{LabelFrom: f.LabelFrom, LabelTo: f.LabelTo}
+}
+
+func (f gfSegment) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	ans := answer.(client.DoublePointAnswer)
+	if ans.From == f.From && ans.To == f.To {
+		return true
+	}
+	// without labels, the order of the points does not matter
+	hasLabels := f.LabelFrom != "" || f.LabelTo != ""
+	return !hasLabels && ans.From == f.To && ans.To == f.From
+}
+
+func (f gfSegment) correctAnswer(repere.RepereBounds) client.Answer {
+	return client.DoublePointAnswer{From: f.From, To: f.To}
+}
+
+type gfLine struct {
+	Label     string
+	Through   repere.IntCoord
+	Direction repere.IntCoord // not zero
+}
+
+func (f gfLine) toClient() client.GeoField {
+	return client.GFVector{AsLine: true, LineLabel: f.Label}
+}
+
+// isColinear returns true if u and the direction vector are colinear
+func (f gfLine) isColinear(u repere.IntCoord) bool {
+	return u.X*f.Direction.Y-u.Y*f.Direction.X == 0
+}
+
+func (f gfLine) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	ans := answer.(client.DoublePointAnswer)
+	if ans.From == ans.To {
+		return false
+	}
+	u := repere.IntCoord{X: ans.To.X - ans.From.X, Y: ans.To.Y - ans.From.Y}
+	v := repere.IntCoord{X: ans.From.X - f.Through.X, Y: ans.From.Y - f.Through.Y}
+	return f.isColinear(u) && f.isColinear(v)
+}
+
+func (f gfLine) correctAnswer(repere.RepereBounds) client.Answer {
+	// use the smallest direction vector
+	d := gcd(absInt(f.Direction.X), absInt(f.Direction.Y))
+	to := repere.IntCoord{X: f.Through.X + f.Direction.X/d, Y: f.Through.Y + f.Direction.Y/d}
+	return client.DoublePointAnswer{From: f.Through, To: to}
+}
+
+type gfPointOnCurve expression.FunctionExpr
+
+func (f gfPointOnCurve) toClient() client.GeoField { return client.GFPoint{} }
+
+func (f gfPointOnCurve) isOnCurve(point repere.IntCoord) bool {
+	y := expression.FunctionExpr(f).Closure()(float64(point.X))
+	return math.Abs(y-float64(point.Y)) <= geoLengthTolerance
+}
+
+func (f gfPointOnCurve) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	return f.isOnCurve(answer.(client.PointAnswer).Point)
+}
+
+// gridPointsOnCurve returns the points with integer coordinates
+// on the curve, for x in [xMin, xMax]
+func (f gfPointOnCurve) gridPointsOnCurve(xMin, xMax int) (out []repere.IntCoord) {
+	fn := expression.FunctionExpr(f).Closure()
+	for x := xMin; x <= xMax; x++ {
+		y := fn(float64(x))
+		if math.IsNaN(y) || math.IsInf(y, 0) {
+			continue
+		}
+		point := repere.IntCoord{X: x, Y: int(math.Round(y))}
+		if f.isOnCurve(point) {
+			out = append(out, point)
+		}
+	}
+	return out
+}
+
+func (f gfPointOnCurve) correctAnswer(bounds repere.RepereBounds) client.Answer {
+	origin := bounds.Origin.Round()
+	// prefer a visible point
+	for _, point := range f.gridPointsOnCurve(-origin.X, bounds.Width-origin.X) {
+		if -origin.Y <= point.Y && point.Y <= bounds.Height-origin.Y {
+			return client.PointAnswer{Point: point}
+		}
+	}
+	if points := f.gridPointsOnCurve(-maxGeoGrid, maxGeoGrid); len(points) != 0 {
+		return client.PointAnswer{Point: points[0]}
+	}
+	return client.PointAnswer{}
+}
+
+type gfAngle struct {
+	Measure float64 // in degrees
+	// It true, the angle must have `Vertex` as vertex
+	MustHaveVertex bool
+	Vertex         repere.IntCoord
+}
+
+func (f gfAngle) toClient() client.GeoField { return client.GFAngle{} }
+
+// angleMeasure returns the measure of the angle (in degrees, in [0, 180])
+// between the two vectors, which must not be zero
+func angleMeasure(u, v repere.IntCoord) float64 {
+	dot := float64(u.X*v.X + u.Y*v.Y)
+	cos := dot / (gridDistance(repere.IntCoord{}, u) * gridDistance(repere.IntCoord{}, v))
+	return math.Acos(math.Max(-1, math.Min(1, cos))) * 180 / math.Pi
+}
+
+// the answer is given by two half-lines [From1 To1) and [From2 To2),
+// where From1 and From2 must be the (same) vertex
+func (f gfAngle) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	ans := answer.(client.DoublePointPairAnswer)
+	if ans.From1 != ans.From2 || ans.To1 == ans.From1 || ans.To2 == ans.From2 {
+		return false
+	}
+	if f.MustHaveVertex && ans.From1 != f.Vertex {
+		return false
+	}
+	u := repere.IntCoord{X: ans.To1.X - ans.From1.X, Y: ans.To1.Y - ans.From1.Y}
+	v := repere.IntCoord{X: ans.To2.X - ans.From2.X, Y: ans.To2.Y - ans.From2.Y}
+	return math.Abs(angleMeasure(u, v)-f.Measure) <= geoAngleTolerance
+}
+
+// gridDirection is a grid vector, with its polar angle in degrees, in [0, 360)
+type gridDirection struct {
+	v     repere.IntCoord
+	theta float64
+}
+
+// gridDirections lists the non zero vectors with integer coordinates
+// and norm at most [maxGeoGrid], sorted by polar angle
+var gridDirections = func() []gridDirection {
+	var out []gridDirection
+	for x := -maxGeoGrid; x <= maxGeoGrid; x++ {
+		for y := -maxGeoGrid; y <= maxGeoGrid; y++ {
+			v := repere.IntCoord{X: x, Y: y}
+			if v == (repere.IntCoord{}) || gridDistance(repere.IntCoord{}, v) > maxGeoGrid {
+				continue
+			}
+			theta := math.Atan2(float64(y), float64(x)) * 180 / math.Pi
+			if theta < 0 {
+				theta += 360
+			}
+			out = append(out, gridDirection{v, theta})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].theta < out[j].theta })
+	return out
+}()
+
+// gridVectorsForAngle returns two vectors with integer coordinates (and norm at most [maxGeoGrid])
+// whose angle is the closest to [measure].
+// The first vector is (1, 0) whenever it is precise enough.
+func gridVectorsForAngle(measure float64) (u, v repere.IntCoord) {
+	u, bestDiff := repere.IntCoord{X: 1}, math.Inf(1)
+	for _, d := range gridDirections {
+		if diff := math.Abs(angleMeasure(u, d.v) - measure); diff < bestDiff {
+			v, bestDiff = d.v, diff
+		}
+	}
+	if bestDiff <= geoAngleTolerance/2 {
+		return u, v
+	}
+
+	// try all the pairs of directions, looking for the direction
+	// closest to theta + measure
+	n := len(gridDirections)
+	for _, d1 := range gridDirections {
+		target := math.Mod(d1.theta+measure, 360)
+		index := sort.Search(n, func(i int) bool { return gridDirections[i].theta >= target })
+		for _, d2 := range [2]gridDirection{gridDirections[(index+n-1)%n], gridDirections[index%n]} {
+			if diff := math.Abs(angleMeasure(d1.v, d2.v) - measure); diff < bestDiff {
+				u, v, bestDiff = d1.v, d2.v, diff
+			}
+		}
+	}
+	return u, v
+}
+
+func (f gfAngle) correctAnswer(repere.RepereBounds) client.Answer {
+	vertex := f.Vertex // zero value if MustHaveVertex is false
+	u, v := gridVectorsForAngle(f.Measure)
+	return client.DoublePointPairAnswer{
+		From1: vertex,
+		To1:   repere.IntCoord{X: vertex.X + u.X, Y: vertex.Y + u.Y},
+		From2: vertex,
+		To2:   repere.IntCoord{X: vertex.X + v.X, Y: vertex.Y + v.Y},
+	}
+}
+
 type VariationTableFieldInstance struct {
 	Answer VariationTableInstance
 	ID     int
@@ -1194,3 +1555,349 @@ func (v SetFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool)
 func (v SetFieldInstance) correctAnswer() client.Answer {
 	return client.SetAnswer{Root: v.Answer.ToList().Expr}
 }
+
+// TextFieldInstance is a free text answer field.
+// Texts are compared after normalization, according to
+// the options of the field.
+type TextFieldInstance struct {
+	Answers    []string         // as provided by the teacher
+	Patterns   []*regexp.Regexp // compiled regular expressions, or nil for plain answers
+	Correction string           // displayed as correction, defaulting to the first answer
+
+	IgnoreCase        bool
+	IgnoreAccents     bool
+	IgnorePunctuation bool
+	MaxTypos          int
+
+	ID int
+}
+
+func (tf TextFieldInstance) fieldID() int { return tf.ID }
+
+func (tf TextFieldInstance) sizeHint() int {
+	out := len([]rune(tf.Correction))
+	if tf.Patterns == nil { // regular expressions are not representative
+		for _, answer := range tf.Answers {
+			out = max(out, len([]rune(answer)))
+		}
+	}
+	return min(max(out, 5), 40)
+}
+
+func (tf TextFieldInstance) toClient() client.Block {
+	return client.TextFieldBlock{ID: tf.ID, SizeHint: tf.sizeHint()}
+}
+
+func (tf TextFieldInstance) validateAnswerSyntax(answer client.Answer) error {
+	_, ok := answer.(client.TextAnswer)
+	if !ok {
+		return InvalidFieldAnswer{
+			ID:     tf.ID,
+			Reason: fmt.Sprintf("expected TextAnswer, got %T", answer),
+		}
+	}
+	return nil
+}
+
+// normalize applies the options of the field to [s]
+// Spaces are always trimmed and merged.
+func (tf TextFieldInstance) normalize(s string) string {
+	if tf.IgnoreCase {
+		s = strings.ToLower(s)
+	}
+	if tf.IgnoreAccents {
+		s = utils.RemoveAccents(s)
+	}
+	if tf.IgnorePunctuation {
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) {
+				return ' '
+			}
+			return r
+		}, s)
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// compile returns the regular expression matching
+// the whole normalized answer.
+func (tf TextFieldInstance) compile(pattern string) (*regexp.Regexp, error) {
+	if tf.IgnoreAccents {
+		pattern = utils.RemoveAccents(pattern)
+	}
+	flags := ""
+	if tf.IgnoreCase {
+		flags = "(?i)"
+	}
+	return regexp.Compile(flags + "^(?:" + pattern + ")$")
+}
+
+func (tf TextFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	text := tf.normalize(answer.(client.TextAnswer).Text)
+	if tf.Patterns != nil {
+		for _, pattern := range tf.Patterns {
+			if pattern.MatchString(text) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ref := range tf.Answers {
+		if utils.EditDistance(tf.normalize(ref), text) <= tf.MaxTypos {
+			return true
+		}
+	}
+	return false
+}
+
+func (tf TextFieldInstance) correctAnswer() client.Answer {
+	if tf.Correction != "" {
+		return client.TextAnswer{Text: tf.Correction}
+	}
+	if len(tf.Answers) == 0 || tf.Patterns != nil { // should not happen on validated questions
+		return client.TextAnswer{}
+	}
+	return client.TextAnswer{Text: tf.Answers[0]}
+}
+
+// linesHash returns a hash used to shuffle [lists] in a deterministic way
+func linesHash(lists ...[]client.TextLine) (hash []byte) {
+	for _, list := range lists {
+		for _, line := range list {
+			hash = append(hash, []byte(textLineToString(line))...)
+		}
+	}
+	return hash
+}
+
+// MatchingFieldInstance asks the student to associate each
+// item of [Left] with one item of [Right] or [AdditionalRight]
+type MatchingFieldInstance struct {
+	Left            []client.TextLine
+	Right           []client.TextLine // Right[i] is associated to Left[i]
+	AdditionalRight []client.TextLine // added to Right when displaying the field
+	ID              int
+}
+
+func (mf MatchingFieldInstance) fieldID() int { return mf.ID }
+
+func (mf MatchingFieldInstance) shuffler() utils.Shuffler {
+	return utils.NewDeterministicShuffler(linesHash(mf.Left, mf.Right), len(mf.Right)+len(mf.AdditionalRight))
+}
+
+// rightItems groups Right and AdditionalRight and shuffle the list
+// in a random way, which only depends on the field content though
+func (mf MatchingFieldInstance) rightItems() []client.TextLine {
+	input := append(append([]client.TextLine(nil), mf.Right...), mf.AdditionalRight...)
+	out := make([]client.TextLine, len(input))
+	mf.shuffler().Shuffle(func(dst, src int) { out[dst] = input[src] })
+	return out
+}
+
+func (mf MatchingFieldInstance) toClient() client.Block {
+	return client.MatchingFieldBlock{
+		Left:  mf.Left,
+		Right: mf.rightItems(),
+		ID:    mf.ID,
+	}
+}
+
+func (mf MatchingFieldInstance) validateAnswerSyntax(answer client.Answer) error {
+	ans, ok := answer.(client.MatchingAnswer)
+	if !ok {
+		return InvalidFieldAnswer{
+			ID:     mf.ID,
+			Reason: fmt.Sprintf("expected MatchingAnswer, got %T", answer),
+		}
+	}
+	if len(ans.Indices) != len(mf.Left) {
+		return InvalidFieldAnswer{
+			ID:     mf.ID,
+			Reason: fmt.Sprintf("invalid answer length %d (expected %d)", len(ans.Indices), len(mf.Left)),
+		}
+	}
+	L := len(mf.Right) + len(mf.AdditionalRight)
+	for _, index := range ans.Indices {
+		if index < 0 || index >= L {
+			return InvalidFieldAnswer{
+				ID:     mf.ID,
+				Reason: fmt.Sprintf("invalid indice %d for length %d", index, L),
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateItems returns the result of each association
+func (mf MatchingFieldInstance) evaluateItems(answer client.Answer) []bool {
+	items := mf.rightItems()
+	indices := answer.(client.MatchingAnswer).Indices
+	out := make([]bool, len(indices))
+	for i, index := range indices {
+		// as for ordered lists, we compare by value
+		out[i] = areLineEquals(items[index], mf.Right[i])
+	}
+	return out
+}
+
+func (mf MatchingFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	return allTrue(mf.evaluateItems(answer))
+}
+
+func (mf MatchingFieldInstance) correctAnswer() client.Answer {
+	indices := mf.shuffler().OriginalToShuffled()
+	return client.MatchingAnswer{Indices: indices[:len(mf.Right)]}
+}
+
+// CategoriesFieldInstance asks the student to sort items
+// into categories
+type CategoriesFieldInstance struct {
+	Categories []client.TextLine
+	Items      [][]client.TextLine // Items[i] belong to Categories[i]
+	ID         int
+}
+
+func (cf CategoriesFieldInstance) fieldID() int { return cf.ID }
+
+// flatten returns the items and their category
+func (cf CategoriesFieldInstance) flatten() (items []client.TextLine, categories []int) {
+	for category, list := range cf.Items {
+		for _, item := range list {
+			items = append(items, item)
+			categories = append(categories, category)
+		}
+	}
+	return items, categories
+}
+
+func (cf CategoriesFieldInstance) shuffler() utils.Shuffler {
+	items, _ := cf.flatten()
+	return utils.NewDeterministicShuffler(linesHash(cf.Categories, items), len(items))
+}
+
+// shuffledItems returns the items as displayed, with their category
+func (cf CategoriesFieldInstance) shuffledItems() (items []client.TextLine, categories []int) {
+	inputItems, inputCategories := cf.flatten()
+	items = make([]client.TextLine, len(inputItems))
+	categories = make([]int, len(inputItems))
+	cf.shuffler().Shuffle(func(dst, src int) {
+		items[dst] = inputItems[src]
+		categories[dst] = inputCategories[src]
+	})
+	return items, categories
+}
+
+func (cf CategoriesFieldInstance) toClient() client.Block {
+	items, _ := cf.shuffledItems()
+	return client.CategoriesFieldBlock{
+		Categories: cf.Categories,
+		Items:      items,
+		ID:         cf.ID,
+	}
+}
+
+func (cf CategoriesFieldInstance) validateAnswerSyntax(answer client.Answer) error {
+	ans, ok := answer.(client.CategoriesAnswer)
+	if !ok {
+		return InvalidFieldAnswer{
+			ID:     cf.ID,
+			Reason: fmt.Sprintf("expected CategoriesAnswer, got %T", answer),
+		}
+	}
+	items, _ := cf.flatten()
+	if len(ans.Categories) != len(items) {
+		return InvalidFieldAnswer{
+			ID:     cf.ID,
+			Reason: fmt.Sprintf("invalid answer length %d (expected %d)", len(ans.Categories), len(items)),
+		}
+	}
+	for _, category := range ans.Categories {
+		if category < 0 || category >= len(cf.Categories) {
+			return InvalidFieldAnswer{
+				ID:     cf.ID,
+				Reason: fmt.Sprintf("invalid category %d for length %d", category, len(cf.Categories)),
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateItems returns, for each displayed item, true
+// if it has been put in the right category
+func (cf CategoriesFieldInstance) evaluateItems(answer client.Answer) []bool {
+	items, _ := cf.shuffledItems()
+	categories := answer.(client.CategoriesAnswer).Categories
+	out := make([]bool, len(categories))
+	for i, category := range categories {
+		// compare by value, since the same item may appear twice
+		for _, ref := range cf.Items[category] {
+			if areLineEquals(items[i], ref) {
+				out[i] = true
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (cf CategoriesFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	return allTrue(cf.evaluateItems(answer))
+}
+
+func (cf CategoriesFieldInstance) correctAnswer() client.Answer {
+	_, categories := cf.shuffledItems()
+	return client.CategoriesAnswer{Categories: categories}
+}
+
+// NumberLineFieldInstance asks for a subset of ℝ, drawn
+// on a number line by selecting segments and points (with brackets).
+type NumberLineFieldInstance struct {
+	Answer NumberLineInstance
+	ID     int
+}
+
+func (f NumberLineFieldInstance) fieldID() int { return f.ID }
+
+func (f NumberLineFieldInstance) toClient() client.Block {
+	return client.NumberLineFieldBlock{Ticks: f.Answer.tickLabels(), ID: f.ID}
+}
+
+func (f NumberLineFieldInstance) validateAnswerSyntax(answer client.Answer) error {
+	ans, ok := answer.(client.NumberLineAnswer)
+	if !ok {
+		return InvalidFieldAnswer{
+			ID:     f.ID,
+			Reason: fmt.Sprintf("expected NumberLineAnswer, got %T", answer),
+		}
+	}
+	if exp := 2*len(f.Answer.Ticks) + 1; len(ans.Pieces) != exp {
+		return InvalidFieldAnswer{
+			ID:     f.ID,
+			Reason: fmt.Sprintf("invalid number of pieces (expected %d, got %d)", exp, len(ans.Pieces)),
+		}
+	}
+	return nil
+}
+
+func (f NumberLineFieldInstance) evaluateAnswer(answer client.Answer) (isCorrect bool) {
+	ans := answer.(client.NumberLineAnswer)
+	// compare the actual sets, so that useless ticks are ignored
+	values := f.Answer.tickValues()
+	return expression.NewSolutionSetFromPieces(values, ans.Pieces).IsEquivalent(f.Answer.Set)
+}
+
+func (f NumberLineFieldInstance) diagnoseAnswer(answer client.Answer) string {
+	ans := answer.(client.NumberLineAnswer)
+	expected := f.Answer.pieces()
+	for i, piece := range ans.Pieces {
+		if i%2 == 0 && piece != expected[i] {
+			return ""
+		}
+	}
+	// only the points are wrong
+	return "Les intervalles sont corrects : vérifier les crochets (bornes incluses ou exclues)."
+}
+
+func (f NumberLineFieldInstance) correctAnswer() client.Answer {
+	return client.NumberLineAnswer{Pieces: f.Answer.pieces()}
+}
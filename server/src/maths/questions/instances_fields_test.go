@@ -24,6 +24,8 @@ func TestFieldInstance_validateAnswerSyntax(t *testing.T) {
 		{ExpressionFieldInstance{}, client.RadioAnswer{}, true},
 		{ExpressionFieldInstance{}, client.ExpressionAnswer{Expression: ""}, true},
 		{ExpressionFieldInstance{}, client.ExpressionAnswer{Expression: "2+4"}, false},
+		{ExpressionFieldInstance{}, client.ExpressionAnswer{Expression: `\frac{2}{4}`}, false},
+		{ExpressionFieldInstance{}, client.ExpressionAnswer{Expression: `\frac{2}{`}, true},
 		{SignTableFieldInstance{
 			Answer: SignTableInstance{Xs: mustParseMany([]string{"1"}), Functions: []client.FunctionSign{{}}},
 		}, client.SignTableAnswer{Xs: []string{"1"}, Functions: []client.FunctionSign{{FxSymbols: []client.SignSymbol{client.Zero}}}}, false},
@@ -52,6 +54,8 @@ func TestNumberFieldInstance_evaluateAnswer(t *testing.T) {
 		{ExpressionFieldInstance{Answer: expression.MustParse("x+2"), ComparisonLevel: SimpleSubstitutions}, client.ExpressionAnswer{Expression: "2+x "}, true},
 		{ExpressionFieldInstance{Answer: expression.MustParse("x+2"), ComparisonLevel: ExpandedSubstitutions}, client.ExpressionAnswer{Expression: "2+ 1*x "}, true},
 		{ExpressionFieldInstance{Answer: expression.MustParse("4x+2y+2"), ComparisonLevel: AsLinearEquation}, client.ExpressionAnswer{Expression: "2x + 1 + y"}, true},
+		{ExpressionFieldInstance{Answer: expression.MustParse("sqrt(x)/2"), ComparisonLevel: SimpleSubstitutions}, client.ExpressionAnswer{Expression: `\frac{\sqrt{x}}{2}`}, true},
+		{ExpressionFieldInstance{Answer: expression.MustParse("sqrt(x)/2"), ComparisonLevel: SimpleSubstitutions}, client.ExpressionAnswer{Expression: `\frac{\sqrt{x}}{3}`}, false},
 	}
 	for _, tt := range tests {
 		f := tt.field
@@ -121,6 +125,62 @@ func TestRadio(t *testing.T) {
 	}
 }
 
+func TestTextField(t *testing.T) {
+	for _, test := range []struct {
+		field  TextFieldBlock
+		answer string
+		want   bool
+	}{
+		{TextFieldBlock{Answers: []string{"Victor Hugo"}}, "Victor Hugo", true},
+		{TextFieldBlock{Answers: []string{"Victor Hugo"}}, "  Victor   Hugo ", true},
+		{TextFieldBlock{Answers: []string{"Victor Hugo"}}, "victor hugo", false},
+		{TextFieldBlock{Answers: []string{"Victor Hugo"}, IgnoreCase: true}, "victor HUGO", true},
+		{TextFieldBlock{Answers: []string{"Hugo", "Victor Hugo"}}, "Hugo", true},
+		{TextFieldBlock{Answers: []string{"été"}}, "ete", false},
+		{TextFieldBlock{Answers: []string{"été"}, IgnoreAccents: true}, "ete", true},
+		{TextFieldBlock{Answers: []string{"Él está aquí"}, IgnoreAccents: true, IgnoreCase: true}, "el esta aqui", true},
+		{TextFieldBlock{Answers: []string{"Oui, bien sûr !"}}, "Oui bien sûr", false},
+		{TextFieldBlock{Answers: []string{"Oui, bien sûr !"}, IgnorePunctuation: true}, "Oui bien sûr", true},
+		{TextFieldBlock{Answers: []string{"Renaissance"}, MaxTypos: 1}, "Renaisance", true},
+		{TextFieldBlock{Answers: []string{"Renaissance"}, MaxTypos: 1}, "Renaisanse", false},
+		{TextFieldBlock{Answers: []string{"Renaissance"}, MaxTypos: 2}, "Renaisanse", true},
+		{TextFieldBlock{Answers: []string{"(the )?(big )?dog"}, UseRegexp: true, Correction: "the big dog"}, "the dog", true},
+		{TextFieldBlock{Answers: []string{"(the )?(big )?dog"}, UseRegexp: true, Correction: "the big dog"}, "big dog", true},
+		{TextFieldBlock{Answers: []string{"(the )?(big )?dog"}, UseRegexp: true, Correction: "the big dog"}, "the cat", false},
+		{TextFieldBlock{Answers: []string{"dog"}, UseRegexp: true, Correction: "dog"}, "the dog", false}, // must match the whole answer
+		{TextFieldBlock{Answers: []string{"dog"}, UseRegexp: true, Correction: "dog", MaxTypos: 2}, "dig", false},
+		{TextFieldBlock{Answers: []string{"1789|1792"}, UseRegexp: true, Correction: "1789"}, "1792", true},
+		{TextFieldBlock{Answers: []string{"allé"}, UseRegexp: true, Correction: "allé", IgnoreCase: true, IgnoreAccents: true}, "ALLE", true},
+	} {
+		_, err := test.field.setupValidator(nil)
+		tu.AssertNoErr(t, err)
+		inst, err := test.field.instantiate(nil, 0)
+		tu.AssertNoErr(t, err)
+		field := inst.(TextFieldInstance)
+		answer := client.TextAnswer{Text: test.answer}
+		tu.AssertNoErr(t, field.validateAnswerSyntax(answer))
+		tu.Assert(t, field.evaluateAnswer(answer) == test.want)
+		correction := test.field.Answers[0]
+		if test.field.UseRegexp {
+			correction = test.field.Correction
+		}
+		tu.Assert(t, field.correctAnswer() == client.TextAnswer{Text: correction})
+		tu.Assert(t, field.evaluateAnswer(field.correctAnswer()))
+	}
+
+	for _, field := range []TextFieldBlock{
+		{},
+		{Answers: []string{"a", " "}},
+		{Answers: []string{"a"}, MaxTypos: -1},
+		{Answers: []string{"(a"}, UseRegexp: true, Correction: "a"},
+		{Answers: []string{"1789|1792"}, UseRegexp: true},                     // missing correction
+		{Answers: []string{"1789|1792"}, UseRegexp: true, Correction: "1790"}, // correction not accepted
+	} {
+		_, err := field.setupValidator(nil)
+		tu.Assert(t, err != nil)
+	}
+}
+
 func TestBug72(t *testing.T) {
 	field := DropDownFieldInstance{
 		Proposals: []client.TextLine{
@@ -169,6 +229,101 @@ func TestFigureAffineLineField(t *testing.T) {
 	}
 }
 
+func TestGeometricConstructionFields(t *testing.T) {
+	background := FigureBlock{Bounds: repere.RepereBounds{Width: 20, Height: 20, Origin: repere.Coord{X: 10, Y: 10}}}
+	params := expression.Vars{expression.NewVar('a'): expression.NewNb(2)}
+	pt := func(x, y int) repere.IntCoord { return repere.IntCoord{X: x, Y: y} }
+	for _, test := range []struct {
+		field GeoField
+		right client.Answer
+		wrong client.Answer
+	}{
+		{
+			GFCircle{Center: CoordExpression{"1", "a"}, Radius: "sqrt(5)"},
+			client.DoublePointAnswer{From: pt(1, 2), To: pt(0, 4)},
+			client.DoublePointAnswer{From: pt(1, 2), To: pt(1, 4)},
+		},
+		{
+			GFSegment{From: CoordExpression{"0", "0"}, To: CoordExpression{"a", "3"}, LabelFrom: "A", LabelTo: "B"},
+			client.DoublePointAnswer{From: pt(0, 0), To: pt(2, 3)},
+			client.DoublePointAnswer{From: pt(2, 3), To: pt(0, 0)},
+		},
+		{
+			GFSegment{From: CoordExpression{"0", "0"}, To: CoordExpression{"a", "3"}},
+			client.DoublePointAnswer{From: pt(2, 3), To: pt(0, 0)},
+			client.DoublePointAnswer{From: pt(2, 3), To: pt(0, 1)},
+		},
+		{
+			GFLine{Through: CoordExpression{"1", "1"}, A: CoordExpression{"0", "0"}, B: CoordExpression{"a", "4"}},
+			client.DoublePointAnswer{From: pt(0, -1), To: pt(2, 3)},
+			client.DoublePointAnswer{From: pt(0, 0), To: pt(2, 4)},
+		},
+		{
+			GFLine{Through: CoordExpression{"1", "1"}, A: CoordExpression{"0", "0"}, B: CoordExpression{"a", "4"}, Perpendicular: true},
+			client.DoublePointAnswer{From: pt(3, 0), To: pt(-1, 2)},
+			client.DoublePointAnswer{From: pt(0, -1), To: pt(2, 3)},
+		},
+		{
+			GFPointOnCurve{Function: "x^2/a - 1", Variable: expression.NewVar('x')},
+			client.PointAnswer{Point: pt(-2, 1)},
+			client.PointAnswer{Point: pt(1, 0)},
+		},
+		{
+			GFAngle{Measure: "45"},
+			client.DoublePointPairAnswer{From1: pt(1, 1), To1: pt(1, 5), From2: pt(1, 1), To2: pt(3, 3)},
+			client.DoublePointPairAnswer{From1: pt(1, 1), To1: pt(1, 5), From2: pt(0, 0), To2: pt(2, 2)},
+		},
+		{
+			GFAngle{Measure: "60", Vertex: CoordExpression{"a", "0"}, MustHaveVertex: true},
+			client.DoublePointPairAnswer{From1: pt(2, 0), To1: pt(6, 0), From2: pt(2, 0), To2: pt(6, 7)},
+			client.DoublePointPairAnswer{From1: pt(0, 0), To1: pt(4, 0), From2: pt(0, 0), To2: pt(4, 7)},
+		},
+	} {
+		block := GeometricConstructionFieldBlock{Field: test.field, Background: background}
+		v, err := block.setupValidator(nil)
+		tu.AssertNoErr(t, err)
+		tu.AssertNoErr(t, v.validate(params))
+
+		inst, err := block.instantiate(params, 0)
+		tu.AssertNoErr(t, err)
+		field := inst.(GeometricConstructionFieldInstance)
+
+		ans := field.correctAnswer()
+		tu.AssertNoErr(t, field.validateAnswerSyntax(ans))
+		tu.Assert(t, field.evaluateAnswer(ans))
+		tu.Assert(t, field.evaluateAnswer(test.right))
+		tu.Assert(t, !field.evaluateAnswer(test.wrong))
+	}
+
+	for _, field := range []GeoField{
+		GFCircle{Center: CoordExpression{"0", "0"}, Radius: "sqrt(3)"},
+		GFCircle{Center: CoordExpression{"0", "0"}, Radius: "-a"},
+		GFSegment{From: CoordExpression{"a", "0"}, To: CoordExpression{"2", "0"}},
+		GFLine{Through: CoordExpression{"1", "1"}, A: CoordExpression{"a", "a"}, B: CoordExpression{"2", "2"}},
+		GFPointOnCurve{Function: "x^2 + 0.5", Variable: expression.NewVar('x')},
+		GFAngle{Measure: "200"},
+	} {
+		v, err := field.setupValidator(nil)
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, v.validate(params) != nil)
+	}
+}
+
+func TestGFAngleCorrectAnswer(t *testing.T) {
+	for measure := 1; measure <= 180; measure++ {
+		for _, offset := range []float64{0, 0.5} {
+			field := gfAngle{Measure: float64(measure) - offset}
+			tu.Assert(t, field.evaluateAnswer(field.correctAnswer(repere.RepereBounds{})))
+		}
+	}
+	field := gfAngle{Measure: 37, MustHaveVertex: true, Vertex: repere.IntCoord{X: 2, Y: -1}}
+	tu.Assert(t, field.evaluateAnswer(field.correctAnswer(repere.RepereBounds{})))
+
+	for _, measure := range []string{"3", "17", "44", "87", "93", "179.5"} {
+		tu.AssertNoErr(t, gfAngleValidator{measure: expression.MustParse(measure)}.validate(nil))
+	}
+}
+
 func Test_shufflingMap(t *testing.T) {
 	tests := []struct {
 		n int
@@ -230,6 +385,113 @@ func TestExpressionCompound(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, !inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "2x + y"}))
 	tu.Assert(t, inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "4x + 6y"}))
+
+}
+
+func TestExpressionFieldSolutionSet(t *testing.T) {
+	params := expression.Vars{expression.NewVar('a'): expression.NewNb(2)}
+	fi := ExpressionFieldBlock{
+		Expression:      "x^2 = a^2",
+		ComparisonLevel: AsSolutionSet,
+	}
+	v, err := fi.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, v.validate(params))
+	inst, err := fi.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	for _, test := range []struct {
+		answer string
+		want   bool
+	}{
+		{"x = -2 ou x = 2", true},
+		{"x = 2 or x = -2", true},
+		{"{-2 ; 2}", true},
+		{"x = 2", false},
+		{"x^2 - 4 = 0", true},
+		{"x > 2", false},
+	} {
+		tu.Assert(t, inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: test.answer}) == test.want)
+	}
+
+	fi = ExpressionFieldBlock{
+		Expression:      "2x - a > 0 et x <= 5",
+		ComparisonLevel: AsSolutionSet,
+	}
+	inst, err = fi.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "]1 ; 5]"}))
+	tu.Assert(t, inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "1 < x <= 5"}))
+	tu.Assert(t, !inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "1 <= x <= 5"}))
+
+	// the answer must describe a subset of R
+	fi = ExpressionFieldBlock{
+		Expression:      "x^3 = a",
+		ComparisonLevel: AsSolutionSet,
+	}
+	v, err = fi.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, v.validate(params) != nil)
+}
+
+func TestExpressionFieldComplex(t *testing.T) {
+	params := expression.Vars{expression.NewVar('a'): expression.NewNb(2)}
+	fi := ExpressionFieldBlock{
+		Expression:      "(1 + i)^a",
+		ComparisonLevel: AsComplexNumber,
+	}
+	v, err := fi.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, v.validate(params))
+	inst, err := fi.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "2i"}))
+	tu.Assert(t, !inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "2"}))
+
+	// i is a regular variable for the other levels
+	fi = ExpressionFieldBlock{
+		Expression:      "-1",
+		ComparisonLevel: ExpandedSubstitutions,
+	}
+	inst, err = fi.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !inst.(fieldInstance).evaluateAnswer(client.ExpressionAnswer{Expression: "i^2"}))
+}
+
+func TestExpressionFieldHints(t *testing.T) {
+	params := expression.Vars{expression.NewVar('a'): expression.NewNb(3)}
+	fi := ExpressionFieldBlock{
+		Expression:      "(x + a)^2",
+		ComparisonLevel: SimpleSubstitutions,
+		Hints:           []WrongAnswerHint{{Answer: "x^2 + a", Hint: "Relis la définition du carré."}},
+	}
+	v, err := fi.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	tu.AssertNoErr(t, v.validate(params))
+	inst, err := fi.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	diagnoser := inst.(fieldDiagnoser)
+
+	for _, test := range []struct {
+		answer string
+		want   string
+	}{
+		{"x^2 + 3", "Relis la définition du carré."},
+		{"x^2 + 9", expression.IdentityMistake.Hint()},
+		{"x^2 + 6x + 9", expression.FormMistake.Hint()},
+		{"x + 1", ""},
+		{"x +* 1", ""},
+	} {
+		tu.Assert(t, diagnoser.diagnoseAnswer(client.ExpressionAnswer{Expression: test.answer}) == test.want)
+	}
+
+	enonce, err := Enonce{fi}.InstantiateWith(params)
+	tu.AssertNoErr(t, err)
+	res := enonce.EvaluateAnswer(client.QuestionAnswersIn{Data: client.Answers{0: client.ExpressionAnswer{Expression: "x^2 + 9"}}})
+	tu.Assert(t, !res.Results[0] && res.Hints[0] == expression.IdentityMistake.Hint())
+
+	fi.Hints = []WrongAnswerHint{{Answer: "x", Hint: " "}}
+	_, err = fi.setupValidator(nil)
+	tu.Assert(t, err != nil)
 }
 
 func TestInstantiate01(t *testing.T) {
@@ -409,3 +671,144 @@ func TestTree(t *testing.T) {
 	}
 	tu.Assert(t, areTreeEquivalent(treeNodeAnswerToInstance(level2), treeNodeAnswerToInstance(level2bis)))
 }
+
+func TestMatchingField(t *testing.T) {
+	block := MatchingFieldBlock{
+		Left:            []Interpolated{"$x^2$", "$e^x$", "$x$"},
+		Right:           []Interpolated{"$2x$", "$e^x$", "$1$"},
+		AdditionalRight: []Interpolated{"$0$", "$2x$"},
+	}
+	_, err := block.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	inst, err := block.instantiate(nil, 0)
+	tu.AssertNoErr(t, err)
+	field := inst.(MatchingFieldInstance)
+
+	right := field.toClient().(client.MatchingFieldBlock).Right
+	tu.Assert(t, len(right) == 5)
+
+	ans := field.correctAnswer()
+	tu.AssertNoErr(t, field.validateAnswerSyntax(ans))
+	tu.Assert(t, field.evaluateAnswer(ans))
+
+	// duplicated items are compared by value
+	indices := ans.(client.MatchingAnswer).Indices
+	for i, item := range right {
+		if i != indices[0] && areLineEquals(item, right[indices[0]]) {
+			tu.Assert(t, field.evaluateAnswer(client.MatchingAnswer{Indices: []int{i, indices[1], indices[2]}}))
+		}
+	}
+
+	wrong := client.MatchingAnswer{Indices: []int{indices[1], indices[0], indices[2]}}
+	tu.Assert(t, !field.evaluateAnswer(wrong))
+	tu.Assert(t, reflect.DeepEqual(field.evaluateItems(wrong), []bool{false, false, true}))
+
+	tu.Assert(t, field.validateAnswerSyntax(client.MatchingAnswer{Indices: []int{0, 1}}) != nil)
+	tu.Assert(t, field.validateAnswerSyntax(client.MatchingAnswer{Indices: []int{0, 1, 5}}) != nil)
+
+	for _, block := range []MatchingFieldBlock{
+		{Left: []Interpolated{"a"}, Right: []Interpolated{"b"}},
+		{Left: []Interpolated{"a", "b"}, Right: []Interpolated{"c"}},
+		{Left: []Interpolated{"a", "&2+*&"}, Right: []Interpolated{"c", "d"}},
+	} {
+		_, err := block.setupValidator(nil)
+		tu.Assert(t, err != nil)
+	}
+}
+
+func TestCategoriesField(t *testing.T) {
+	block := CategoriesFieldBlock{
+		Categories: []Interpolated{"Rationnel", "Irrationnel"},
+		Items:      [][]Interpolated{{"$0.5$", "$1/3$", "$2$"}, {"$\\sqrt{2}$", "$\\pi$"}},
+	}
+	_, err := block.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	inst, err := block.instantiate(nil, 0)
+	tu.AssertNoErr(t, err)
+	field := inst.(CategoriesFieldInstance)
+
+	items := field.toClient().(client.CategoriesFieldBlock).Items
+	tu.Assert(t, len(items) == 5)
+
+	ans := field.correctAnswer().(client.CategoriesAnswer)
+	tu.AssertNoErr(t, field.validateAnswerSyntax(ans))
+	tu.Assert(t, field.evaluateAnswer(ans))
+	for i, category := range ans.Categories {
+		isRational := textLineToString(items[i]) != `\sqrt{2}` && textLineToString(items[i]) != `\pi`
+		tu.Assert(t, isRational == (category == 0))
+	}
+
+	wrong := client.CategoriesAnswer{Categories: []int{0, 0, 0, 0, 0}}
+	tu.Assert(t, !field.evaluateAnswer(wrong))
+	results := field.evaluateItems(wrong)
+	for i, category := range ans.Categories {
+		tu.Assert(t, results[i] == (category == 0))
+	}
+
+	qu := EnonceInstance{field}
+	out := qu.EvaluateAnswer(client.QuestionAnswersIn{Data: client.Answers{0: wrong}})
+	tu.Assert(t, !out.Results[0] && len(out.Hints) == 0)
+	tu.Assert(t, reflect.DeepEqual(out.ItemResults[0], results))
+	out = qu.EvaluateAnswer(client.QuestionAnswersIn{Data: client.Answers{0: ans}})
+	tu.Assert(t, out.Results[0] && out.ItemResults == nil)
+
+	tu.Assert(t, field.validateAnswerSyntax(client.CategoriesAnswer{Categories: []int{0, 0}}) != nil)
+	tu.Assert(t, field.validateAnswerSyntax(client.CategoriesAnswer{Categories: []int{0, 0, 0, 0, 2}}) != nil)
+
+	for _, block := range []CategoriesFieldBlock{
+		{Categories: []Interpolated{"a"}, Items: [][]Interpolated{{"b"}}},
+		{Categories: []Interpolated{"a", "b"}, Items: [][]Interpolated{{"c"}}},
+		{Categories: []Interpolated{"a", "b"}, Items: [][]Interpolated{{}, {}}},
+	} {
+		_, err := block.setupValidator(nil)
+		tu.Assert(t, err != nil)
+	}
+}
+
+func TestNumberLineField(t *testing.T) {
+	block := NumberLineFieldBlock{Answer: NumberLineBlock{Set: "]-inf;a[ ∪ [2;4] ∪ {5}", Ticks: []string{"0", "2*a"}}}
+	params := expression.Vars{expression.NewVar('a'): expression.NewNb(-1)}
+	_, err := block.setupValidator(nil)
+	tu.AssertNoErr(t, err)
+	inst, err := block.instantiate(params, 0)
+	tu.AssertNoErr(t, err)
+	field := inst.(NumberLineFieldInstance)
+
+	// -2, -1, 0, 2, 4, 5
+	ticks := field.toClient().(client.NumberLineFieldBlock).Ticks
+	tu.Assert(t, reflect.DeepEqual(ticks, []string{"-2", "-1", "0", "2", "4", "5"}))
+
+	ans := field.correctAnswer().(client.NumberLineAnswer)
+	tu.Assert(t, reflect.DeepEqual(ans.Pieces, []bool{
+		true, true, true, false, false, false, false, true, true, true, false, true, false,
+	}))
+	tu.AssertNoErr(t, field.validateAnswerSyntax(ans))
+	tu.Assert(t, field.evaluateAnswer(ans))
+
+	wrong := client.NumberLineAnswer{Pieces: append([]bool(nil), ans.Pieces...)}
+	wrong.Pieces[7] = false // ]2;4]
+	tu.Assert(t, !field.evaluateAnswer(wrong))
+	tu.Assert(t, field.diagnoseAnswer(wrong) != "")
+	wrong.Pieces[8] = false
+	tu.Assert(t, field.diagnoseAnswer(wrong) == "")
+
+	tu.Assert(t, field.validateAnswerSyntax(client.NumberLineAnswer{Pieces: []bool{true}}) != nil)
+
+	// the bounds are displayed with their exact expression
+	nl, err := NumberLineBlock{Set: "[1/3; sqrt(2)] ∪ x > 7/2"}.instantiateNL(nil)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, reflect.DeepEqual(nl.tickLabels(), []string{
+		expression.MustParse("1/3").AsLaTeX(), expression.MustParse("sqrt(2)").AsLaTeX(), expression.MustParse("7/2").AsLaTeX(),
+	}))
+
+	for _, block := range []NumberLineBlock{
+		{Set: "[1;2] ∪ "},
+		{Set: "[1;2", Ticks: nil},
+		{Set: "[1;2]", Ticks: []string{"2+"}},
+	} {
+		_, err := block.setupValidator(nil)
+		tu.Assert(t, err != nil)
+	}
+	_, err = NumberLineBlock{Set: "{1;2;3;4;5;6;7;8;9;10;11}"}.instantiate(nil, 0)
+	tu.Assert(t, err != nil)
+}
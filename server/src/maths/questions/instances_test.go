@@ -163,3 +163,32 @@ func TestIssue373(t *testing.T) {
 	})
 	tu.Assert(t, out.IsCorrect())
 }
+
+func TestInstantiateHint(t *testing.T) {
+	page := QuestionPage{
+		Parameters: Parameters{Rp{Variable: ex.NewVar('a'), Expression: "randint(2;5)"}},
+		Enonce:     Enonce{NumberFieldBlock{Expression: "a"}},
+		Hints: Hints{
+			{TextBlock{Parts: "Utiliser &a&"}},
+			{FormulaBlock{Parts: "&a + 1&"}},
+		},
+	}
+	tu.AssertNoErr(t, page.Validate())
+
+	_, vars, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+	a := vars[ex.NewVar('a')].String()
+
+	hint, err := page.InstantiateHint(vars, 0)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(hint) == 1)
+	tu.Assert(t, hint[0].(client.TextBlock).Parts[1].Text == a)
+
+	_, err = page.InstantiateHint(vars, 2)
+	tu.Assert(t, err != nil)
+
+	// hints are validated
+	page.Hints = append(page.Hints, Enonce{FormulaBlock{Parts: "&2 +&"}})
+	err = page.Validate()
+	tu.Assert(t, err != nil && err.(ErrQuestionInvalid).Kind == ErrHint && err.(ErrQuestionInvalid).HintIndex == 2)
+}
@@ -1,7 +1,9 @@
 package questions
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
@@ -10,16 +12,119 @@ import (
 
 // Interpolated is a string with $<static math>$ or &<expression>&
 // delimiters, with && are allowed in $$.
-// Also, $$ ... $$ may be used to define a FormulaBlock, and
-// # ... # to define NumberFieldBlock
+// Also, $$ ... $$ may be used to define a FormulaBlock,
+// # ... # to define NumberFieldBlock, and the following blanks
+// to define inline fields :
+//   - [[texte: answer1 | answer2]] for a TextFieldBlock (case insensitive)
+//   - [[choix: proposal1 | *correct proposal | proposal3]] for a drop down RadioFieldBlock
+//   - [[expression: expr]] for an ExpressionFieldBlock
 type Interpolated string
 
 const (
 	iText uint8 = iota
 	iFormula
 	iNumberField
+	iTextField
+	iDropDownField
+	iExpressionField
 )
 
+// reBlank matches the inline fields [[<kind>: <content>]]
+var reBlank = regexp.MustCompile(`\[\[\s*(texte|choix|expression)\s*:(.*?)\]\]`)
+
+var blankKinds = map[string]uint8{
+	"texte":      iTextField,
+	"choix":      iDropDownField,
+	"expression": iExpressionField,
+}
+
+// splitFields looks for blanks and number fields in [text]
+func splitFields(text string) (out []textChunck) {
+	cursor := 0
+	for _, match := range reBlank.FindAllStringSubmatchIndex(text, -1) {
+		if match[0] > cursor {
+			out = append(out, splitNumberField(text[cursor:match[0]])...)
+		}
+		kind := blankKinds[text[match[2]:match[3]]]
+		out = append(out, textChunck{s: strings.TrimSpace(text[match[4]:match[5]]), kind: kind})
+		cursor = match[1]
+	}
+	if cursor < len(text) {
+		out = append(out, splitNumberField(text[cursor:])...)
+	}
+	return out
+}
+
+// splitBlankOptions splits the content of a blank on '|',
+// ignoring the separators inside $ $ and & &, and trimming the options
+func splitBlankOptions(content string) []string {
+	var (
+		out            []string
+		inMath, inExpr bool
+		cursor         int
+	)
+	for i, r := range content {
+		switch r {
+		case '$':
+			inMath = !inMath
+		case '&':
+			inExpr = !inExpr
+		case '|':
+			if !inMath && !inExpr {
+				out = append(out, strings.TrimSpace(content[cursor:i]))
+				cursor = i + 1
+			}
+		}
+	}
+	return append(out, strings.TrimSpace(content[cursor:]))
+}
+
+// blankDropDown returns the drop down field defined by [content],
+// where the correct proposal is prefixed by a star.
+// If the star is missing (or duplicated), the answer is set to 0,
+// which is reported as invalid by [validateBlanks].
+func blankDropDown(content string) RadioFieldBlock {
+	options := splitBlankOptions(content)
+	out := RadioFieldBlock{Answer: "0", Proposals: make([]Interpolated, len(options)), AsDropDown: true}
+	nbCorrect := 0
+	for i, option := range options {
+		if strings.HasPrefix(option, "*") {
+			nbCorrect++
+			out.Answer = strconv.Itoa(i + 1)
+			option = strings.TrimSpace(option[1:])
+		}
+		out.Proposals[i] = Interpolated(option)
+	}
+	if nbCorrect != 1 {
+		out.Answer = "0"
+	}
+	return out
+}
+
+// validateBlanks checks the syntax of the blanks, which
+// can't be reported by the validation of the fields
+func (s Interpolated) validateBlanks() error {
+	for _, match := range reBlank.FindAllStringSubmatch(string(s), -1) {
+		kind, content := blankKinds[match[1]], strings.TrimSpace(match[2])
+		if content == "" {
+			return fmt.Errorf("Le champ %s est vide.", match[0])
+		}
+		if kind != iDropDownField {
+			continue
+		}
+		nbCorrect := 0
+		for _, option := range splitBlankOptions(content) {
+			if strings.HasPrefix(option, "*") {
+				nbCorrect++
+			}
+		}
+		if nbCorrect != 1 {
+			return fmt.Errorf("Le champ %s doit indiquer exactement une bonne réponse, précédée de *.", match[0])
+		}
+	}
+	return nil
+}
+
 type textChunck struct {
 	s    string
 	kind uint8
@@ -46,7 +151,7 @@ func splitNumberField(text string) (out []textChunck) {
 	return out
 }
 
-// parseFormula looks for $$ $$ lines and # # chunks
+// parseFormula looks for $$ $$ lines, [[ ]] blanks and # # chunks
 func (s Interpolated) parseFormula() (out []textChunck) {
 	if s == "" {
 		// always return at least one chunk
@@ -74,13 +179,13 @@ func (s Interpolated) parseFormula() (out []textChunck) {
 		tmp = append(tmp, textChunck{strings.Join(currentLines, "\n"), iText})
 	}
 
-	// parse lines again to look for #
+	// parse lines again to look for blanks and #
 	for _, line := range tmp {
 		if line.kind == iFormula {
 			out = append(out, line)
 			continue
 		}
-		out = append(out, splitNumberField(line.s)...)
+		out = append(out, splitFields(line.s)...)
 	}
 	return out
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
 )
 
 const regularText = "This is a regular text"
@@ -198,3 +199,59 @@ func Test_splitNumberField(t *testing.T) {
 
 	}
 }
+
+func Test_splitFields(t *testing.T) {
+	tests := []struct {
+		args    string
+		wantOut []textChunck
+	}{
+		{"", nil},
+		{"regular [[inconnu: a]]", []textChunck{text("regular [[inconnu: a]]")}},
+		{"[[texte: Paris]]", []textChunck{{"Paris", iTextField}}},
+		{"La capitale est [[texte: Paris | paris]].", []textChunck{text("La capitale est "), {"Paris | paris", iTextField}, text(".")}},
+		{"[[choix: *oui|non]] et #x#", []textChunck{{"*oui|non", iDropDownField}, text(" et "), nb("x")}},
+		{"$f(x) = $ [[ expression : 2x + 1 ]]", []textChunck{text("$f(x) = $ "), {"2x + 1", iExpressionField}}},
+	}
+	for _, tt := range tests {
+		if gotOut := splitFields(tt.args); !reflect.DeepEqual(gotOut, tt.wantOut) {
+			t.Errorf("splitFields() = %v, want %v", gotOut, tt.wantOut)
+		}
+	}
+}
+
+func Test_splitBlankOptions(t *testing.T) {
+	tu.Assert(t, reflect.DeepEqual(splitBlankOptions("a"), []string{"a"}))
+	tu.Assert(t, reflect.DeepEqual(splitBlankOptions(" a | b |c "), []string{"a", "b", "c"}))
+	tu.Assert(t, reflect.DeepEqual(splitBlankOptions("$|x|$ | &abs(a)|b& | c"), []string{"$|x|$", "&abs(a)|b&", "c"}))
+}
+
+func TestBlanks(t *testing.T) {
+	page := QuestionPage{Enonce: Enonce{TextBlock{Parts: "Paris est [[texte: la capitale]] de la [[choix: Belgique | *France]], et $f'(x) = $ [[expression: 2x + a]]."}},
+		Parameters: Parameters{Rp{Variable: expression.NewVar('a'), Expression: "3"}},
+	}
+	tu.AssertNoErr(t, page.Validate())
+
+	instance, _, err := page.InstantiateErr()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(instance.Enonce) == 7)
+	text := instance.Enonce[1].(TextFieldInstance)
+	tu.Assert(t, text.ID == 0 && text.IgnoreCase && text.Answers[0] == "la capitale")
+	dropDown := instance.Enonce[3].(DropDownFieldInstance)
+	tu.Assert(t, dropDown.ID == 1 && dropDown.Answer == 2 && len(dropDown.Proposals) == 2)
+	expr := instance.Enonce[5].(ExpressionFieldInstance)
+	tu.Assert(t, expr.ID == 2 && expr.Answer.String() == "2x + 3")
+
+	answers := instance.Enonce.CorrectAnswer()
+	tu.Assert(t, len(answers.Data) == 3)
+	tu.Assert(t, instance.Enonce.EvaluateAnswer(answers).IsCorrect())
+
+	for _, invalid := range []Interpolated{
+		"[[choix: a | b]]",
+		"[[choix: *a | *b]]",
+		"[[texte:  ]]",
+		"[[expression: 2x + ]]",
+	} {
+		err := QuestionPage{Enonce: Enonce{TextBlock{Parts: invalid}}}.Validate()
+		tu.Assert(t, err != nil)
+	}
+}
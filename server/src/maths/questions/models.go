@@ -17,6 +17,11 @@ type Parameters []ParameterEntry
 
 type Enonce []Block
 
+// Hints is an ordered list of help contents, revealed
+// one by one to the students, and instantiated with
+// the same parameters as the [Enonce]
+type Hints []Enonce
+
 type GeometricConstructionFieldBlock struct {
 	Field      GeoField
 	Background FiguresOrGraphs
@@ -54,6 +59,10 @@ func dumpJSON(s interface{}) (driver.Value, error) {
 func (s *Enonce) Scan(src interface{}) error  { return loadJSON(s, src) }
 func (s Enonce) Value() (driver.Value, error) { return dumpJSON(s) }
 
+// Scan implements the driver.Scanner interface using JSON
+func (s *Hints) Scan(src interface{}) error  { return loadJSON(s, src) }
+func (s Hints) Value() (driver.Value, error) { return dumpJSON(s) }
+
 // Scan implements the driver.Scanner interface using JSON
 func (s *Parameters) Scan(src interface{}) error  { return loadJSON(s, src) }
 func (s Parameters) Value() (driver.Value, error) { return dumpJSON(s) }
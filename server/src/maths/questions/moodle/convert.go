@@ -0,0 +1,363 @@
+package moodle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+)
+
+// this file implements the mapping between [lmsQuestion] and [questions.QuestionPage]
+
+// ---------------------------------- export ----------------------------------
+
+// clozePart is either a text (HTML) or an embedded field
+type clozePart struct {
+	text  string
+	field *lmsQuestion
+}
+
+// blockName returns a readable name for the given instance
+func blockName(block any) string {
+	name := fmt.Sprintf("%T", block)
+	name = strings.TrimPrefix(name, "questions.")
+	return strings.TrimSuffix(name, "Instance")
+}
+
+func fromPage(qu Question, report *Report) (lmsQuestion, bool) {
+	instance, _, err := qu.Page.InstantiateErr()
+	if err != nil {
+		report.addf(qu.Name, "instanciation impossible (%s)", err)
+		return lmsQuestion{}, false
+	}
+	if len(qu.Page.Parameters) != 0 {
+		report.addf(qu.Name, "les paramètres aléatoires sont remplacés par une valeur tirée au hasard")
+	}
+
+	var parts []clozePart
+	addText := func(s string) {
+		if L := len(parts); L != 0 && parts[L-1].field == nil {
+			parts[L-1].text += s
+		} else {
+			parts = append(parts, clozePart{text: s})
+		}
+	}
+	for _, block := range instance.Enonce {
+		switch block := block.(type) {
+		case questions.TextInstance:
+			text := textLineToHTML(block.Parts)
+			if block.Bold {
+				text = "<b>" + text + "</b>"
+			}
+			if block.Italic {
+				text = "<i>" + text + "</i>"
+			}
+			addText(text)
+		case questions.FormulaDisplayInstance:
+			addText(`<p>\[` + strings.Join(block, " ") + `\]</p>`)
+		case questions.ImageInstance:
+			addText(fmt.Sprintf(`<p><img src="%s" alt="image"></p>`, block.URL))
+		case questions.NumberFieldInstance:
+			parts = append(parts, clozePart{field: &lmsQuestion{
+				kind:    numerical,
+				answers: []lmsAnswer{{Text: formatNumber(block.Answer), Fraction: 100}},
+			}})
+		case questions.ExpressionFieldInstance:
+			report.addf(qu.Name, "l'expression attendue est comparée comme un texte, sans équivalence mathématique")
+			if block.LabelLaTeX != "" {
+				addText(`<br>\(` + block.LabelLaTeX + `\) `)
+			}
+			parts = append(parts, clozePart{field: &lmsQuestion{
+				kind:    shortAnswer,
+				usecase: true,
+				answers: []lmsAnswer{{Text: block.Answer.String(), Fraction: 100}},
+			}})
+		case questions.TextFieldInstance:
+			if block.Patterns != nil {
+				report.addf(qu.Name, "les expressions régulières ne sont pas supportées : le champ texte n'est pas exporté")
+				continue
+			}
+			if block.MaxTypos != 0 || block.IgnoreAccents || block.IgnorePunctuation {
+				report.addf(qu.Name, "les tolérances (accents, ponctuation, fautes de frappe) du champ texte ne sont pas exportées")
+			}
+			field := lmsQuestion{kind: shortAnswer, usecase: !block.IgnoreCase}
+			for _, answer := range block.Answers {
+				field.answers = append(field.answers, lmsAnswer{Text: answer, Fraction: 100})
+			}
+			parts = append(parts, clozePart{field: &field})
+		case questions.RadioFieldInstance:
+			parts = append(parts, clozePart{field: choicesField(block, false)})
+		case questions.DropDownFieldInstance:
+			parts = append(parts, clozePart{field: choicesField(questions.RadioFieldInstance(block), true)})
+		case questions.MatchingFieldInstance:
+			parts = append(parts, clozePart{field: matchingField(block)})
+		default:
+			report.addf(qu.Name, "le contenu %s n'est pas supporté et n'est pas exporté", blockName(block))
+		}
+	}
+
+	out := lmsQuestion{name: qu.Name}
+	if len(instance.Correction) != 0 {
+		var feedback strings.Builder
+		for _, block := range instance.Correction {
+			switch block := block.(type) {
+			case questions.TextInstance:
+				feedback.WriteString(textLineToHTML(block.Parts))
+			case questions.FormulaDisplayInstance:
+				feedback.WriteString(`<p>\[` + strings.Join(block, " ") + `\]</p>`)
+			default:
+				report.addf(qu.Name, "le contenu %s de la correction n'est pas exporté", blockName(block))
+			}
+		}
+		out.feedback = feedback.String()
+	}
+
+	parts = filterMatching(qu.Name, parts, report)
+	var fields []*lmsQuestion
+	for _, part := range parts {
+		if part.field != nil {
+			fields = append(fields, part.field)
+		}
+	}
+	isLastField := len(parts) != 0 && parts[len(parts)-1].field != nil
+	switch {
+	case len(fields) == 0:
+		out.kind = description
+		if len(parts) != 0 {
+			out.text = parts[0].text
+		}
+	case len(fields) == 1 && isLastField && !fields[0].dropdown:
+		// use the specialized question type
+		field := *fields[0]
+		field.name, field.feedback = out.name, out.feedback
+		if len(parts) == 2 {
+			field.text = parts[0].text
+		}
+		out = field
+	default:
+		out.kind = cloze
+		out.parts = parts
+	}
+	return out, true
+}
+
+func choicesField(field questions.RadioFieldInstance, dropdown bool) *lmsQuestion {
+	out := lmsQuestion{kind: multiChoice, single: true, dropdown: dropdown}
+	for i, proposal := range field.Proposals {
+		answer := lmsAnswer{Text: textLineToHTML(proposal)}
+		if i == field.Answer-1 {
+			answer.Fraction = 100
+		}
+		out.answers = append(out.answers, answer)
+	}
+	return &out
+}
+
+// matchingField uses subquestions without text for the distractors
+func matchingField(field questions.MatchingFieldInstance) *lmsQuestion {
+	out := lmsQuestion{kind: matching}
+	for i, left := range field.Left {
+		out.subquestions = append(out.subquestions, lmsSubquestion{
+			Text:   textLineToHTML(left),
+			Answer: textLineToPlain(field.Right[i]),
+		})
+	}
+	for _, right := range field.AdditionalRight {
+		out.subquestions = append(out.subquestions, lmsSubquestion{Answer: textLineToPlain(right)})
+	}
+	return &out
+}
+
+// filterMatching removes the matching fields from [parts], unless
+// the question only has one, at the end : LMS matching questions may
+// not be embedded in cloze questions.
+func filterMatching(name string, parts []clozePart, report *Report) []clozePart {
+	var nbFields, nbMatching int
+	for _, part := range parts {
+		if part.field != nil {
+			nbFields++
+			if part.field.kind == matching {
+				nbMatching++
+			}
+		}
+	}
+	isLastField := len(parts) != 0 && parts[len(parts)-1].field != nil
+	if nbMatching == 0 || (nbFields == 1 && isLastField) {
+		return parts
+	}
+	report.addf(name, "un champ d'association doit être le seul champ, en fin de question : il n'est pas exporté")
+	var out []clozePart
+	for _, part := range parts {
+		switch {
+		case part.field != nil && part.field.kind == matching:
+			continue
+		case part.field == nil && len(out) != 0 && out[len(out)-1].field == nil:
+			out[len(out)-1].text += part.text // merge the texts around the removed field
+		default:
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// ---------------------------------- import ----------------------------------
+
+func (lq lmsQuestion) toPage(report *Report) (questions.QuestionPage, bool) {
+	var enonce questions.Enonce
+	addText := func(html string) {
+		if text := toInterpolated(html, !lq.plain); text != "" {
+			enonce = append(enonce, questions.TextBlock{Parts: text})
+		}
+	}
+
+	switch lq.kind {
+	case description:
+		addText(lq.text)
+	case numerical, shortAnswer, multiChoice, trueFalse:
+		addText(lq.text)
+		field, ok := lq.toField(report)
+		if !ok {
+			return questions.QuestionPage{}, false
+		}
+		enonce = append(enonce, field)
+	case cloze:
+		for _, part := range lq.parts {
+			if part.field == nil {
+				// do not trim : texts and fields are displayed on the same line
+				if text := toInterpolated(part.text, !lq.plain); text != "" {
+					enonce = append(enonce, questions.TextBlock{Parts: text + " "})
+				}
+				continue
+			}
+			part.field.name = lq.name
+			field, ok := part.field.toField(report)
+			if !ok {
+				return questions.QuestionPage{}, false
+			}
+			enonce = append(enonce, field)
+		}
+	case matching:
+		addText(lq.text)
+		var field questions.MatchingFieldBlock
+		for _, sub := range lq.subquestions {
+			right := toInterpolated(sub.Answer, false)
+			if left := toInterpolated(sub.Text, !lq.plain); left != "" {
+				field.Left = append(field.Left, left)
+				field.Right = append(field.Right, right)
+			} else { // distractor
+				field.AdditionalRight = append(field.AdditionalRight, right)
+			}
+		}
+		enonce = append(enonce, field)
+	default:
+		report.addf(lq.name, "le type de question %s n'est pas supporté", lq.typeName)
+		return questions.QuestionPage{}, false
+	}
+
+	page := questions.QuestionPage{Enonce: enonce}
+	if text := toInterpolated(lq.feedback, !lq.plain); text != "" {
+		page.Correction = questions.Enonce{questions.TextBlock{Parts: text}}
+	}
+
+	if err := page.Validate(); err != nil {
+		report.addf(lq.name, "la question convertie est invalide (%s)", err)
+		return questions.QuestionPage{}, false
+	}
+	return page, true
+}
+
+// toField converts a numerical, short answer or choices question
+// to the equivalent answer field.
+func (lq lmsQuestion) toField(report *Report) (questions.Block, bool) {
+	if lq.kind == unsupported {
+		report.addf(lq.name, "le champ %s n'est pas supporté", lq.typeName)
+		return nil, false
+	}
+	if lq.kind == multiChoice && !lq.single {
+		report.addf(lq.name, "les questions à réponses multiples ne sont pas supportées")
+		return nil, false
+	}
+	correct := lq.correctAnswers()
+	if lq.kind != trueFalse && len(correct) == 0 {
+		report.addf(lq.name, "aucune réponse correcte (à 100%%) n'est définie")
+		return nil, false
+	}
+	if len(correct) != len(lq.answers) && (lq.kind == numerical || lq.kind == shortAnswer) {
+		report.addf(lq.name, "les réponses partiellement correctes sont ignorées")
+	}
+
+	switch lq.kind {
+	case numerical:
+		if len(correct) > 1 {
+			report.addf(lq.name, "seule la première réponse numérique est utilisée")
+		}
+		answer := correct[0]
+		if answer.Tolerance != 0 {
+			report.addf(lq.name, "la tolérance %s est ignorée", formatNumber(answer.Tolerance))
+		}
+		number := strings.ReplaceAll(strings.TrimSpace(answer.Text), ",", ".")
+		if _, err := expression.Parse(number); err != nil {
+			report.addf(lq.name, "la réponse numérique %s est invalide", answer.Text)
+			return nil, false
+		}
+		return questions.NumberFieldBlock{Expression: number}, true
+	case shortAnswer:
+		field := questions.TextFieldBlock{IgnoreCase: !lq.usecase}
+		hasWildcard := false
+		for _, answer := range correct {
+			if strings.Contains(answer.Text, "*") {
+				hasWildcard = true
+			}
+		}
+		for _, answer := range correct {
+			text := toPlainText(answer.Text)
+			if hasWildcard { // the * wildcard matches any string
+				if field.Correction == "" {
+					field.Correction = strings.TrimSpace(strings.ReplaceAll(text, "*", ""))
+				}
+				chunks := strings.Split(text, "*")
+				for i, c := range chunks {
+					chunks[i] = regexp.QuoteMeta(c)
+				}
+				text = strings.Join(chunks, ".*")
+			}
+			field.Answers = append(field.Answers, text)
+		}
+		field.UseRegexp = hasWildcard
+		return field, true
+	case multiChoice:
+		field := questions.RadioFieldBlock{AsDropDown: lq.dropdown}
+		best := 0
+		for i, answer := range lq.answers {
+			field.Proposals = append(field.Proposals, toInterpolated(answer.Text, !lq.plain))
+			if answer.Fraction > lq.answers[best].Fraction {
+				best = i
+			}
+			if 0 < answer.Fraction && answer.Fraction < 100 {
+				report.addf(lq.name, "la réponse partiellement correcte %q est considérée fausse", toPlainText(answer.Text))
+			}
+		}
+		field.Answer = strconv.Itoa(best + 1)
+		return field, true
+	case trueFalse:
+		answer := "2"
+		if len(correct) != 0 && isTrue(correct[0].Text) {
+			answer = "1"
+		}
+		return questions.RadioFieldBlock{Proposals: []questions.Interpolated{"Vrai", "Faux"}, Answer: answer}, true
+	default:
+		panic("exhaustive switch")
+	}
+}
+
+func isTrue(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "vrai":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,359 @@
+package moodle
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// this file implements the GIFT text format
+// See https://docs.moodle.org/en/GIFT_format
+
+const giftSpecials = `\~=#{}:`
+
+var giftEscaper = strings.NewReplacer(`\`, `\\`, "~", `\~`, "=", `\=`, "#", `\#`, "{", `\{`, "}", `\}`, ":", `\:`)
+
+// giftAnswers returns the content of the answer block
+func giftAnswers(field lmsQuestion) string {
+	var chunks []string
+	switch field.kind {
+	case numerical:
+		if len(field.answers) == 1 {
+			answer := field.answers[0]
+			return "#" + giftEscaper.Replace(answer.Text) + ":" + formatNumber(answer.Tolerance)
+		}
+		for _, answer := range field.answers {
+			chunks = append(chunks, giftWeight("=", answer.Fraction)+giftEscaper.Replace(answer.Text)+":"+formatNumber(answer.Tolerance))
+		}
+		return "#" + strings.Join(chunks, " ")
+	case trueFalse:
+		if len(field.correctAnswers()) != 0 && isTrue(field.correctAnswers()[0].Text) {
+			return "T"
+		}
+		return "F"
+	case shortAnswer:
+		for _, answer := range field.answers {
+			chunks = append(chunks, giftWeight("=", answer.Fraction)+giftEscaper.Replace(answer.Text))
+		}
+	case multiChoice:
+		for _, answer := range field.answers {
+			marker := "~"
+			if answer.Fraction == 100 {
+				marker = "="
+			}
+			chunks = append(chunks, giftWeight(marker, answer.Fraction)+giftEscaper.Replace(answer.Text))
+		}
+	case matching:
+		for _, sub := range field.subquestions {
+			if sub.Text == "" { // distractors are not supported
+				continue
+			}
+			chunks = append(chunks, "="+giftEscaper.Replace(sub.Text)+" -> "+giftEscaper.Replace(sub.Answer))
+		}
+	}
+	return strings.Join(chunks, " ")
+}
+
+func hasDistractors(subs []lmsSubquestion) bool {
+	for _, sub := range subs {
+		if sub.Text == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// giftWeight returns the answer marker, with the
+// optional %n% weight
+func giftWeight(marker string, fraction float64) string {
+	if fraction == 100 || fraction == 0 {
+		return marker
+	}
+	return marker + "%" + formatNumber(fraction) + "%"
+}
+
+func writeGIFT(list []lmsQuestion, report *Report) []byte {
+	var out strings.Builder
+	for _, lq := range list {
+		out.WriteString("::" + giftEscaper.Replace(lq.name) + "::[html]")
+
+		feedback := ""
+		if lq.feedback != "" {
+			feedback = " ####" + giftEscaper.Replace(lq.feedback)
+		}
+
+		switch lq.kind {
+		case description:
+			out.WriteString(giftEscaper.Replace(lq.text))
+			if feedback != "" {
+				report.addf(lq.name, "la correction d'une question sans champ de réponse n'est pas exportée")
+			}
+		case cloze:
+			// GIFT only supports one answer block per question
+			fieldCount := 0
+			for _, part := range lq.parts {
+				if part.field == nil {
+					out.WriteString(giftEscaper.Replace(part.text))
+					continue
+				}
+				fieldCount++
+				if fieldCount == 1 {
+					out.WriteString("{" + giftAnswers(*part.field) + feedback + "}")
+				} else {
+					out.WriteString("_____")
+				}
+			}
+			if fieldCount > 1 {
+				report.addf(lq.name, "le format GIFT ne supporte qu'un champ par question : seul le premier champ est exporté")
+			}
+		default:
+			if lq.kind == shortAnswer && lq.usecase {
+				report.addf(lq.name, "le format GIFT ne distingue pas les majuscules des minuscules")
+			}
+			if lq.kind == matching && hasDistractors(lq.subquestions) {
+				report.addf(lq.name, "le format GIFT ne supporte pas les propositions supplémentaires d'un champ d'association")
+			}
+			out.WriteString(giftEscaper.Replace(lq.text) + " {" + giftAnswers(lq) + feedback + "}")
+		}
+		out.WriteString("\n\n")
+	}
+	return []byte(out.String())
+}
+
+// indexUnescaped returns the index of the first unescaped occurence of [sub]
+// in [s], or -1
+func indexUnescaped(s string, sub string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++ // skip the escaped character
+		} else if strings.HasPrefix(s[i:], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitGIFTQuestions removes the comments and categories,
+// and returns the source of each question
+func splitGIFTQuestions(content string) (out []string) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimPrefix(content, "\ufeff") // BOM
+	var current []string
+	flush := func() {
+		if len(current) != 0 {
+			source := strings.Join(current, "\n")
+			if !strings.HasPrefix(source, "$CATEGORY:") {
+				out = append(out, source)
+			}
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		current = append(current, trimmed)
+	}
+	flush()
+	return out
+}
+
+func parseGIFT(content string) ([]lmsQuestion, error) {
+	sources := splitGIFTQuestions(content)
+	if len(sources) == 0 {
+		return nil, errors.New("Le fichier GIFT ne contient aucune question.")
+	}
+	out := make([]lmsQuestion, len(sources))
+	for i, source := range sources {
+		out[i] = parseGIFTQuestion(source)
+	}
+	return out, nil
+}
+
+func parseGIFTQuestion(source string) lmsQuestion {
+	var lq lmsQuestion
+	if strings.HasPrefix(source, "::") {
+		if end := indexUnescaped(source[2:], "::"); end != -1 {
+			lq.name = strings.TrimSpace(unescape(source[2:end+2], giftSpecials))
+			source = strings.TrimSpace(source[end+4:])
+		}
+	}
+	lq.plain = true
+	if strings.HasPrefix(source, "[") {
+		if end := strings.IndexByte(source, ']'); end != -1 {
+			lq.plain = source[1:end] != "html"
+			source = source[end+1:]
+		}
+	}
+
+	start := indexUnescaped(source, "{")
+	if start == -1 {
+		lq.kind = description
+		lq.text = unescape(source, giftSpecials)
+		return lq
+	}
+	end := indexUnescaped(source[start:], "}")
+	if end == -1 {
+		end = len(source)
+	} else {
+		end += start
+	}
+	before, block := source[:start], source[start+1:end]
+	after := ""
+	if end < len(source) {
+		after = source[end+1:]
+	}
+
+	if i := indexUnescaped(block, "####"); i != -1 {
+		lq.feedback = unescape(strings.TrimSpace(block[i+4:]), giftSpecials)
+		block = block[:i]
+	}
+	field := parseGIFTAnswers(strings.TrimSpace(block))
+	field.name, field.plain = lq.name, lq.plain
+
+	before = unescape(strings.TrimSpace(before), giftSpecials)
+	after = unescape(strings.TrimSpace(after), giftSpecials)
+	if after == "" || field.kind == unsupported || field.kind == matching {
+		// standard question
+		field.text, field.feedback = before, lq.feedback
+		return field
+	}
+
+	// missing word format
+	lq.kind = cloze
+	if before != "" {
+		lq.parts = append(lq.parts, clozePart{text: before})
+	}
+	lq.parts = append(lq.parts, clozePart{field: &field})
+	lq.parts = append(lq.parts, clozePart{text: after})
+	return lq
+}
+
+// splitGIFTAnswers splits [block] on the unescaped '=' and '~' markers,
+// which are kept at the start of each chunk.
+func splitGIFTAnswers(block string) (out []string) {
+	start := -1
+	for i := 0; i < len(block); i++ {
+		switch block[i] {
+		case '\\':
+			i++
+		case '=', '~':
+			if start != -1 {
+				out = append(out, strings.TrimSpace(block[start:i]))
+			}
+			start = i
+		}
+	}
+	if start != -1 {
+		out = append(out, strings.TrimSpace(block[start:]))
+	}
+	return out
+}
+
+// parseGIFTWeight parses the answer marker and its optional %n% weight
+func parseGIFTWeight(chunk string) (text string, fraction float64) {
+	marker, text := chunk[0], strings.TrimSpace(chunk[1:])
+	if marker == '=' {
+		fraction = 100
+	}
+	if strings.HasPrefix(text, "%") {
+		if end := strings.IndexByte(text[1:], '%'); end != -1 {
+			fraction, _ = strconv.ParseFloat(text[1:end+1], 64)
+			text = strings.TrimSpace(text[end+2:])
+		}
+	}
+	// remove the answer feedback
+	if i := indexUnescaped(text, "#"); i != -1 {
+		text = strings.TrimSpace(text[:i])
+	}
+	return text, fraction
+}
+
+func parseGIFTNumber(s string) lmsAnswer {
+	if i := indexUnescaped(s, "#"); i != -1 { // answer feedback
+		s = strings.TrimSpace(s[:i])
+	}
+	if min, max, isRange := strings.Cut(s, ".."); isRange {
+		a, _ := strconv.ParseFloat(strings.TrimSpace(min), 64)
+		b, _ := strconv.ParseFloat(strings.TrimSpace(max), 64)
+		return lmsAnswer{Text: formatNumber((a + b) / 2), Tolerance: (b - a) / 2}
+	}
+	value, tolerance, _ := strings.Cut(s, ":")
+	out := lmsAnswer{Text: strings.TrimSpace(value)}
+	out.Tolerance, _ = strconv.ParseFloat(strings.TrimSpace(tolerance), 64)
+	return out
+}
+
+// parseGIFTAnswers parses the content of an answer block
+// (without the general feedback)
+func parseGIFTAnswers(block string) lmsQuestion {
+	out := lmsQuestion{kind: unsupported, typeName: "essay"}
+	if block == "" {
+		return out
+	}
+
+	if strings.HasPrefix(block, "#") {
+		out.kind = numerical
+		chunks := splitGIFTAnswers(block[1:])
+		if len(chunks) == 0 {
+			answer := parseGIFTNumber(block[1:])
+			answer.Fraction = 100
+			out.answers = []lmsAnswer{answer}
+			return out
+		}
+		for _, chunk := range chunks {
+			text, fraction := parseGIFTWeight(chunk)
+			answer := parseGIFTNumber(text)
+			answer.Fraction = fraction
+			out.answers = append(out.answers, answer)
+		}
+		return out
+	}
+
+	head := block
+	if i := indexUnescaped(block, "#"); i != -1 {
+		head = strings.TrimSpace(block[:i])
+	}
+	switch head {
+	case "T", "TRUE":
+		out.kind = trueFalse
+		out.answers = []lmsAnswer{{Text: "true", Fraction: 100}, {Text: "false"}}
+		return out
+	case "F", "FALSE":
+		out.kind = trueFalse
+		out.answers = []lmsAnswer{{Text: "true"}, {Text: "false", Fraction: 100}}
+		return out
+	}
+
+	chunks := splitGIFTAnswers(block)
+	out.kind, out.typeName = shortAnswer, "shortanswer"
+	for _, chunk := range chunks {
+		if chunk[0] == '~' {
+			out.kind, out.typeName = multiChoice, "multichoice"
+		}
+		if chunk[0] == '=' && indexUnescaped(chunk, "->") != -1 {
+			out.kind, out.typeName = matching, "matching"
+		}
+	}
+	for _, chunk := range chunks {
+		text, fraction := parseGIFTWeight(chunk)
+		if chunk[0] == '=' {
+			out.single = true
+		}
+		if out.kind == matching {
+			question, answer, _ := strings.Cut(text, "->")
+			out.subquestions = append(out.subquestions, lmsSubquestion{
+				Text:   unescape(strings.TrimSpace(question), giftSpecials),
+				Answer: unescape(strings.TrimSpace(answer), giftSpecials),
+			})
+			continue
+		}
+		out.answers = append(out.answers, lmsAnswer{Text: unescape(text, giftSpecials), Fraction: fraction})
+	}
+	return out
+}
@@ -0,0 +1,244 @@
+// Package moodle implements conversions between Isyro questions
+// and the formats used by Moodle (and other LMS) to exchange question banks :
+// Moodle XML and GIFT.
+//
+// Both formats are converted to and from an intermediate representation ([lmsQuestion]),
+// which is then mapped to [questions.QuestionPage].
+// Since the formats do not support all Isyro features (and vice versa),
+// each conversion returns a [Report] listing the contents which
+// could not be converted.
+package moodle
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+)
+
+// Question is a question of a bank, as exported to
+// or imported from an LMS.
+type Question struct {
+	Name string
+	Page questions.QuestionPage
+}
+
+// Report lists the contents which could not be converted,
+// as messages displayed to the teacher.
+type Report []string
+
+func (r *Report) addf(question string, format string, args ...any) {
+	*r = append(*r, fmt.Sprintf("Question %q : ", question)+fmt.Sprintf(format, args...))
+}
+
+// Format is a file format supported by this package
+type Format uint8
+
+const (
+	XML  Format = iota // Moodle XML
+	GIFT               // GIFT text format
+)
+
+// Extension returns the usual file extension, including the dot.
+func (f Format) Extension() string {
+	if f == GIFT {
+		return ".gift.txt"
+	}
+	return ".xml"
+}
+
+// Export converts the given questions to the [format] file format.
+// One instance of the random parameters is used for each question.
+func Export(qus []Question, format Format) ([]byte, Report) {
+	var report Report
+	list := make([]lmsQuestion, 0, len(qus))
+	for _, qu := range qus {
+		lq, ok := fromPage(qu, &report)
+		if ok {
+			list = append(list, lq)
+		}
+	}
+	if format == GIFT {
+		return writeGIFT(list, &report), report
+	}
+	return writeXML(list), report
+}
+
+// Import parses the given file, whose format is
+// infered from [filename] and [content], and returns
+// the converted questions.
+func Import(filename string, content []byte) ([]Question, Report, error) {
+	var (
+		list []lmsQuestion
+		err  error
+	)
+	trimmed := strings.TrimSpace(string(content))
+	if strings.ToLower(filepath.Ext(filename)) == ".xml" || strings.HasPrefix(trimmed, "<") {
+		list, err = parseXML(content)
+	} else {
+		list, err = parseGIFT(string(content))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var report Report
+	out := make([]Question, 0, len(list))
+	for i, lq := range list {
+		if lq.name == "" {
+			lq.name = fmt.Sprintf("Question %d", i+1)
+		}
+		page, ok := lq.toPage(&report)
+		if ok {
+			out = append(out, Question{Name: lq.name, Page: page})
+		}
+	}
+	return out, report, nil
+}
+
+// ------------------------ intermediate representation ------------------------
+
+type qKind uint8
+
+const (
+	description qKind = iota
+	numerical
+	shortAnswer
+	multiChoice
+	trueFalse
+	matching
+	cloze
+	unsupported // essay, calculated, etc...
+)
+
+// lmsAnswer is a possible answer of a question.
+type lmsAnswer struct {
+	Text      string  // HTML for multichoice, plain text otherwise
+	Fraction  float64 // percentage of the grade, 100 for a correct answer
+	Tolerance float64 // for numerical answers
+}
+
+type lmsSubquestion struct {
+	Text   string // HTML
+	Answer string // plain text
+}
+
+// lmsQuestion is a format independent description
+// of an LMS question.
+type lmsQuestion struct {
+	kind     qKind
+	typeName string // the original type, used in reports
+	name     string
+	text     string // HTML, with \( \) delimiters for maths
+	feedback string // general feedback, as HTML
+	plain    bool   // if true, [text] and [feedback] are plain text instead of HTML
+
+	answers      []lmsAnswer      // for numerical, shortAnswer, multiChoice and trueFalse
+	usecase      bool             // for shortAnswer
+	single       bool             // for multiChoice
+	dropdown     bool             // for multiChoice, only used in cloze questions
+	subquestions []lmsSubquestion // for matching
+	parts        []clozePart      // for cloze, replacing [text]
+}
+
+func (lq lmsQuestion) correctAnswers() (out []lmsAnswer) {
+	for _, answer := range lq.answers {
+		if answer.Fraction >= 100 {
+			out = append(out, answer)
+		}
+	}
+	return out
+}
+
+// ------------------------------ text conversion ------------------------------
+
+// textLineToHTML returns an HTML version of [line], using
+// \( \) as math delimiters.
+func textLineToHTML(line client.TextLine) string {
+	var out strings.Builder
+	for _, part := range line {
+		if part.IsMath {
+			out.WriteString(`\(` + html.EscapeString(part.Text) + `\)`)
+		} else {
+			out.WriteString(strings.ReplaceAll(html.EscapeString(part.Text), "\n", "<br>"))
+		}
+	}
+	return out.String()
+}
+
+// textLineToPlain returns a plain text version of [line], using
+// \( \) as math delimiters, used for matching answers.
+func textLineToPlain(line client.TextLine) string {
+	var out strings.Builder
+	for _, part := range line {
+		if part.IsMath {
+			out.WriteString(`\(` + part.Text + `\)`)
+		} else {
+			out.WriteString(part.Text)
+		}
+	}
+	return strings.TrimSpace(out.String())
+}
+
+var (
+	reHTMLBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>|</h\d>|</tr>`)
+	reHTMLItem   = regexp.MustCompile(`(?i)<li[^>]*>`)
+	reHTMLTags   = regexp.MustCompile(`<[^>]*>`)
+	reBlankLines = regexp.MustCompile(`\n\s*\n+`)
+
+	// \( \), \[ \] and $$ $$ are the delimiters supported by MathJax in Moodle
+	reMath = regexp.MustCompile(`(?s)\\\((.+?)\\\)|\\\[(.+?)\\\]|\$\$(.+?)\$\$`)
+)
+
+// text special characters, which must be escaped
+// outside of maths
+var textEscaper = strings.NewReplacer("&", `$\&$`, "#", `$\sharp$`, "$", `$\textdollar$`)
+
+// toInterpolated converts the given LMS text to the format used by
+// [questions.TextBlock], interpreting [s] as HTML if [isHTML] is true.
+func toInterpolated(s string, isHTML bool) questions.Interpolated {
+	if isHTML {
+		s = reHTMLBreaks.ReplaceAllString(s, "\n")
+		s = reHTMLItem.ReplaceAllString(s, "- ")
+		s = reHTMLTags.ReplaceAllString(s, "")
+		s = html.UnescapeString(s)
+	}
+	s = strings.ReplaceAll(s, " ", " ") // non breaking spaces
+
+	var out strings.Builder
+	cursor := 0
+	for _, match := range reMath.FindAllStringSubmatchIndex(s, -1) {
+		out.WriteString(textEscaper.Replace(s[cursor:match[0]]))
+		switch {
+		case match[2] != -1: // inline
+			out.WriteString("$" + strings.TrimSpace(s[match[2]:match[3]]) + "$")
+		case match[4] != -1: // display
+			out.WriteString("\n$$" + strings.TrimSpace(s[match[4]:match[5]]) + "$$\n")
+		default: // $$ $$ is inline in Moodle
+			out.WriteString("$" + strings.TrimSpace(s[match[6]:match[7]]) + "$")
+		}
+		cursor = match[1]
+	}
+	out.WriteString(textEscaper.Replace(s[cursor:]))
+
+	text := reBlankLines.ReplaceAllString(out.String(), "\n\n")
+	return questions.Interpolated(strings.TrimSpace(text))
+}
+
+// toPlainText removes HTML tags and maths delimiters,
+// used for short answers.
+func toPlainText(s string) string {
+	s = reHTMLTags.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(s)
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(expression.RoundFloat(v), 'f', -1, 64)
+}
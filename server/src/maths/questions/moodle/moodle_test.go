@@ -0,0 +1,241 @@
+package moodle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+var sampleQuestions = []Question{
+	{Name: "Nombre", Page: questions.QuestionPage{
+		Enonce: questions.Enonce{
+			questions.TextBlock{Parts: "Calculer $2 \\times 3$ :"},
+			questions.NumberFieldBlock{Expression: "6"},
+		},
+		Correction: questions.Enonce{questions.TextBlock{Parts: "On a $2 \\times 3 = 6$."}},
+	}},
+	{Name: "Choix", Page: questions.QuestionPage{
+		Enonce: questions.Enonce{
+			questions.TextBlock{Parts: "La capitale de la France est"},
+			questions.RadioFieldBlock{Proposals: []questions.Interpolated{"Lyon", "Paris", "Lille"}, Answer: "2"},
+		},
+	}},
+	{Name: "Texte", Page: questions.QuestionPage{
+		Enonce: questions.Enonce{
+			questions.TextBlock{Parts: "Le symbole du fer est"},
+			questions.TextFieldBlock{Answers: []string{"Fe"}, IgnoreCase: true},
+		},
+	}},
+	{Name: "Texte à trous", Page: questions.QuestionPage{
+		Enonce: questions.Enonce{
+			questions.TextBlock{Parts: "$x = $"},
+			questions.NumberFieldBlock{Expression: "2"},
+			questions.TextBlock{Parts: " et "},
+			questions.RadioFieldBlock{Proposals: []questions.Interpolated{"oui", "non"}, Answer: "1", AsDropDown: true},
+			questions.TextBlock{Parts: "."},
+		},
+	}},
+	{Name: "Association", Page: questions.QuestionPage{
+		Enonce: questions.Enonce{
+			questions.TextBlock{Parts: "Associer chaque fonction à sa dérivée."},
+			questions.MatchingFieldBlock{
+				Left:  []questions.Interpolated{"$x^2$", "$e^x$"},
+				Right: []questions.Interpolated{"$2x$", "$e^x$"},
+			},
+		},
+	}},
+}
+
+// fieldsOf returns the answer fields of the page
+func fieldsOf(page questions.QuestionPage) (out []questions.Block) {
+	for _, block := range page.Enonce {
+		if _, isText := block.(questions.TextBlock); !isText {
+			out = append(out, block)
+		}
+	}
+	return out
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	content, report := Export(sampleQuestions, XML)
+	tu.Assert(t, len(report) == 0)
+	tu.Assert(t, strings.Contains(string(content), `<question type="numerical">`))
+	tu.Assert(t, strings.Contains(string(content), `<question type="cloze">`))
+	tu.Assert(t, strings.Contains(string(content), `{1:NUMERICAL:=2:0}`))
+	tu.Assert(t, strings.Contains(string(content), `{1:MULTICHOICE:=oui~non}`))
+
+	qus, report, err := Import("export.xml", content)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(report) == 0)
+	tu.Assert(t, len(qus) == len(sampleQuestions))
+	for i, qu := range qus {
+		tu.Assert(t, qu.Name == sampleQuestions[i].Name)
+		tu.Assert(t, reflect.DeepEqual(fieldsOf(qu.Page), fieldsOf(sampleQuestions[i].Page)))
+	}
+	tu.Assert(t, qus[0].Page.Enonce[0] == questions.TextBlock{Parts: `Calculer $2 \times 3$ :`})
+	tu.Assert(t, qus[0].Page.Correction[0] == questions.TextBlock{Parts: `On a $2 \times 3 = 6$.`})
+}
+
+func TestGIFTRoundTrip(t *testing.T) {
+	content, report := Export(sampleQuestions, GIFT)
+	// the cloze question has two fields
+	tu.Assert(t, len(report) == 1)
+	tu.Assert(t, strings.Contains(string(content), `::Nombre::[html]Calculer \\(2 \\times 3\\) \: {#6:0 ####`))
+
+	qus, _, err := Import("export.gift.txt", content)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(qus) == len(sampleQuestions))
+	for i, qu := range qus[:3] {
+		tu.Assert(t, qu.Name == sampleQuestions[i].Name)
+		tu.Assert(t, reflect.DeepEqual(fieldsOf(qu.Page), fieldsOf(sampleQuestions[i].Page)))
+	}
+	tu.Assert(t, len(fieldsOf(qus[3].Page)) == 1)
+	tu.Assert(t, reflect.DeepEqual(fieldsOf(qus[4].Page), fieldsOf(sampleQuestions[4].Page)))
+}
+
+func TestExportMatching(t *testing.T) {
+	field := questions.MatchingFieldBlock{
+		Left:            []questions.Interpolated{"chien", "chat"},
+		Right:           []questions.Interpolated{"aboie", "miaule"},
+		AdditionalRight: []questions.Interpolated{"rugit"},
+	}
+	qus := []Question{
+		{Name: "Distracteurs", Page: questions.QuestionPage{Enonce: questions.Enonce{field}}},
+		{Name: "Combiné", Page: questions.QuestionPage{Enonce: questions.Enonce{
+			field,
+			questions.TextBlock{Parts: "Et "},
+			questions.NumberFieldBlock{Expression: "2"},
+		}}},
+	}
+
+	content, report := Export(qus, XML)
+	// the matching field can't be combined with other fields
+	tu.Assert(t, len(report) == 1 && strings.HasPrefix(report[0], `Question "Combiné"`))
+	imported, _, err := Import("export.xml", content)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(imported) == 2)
+	tu.Assert(t, reflect.DeepEqual(fieldsOf(imported[0].Page), []questions.Block{field}))
+	tu.Assert(t, reflect.DeepEqual(fieldsOf(imported[1].Page), []questions.Block{questions.NumberFieldBlock{Expression: "2"}}))
+
+	// distractors are not supported by GIFT
+	_, report = Export(qus[:1], GIFT)
+	tu.Assert(t, len(report) == 1)
+}
+
+func TestParseGIFT(t *testing.T) {
+	const source = `
+// a comment
+$CATEGORY: $course$/Maths
+
+::Q1:: 1 + 1 = ? {#2}
+
+::Q2:: Quelle est la couleur du ciel ? {
+	=bleu#Bravo
+	~vert
+	~rouge
+}
+
+Paris est la capitale de la France. {T}
+
+::Q4:: Les chats {~aboient =miaulent ~chantent}.
+
+::Q5:: Associer {
+	=chien -> aboie
+	=chat -> miaule
+}
+
+::Q6:: Racontez votre journée. {}
+
+::Q7:: Un nombre entre 1 et 3 {#1..3}
+
+Seulement du texte.
+`
+	list, err := parseGIFT(source)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(list) == 8)
+
+	tu.Assert(t, list[0].kind == numerical && list[0].name == "Q1" && list[0].text == "1 + 1 = ?")
+	tu.Assert(t, list[0].answers[0] == lmsAnswer{Text: "2", Fraction: 100})
+
+	tu.Assert(t, list[1].kind == multiChoice && list[1].single)
+	tu.Assert(t, len(list[1].answers) == 3 && list[1].answers[0] == lmsAnswer{Text: "bleu", Fraction: 100})
+
+	tu.Assert(t, list[2].kind == trueFalse && list[2].name == "")
+	tu.Assert(t, isTrue(list[2].correctAnswers()[0].Text))
+
+	tu.Assert(t, list[3].kind == cloze && len(list[3].parts) == 3)
+	tu.Assert(t, list[3].parts[1].field.kind == multiChoice)
+
+	tu.Assert(t, list[4].kind == matching && len(list[4].subquestions) == 2)
+	tu.Assert(t, list[4].subquestions[1] == lmsSubquestion{Text: "chat", Answer: "miaule"})
+
+	tu.Assert(t, list[5].kind == unsupported)
+
+	tu.Assert(t, list[6].kind == numerical && list[6].answers[0].Text == "2" && list[6].answers[0].Tolerance == 1)
+
+	tu.Assert(t, list[7].kind == description)
+}
+
+func TestImportReport(t *testing.T) {
+	const source = `
+::Q1:: Associer {
+	=chien -> aboie
+	=chat -> miaule
+}
+
+::Q2:: Racontez votre journée. {}
+
+::Q3:: Une valeur approchée de pi {#3.14:0.01}
+`
+	qus, report, err := Import("questions.txt", []byte(source))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(qus) == 2)
+	tu.Assert(t, reflect.DeepEqual(fieldsOf(qus[0].Page), []questions.Block{questions.MatchingFieldBlock{
+		Left:  []questions.Interpolated{"chien", "chat"},
+		Right: []questions.Interpolated{"aboie", "miaule"},
+	}}))
+	tu.Assert(t, len(report) == 2)
+	tu.Assert(t, strings.HasPrefix(report[1], `Question "Q3"`))
+
+	_, _, err = Import("questions.xml", []byte("<quiz>"))
+	tu.Assert(t, err != nil)
+	_, _, err = Import("questions.txt", []byte("// empty"))
+	tu.Assert(t, err != nil)
+}
+
+func TestParseCloze(t *testing.T) {
+	parts := parseCloze(`Paris est {1:SA:=capitale#Bien~%50%ville} de {2:MCV:Belgique~=France}, {:NM:=2.5:0.1}`)
+	tu.Assert(t, len(parts) == 6)
+	tu.Assert(t, parts[0].text == "Paris est ")
+	sa := parts[1].field
+	tu.Assert(t, sa.kind == shortAnswer && !sa.usecase)
+	tu.Assert(t, reflect.DeepEqual(sa.answers, []lmsAnswer{{Text: "capitale", Fraction: 100}, {Text: "ville", Fraction: 50}}))
+	mc := parts[3].field
+	tu.Assert(t, mc.kind == multiChoice && !mc.dropdown && len(mc.correctAnswers()) == 1)
+	nm := parts[5].field
+	tu.Assert(t, nm.kind == numerical && nm.answers[0] == lmsAnswer{Text: "2.5", Fraction: 100, Tolerance: 0.1})
+
+	tu.Assert(t, clozeText(parts) == `Paris est {1:SHORTANSWER:=capitale~%50%ville} de {1:MULTICHOICE_V:Belgique~=France}, {1:NUMERICAL:=2.5:0.1}`)
+}
+
+func TestToInterpolated(t *testing.T) {
+	for _, test := range []struct {
+		html     string
+		isHTML   bool
+		expected questions.Interpolated
+	}{
+		{`<p>Soit \(x &gt; 0\).</p><p>Calculer</p>`, true, "Soit $x > 0$.\nCalculer"},
+		{`Le prix est 5$ & #1`, false, `Le prix est 5$\textdollar$ $\&$ $\sharp$1`},
+		{`On a \[x^2 = 4\] donc`, true, "On a \n$$x^2 = 4$$\n donc"},
+		{`Avec $$\sqrt{2}$$`, true, `Avec $\sqrt{2}$`},
+		{`<ul><li>a</li><li>b</li></ul>`, true, "- a\n- b"},
+	} {
+		got := toInterpolated(test.html, test.isHTML)
+		if got != test.expected {
+			t.Fatalf("expected %q, got %q", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package moodle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// this file implements the Moodle XML format
+// See https://docs.moodle.org/en/Moodle_XML_format
+
+type xmlText struct {
+	Text string `xml:",cdata"`
+}
+
+type xmlFormattedText struct {
+	Format string  `xml:"format,attr,omitempty"`
+	Text   xmlText `xml:"text"`
+}
+
+type xmlAnswer struct {
+	Fraction  string  `xml:"fraction,attr"`
+	Format    string  `xml:"format,attr,omitempty"`
+	Text      xmlText `xml:"text"`
+	Tolerance string  `xml:"tolerance,omitempty"`
+}
+
+type xmlSubquestion struct {
+	Format string  `xml:"format,attr,omitempty"`
+	Text   xmlText `xml:"text"`
+	Answer struct {
+		Text xmlText `xml:"text"`
+	} `xml:"answer"`
+}
+
+type xmlQuestion struct {
+	Type            string           `xml:"type,attr"`
+	Name            xmlFormattedText `xml:"name"`
+	QuestionText    xmlFormattedText `xml:"questiontext"`
+	GeneralFeedback xmlFormattedText `xml:"generalfeedback"`
+	DefaultGrade    string           `xml:"defaultgrade,omitempty"`
+	Single          string           `xml:"single,omitempty"`
+	ShuffleAnswers  string           `xml:"shuffleanswers,omitempty"`
+	UseCase         string           `xml:"usecase,omitempty"`
+	Answers         []xmlAnswer      `xml:"answer"`
+	Subquestions    []xmlSubquestion `xml:"subquestion"`
+}
+
+type xmlQuiz struct {
+	XMLName   xml.Name      `xml:"quiz"`
+	Questions []xmlQuestion `xml:"question"`
+}
+
+var xmlTypes = [...]string{
+	description: "description",
+	numerical:   "numerical",
+	shortAnswer: "shortanswer",
+	multiChoice: "multichoice",
+	trueFalse:   "truefalse",
+	matching:    "matching",
+	cloze:       "cloze",
+}
+
+func writeXML(list []lmsQuestion) []byte {
+	quiz := xmlQuiz{Questions: make([]xmlQuestion, len(list))}
+	for i, lq := range list {
+		qu := xmlQuestion{
+			Type:            xmlTypes[lq.kind],
+			Name:            xmlFormattedText{Text: xmlText{lq.name}},
+			QuestionText:    xmlFormattedText{Format: "html", Text: xmlText{lq.text}},
+			GeneralFeedback: xmlFormattedText{Format: "html", Text: xmlText{lq.feedback}},
+			DefaultGrade:    "1",
+		}
+		switch lq.kind {
+		case shortAnswer:
+			qu.UseCase = "0"
+			if lq.usecase {
+				qu.UseCase = "1"
+			}
+		case multiChoice:
+			qu.Single, qu.ShuffleAnswers = "true", "1"
+		case cloze:
+			qu.QuestionText.Text.Text = clozeText(lq.parts)
+		}
+		for _, answer := range lq.answers {
+			xa := xmlAnswer{Fraction: formatNumber(answer.Fraction), Format: "moodle_auto_format", Text: xmlText{answer.Text}}
+			if lq.kind == multiChoice {
+				xa.Format = "html"
+			} else if lq.kind == numerical {
+				xa.Tolerance = formatNumber(answer.Tolerance)
+			}
+			qu.Answers = append(qu.Answers, xa)
+		}
+		for _, sub := range lq.subquestions {
+			xs := xmlSubquestion{Format: "html", Text: xmlText{sub.Text}}
+			xs.Answer.Text.Text = sub.Answer
+			qu.Subquestions = append(qu.Subquestions, xs)
+		}
+		quiz.Questions[i] = qu
+	}
+
+	content, _ := xml.MarshalIndent(quiz, "", "  ") // the types are always valid
+	return append([]byte(xml.Header), content...)
+}
+
+func parseXML(content []byte) ([]lmsQuestion, error) {
+	var quiz xmlQuiz
+	if err := xml.Unmarshal(content, &quiz); err != nil {
+		return nil, fmt.Errorf("Le fichier XML est invalide : %s", err)
+	}
+
+	var out []lmsQuestion
+	for _, qu := range quiz.Questions {
+		if qu.Type == "category" {
+			continue
+		}
+		lq := lmsQuestion{
+			kind:     unsupported,
+			typeName: qu.Type,
+			name:     strings.TrimSpace(qu.Name.Text.Text),
+			text:     qu.QuestionText.Text.Text,
+			feedback: qu.GeneralFeedback.Text.Text,
+			plain:    qu.QuestionText.Format != "" && qu.QuestionText.Format != "html",
+			usecase:  qu.UseCase == "1",
+			single:   qu.Single == "true" || qu.Single == "1",
+		}
+		for kind, name := range xmlTypes {
+			if name == qu.Type {
+				lq.kind = qKind(kind)
+			}
+		}
+		for _, answer := range qu.Answers {
+			fraction, _ := strconv.ParseFloat(answer.Fraction, 64)
+			tolerance, _ := strconv.ParseFloat(answer.Tolerance, 64)
+			lq.answers = append(lq.answers, lmsAnswer{Text: answer.Text.Text, Fraction: fraction, Tolerance: tolerance})
+		}
+		for _, sub := range qu.Subquestions {
+			lq.subquestions = append(lq.subquestions, lmsSubquestion{Text: sub.Text.Text, Answer: sub.Answer.Text.Text})
+		}
+		if lq.kind == cloze {
+			lq.parts = parseCloze(lq.text)
+		}
+		out = append(out, lq)
+	}
+	return out, nil
+}
+
+// --------------------------- embedded answers (cloze) ---------------------------
+
+// See https://docs.moodle.org/en/Embedded_Answers_(Cloze)_question_type
+
+const clozeSpecials = `\\}#~/"`
+
+var clozeEscaper = strings.NewReplacer(`\`, `\\`, "}", `\}`, "#", `\#`, "~", `\~`, "/", `\/`, `"`, `\"`)
+
+func clozeAnswers(answers []lmsAnswer, withTolerance bool) string {
+	chunks := make([]string, len(answers))
+	for i, answer := range answers {
+		prefix := fmt.Sprintf("%%%s%%", formatNumber(answer.Fraction))
+		if answer.Fraction == 100 {
+			prefix = "="
+		} else if answer.Fraction == 0 {
+			prefix = ""
+		}
+		text := clozeEscaper.Replace(answer.Text)
+		if withTolerance {
+			text += ":" + formatNumber(answer.Tolerance)
+		}
+		chunks[i] = prefix + text
+	}
+	return strings.Join(chunks, "~")
+}
+
+// clozeText returns the question text with the embedded answers
+func clozeText(parts []clozePart) string {
+	var out strings.Builder
+	for _, part := range parts {
+		field := part.field
+		if field == nil {
+			out.WriteString(part.text)
+			continue
+		}
+		switch field.kind {
+		case numerical:
+			out.WriteString("{1:NUMERICAL:" + clozeAnswers(field.answers, true) + "}")
+		case shortAnswer:
+			name := "SHORTANSWER"
+			if field.usecase {
+				name = "SHORTANSWER_C"
+			}
+			out.WriteString("{1:" + name + ":" + clozeAnswers(field.answers, false) + "}")
+		case multiChoice:
+			name := "MULTICHOICE_V"
+			if field.dropdown {
+				name = "MULTICHOICE"
+			}
+			out.WriteString("{1:" + name + ":" + clozeAnswers(field.answers, false) + "}")
+		}
+	}
+	return out.String()
+}
+
+var reCloze = regexp.MustCompile(`\{(\d*):([A-Z_]+):((?:\\.|[^}\\])*)\}`)
+
+// splitEscaped splits [s] on the unescaped occurrences of [sep]
+func splitEscaped(s string, sep byte) (out []string) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++ // skip the escaped character
+		} else if s[i] == sep {
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// unescape removes the backslash before the characters in [specials]
+func unescape(s string, specials string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(specials, s[i+1]) != -1 {
+			i++
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+// parseAnswerWeight parses the prefix of an answer (=, %50%, or nothing)
+func parseAnswerWeight(s string) (text string, fraction float64) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "=") {
+		return s[1:], 100
+	}
+	if strings.HasPrefix(s, "%") {
+		if end := strings.IndexByte(s[1:], '%'); end != -1 {
+			fraction, _ = strconv.ParseFloat(s[1:end+1], 64)
+			return s[end+2:], fraction
+		}
+	}
+	return s, 0
+}
+
+func parseCloze(text string) (parts []clozePart) {
+	cursor := 0
+	for _, match := range reCloze.FindAllStringSubmatchIndex(text, -1) {
+		if match[0] > cursor {
+			parts = append(parts, clozePart{text: text[cursor:match[0]]})
+		}
+		cursor = match[1]
+
+		typeName, content := text[match[4]:match[5]], text[match[6]:match[7]]
+		field := lmsQuestion{kind: unsupported, typeName: typeName, single: true}
+		switch typeName {
+		case "NUMERICAL", "NM":
+			field.kind = numerical
+		case "SHORTANSWER", "SA", "MW":
+			field.kind = shortAnswer
+		case "SHORTANSWER_C", "SAC", "MWC":
+			field.kind, field.usecase = shortAnswer, true
+		case "MULTICHOICE", "MC", "MULTICHOICE_S", "MCS":
+			field.kind, field.dropdown = multiChoice, true
+		case "MULTICHOICE_V", "MCV", "MULTICHOICE_H", "MCH", "MULTICHOICE_VS", "MCVS", "MULTICHOICE_HS", "MCHS":
+			field.kind = multiChoice
+		}
+		for _, chunk := range splitEscaped(content, '~') {
+			chunk = splitEscaped(chunk, '#')[0] // remove feedback
+			answerText, fraction := parseAnswerWeight(chunk)
+			answer := lmsAnswer{Fraction: fraction}
+			if field.kind == numerical {
+				value := splitEscaped(answerText, ':')
+				answerText = value[0]
+				if len(value) > 1 {
+					answer.Tolerance, _ = strconv.ParseFloat(strings.TrimSpace(value[1]), 64)
+				}
+			}
+			answer.Text = unescape(answerText, clozeSpecials)
+			field.answers = append(field.answers, answer)
+		}
+		parts = append(parts, clozePart{field: &field})
+	}
+	if cursor < len(text) {
+		parts = append(parts, clozePart{text: text[cursor:]})
+	}
+	return parts
+}
@@ -0,0 +1,259 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/functiongrapher"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+)
+
+// this file implements the rendering of figures and function graphs
+
+const (
+	unit   = 40. // pixels for one logical unit
+	margin = 16. // pixels around the figure
+)
+
+// repereCanvas converts the mathematical coordinates
+// to SVG ones
+type repereCanvas struct {
+	bounds repere.RepereBounds
+}
+
+func (rc repereCanvas) width() float64 { return float64(rc.bounds.Width)*unit + 2*margin }
+
+func (rc repereCanvas) height() float64 { return float64(rc.bounds.Height)*unit + 2*margin }
+
+// point returns the SVG coordinates of the mathematical point [p]
+func (rc repereCanvas) point(p repere.Coord) (x, y float64) {
+	x = margin + (p.X+rc.bounds.Origin.X)*unit
+	y = margin + (float64(rc.bounds.Height)-(p.Y+rc.bounds.Origin.Y))*unit
+	return x, y
+}
+
+// frame returns the grid (if [showGrid] is true), the axis
+// and the origin label (if [showOrigin] is true)
+func (rc repereCanvas) frame(showGrid, showOrigin bool) []string {
+	var out []string
+	W, H := float64(rc.bounds.Width)*unit, float64(rc.bounds.Height)*unit
+	if showGrid {
+		for i := 0; i <= rc.bounds.Width; i++ {
+			x := margin + float64(i)*unit
+			out = append(out, line(x, margin, x, margin+H, `stroke="#DDDDDD" stroke-width="1"`))
+		}
+		for j := 0; j <= rc.bounds.Height; j++ {
+			y := margin + float64(j)*unit
+			out = append(out, line(margin, y, margin+W, y, `stroke="#DDDDDD" stroke-width="1"`))
+		}
+	}
+	ox, oy := rc.point(repere.Coord{})
+	const axis = `stroke="#000000" stroke-width="1.5" marker-end="url(#arrow)"`
+	out = append(out,
+		line(margin/2, oy, margin*1.5+W, oy, axis),
+		line(ox, margin*1.5+H, ox, margin/2, axis),
+	)
+	if showOrigin {
+		out = append(out,
+			fmt.Sprintf(`<circle cx="%s" cy="%s" r="3" fill="#000000"/>`, num(ox), num(oy)),
+			text(ox-4, oy+fontSize+2, "end", "O"),
+		)
+	}
+	return out
+}
+
+// clip returns the definition of a clip path with id [id],
+// restricting the drawing to the figure
+func (rc repereCanvas) clip(id string) string {
+	return fmt.Sprintf(`<clipPath id="%s"><rect x="%s" y="%s" width="%s" height="%s"/></clipPath>`, id,
+		num(margin), num(margin), num(float64(rc.bounds.Width)*unit), num(float64(rc.bounds.Height)*unit))
+}
+
+// label returns a text placed around the point (x, y), according to [pos]
+func label(x, y float64, pos repere.LabelPos, content string) string {
+	const shift = 6.
+	switch pos {
+	case repere.Hide:
+		return ""
+	case repere.Top:
+		return text(x, y-shift, "middle", content)
+	case repere.Bottom:
+		return text(x, y+shift+fontSize, "middle", content)
+	case repere.Left:
+		return text(x-shift, y+fontSize/3, "end", content)
+	case repere.Right:
+		return text(x+shift, y+fontSize/3, "start", content)
+	case repere.TopLeft:
+		return text(x-shift, y-shift, "end", content)
+	case repere.BottomRight:
+		return text(x+shift, y+shift+fontSize, "start", content)
+	case repere.BottomLeft:
+		return text(x-shift, y+shift+fontSize, "end", content)
+	default: // TopRight
+		return text(x+shift, y-shift, "start", content)
+	}
+}
+
+func figure(fig repere.Figure) string {
+	rc := repereCanvas{bounds: fig.Bounds}
+	dr := fig.Drawings
+	content := rc.frame(fig.ShowGrid, fig.ShowOrigin)
+
+	for _, area := range dr.Areas {
+		points := make([]string, 0, len(area.Points))
+		for _, name := range area.Points {
+			x, y := rc.point(dr.Points[name].Point.Point)
+			points = append(points, num(x)+","+num(y))
+		}
+		fill, opacity := color(area.Color, "#AAAAAA")
+		content = append(content, fmt.Sprintf(`<polygon points="%s" fill="%s" fill-opacity="%s"/>`, strings.Join(points, " "), fill, opacity))
+	}
+
+	for _, circle := range dr.Circles {
+		stroke, strokeOpacity := color(circle.LineColor, "#000000")
+		fill, fillOpacity := color(circle.FillColor, "none")
+		if circle.FillColor == "" {
+			fillOpacity = "0"
+		}
+		x, y := rc.point(circle.Center)
+		r := circle.Radius * unit
+		content = append(content,
+			fmt.Sprintf(`<circle cx="%s" cy="%s" r="%s" stroke="%s" stroke-opacity="%s" fill="%s" fill-opacity="%s" stroke-width="1.5"/>`,
+				num(x), num(y), num(r), stroke, strokeOpacity, fill, fillOpacity),
+			label(x+r/math.Sqrt2, y-r/math.Sqrt2, repere.TopRight, latexToSVG(circle.Legend)),
+		)
+	}
+
+	lines := append([]repere.Line(nil), dr.Lines...)
+	for _, segment := range dr.Segments {
+		from, to := dr.Points[segment.From].Point.Point, dr.Points[segment.To].Point.Point
+		if segment.Kind == repere.SKLine {
+			// infer the affine line and draw it later
+			a, b := repere.InferLine(from, to)
+			lines = append(lines, repere.Line{A: a, B: b, Label: segment.LabelName, Color: segment.Color})
+			continue
+		}
+		stroke, opacity := color(segment.Color, "#000000")
+		attrs := fmt.Sprintf(`stroke="%s" stroke-opacity="%s" stroke-width="2"`, stroke, opacity)
+		if segment.Kind == repere.SKVector {
+			attrs += ` marker-end="url(#arrow)"`
+		}
+		x1, y1 := rc.point(from)
+		x2, y2 := rc.point(to)
+		content = append(content, line(x1, y1, x2, y2, attrs))
+		if segment.LabelName != "" {
+			content = append(content, label((x1+x2)/2, (y1+y2)/2, segment.LabelPos, latexToSVG(segment.LabelName)))
+		}
+	}
+
+	for _, li := range lines {
+		start, end := li.Bounds(fig.Bounds)
+		stroke, opacity := color(li.Color, "#000000")
+		x1, y1 := rc.point(start)
+		x2, y2 := rc.point(end)
+		content = append(content,
+			line(x1, y1, x2, y2, fmt.Sprintf(`stroke="%s" stroke-opacity="%s" stroke-width="2"`, stroke, opacity)),
+			label(x2, y2, repere.BottomLeft, latexToSVG(li.Label)),
+		)
+	}
+
+	// sort the points for deterministic output
+	names := make([]string, 0, len(dr.Points))
+	for name := range dr.Points {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		point := dr.Points[name]
+		fill, opacity := color(point.Color, "#000000")
+		x, y := rc.point(point.Point.Point)
+		content = append(content,
+			fmt.Sprintf(`<circle cx="%s" cy="%s" r="3" fill="%s" fill-opacity="%s"/>`, num(x), num(y), fill, opacity),
+			label(x, y, point.Point.Pos, latexToSVG(name)),
+		)
+	}
+
+	return document(rc.width(), rc.height(), content)
+}
+
+func isFinite(p repere.Coord) bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// bezierPath returns a SVG path following [curves], starting
+// a new sub-path when two curves are not consecutive.
+// Curves with non finite points (near asymptotes) are ignored.
+func (rc repereCanvas) bezierPath(curves []functiongrapher.BezierCurve) string {
+	var chunks []string
+	var last repere.Coord
+	isFirst := true
+	for _, curve := range curves {
+		if !isFinite(curve.P0) || !isFinite(curve.P1) || !isFinite(curve.P2) {
+			isFirst = true
+			continue
+		}
+		if isFirst || curve.P0 != last {
+			isFirst = false
+			x, y := rc.point(curve.P0)
+			chunks = append(chunks, fmt.Sprintf("M %s %s", num(x), num(y)))
+		}
+		x1, y1 := rc.point(curve.P1)
+		x2, y2 := rc.point(curve.P2)
+		chunks = append(chunks, fmt.Sprintf("Q %s %s %s %s", num(x1), num(y1), num(x2), num(y2)))
+		last = curve.P2
+	}
+	return strings.Join(chunks, " ")
+}
+
+func functionsGraph(fg client.FunctionsGraphBlock) string {
+	rc := repereCanvas{bounds: fg.Bounds}
+	content := rc.frame(true, false)
+
+	// curves may go far outside the figure (asymptotes)
+	var drawings []string
+	for _, area := range fg.Areas {
+		if len(area.Path) == 0 {
+			continue
+		}
+		fill, opacity := color(area.Color, "#AAAAAA")
+		drawings = append(drawings, fmt.Sprintf(`<path d="%s Z" fill="%s" fill-opacity="%s"/>`, rc.bezierPath(area.Path), fill, opacity))
+	}
+	var labels []string
+	for _, fn := range fg.Functions {
+		if len(fn.Segments) == 0 {
+			continue
+		}
+		stroke, opacity := color(repere.ColorHex(fn.Decoration.Color), "#000000")
+		drawings = append(drawings, fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-opacity="%s" stroke-width="2"/>`,
+			rc.bezierPath(fn.Segments), stroke, opacity))
+		// the label is placed at the end of the curve
+		x, y := rc.point(fn.Segments[len(fn.Segments)-1].P2)
+		labels = append(labels, label(x, y, repere.TopRight, latexToSVG(fn.Decoration.Label)))
+	}
+	for _, seq := range fg.Sequences {
+		fill, opacity := color(repere.ColorHex(seq.Decoration.Color), "#000000")
+		for i, p := range seq.Points {
+			x, y := rc.point(p)
+			drawings = append(drawings, fmt.Sprintf(`<circle cx="%s" cy="%s" r="3" fill="%s" fill-opacity="%s"/>`, num(x), num(y), fill, opacity))
+			if i == len(seq.Points)-1 {
+				labels = append(labels, label(x, y, repere.TopRight, latexToSVG(seq.Decoration.Label)))
+			}
+		}
+	}
+	for _, point := range fg.Points {
+		fill, opacity := color(point.Color, "#000000")
+		x, y := rc.point(point.Coord)
+		drawings = append(drawings, fmt.Sprintf(`<circle cx="%s" cy="%s" r="4" fill="%s" fill-opacity="%s"/>`, num(x), num(y), fill, opacity))
+		labels = append(labels, label(x, y, repere.TopRight, latexToSVG(point.Legend)))
+	}
+
+	content = append(content,
+		rc.clip("figure"),
+		fmt.Sprintf(`<g clip-path="url(#figure)">`+"\n%s\n</g>", strings.Join(drawings, "\n")),
+	)
+	content = append(content, labels...)
+	return document(rc.width(), rc.height(), content)
+}
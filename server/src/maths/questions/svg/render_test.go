@@ -0,0 +1,128 @@
+package svg_test
+
+import (
+	"encoding/xml"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	que "github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/svg"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+// the golden tests use the questions package to build the blocks,
+// so that they are defined in an external test package
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// isValidXML checks that [content] is well formed
+func isValidXML(content string) error {
+	dec := xml.NewDecoder(strings.NewReader(content))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	bounds := repere.RepereBounds{Width: 10, Height: 10, Origin: repere.Coord{X: 3, Y: 3}}
+	x := expression.NewVar('x')
+	for _, test := range []struct {
+		name  string
+		block que.Block
+	}{
+		{"figure", que.FigureBlock{
+			Drawings: repere.RandomDrawings{
+				Points: []repere.NamedRandomLabeledPoint{
+					{Name: "A", Point: repere.RandomLabeledPoint{Coord: repere.RandomCoord{X: "1", Y: "2"}, Pos: repere.Top}},
+					{Name: "B", Point: repere.RandomLabeledPoint{Coord: repere.RandomCoord{X: "4", Y: "-1"}, Color: "#FF0000"}},
+					{Name: "C", Point: repere.RandomLabeledPoint{Coord: repere.RandomCoord{X: "-2", Y: "3"}, Pos: repere.Hide}},
+				},
+				Segments: []repere.RandomSegment{
+					{From: "A", To: "B", LabelName: `\overrightarrow{u}`, Kind: repere.SKVector},
+					{From: "A", To: "C", Kind: repere.SKLine, LabelName: "(AC)"},
+				},
+				Lines:   []repere.RandomLine{{Label: "d", A: "2", B: "-1", Color: "#0000FF"}},
+				Circles: []repere.RandomCircle{{Center: repere.RandomCoord{X: "0", Y: "0"}, Radius: "2", FillColor: "#4400FF00", Legend: `\mathcal{C}`}},
+				Areas:   []repere.RandomArea{{Color: "#880000FF", Points: []string{"A", "B", "C"}}},
+			},
+			Bounds:     bounds,
+			ShowGrid:   true,
+			ShowOrigin: true,
+		}},
+		{"functions_graph", que.FunctionsGraphBlock{
+			FunctionExprs: []que.FunctionDefinition{
+				{Function: "x^2 - 5", Decoration: que.FunctionDecoration{Label: "C_f", Color: "#FF0000"}, Variable: x, From: "-3", To: "3"},
+				{Function: "1/x", Decoration: que.FunctionDecoration{Label: "C_g"}, Variable: x, From: "-4", To: "4"},
+			},
+			SequenceExprs: []que.FunctionDefinition{
+				{Function: "x/2", Decoration: que.FunctionDecoration{Label: "u", Color: "#0000FF"}, Variable: x, From: "0", To: "3"},
+			},
+			Areas:  []que.FunctionArea{{Bottom: "C_f", Top: "", Left: "-1", Right: "1", Color: "#8800FF00"}},
+			Points: []que.FunctionPoint{{Function: "C_f", X: "1", Color: "#000000", Legend: "A"}},
+		}},
+		{"tree", que.TreeBlock{
+			EventsProposals: []que.Interpolated{"A", "$\\overline{A}$"},
+			AnswerRoot: que.TreeNodeAnswer{
+				Probabilities: []string{"1/3", "2/3"},
+				Children: []que.TreeNodeAnswer{
+					{Value: 0, Probabilities: []string{"0.5", "0.5"}, Children: []que.TreeNodeAnswer{{Value: 0}, {Value: 1}}},
+					{Value: 1, Probabilities: []string{"0.1", "0.9"}, Children: []que.TreeNodeAnswer{{Value: 0}, {Value: 1}}},
+				},
+			},
+		}},
+		{"variation_table", que.VariationTableBlock{Label: "g(x)", Xs: []string{"-5", "0", "2/3"}, Fxs: []string{"4.5", "2/9", "-12"}}},
+		{"sign_table", que.SignTableBlock{
+			Xs: []string{"-inf", "1/2", "3", "+inf"},
+			Functions: []que.FunctionSign{
+				{Label: "g(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.ForbiddenValue, client.Nothing}, Signs: []bool{true, false, true}},
+				{Label: "h(x)", FxSymbols: []client.SignSymbol{client.Nothing, client.Zero, client.Nothing, client.Nothing}, Signs: []bool{false, true, true}},
+			},
+		}},
+		{"number_line", que.NumberLineBlock{Set: "]-inf;-1[ ∪ [0;2[ ∪ ]2;4] ∪ {5}", Ticks: []string{"1"}}},
+		{"geometric_construction", que.GeometricConstructionFieldBlock{
+			Field:      que.GFPoint{Answer: que.CoordExpression{X: "2", Y: "-1"}},
+			Background: que.FigureBlock{Bounds: bounds, ShowGrid: true},
+		}},
+	} {
+		page := que.QuestionPage{Enonce: que.Enonce{test.block}}
+		instance, _, err := page.InstantiateErr()
+		tu.AssertNoErr(t, err)
+		got, ok := svg.Render(instance.ToClient().Enonce[0])
+		tu.Assert(t, ok)
+		if err := isValidXML(got); err != nil {
+			t.Fatalf("%s: invalid SVG: %s", test.name, err)
+		}
+
+		path := filepath.Join("testdata", test.name+".svg")
+		if *updateGolden {
+			tu.AssertNoErr(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+			tu.AssertNoErr(t, os.WriteFile(path, []byte(got), 0o644))
+			continue
+		}
+		want, err := os.ReadFile(path)
+		tu.AssertNoErr(t, err)
+		if got != string(want) {
+			t.Errorf("%s: unexpected SVG (run with -update to regenerate the golden files):\n%s", test.name, got)
+		}
+	}
+}
+
+func TestRenderUnsupported(t *testing.T) {
+	_, ok := svg.Render(client.TextBlock{})
+	tu.Assert(t, !ok)
+	_, ok = svg.Render(client.NumberFieldBlock{})
+	tu.Assert(t, !ok)
+}
@@ -0,0 +1,256 @@
+// Package svg renders the graphical content of questions
+// (figures, function graphs, probability trees, variation and sign tables, number lines)
+// as standalone SVG images.
+//
+// It works on the instantiated blocks (see [client.Block]), so that the
+// images match what the students see in the apps, and may be
+// used outside of them (print views, exports, previews).
+package svg
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+)
+
+const (
+	fontSize  = 14
+	textColor = "#000000"
+)
+
+// Render returns the SVG image for the given block, or false
+// if the block has no graphical content.
+// Supported blocks are figures, function graphs, probability trees,
+// variation and sign tables, number lines and the background
+// of geometric construction fields.
+func Render(block client.Block) (string, bool) {
+	switch block := block.(type) {
+	case client.FigureBlock:
+		return figure(block.Figure), true
+	case client.FunctionsGraphBlock:
+		return functionsGraph(block), true
+	case client.GeometricConstructionFieldBlock:
+		switch bg := block.Background.(type) {
+		case client.FigureBlock:
+			return figure(bg.Figure), true
+		case client.FunctionsGraphBlock:
+			return functionsGraph(bg), true
+		}
+	case client.TreeBlock:
+		return tree(block), true
+	case client.VariationTableBlock:
+		return variationTable(block), true
+	case client.SignTableBlock:
+		return signTable(block), true
+	case client.NumberLineBlock:
+		return numberLine(block.Ticks, block.Pieces), true
+	case client.NumberLineFieldBlock:
+		return numberLine(block.Ticks, nil), true
+	}
+	return "", false
+}
+
+// num formats [v] with at most two decimals
+func num(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 { // avoid -0
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// document wraps [content] in an <svg> root element
+// of the given size (in pixels)
+func document(width, height float64, content []string) string {
+	// remove the empty elements, such as hidden labels
+	filtered := content[:0]
+	for _, element := range content {
+		if element != "" {
+			filtered = append(filtered, element)
+		}
+	}
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]s" height="%[2]s" viewBox="0 0 %[1]s %[2]s" font-family="sans-serif" font-size="%[3]d">
+<defs>
+<marker id="arrow" viewBox="0 0 10 10" refX="9" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="context-stroke"/></marker>
+</defs>
+<rect width="100%%" height="100%%" fill="#FFFFFF"/>
+%[4]s
+</svg>
+`, num(width), num(height), fontSize, strings.Join(filtered, "\n"))
+}
+
+// color returns the SVG color and opacity for [c],
+// using [def] for empty colors
+func color(c repere.ColorHex, def string) (string, string) {
+	if c == "" {
+		return def, "1"
+	}
+	a, r, g, b := c.ToARGB()
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b), num(float64(a) / 255)
+}
+
+func line(x1, y1, x2, y2 float64, attrs string) string {
+	return fmt.Sprintf(`<line x1="%s" y1="%s" x2="%s" y2="%s" %s/>`, num(x1), num(y1), num(x2), num(y2), attrs)
+}
+
+// text returns a <text> element, with [content] given as markup
+// (see [latexToSVG] and [textLineToSVG])
+func text(x, y float64, anchor, content string) string {
+	if content == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<text x="%s" y="%s" text-anchor="%s" fill="%s">%s</text>`, num(x), num(y), anchor, textColor, content)
+}
+
+// textWidth returns an estimation of the width of [markup],
+// in pixels
+func textWidth(markup string) float64 {
+	return float64(utf8.RuneCountInString(plainText(markup))) * fontSize * 0.6
+}
+
+// ------------------------------- LaTeX to text -------------------------------
+
+// the order matters : for a given position,
+// the first matching command is used
+var latexSymbols = strings.NewReplacer(
+	`\left`, "",
+	`\right`, "",
+	`\{`, braceOpen,
+	`\}`, braceClose,
+	`\infty`, "∞",
+	`\pi`, "π",
+	`\alpha`, "α",
+	`\beta`, "β",
+	`\theta`, "θ",
+	`\lambda`, "λ",
+	`\Delta`, "Δ",
+	`\times`, "×",
+	`\cdot`, "·",
+	`\div`, "÷",
+	`\pm`, "±",
+	`\leq`, "≤",
+	`\geq`, "≥",
+	`\le`, "≤",
+	`\ge`, "≥",
+	`\neq`, "≠",
+	`\approx`, "≈",
+	`\in`, "∈",
+	`\cup`, "∪",
+	`\cap`, "∩",
+	`\emptyset`, "∅",
+	`\varnothing`, "∅",
+	`\mathbb{R}`, "ℝ",
+	`\mathbb{N}`, "ℕ",
+	`\mathbb{Z}`, "ℤ",
+	`\mathbb{Q}`, "ℚ",
+	`\sqrt`, "√",
+	`\,`, " ",
+	`\;`, " ",
+	`\ `, " ",
+)
+
+var (
+	reLatexFrac    = regexp.MustCompile(`\\[dt]?frac\{([^{}]*)\}\{([^{}]*)\}`)
+	reLatexCommand = regexp.MustCompile(`\\(?:text|mathrm|mathbf|mathit|operatorname)\{([^{}]*)\}`)
+	reLatexBar     = regexp.MustCompile(`\\(?:overline|bar)\{([^{}]*)\}`)
+	reLatexVector  = regexp.MustCompile(`\\(?:overrightarrow|vec)\{([^{}]*)\}`)
+	reLatexUnknown = regexp.MustCompile(`\\[a-zA-Z]+`)
+)
+
+// placeholders used to keep the overline markers and
+// the escaped braces through the conversion
+const (
+	overlineStart = "\x01"
+	overlineEnd   = "\x02"
+	braceOpen     = "\x03"
+	braceClose    = "\x04"
+)
+
+// latexToSVG converts the (simple) LaTeX code [code] to SVG text markup :
+// common commands are replaced by their unicode equivalent,
+// and subscripts and superscripts are rendered with <tspan> elements.
+func latexToSVG(code string) string {
+	code = reLatexFrac.ReplaceAllString(code, "$1/$2")
+	code = reLatexCommand.ReplaceAllString(code, "$1")
+	code = reLatexBar.ReplaceAllString(code, overlineStart+"$1"+overlineEnd)
+	code = reLatexVector.ReplaceAllString(code, "$1\u20D7") // combining arrow
+	code = latexSymbols.Replace(code)
+	code = reLatexUnknown.ReplaceAllString(code, "") // such as \mathcal
+	code = strings.ReplaceAll(code, `\`, "")
+
+	var out strings.Builder
+	runes := []rune(code)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '_', '^':
+			// read the argument : a group or a single rune
+			var arg []rune
+			if i+1 < len(runes) && runes[i+1] == '{' {
+				end := i + 2
+				for end < len(runes) && runes[end] != '}' {
+					end++
+				}
+				arg = runes[i+2 : min(end, len(runes))]
+				i = end
+			} else if i+1 < len(runes) {
+				arg = runes[i+1 : i+2]
+				i++
+			}
+			shift := "sub"
+			if r == '^' {
+				shift = "super"
+			}
+			fmt.Fprintf(&out, `<tspan baseline-shift="%s" font-size="75%%">%s</tspan>`, shift, html.EscapeString(string(arg)))
+		case '{', '}':
+			// remove grouping
+		case []rune(overlineStart)[0]:
+			out.WriteString(`<tspan text-decoration="overline">`)
+		case []rune(overlineEnd)[0]:
+			out.WriteString(`</tspan>`)
+		case []rune(braceOpen)[0]:
+			out.WriteByte('{')
+		case []rune(braceClose)[0]:
+			out.WriteByte('}')
+		default:
+			out.WriteString(html.EscapeString(string(r)))
+		}
+	}
+	return out.String()
+}
+
+// textLineToSVG returns the SVG markup for [line]
+func textLineToSVG(line client.TextLine) string {
+	var out strings.Builder
+	for _, part := range line {
+		if part.IsMath {
+			out.WriteString(latexToSVG(part.Text))
+		} else {
+			out.WriteString(html.EscapeString(part.Text))
+		}
+	}
+	return out.String()
+}
+
+// plainText returns the visible characters of [markup]
+func plainText(markup string) string {
+	var out strings.Builder
+	inTag := false
+	for _, r := range markup {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag:
+			out.WriteRune(r)
+		}
+	}
+	return html.UnescapeString(out.String())
+}
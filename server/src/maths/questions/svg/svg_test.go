@@ -0,0 +1,25 @@
+package svg
+
+import "testing"
+
+func TestLatexToSVG(t *testing.T) {
+	for _, test := range []struct {
+		latex    string
+		expected string
+	}{
+		{`x`, `x`},
+		{`C_f`, `C<tspan baseline-shift="sub" font-size="75%">f</tspan>`},
+		{`x^{2n}`, `x<tspan baseline-shift="super" font-size="75%">2n</tspan>`},
+		{`\frac{1}{2} \leq \pi`, `1/2 ≤ π`},
+		{`\left] -\infty ; 3 \right]`, `] -∞ ; 3 ]`},
+		{`\overline{A}`, `<tspan text-decoration="overline">A</tspan>`},
+		{`\{1 ; 2\} \cup \mathbb{R}`, `{1 ; 2} ∪ ℝ`},
+		{`a < b & c`, `a &lt; b &amp; c`},
+		{`\overrightarrow{AB} + \mathcal{C}`, "AB\u20D7 + C"},
+	} {
+		got := latexToSVG(test.latex)
+		if got != test.expected {
+			t.Fatalf("for %q, expected %q, got %q", test.latex, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
+)
+
+// this file implements the rendering of trees, tables and number lines
+
+const (
+	rowHeight   = 32.  // height of a table line
+	columnWidth = 80.  // width between two x values
+	levelWidth  = 120. // distance between two tree levels
+	leafHeight  = 40.  // distance between two tree leaves
+	padding     = 12.  // space around a text
+)
+
+// labelColumnWidth returns the width of the first column
+// of a table, adjusted to its content
+func labelColumnWidth(labels []string) float64 {
+	out := 40.
+	for _, label := range labels {
+		out = math.Max(out, textWidth(label)+2*padding)
+	}
+	return out
+}
+
+// tableFrame returns the borders of a table with the given size,
+// whose first row has height [rowHeight]
+func tableFrame(labelWidth, width, height float64) []string {
+	const attrs = `stroke="#000000" stroke-width="1"`
+	return []string{
+		fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" fill="none" %s/>`, num(margin), num(margin), num(width), num(height), attrs),
+		line(margin, margin+rowHeight, margin+width, margin+rowHeight, attrs),
+		line(margin+labelWidth, margin, margin+labelWidth, margin+height, attrs),
+	}
+}
+
+func variationTable(vt client.VariationTableBlock) string {
+	label := latexToSVG(vt.Label)
+	labelWidth := labelColumnWidth([]string{label})
+	width := labelWidth + columnWidth*float64(len(vt.Columns))
+	fRowHeight := 2.5 * rowHeight
+	height := rowHeight + fRowHeight
+
+	content := tableFrame(labelWidth, width, height)
+	content = append(content,
+		text(margin+labelWidth/2, margin+rowHeight/2+fontSize/3, "middle", "x"),
+		text(margin+labelWidth/2, margin+rowHeight+fRowHeight/2+fontSize/3, "middle", label),
+	)
+
+	columnX := func(i int) float64 { return margin + labelWidth + columnWidth*(float64(i)+0.5) }
+	top, bottom := margin+rowHeight+fontSize+4, margin+height-8
+	columnY := func(i int) float64 {
+		if vt.Columns[i].IsUp {
+			return top
+		}
+		return bottom
+	}
+	for i, column := range vt.Columns {
+		x := columnX(i)
+		content = append(content,
+			text(x, margin+rowHeight/2+fontSize/3, "middle", latexToSVG(column.X)),
+			text(x, columnY(i), "middle", latexToSVG(column.Y)),
+		)
+	}
+	for i := range vt.Arrows {
+		if i+1 >= len(vt.Columns) {
+			break
+		}
+		// start and end a bit away from the numbers
+		x1, x2 := columnX(i)+columnWidth/4, columnX(i+1)-columnWidth/4
+		y1, y2 := columnY(i)-fontSize/3, columnY(i+1)-fontSize/3
+		content = append(content, line(x1, y1, x2, y2, `stroke="#000000" stroke-width="1.5" marker-end="url(#arrow)"`))
+	}
+	return document(width+2*margin, height+2*margin, content)
+}
+
+func signTable(st client.SignTableBlock) string {
+	labels := []string{"x"}
+	for _, fn := range st.Functions {
+		labels = append(labels, latexToSVG(fn.Label))
+	}
+	labelWidth := labelColumnWidth(labels)
+	width := labelWidth + columnWidth*float64(len(st.Xs))
+	height := rowHeight * float64(1+len(st.Functions))
+
+	content := tableFrame(labelWidth, width, height)
+	columnX := func(i int) float64 { return margin + labelWidth + columnWidth*(float64(i)+0.5) }
+	rowY := func(row int) float64 { return margin + rowHeight*(float64(row)+0.5) + fontSize/3 }
+	for row, label := range labels {
+		content = append(content, text(margin+labelWidth/2, rowY(row), "middle", label))
+		if row >= 2 {
+			content = append(content, line(margin, margin+rowHeight*float64(row), margin+width, margin+rowHeight*float64(row), `stroke="#000000" stroke-width="1"`))
+		}
+	}
+	for i, x := range st.Xs {
+		content = append(content, text(columnX(i), rowY(0), "middle", latexToSVG(x)))
+	}
+	for j, fn := range st.Functions {
+		top, bottom := margin+rowHeight*float64(j+1), margin+rowHeight*float64(j+2)
+		for i, symbol := range fn.FxSymbols {
+			x := columnX(i)
+			switch symbol {
+			case client.Zero:
+				content = append(content,
+					line(x, top, x, bottom, `stroke="#000000" stroke-width="1" stroke-dasharray="3 3"`),
+					fmt.Sprintf(`<circle cx="%s" cy="%s" r="8" fill="#FFFFFF"/>`, num(x), num(rowY(j+1)-fontSize/3)),
+					text(x, rowY(j+1), "middle", "0"),
+				)
+			case client.ForbiddenValue:
+				content = append(content,
+					line(x-2, top, x-2, bottom, `stroke="#000000" stroke-width="1"`),
+					line(x+2, top, x+2, bottom, `stroke="#000000" stroke-width="1"`),
+				)
+			}
+		}
+		for i, isPositive := range fn.Signs {
+			sign := "−"
+			if isPositive {
+				sign = "+"
+			}
+			content = append(content, text(columnX(i)+columnWidth/2, rowY(j+1), "middle", sign))
+		}
+	}
+	return document(width+2*margin, height+2*margin, content)
+}
+
+// treeLayout stores the position of the nodes of a tree
+type treeLayout struct {
+	nextLeaf int // index of the next leaf
+	content  []string
+	events   []client.TextLine
+}
+
+// place recursively draws [node] and its children, whose level is [level],
+// returning the position of the node
+func (tl *treeLayout) place(node client.TreeNodeAnswer, level int) (x, y float64) {
+	x = margin + padding + levelWidth*float64(level)
+	if len(node.Children) == 0 {
+		y = margin + leafHeight*(float64(tl.nextLeaf)+0.5)
+		tl.nextLeaf++
+		return x, y
+	}
+	ys := make([]float64, len(node.Children))
+	for i, child := range node.Children {
+		_, ys[i] = tl.place(child, level+1)
+	}
+	y = (ys[0] + ys[len(ys)-1]) / 2
+
+	cx := x + levelWidth
+	for i, child := range node.Children {
+		cy := ys[i]
+		// the edges go from the right of the parent to the left of the child
+		tl.content = append(tl.content, line(x+padding, y, cx-padding, cy, `stroke="#000000" stroke-width="1"`))
+		if child.Value >= 0 && child.Value < len(tl.events) {
+			tl.content = append(tl.content, text(cx, cy+fontSize/3, "start", textLineToSVG(tl.events[child.Value])))
+		}
+		if i < len(node.Probabilities) {
+			proba := latexToSVG(node.Probabilities[i])
+			mx, my := (x+cx)/2, (y+cy)/2
+			w := textWidth(proba) + 4
+			tl.content = append(tl.content,
+				fmt.Sprintf(`<rect x="%s" y="%s" width="%s" height="%s" fill="#FFFFFF"/>`,
+					num(mx-w/2), num(my-fontSize/2-2), num(w), num(fontSize+4)),
+				text(mx, my+fontSize/3, "middle", proba),
+			)
+		}
+	}
+	return x, y
+}
+
+// depth returns the number of levels below [node]
+func depth(node client.TreeNodeAnswer) int {
+	out := 0
+	for _, child := range node.Children {
+		out = max(out, 1+depth(child))
+	}
+	return out
+}
+
+func tree(tr client.TreeBlock) string {
+	tl := treeLayout{events: tr.EventsProposals}
+	x, y := tl.place(tr.Root, 0)
+	tl.content = append(tl.content, fmt.Sprintf(`<circle cx="%s" cy="%s" r="3" fill="#000000"/>`, num(x), num(y)))
+	width := levelWidth*float64(depth(tr.Root)) + 2*padding + levelWidth/2
+	height := leafHeight * float64(max(tl.nextLeaf, 1))
+	return document(width+2*margin, height+2*margin, tl.content)
+}
+
+// numberLine draws the number line with the given ticks
+// and, if [pieces] is not nil, the corresponding subset
+// using the french brackets notation.
+func numberLine(ticks []string, pieces []bool) string {
+	const step = 60. // distance between two ticks
+	n := len(ticks)
+	width := step * float64(n+1)
+	y := margin + rowHeight
+	tickX := func(k int) float64 { return margin + step*float64(k+1) }
+
+	content := []string{line(margin, y, margin+width, y, `stroke="#000000" stroke-width="1.5" marker-end="url(#arrow)"`)}
+	const setStyle = `stroke="#1565C0" stroke-opacity="0.5" stroke-width="6"`
+	for piece, isIn := range pieces {
+		if !isIn || piece%2 != 0 {
+			continue
+		}
+		// segment before the tick k
+		k := piece / 2
+		start, end := margin, margin+width
+		if k > 0 {
+			start = tickX(k - 1)
+		}
+		if k < n {
+			end = tickX(k)
+		}
+		content = append(content, line(start, y, end, y, setStyle))
+	}
+	for k := 0; pieces != nil && k < n; k++ {
+		isIn, before, after := pieces[2*k+1], pieces[2*k], pieces[2*k+2]
+		x := tickX(k)
+		var bracket string
+		switch {
+		case before && after:
+			if !isIn { // excluded point
+				content = append(content, fmt.Sprintf(`<circle cx="%s" cy="%s" r="4" stroke="#1565C0" fill="#FFFFFF"/>`, num(x), num(y)))
+			}
+		case after: // start of an interval
+			bracket = "["
+			if !isIn {
+				bracket = "]"
+			}
+		case before: // end of an interval
+			bracket = "]"
+			if !isIn {
+				bracket = "["
+			}
+		case isIn: // isolated point
+			content = append(content, fmt.Sprintf(`<circle cx="%s" cy="%s" r="4" fill="#1565C0"/>`, num(x), num(y)))
+		}
+		if bracket != "" {
+			content = append(content, fmt.Sprintf(`<text x="%s" y="%s" text-anchor="middle" font-size="%d" fill="#1565C0">%s</text>`,
+				num(x), num(y+fontSize/2+1), 2*fontSize, bracket))
+		}
+	}
+	for k, tick := range ticks {
+		x := tickX(k)
+		content = append(content,
+			line(x, y-5, x, y+5, `stroke="#000000" stroke-width="1"`),
+			text(x, y+fontSize+12, "middle", latexToSVG(tick)),
+		)
+	}
+	return document(width+2*margin+10, y+rowHeight+margin, content)
+}
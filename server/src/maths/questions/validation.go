@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
 )
 
 // maxFunctionBound is the maximum value a function
@@ -58,7 +59,7 @@ func (pr Parameters) Validate() error {
 
 type errEnonce struct {
 	Error string            // detailed error
-	Block int               // index of the invalid block
+	Block int               // index of the invalid block, or -1 for errors about the constraints
 	Vars  map[string]string // the actual values used when the error was encountered, or nil
 }
 
@@ -68,14 +69,17 @@ const (
 	ErrParameters_ ErrorKind = iota
 	ErrEnonce
 	ErrCorrection
+	ErrHint
 )
 
 // ErrQuestionInvalid is returned by  Question.Validate()
-// It is either an error about the random parameters, or the blocks content (enonce or correction).
+// It is either an error about the random parameters, or the blocks content (enonce, correction or hints).
 type ErrQuestionInvalid struct {
 	ErrParameters ErrParameters
 	ErrEnonce     errEnonce
 	ErrCorrection errEnonce
+	ErrHint       errEnonce
+	HintIndex     int       // index of the invalid hint, for [ErrHint]
 	Kind          ErrorKind // indicates which field is valid
 }
 
@@ -87,12 +91,21 @@ func (e ErrQuestionInvalid) Error() string {
 		return fmt.Sprintf("invalid question blocks: %v", e.ErrEnonce)
 	case ErrCorrection:
 		return fmt.Sprintf("invalid correction blocks: %v", e.ErrCorrection)
+	case ErrHint:
+		return fmt.Sprintf("invalid blocks for hint %d: %v", e.HintIndex+1, e.ErrHint)
 	default:
 		panic("exhaustive switch")
 	}
 }
 
 func (en Enonce) validate(params *expression.RandomParameters) (bool, errEnonce) {
+	for i, block := range en {
+		if text, isText := block.(TextBlock); isText {
+			if err := text.Parts.validateBlanks(); err != nil {
+				return false, errEnonce{Block: i, Error: err.Error()}
+			}
+		}
+	}
 	en = en.expandText()
 
 	// setup the validators
@@ -111,7 +124,10 @@ func (en Enonce) validate(params *expression.RandomParameters) (bool, errEnonce)
 	for try := 0; try < nbTries; try++ {
 		// instantiate the parameters for this try
 		inst.Reset()
-		vars, _ := inst.Instantiate()
+		vars, err := inst.Instantiate()
+		if err != nil { // invalid parameters or unsatisfied constraints
+			return false, errEnonce{Block: -1, Error: err.Error()}
+		}
 
 		// run through the blocks
 		for i, v := range validators {
@@ -128,6 +144,10 @@ func (en Enonce) validate(params *expression.RandomParameters) (bool, errEnonce)
 		}
 	}
 
+	if rate := inst.AcceptanceRate(); rate < expression.MinAcceptanceRate {
+		return false, errEnonce{Block: -1, Error: fmt.Sprintf("Les contraintes ne sont satisfaites que dans %.1f %% des tirages.", 100*rate)}
+	}
+
 	return true, errEnonce{}
 }
 
@@ -148,6 +168,12 @@ func (qu QuestionPage) Validate() error {
 		return ErrQuestionInvalid{Kind: ErrCorrection, ErrCorrection: err}
 	}
 
+	for i, hint := range qu.Hints {
+		if ok, err := hint.validate(params); !ok {
+			return ErrQuestionInvalid{Kind: ErrHint, ErrHint: err, HintIndex: i}
+		}
+	}
+
 	return nil
 }
 
@@ -182,6 +208,20 @@ func (v linearEquationValidator) validate(vars expression.Vars) error {
 	return v.expr.IsValidLinearEquation(vars)
 }
 
+type solutionSetValidator struct {
+	expression string
+}
+
+func (v solutionSetValidator) validate(vars expression.Vars) error {
+	answer, err := expression.ParseEquationCompound(v.expression)
+	if err != nil {
+		return err
+	}
+	answer.Substitute(vars)
+	_, err = expression.SolutionSetOf(answer)
+	return err
+}
+
 type variationTableValidator struct {
 	label TextParts
 	xs    []*expression.Expr
@@ -503,6 +543,128 @@ func (v gfAffineLineValidator) validate(vars expression.Vars) error {
 	return nil
 }
 
+// evaluate returns the rounded coordinates,
+// as done in [CoordExpression.instantiate]
+func (c parsedCoord) evaluate(vars expression.Vars) (repere.IntCoord, error) {
+	if err := c.validate(vars, false); err != nil {
+		return repere.IntCoord{}, err
+	}
+	x, err := c.X.Evaluate(vars)
+	if err != nil {
+		return repere.IntCoord{}, err
+	}
+	y, err := c.Y.Evaluate(vars)
+	if err != nil {
+		return repere.IntCoord{}, err
+	}
+	return repere.Coord{X: x, Y: y}.Round(), nil
+}
+
+type gfCircleValidator struct {
+	center parsedCoord
+	radius *expression.Expr
+}
+
+func (v gfCircleValidator) validate(vars expression.Vars) error {
+	if err := v.center.validate(vars, false); err != nil {
+		return err
+	}
+	if err := v.radius.IsValidNumber(vars, false, true); err != nil {
+		return err
+	}
+	radius, err := v.radius.Evaluate(vars)
+	if err != nil {
+		return err
+	}
+	if radius <= 0 {
+		return fmt.Errorf("Le rayon %s doit être strictement positif (%g).", v.radius, radius)
+	}
+	if _, ok := gridVectorOfLength(radius); !ok {
+		return fmt.Errorf("Le rayon %s (%g) ne peut pas être construit à partir des points de la grille.", v.radius, expression.RoundFloat(radius))
+	}
+	return nil
+}
+
+type gfSegmentValidator struct {
+	from, to parsedCoord
+}
+
+func (v gfSegmentValidator) validate(vars expression.Vars) error {
+	from, err := v.from.evaluate(vars)
+	if err != nil {
+		return err
+	}
+	to, err := v.to.evaluate(vars)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return errors.New("Les extrémités du segment sont confondues.")
+	}
+	return nil
+}
+
+type gfLineValidator struct {
+	through, a, b parsedCoord
+}
+
+func (v gfLineValidator) validate(vars expression.Vars) error {
+	if err := v.through.validate(vars, false); err != nil {
+		return err
+	}
+	a, err := v.a.evaluate(vars)
+	if err != nil {
+		return err
+	}
+	b, err := v.b.evaluate(vars)
+	if err != nil {
+		return err
+	}
+	if a == b {
+		return errors.New("Les points définissant la droite de référence sont confondus.")
+	}
+	return nil
+}
+
+type gfPointOnCurveValidator expression.FunctionExpr
+
+// checks that the curve has at least one point with integer coordinates
+func (v gfPointOnCurveValidator) validate(vars expression.Vars) error {
+	fn := v.Function.Copy()
+	fn.Substitute(vars)
+	points := gfPointOnCurve{Function: fn, Variable: v.Variable}.gridPointsOnCurve(-maxGeoGrid, maxGeoGrid)
+	if len(points) == 0 {
+		return fmt.Errorf("La courbe de %s ne passe par aucun point à coordonnées entières (pour %s entre %d et %d).",
+			fn, v.Variable, -maxGeoGrid, maxGeoGrid)
+	}
+	return nil
+}
+
+type gfAngleValidator struct {
+	measure *expression.Expr
+	vertex  *parsedCoord // optional
+}
+
+func (v gfAngleValidator) validate(vars expression.Vars) error {
+	if err := v.measure.IsValidNumber(vars, false, true); err != nil {
+		return err
+	}
+	measure, err := v.measure.Evaluate(vars)
+	if err != nil {
+		return err
+	}
+	if measure <= 0 || measure > 180 {
+		return fmt.Errorf("La mesure de l'angle %s doit être comprise entre 0 (exclu) et 180 degrés (%g).", v.measure, expression.RoundFloat(measure))
+	}
+	if field := (gfAngle{Measure: measure}); !field.evaluateAnswer(field.correctAnswer(repere.RepereBounds{})) {
+		return fmt.Errorf("L'angle %s (%g degrés) ne peut pas être construit à partir des points de la grille.", v.measure, expression.RoundFloat(measure))
+	}
+	if v.vertex != nil {
+		return v.vertex.validate(vars, false)
+	}
+	return nil
+}
+
 type functionPointsValidator struct {
 	xGrid    []*expression.Expr
 	function functionValidator
@@ -608,3 +770,12 @@ func (sv setValidator) validate(vars expression.Vars) error {
 	_, err := e.ToBinarySet()
 	return err
 }
+
+type numberLineValidator struct {
+	data NumberLineBlock
+}
+
+func (v numberLineValidator) validate(vars expression.Vars) error {
+	_, err := v.data.instantiateNL(vars)
+	return err
+}
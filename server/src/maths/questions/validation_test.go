@@ -138,3 +138,88 @@ func TestSequences(t *testing.T) {
 	err = v.validate(nil)
 	tu.AssertNoErr(t, err)
 }
+
+func TestParametersConstraints(t *testing.T) {
+	params := Parameters{
+		Rp{Variable: ex.NewVar('a'), Expression: "randInt(-5;5)"},
+		Rp{Variable: ex.NewVar('b'), Expression: "randInt(-5;5)"},
+		Ct("a != 0"),
+		Ct("b^2 - 4a > 0"),
+		Ct("round(b/(2a); 0) == b/(2a)"), // integer
+	}
+	tu.AssertNoErr(t, params.Validate())
+
+	qu := QuestionPage{Parameters: params, Enonce: Enonce{NumberFieldBlock{Expression: "-b/(2a)"}}}
+	tu.AssertNoErr(t, qu.Validate())
+
+	inst := ex.NewInstantiater(*params.ToMap())
+	for range [100]int{} {
+		inst.Reset()
+		vars, err := inst.Instantiate()
+		tu.AssertNoErr(t, err)
+		a, b := vars[ex.NewVar('a')], vars[ex.NewVar('b')]
+		d, err := ex.MustParse("b^2 - 4a").Evaluate(ex.Vars{ex.NewVar('a'): a, ex.NewVar('b'): b})
+		tu.AssertNoErr(t, err)
+		tu.Assert(t, d > 0)
+	}
+
+	params = append(params, Ct("a == 100"))
+	err := params.Validate()
+	tu.Assert(t, err != nil)
+	_, isParams := err.(ErrParameters)
+	tu.Assert(t, isParams)
+
+	// constraints are also checked in enonce
+	ok, errE := Enonce{NumberFieldBlock{Expression: "a"}}.validate(params.ToMap())
+	tu.Assert(t, !ok && errE.Block == -1)
+}
+
+func TestParametersFunctions(t *testing.T) {
+	params := Parameters{
+		Rp{Variable: ex.NewVar('a'), Expression: "randInt(2;2)"},
+		Fn{Function: ex.NewVar('f'), Variable: ex.NewVar('x'), Expression: "ax^2 + 3"},
+		Rp{Variable: ex.NewVar('b'), Expression: "f(1)"},
+	}
+	tu.AssertNoErr(t, params.Validate())
+	tu.Assert(t, params[1].String() == "f(x) = ax^2 + 3")
+
+	qu := QuestionPage{Parameters: params, Enonce: Enonce{
+		NumberFieldBlock{Expression: "f(2) + b"},
+		FunctionsGraphBlock{FunctionExprs: []FunctionDefinition{
+			{Function: "f(x) - 1", Variable: ex.NewVar('x'), From: "-5", To: "5"},
+		}},
+	}}
+	tu.AssertNoErr(t, qu.Validate())
+
+	inst, _, err := qu.InstantiateErr()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, inst.Enonce[0].(NumberFieldInstance).Answer == 16)
+
+	// duplicate names are detected
+	params = append(params, Rp{Variable: ex.NewVar('f'), Expression: "1"})
+	tu.Assert(t, params.Validate() != nil)
+}
+
+func TestParametersLists(t *testing.T) {
+	params := Parameters{
+		Rp{Variable: ex.NewVar('L'), Expression: "list(12 ; 15 ; 8 ; 15 ; 10)"},
+		Rp{Variable: ex.NewVar('m'), Expression: "mean(L)"},
+	}
+	qu := QuestionPage{Parameters: params, Enonce: Enonce{
+		NumberFieldBlock{Expression: "median(L)"},
+		NumberFieldBlock{Expression: "m + count(L ; 15)"},
+		TableBlock{
+			HorizontalHeaders: []TextPart{{Kind: Text, Content: "Série"}, {Kind: Text, Content: "Q3"}},
+			Values:            [][]TextPart{{{Kind: Expression, Content: "sort(L)"}, {Kind: Expression, Content: "q3(L)"}}},
+		},
+	}}
+	tu.AssertNoErr(t, qu.Validate())
+
+	inst, _, err := qu.InstantiateErr()
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, inst.Enonce[0].(NumberFieldInstance).Answer == 12)
+	tu.Assert(t, inst.Enonce[1].(NumberFieldInstance).Answer == 14)
+	table := inst.Enonce[2].(TableInstance)
+	tu.Assert(t, table.Values[0][0].Text == "8 ; 10 ; 12 ; 15 ; 15")
+	tu.Assert(t, table.Values[0][1].Text == "15")
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"sync"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	"github.com/benoitkugler/maths-online/server/src/pass"
 	ce "github.com/benoitkugler/maths-online/server/src/sql/ceintures"
 	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
@@ -326,7 +327,54 @@ func (ct *Controller) instantiateOneQuestion(id ce.IdBeltquestion) (tasks.Instan
 	if err != nil {
 		return tasks.InstantiatedBeltQuestion{}, err
 	}
-	return l[0], nil
+	out := l[0]
+	// hints are only available in training mode
+	out.NbHints = len(qu.Hints)
+	return out, nil
+}
+
+// CeinturesShowHint returns the requested hint, for a question
+// in training mode. The usage is recorded in the student stats.
+func (ct *Controller) CeinturesShowHint(c echo.Context) error {
+	var args ShowHintIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+	out, err := ct.showHint(args)
+	if err != nil {
+		return err
+	}
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) showHint(args ShowHintIn) (client.Enonce, error) {
+	qu, err := ce.SelectBeltquestion(ct.db, args.Question)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	vars, err := args.Params.ToMap()
+	if err != nil {
+		return nil, err
+	}
+	out, err := qu.Page().InstantiateHint(vars, args.HintIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	current, has, err := ct.getEvolution(args.Tokens)
+	if err != nil {
+		return nil, err
+	}
+	if has { // record the usage
+		stats := current.Stats
+		stats[qu.Domain][qu.Rank].Hints += 1
+		err = ct.setEvolution(args.Tokens, current.Advance, stats)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
 }
 
 func (ct *Controller) CeinturesEvaluateTraining(c echo.Context) error {
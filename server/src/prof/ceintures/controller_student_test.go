@@ -139,3 +139,34 @@ func TestSelectEvaluateQuestions(t *testing.T) {
 	tu.Assert(t, res.Evolution.Advance == ce.Advance{})                                                                     // incorrect answer
 	tu.Assert(t, res.Evolution.Stats[stage.Domain][stage.Rank] == ce.Stat{Success: 0, Failure: uint16(len(out.Questions))}) // incorrect answer
 }
+
+func TestShowHint(t *testing.T) {
+	db := tu.NewTestDB(t, "../../sql/teacher/gen_create.sql", "../../sql/editor/gen_create.sql", "../../sql/ceintures/gen_create.sql")
+	defer db.Remove()
+
+	qu, err := ce.Beltquestion{Domain: ce.Fractions, Rank: ce.Jaune, Repeat: 1, Hints: questions.Hints{
+		{questions.TextBlock{Parts: "Réduire au même dénominateur"}},
+	}}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	ct := NewController(db.DB, teacher.Teacher{Id: 1}, pass.Encrypter{})
+
+	// no evolution : the hint is still returned
+	_, err = ct.showHint(ShowHintIn{Question: qu.Id, HintIndex: 0})
+	tu.AssertNoErr(t, err)
+
+	ev, err := ct.createEvolution(CreateEvolutionIn{Level: ce.Seconde})
+	tu.AssertNoErr(t, err)
+	tokens := StudentTokens{AnonymousID: ev.AnonymousID}
+	for range [2]int{} {
+		_, err = ct.showHint(ShowHintIn{Tokens: tokens, Question: qu.Id, HintIndex: 0})
+		tu.AssertNoErr(t, err)
+	}
+
+	get, _, err := ct.getEvolution(tokens)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, get.Stats[ce.Fractions][ce.Jaune] == ce.Stat{Hints: 2})
+
+	_, err = ct.showHint(ShowHintIn{Tokens: tokens, Question: qu.Id, HintIndex: 1})
+	tu.Assert(t, err != nil)
+}
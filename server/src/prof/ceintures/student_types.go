@@ -71,6 +71,13 @@ type InstantiateTrainingQuestionIn struct {
 	Id     ce.IdBeltquestion
 }
 
+type ShowHintIn struct {
+	Tokens    StudentTokens
+	Question  ce.IdBeltquestion
+	Params    tasks.Params // as sent with the question
+	HintIndex int          // index of the hint to show, starting at 0
+}
+
 type EvaluateAnswerTrainingIn struct {
 	Tokens   StudentTokens
 	Stage    Stage
@@ -1239,6 +1239,7 @@ func (ct *Controller) saveExerciceAndPreview(params SaveExerciceAndPreviewIn, us
 			// update the content
 			qu.Enonce = incomming.Enonce
 			qu.Correction = incomming.Correction
+			qu.Hints = incomming.Hints
 			qu.Parameters = incomming.Parameters
 			data.QuestionsMap[incomming.Id] = qu
 		}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/svg"
 	"github.com/benoitkugler/maths-online/server/src/prof/preview"
 	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
@@ -120,18 +121,38 @@ func (ct *Controller) createQuestion(userID uID) (QuestiongroupExt, error) {
 		return QuestiongroupExt{}, utils.SQLError(err)
 	}
 
-	group, err := ed.Questiongroup{IdTeacher: userID, Public: false}.Insert(tx)
+	out, err := ct.insertQuestiongroup(tx, user, "", questions.QuestionPage{
+		Enonce: questions.Enonce{questions.TextBlock{}}, // add a text block, very common in practice
+	})
 	if err != nil {
 		_ = tx.Rollback()
+		return QuestiongroupExt{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return QuestiongroupExt{}, utils.SQLError(err)
+	}
+
+	return out, nil
+}
+
+// insertQuestiongroup creates a group owned by [user], with one question
+// built from [page], and tagged with the favorite matiere of [user].
+func (ct *Controller) insertQuestiongroup(tx *sql.Tx, user teacher.Teacher, title string, page questions.QuestionPage) (QuestiongroupExt, error) {
+	group, err := ed.Questiongroup{IdTeacher: user.Id, Title: title, Public: false}.Insert(tx)
+	if err != nil {
 		return QuestiongroupExt{}, utils.SQLError(err)
 	}
 
 	qu, err := ed.Question{
-		IdGroup: group.Id.AsOptional(),
-		Enonce:  questions.Enonce{questions.TextBlock{}}, // add a text block, very common in practice
+		IdGroup:    group.Id.AsOptional(),
+		Enonce:     page.Enonce,
+		Parameters: page.Parameters,
+		Correction: page.Correction,
+		Hints:      page.Hints,
 	}.Insert(tx)
 	if err != nil {
-		_ = tx.Rollback()
 		return QuestiongroupExt{}, utils.SQLError(err)
 	}
 
@@ -142,17 +163,11 @@ func (ct *Controller) createQuestion(userID uID) (QuestiongroupExt, error) {
 		Section:         ts.Section,
 		IdQuestiongroup: group.Id,
 	}.Insert(tx)
-	if err != nil {
-		_ = tx.Rollback()
-		return QuestiongroupExt{}, utils.SQLError(err)
-	}
-
-	err = tx.Commit()
 	if err != nil {
 		return QuestiongroupExt{}, utils.SQLError(err)
 	}
 
-	origin := questionOrigin(group, tcAPI.OptionalIdReview{}, userID, ct.admin.Id)
+	origin := questionOrigin(group, tcAPI.OptionalIdReview{}, user.Id, ct.admin.Id)
 	return QuestiongroupExt{
 		Group:    group,
 		Tags:     ed.Tags{ts},
@@ -980,6 +995,7 @@ func (ct *Controller) saveQuestionAndPreview(params SaveQuestionAndPreviewIn, us
 	if group.IdTeacher == userID {
 		qu.Enonce = params.Page.Enonce
 		qu.Correction = params.Page.Correction
+		qu.Hints = params.Page.Hints
 		qu.Parameters = params.Page.Parameters
 		_, err := qu.Update(ct.db)
 		if err != nil {
@@ -1038,3 +1054,82 @@ func exportQuestionLatex(question questions.QuestionPage) (ExportQuestionLatexOu
 		Latex:   instance.Enonce.ToLatex(true),
 	}, nil
 }
+
+// EditorQuestionExportSVG instantiate the given question and returns
+// the SVG images of its figures, graphs, trees and tables
+func (ct *Controller) EditorQuestionExportSVG(c echo.Context) error {
+	var args questions.QuestionPage
+	if err := c.Bind(&args); err != nil {
+		return fmt.Errorf("invalid parameters: %s", err)
+	}
+
+	out, err := exportQuestionSVG(args)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+type ExportQuestionSVGOut struct {
+	Error   questions.ErrQuestionInvalid
+	IsValid bool
+	Figures []string // one SVG image for each graphical block of the statement
+}
+
+func exportQuestionSVG(question questions.QuestionPage) (ExportQuestionSVGOut, error) {
+	if err := question.Validate(); err != nil {
+		return ExportQuestionSVGOut{Error: err.(questions.ErrQuestionInvalid)}, nil
+	}
+
+	instance, _, err := question.InstantiateErr()
+	if err != nil {
+		return ExportQuestionSVGOut{}, err
+	}
+
+	out := ExportQuestionSVGOut{IsValid: true}
+	for _, block := range instance.ToClient().Enonce {
+		if figure, ok := svg.Render(block); ok {
+			out.Figures = append(out.Figures, figure)
+		}
+	}
+	return out, nil
+}
+
+// EditorQuestionExportHTML instantiate the given question and returns
+// a standalone HTML page, used as print view
+func (ct *Controller) EditorQuestionExportHTML(c echo.Context) error {
+	var args questions.QuestionPage
+	if err := c.Bind(&args); err != nil {
+		return fmt.Errorf("invalid parameters: %s", err)
+	}
+
+	out, err := exportQuestionHTML(args)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+type ExportQuestionHTMLOut struct {
+	Error   questions.ErrQuestionInvalid
+	IsValid bool
+	HTML    string
+}
+
+func exportQuestionHTML(question questions.QuestionPage) (ExportQuestionHTMLOut, error) {
+	if err := question.Validate(); err != nil {
+		return ExportQuestionHTMLOut{Error: err.(questions.ErrQuestionInvalid)}, nil
+	}
+
+	instance, _, err := question.InstantiateErr()
+	if err != nil {
+		return ExportQuestionHTMLOut{}, err
+	}
+
+	return ExportQuestionHTMLOut{
+		IsValid: true,
+		HTML:    questions.QuestionToHTML(instance.ToClient(), questions.HTMLOptions{WithCorrection: true}),
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions"
 	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
@@ -91,6 +92,38 @@ func (ct *Controller) EditorGenerateSyntaxHint(c echo.Context) error {
 	return c.JSON(200, out)
 }
 
+type ConvertLaTeXIn struct {
+	LaTeX string
+}
+
+type ConvertLaTeXOut struct {
+	Expression string // in the plain syntax
+}
+
+// EditorConvertLaTeX translates a formula pasted from a LaTeX document
+// to the syntax used in parameters and expressions.
+func (ct *Controller) EditorConvertLaTeX(c echo.Context) error {
+	var args ConvertLaTeXIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := convertLaTeX(args.LaTeX)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func convertLaTeX(latex string) (ConvertLaTeXOut, error) {
+	expr, err := expression.ParseLaTeXCompound(latex)
+	if err != nil {
+		return ConvertLaTeXOut{}, err
+	}
+	return ConvertLaTeXOut{Expression: expr.String()}, nil
+}
+
 func (ct *Controller) EditorCheckExerciceParameters(c echo.Context) error {
 	var args CheckExerciceParametersIn
 	if err := c.Bind(&args); err != nil {
@@ -2,9 +2,11 @@ package editor
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions/examples"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
@@ -220,3 +222,38 @@ func TestExportLatex(t *testing.T) {
 
 	tu.GenerateLatex(t, "", out2.Latex, "exercice-test.pdf")
 }
+
+func TestExportSVG(t *testing.T) {
+	page := questions.QuestionPage{Enonce: examples.BlockList[:], Parameters: nil}
+	out, err := exportQuestionSVG(page)
+	tu.AssertNoErr(t, err)
+
+	tu.Assert(t, out.IsValid)
+	tu.Assert(t, len(out.Figures) > 0)
+	for _, figure := range out.Figures {
+		tu.Assert(t, strings.HasPrefix(figure, "<svg"))
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	page := questions.QuestionPage{Enonce: examples.BlockList[:], Parameters: nil}
+	out, err := exportQuestionHTML(page)
+	tu.AssertNoErr(t, err)
+
+	tu.Assert(t, out.IsValid)
+	tu.Assert(t, strings.HasPrefix(out.HTML, "<!DOCTYPE html>"))
+}
+
+func TestConvertLaTeX(t *testing.T) {
+	out, err := convertLaTeX(`\frac{1}{2}x^{2} + \sqrt{3}`)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, out.Expression == "(1/2)x ^ 2 + sqrt(3)")
+
+	out, err = convertLaTeX(`\left] -\infty ; \pi \right]`)
+	tu.AssertNoErr(t, err)
+	_, err = expression.ParseCompound(out.Expression)
+	tu.AssertNoErr(t, err)
+
+	_, err = convertLaTeX(`\frac{1}{`)
+	tu.Assert(t, err != nil)
+}
@@ -0,0 +1,165 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/moodle"
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file implements the import and export of question groups
+// to the formats used by Moodle (and other LMS)
+
+// maxLMSFileSize is the maximum size of an imported file
+const maxLMSFileSize = 5 << 20 // 5MB
+
+type ExportQuestiongroupLMSOut struct {
+	Filename string
+	Content  string
+	Report   []string // the contents which could not be exported
+}
+
+// EditorExportQuestiongroupLMS converts the variants of the given group
+// to the format given by the 'format' query param ("xml" or "gift").
+func (ct *Controller) EditorExportQuestiongroupLMS(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	idGroup, err := utils.QueryParamInt64(c, "id")
+	if err != nil {
+		return err
+	}
+	var format moodle.Format
+	switch f := c.QueryParam("format"); f {
+	case "xml":
+		format = moodle.XML
+	case "gift":
+		format = moodle.GIFT
+	default:
+		return fmt.Errorf("invalid format parameter %s", f)
+	}
+
+	out, err := ct.exportQuestiongroupLMS(ed.IdQuestiongroup(idGroup), format, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) exportQuestiongroupLMS(idGroup ed.IdQuestiongroup, format moodle.Format, userID uID) (ExportQuestiongroupLMSOut, error) {
+	group, err := ed.SelectQuestiongroup(ct.db, idGroup)
+	if err != nil {
+		return ExportQuestiongroupLMSOut{}, utils.SQLError(err)
+	}
+	if !group.IsVisibleBy(userID) {
+		return ExportQuestiongroupLMSOut{}, errAccessForbidden
+	}
+
+	variants, err := LoadQuestionVariants(ct.db, idGroup)
+	if err != nil {
+		return ExportQuestiongroupLMSOut{}, err
+	}
+
+	title := group.Title
+	if title == "" {
+		title = "Question"
+	}
+	qus := make([]moodle.Question, len(variants))
+	for i, variant := range variants {
+		name := title
+		if len(variants) > 1 {
+			name = fmt.Sprintf("%s (%d)", title, i+1)
+			if variant.Subtitle != "" {
+				name = fmt.Sprintf("%s - %s", title, variant.Subtitle)
+			}
+		}
+		qus[i] = moodle.Question{Name: name, Page: variant.Page()}
+	}
+
+	content, report := moodle.Export(qus, format)
+	return ExportQuestiongroupLMSOut{
+		Filename: title + format.Extension(),
+		Content:  string(content),
+		Report:   report,
+	}, nil
+}
+
+type ImportQuestiongroupsLMSOut struct {
+	Groups []QuestiongroupExt // the created groups
+	Report []string           // the contents which could not be imported
+}
+
+// EditorImportQuestiongroupsLMS reads a Moodle XML or GIFT file, sent as the "file"
+// form value, and creates one question group for each valid question.
+func (ct *Controller) EditorImportQuestiongroupsLMS(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return err
+	}
+	if header.Size > maxLMSFileSize {
+		return errors.New("Le fichier est trop volumineux.")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	out, err := ct.importQuestiongroupsLMS(header.Filename, content, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) importQuestiongroupsLMS(filename string, content []byte, userID uID) (ImportQuestiongroupsLMSOut, error) {
+	qus, report, err := moodle.Import(filename, content)
+	if err != nil {
+		return ImportQuestiongroupsLMSOut{}, err
+	}
+	if len(qus) == 0 {
+		return ImportQuestiongroupsLMSOut{Report: report}, nil
+	}
+
+	user, err := teacher.SelectTeacher(ct.db, userID)
+	if err != nil {
+		return ImportQuestiongroupsLMSOut{}, utils.SQLError(err)
+	}
+
+	tx, err := ct.db.Begin()
+	if err != nil {
+		return ImportQuestiongroupsLMSOut{}, utils.SQLError(err)
+	}
+
+	out := ImportQuestiongroupsLMSOut{Report: report}
+	for _, qu := range qus {
+		group, err := ct.insertQuestiongroup(tx, user, qu.Name, qu.Page)
+		if err != nil {
+			_ = tx.Rollback()
+			return ImportQuestiongroupsLMSOut{}, err
+		}
+		out.Groups = append(out.Groups, group)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return ImportQuestiongroupsLMSOut{}, utils.SQLError(err)
+	}
+
+	return out, nil
+}
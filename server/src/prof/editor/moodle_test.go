@@ -0,0 +1,39 @@
+package editor
+
+import (
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/moodle"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestImportExportLMS(t *testing.T) {
+	db := tu.NewTestDB(t, "../../sql/teacher/gen_create.sql", "../../sql/editor/gen_create.sql", "../../sql/tasks/gen_create.sql",
+		"../../sql/homework/gen_create.sql", "../../sql/reviews/gen_create.sql")
+	defer db.Remove()
+
+	_, err := teacher.Teacher{IsAdmin: true, FavoriteMatiere: teacher.Mathematiques}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	ct := NewController(db.DB, teacher.Teacher{Id: 1})
+
+	const source = `
+::Somme:: Calculer 1 + 1 {#2}
+
+::Capitale:: La capitale de la France est {=Paris ~Lyon ~Lille}
+
+::Rédaction:: Racontez votre journée. {}
+`
+	out, err := ct.importQuestiongroupsLMS("questions.gift", []byte(source), 1)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(out.Groups) == 2 && len(out.Report) == 1)
+	tu.Assert(t, out.Groups[0].Group.Title == "Somme" && len(out.Groups[0].Tags) == 1)
+
+	exported, err := ct.exportQuestiongroupLMS(out.Groups[1].Group.Id, moodle.XML, 1)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, exported.Filename == "Capitale.xml" && len(exported.Report) == 0)
+
+	_, err = ct.exportQuestiongroupLMS(out.Groups[1].Group.Id, moodle.GIFT, 2)
+	tu.Assert(t, err == errAccessForbidden)
+}
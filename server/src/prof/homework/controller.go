@@ -794,6 +794,58 @@ func (ct *Controller) reorderSheetTasks(args ReorderSheetTasksIn, userID uID) er
 	return nil
 }
 
+// HomeworkUpdateSheetTask updates the access settings
+// of the given task (optional, requirement and min mark).
+// The index of the task in the sheet is not modified.
+func (ct *Controller) HomeworkUpdateSheetTask(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args ho.SheetTask
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.updateSheetTask(args, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) updateSheetTask(args ho.SheetTask, userID uID) (TaskExt, error) {
+	if args.MinMark < 0 || args.MinMark > 100 {
+		return TaskExt{}, errors.New("La note minimale doit être un pourcentage (entre 0 et 100).")
+	}
+
+	link, found, err := ho.SelectSheetTaskByIdTask(ct.db, args.IdTask)
+	if err != nil {
+		return TaskExt{}, utils.SQLError(err)
+	}
+	if !found {
+		return TaskExt{}, errors.New("internal error: task without sheet")
+	}
+
+	if err := ct.checkSheetOwner(link.IdSheet, userID); err != nil {
+		return TaskExt{}, err
+	}
+
+	link.Optional = args.Optional
+	link.Requirement = args.Requirement
+	link.MinMark = args.MinMark
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		if err := link.Delete(tx); err != nil {
+			return err
+		}
+		return link.Insert(tx)
+	})
+	if err != nil {
+		return TaskExt{}, err
+	}
+
+	return loadTaskExt(ct.db, link.IdTask)
+}
+
 func (ct *Controller) HomeworkDeleteSheet(c echo.Context) error {
 	userID := tcAPI.JWTTeacher(c)
 
@@ -963,6 +1015,8 @@ func duplicateSheetTx(tx *sql.Tx, idSheet ho.IdSheet, userID uID) (ho.Sheet, err
 		return ho.Sheet{}, utils.SQLError(err)
 	}
 
+	links.EnsureOrder()
+
 	taskMap, err := tasks.SelectTasks(tx, links.IdTasks()...)
 	if err != nil {
 		return ho.Sheet{}, utils.SQLError(err)
@@ -1010,7 +1064,10 @@ func duplicateSheetTx(tx *sql.Tx, idSheet ho.IdSheet, userID uID) (ho.Sheet, err
 		if err != nil {
 			return ho.Sheet{}, utils.SQLError(err)
 		}
-		newLinks[i] = ho.SheetTask{IdSheet: newSheet.Id, IdTask: newTask.Id, Index: i}
+		// also copy the access settings
+		newLink := link
+		newLink.IdSheet, newLink.IdTask, newLink.Index = newSheet.Id, newTask.Id, i
+		newLinks[i] = newLink
 	}
 
 	err = ho.InsertManySheetTasks(tx, newLinks...)
@@ -1056,69 +1113,137 @@ func (ct *Controller) copyTravailTo(args CopyTravailIn, userID uID) (CopyTravail
 		return CopyTravailToOut{}, err
 	}
 
-	travail, err := ho.SelectTravail(ct.db, args.IdTravail)
+	travail, err := ct.checkTravailOwner(args.IdTravail, userID)
 	if err != nil {
-		return CopyTravailToOut{}, utils.SQLError(err)
+		return CopyTravailToOut{}, err
 	}
 
-	sheet, err := ho.SelectSheet(ct.db, travail.IdSheet)
+	var out CopyTravailToOut
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		out, err = ct.copyTravailTx(tx, travail, args.IdClassroom, userID)
+		return err
+	})
+	return out, err
+}
+
+// copyTravailTx does not check the ownership of the target classroom
+func (ct *Controller) copyTravailTx(tx *sql.Tx, travail ho.Travail, idClassroom teacher.IdClassroom, userID uID) (CopyTravailToOut, error) {
+	sheet, err := ho.SelectSheet(tx, travail.IdSheet)
 	if err != nil {
 		return CopyTravailToOut{}, utils.SQLError(err)
 	}
 
 	isAnonymous := sheet.Anonymous.Valid
 
-	tx, err := ct.db.Begin()
-	if err != nil {
-		return CopyTravailToOut{}, utils.SQLError(err)
-	}
-
 	var newSheet SheetExt
 	if isAnonymous {
 		// also duplicate the underlying sheet
 		newSheet.Sheet, err = duplicateSheetTx(tx, sheet.Id, userID)
 		if err != nil {
-			_ = tx.Rollback()
-			return CopyTravailToOut{}, utils.SQLError(err)
+			return CopyTravailToOut{}, err
 		}
 		travail.IdSheet = newSheet.Sheet.Id
 	}
 
+	// groups only make sense in the same classroom
+	var groups ho.TravailGroups
+	if travail.IdClassroom == idClassroom {
+		groups, err = ho.SelectTravailGroupsByIdTravails(tx, travail.Id)
+		if err != nil {
+			return CopyTravailToOut{}, utils.SQLError(err)
+		}
+	}
+
 	// shallow copy is enough
-	travail.IdClassroom = args.IdClassroom
+	travail.IdClassroom = idClassroom
 	travail, err = travail.Insert(tx)
 	if err != nil {
-		_ = tx.Rollback()
 		return CopyTravailToOut{}, utils.SQLError(err)
 	}
 
+	for i := range groups {
+		groups[i].IdTravail = travail.Id
+	}
+	err = ho.InsertManyTravailGroups(tx, groups...)
+	if err != nil {
+		return CopyTravailToOut{}, utils.SQLError(err)
+	}
+
+	out := CopyTravailToOut{Travail: travail}
 	if isAnonymous {
 		// map the new sheet to its new travail
 		newSheet.Sheet.Anonymous = travail.Id.AsOptional()
 		_, err = newSheet.Sheet.Update(tx)
 		if err != nil {
-			_ = tx.Rollback()
 			return CopyTravailToOut{}, utils.SQLError(err)
 		}
 
 		newSheet, err = LoadSheet(tx, newSheet.Sheet.Id, userID, ct.admin.Id)
 		if err != nil {
-			_ = tx.Rollback()
 			return CopyTravailToOut{}, err
 		}
+
+		out.HasNewSheet = true
+		out.NewSheet = newSheet
 	}
 
-	err = tx.Commit()
+	return out, nil
+}
+
+type CopyTravailManyIn struct {
+	IdTravail    ho.IdTravail
+	IdClassrooms []teacher.IdClassroom
+	// Shift is the number of hours added to the dates
+	// of each copy, with respect to the previous one.
+	// Zero means the same dates for all classrooms.
+	Shift int
+}
+
+// HomeworkCopyTravailMany duplicate the given [Travail] entry
+// in several classrooms, with staggered dates.
+func (ct *Controller) HomeworkCopyTravailMany(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args CopyTravailManyIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.copyTravailToMany(args, userID)
 	if err != nil {
-		return CopyTravailToOut{}, utils.SQLError(err)
+		return err
 	}
 
-	out := CopyTravailToOut{Travail: travail}
-	if isAnonymous {
-		out.HasNewSheet = true
-		out.NewSheet = newSheet
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) copyTravailToMany(args CopyTravailManyIn, userID uID) ([]CopyTravailToOut, error) {
+	for _, idClassroom := range args.IdClassrooms {
+		if err := ct.checkClassroomOwner(userID, idClassroom); err != nil {
+			return nil, err
+		}
 	}
-	return out, nil
+
+	travail, err := ct.checkTravailOwner(args.IdTravail, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]CopyTravailToOut, len(args.IdClassrooms))
+	err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+		for i, idClassroom := range args.IdClassrooms {
+			shift := time.Duration(i*args.Shift) * time.Hour
+			tr := travail
+			tr.ShowAfter = ho.Time(time.Time(travail.ShowAfter).Add(shift))
+			tr.Deadline = ho.Time(time.Time(travail.Deadline).Add(shift))
+			out[i], err = ct.copyTravailTx(tx, tr, idClassroom, userID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return out, err
 }
 
 func (ct *Controller) HomeworkGetDispenses(c echo.Context) error {
@@ -14,6 +14,7 @@ import (
 	ta "github.com/benoitkugler/maths-online/server/src/sql/tasks"
 	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
 	"github.com/benoitkugler/maths-online/server/src/tasks"
+	"github.com/benoitkugler/maths-online/server/src/utils"
 	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
 )
 
@@ -323,6 +324,53 @@ func TestEvaluateTask(t *testing.T) {
 	tu.Assert(t, len(taHeader.Progression[0]) == 2)
 }
 
+func TestShowHintAccess(t *testing.T) {
+	db, sp := setupDB(t)
+	defer db.Remove()
+	studentKey := pass.Encrypter{}
+	ct := NewController(db.DB, teacher.Teacher{Id: sp.userID}, studentKey)
+
+	// a noted travail with exe1, and a free one with exe2
+	sh1, err := ct.createSheet(sp.userID)
+	tu.AssertNoErr(t, err)
+	task1, err := ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh1.Sheet.Id, IdExercice: sp.exe1.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr1, err := ct.assignSheetTo(CreateTravailWithIn{IdSheet: sh1.Sheet.Id, IdClassroom: sp.class.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr1.Noted = true
+	tr1.Deadline = ho.Time(time.Now().Add(time.Hour))
+	err = ct.updateTravail(tr1, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	sh2, err := ct.createSheet(sp.userID)
+	tu.AssertNoErr(t, err)
+	task2, err := ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh2.Sheet.Id, IdExercice: sp.exe2.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr2, err := ct.assignSheetTo(CreateTravailWithIn{IdSheet: sh2.Sheet.Id, IdClassroom: sp.class.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr2.Noted = false
+	err = ct.updateTravail(tr2, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	isNoted, err := IsWorkNoted(ct.db, task1.IdWork)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, isNoted)
+	isNoted, err = IsWorkNoted(ct.db, task2.IdWork)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, !isNoted)
+
+	student, err := teacher.Student{IdClassroom: sp.class.Id}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+
+	// task1 does not belong to the free travail
+	_, err = ct.studentShowHint(StudentShowHintIn{
+		StudentID: studentKey.EncryptID(int64(student.Id)),
+		IdTravail: tr2.Id,
+		IdTask:    task1.Id,
+	})
+	tu.Assert(t, err == errAccessForbidden)
+}
+
 func insertProgression(db *sql.DB, idTask ta.IdTask, idStudent teacher.IdStudent, questions []ta.QuestionHistory) error {
 	links := make(ta.Progressions, len(questions))
 	for i, qu := range questions {
@@ -368,3 +416,219 @@ func TestTravauxMultiTeacher(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, len(out.Travaux) == 1 && len(out.Travaux[0].Travaux) == 1)
 }
+
+func TestResolveTasksStatus(t *testing.T) {
+	links := ho.SheetTasks{
+		{IdTask: 1},
+		{IdTask: 2, Optional: true},
+		{IdTask: 3, Requirement: ho.RequireCompletion},
+		{IdTask: 4, Requirement: ho.RequireMinMark, MinMark: 50},
+	}
+	progressions := map[ta.IdTask]tasks.TaskProgressionHeader{
+		1: {HasProgression: true, Progression: tasks.Progression{{true}, {false}}, Mark: 1, Bareme: 2},
+	}
+	status := resolveTasksStatus(links, progressions, 0)
+	tu.Assert(t, len(status) == 4)
+	tu.Assert(t, !status[0].Locked && !status[1].Locked)
+	tu.Assert(t, status[1].Optional)
+	tu.Assert(t, status[2].Locked)  // task 1 is not complete
+	tu.Assert(t, !status[3].Locked) // 1/2 is enough, optional task 2 is ignored
+
+	links[3].MinMark = 60
+	status = resolveTasksStatus(links, progressions, 0)
+	tu.Assert(t, status[3].Locked)
+
+	progressions[1] = tasks.TaskProgressionHeader{HasProgression: true, Progression: tasks.Progression{{true}, {true}}, Mark: 2, Bareme: 2}
+	status = resolveTasksStatus(links, progressions, 0)
+	tu.Assert(t, !status[2].Locked && !status[3].Locked)
+}
+
+func TestLockedTask(t *testing.T) {
+	db, sp := setupDB(t)
+	defer db.Remove()
+	studentKey := pass.Encrypter{}
+	ct := NewController(db.DB, teacher.Teacher{Id: sp.userID}, studentKey)
+
+	sh, err := ct.createSheet(sp.userID)
+	tu.AssertNoErr(t, err)
+	task1, err := ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh.Sheet.Id, IdExercice: sp.exe1.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	task2, err := ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh.Sheet.Id, IdExercice: sp.exe1.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	_, err = ct.updateSheetTask(ho.SheetTask{IdTask: task2.Id, MinMark: 120}, sp.userID)
+	tu.Assert(t, err != nil)
+	updated, err := ct.updateSheetTask(ho.SheetTask{IdTask: task2.Id, Requirement: ho.RequireCompletion}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, updated.Requirement == ho.RequireCompletion)
+
+	// reordering preserves the settings
+	err = ct.reorderSheetTasks(ReorderSheetTasksIn{IdSheet: sh.Sheet.Id, Tasks: []ta.IdTask{task1.Id, task2.Id}}, sp.userID)
+	tu.AssertNoErr(t, err)
+	ext, err := loadTaskExt(ct.db, task2.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, ext.Requirement == ho.RequireCompletion)
+
+	tr, err := ct.assignSheetTo(CreateTravailWithIn{IdSheet: sh.Sheet.Id, IdClassroom: sp.class.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr.ShowAfter = ho.Time(time.Now().Add(-time.Second))
+	err = ct.updateTravail(tr, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	student, err := teacher.Student{IdClassroom: sp.class.Id}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+
+	sheet, err := ct.getStudentTravail(student.Id, tr.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(sheet.TasksStatus) == 2)
+	tu.Assert(t, !sheet.TasksStatus[0].Locked && sheet.TasksStatus[1].Locked)
+
+	err = checkTaskAccess(ct.db, tr, student.Id, task2.Id)
+	tu.Assert(t, err == errTaskLocked)
+
+	// complete the first task
+	err = insertProgression(ct.db, task1.Id, student.Id, []ta.QuestionHistory{{true}})
+	tu.AssertNoErr(t, err)
+
+	err = checkTaskAccess(ct.db, tr, student.Id, task2.Id)
+	tu.AssertNoErr(t, err)
+
+	// the hints used lower the mark of the first task
+	_, err = ct.updateSheetTask(ho.SheetTask{IdTask: task2.Id, Requirement: ho.RequireMinMark, MinMark: 100}, sp.userID)
+	tu.AssertNoErr(t, err)
+	err = checkTaskAccess(ct.db, tr, student.Id, task2.Id)
+	tu.AssertNoErr(t, err)
+	err = tasks.RecordHintUsage(ct.db, student.Id, task1.Id, 0, 0)
+	tu.AssertNoErr(t, err)
+	err = checkTaskAccess(ct.db, tr, student.Id, task2.Id)
+	tu.AssertNoErr(t, err) // no penalty
+	tr.HintPenalty = 1
+	err = checkTaskAccess(ct.db, tr, student.Id, task2.Id)
+	tu.Assert(t, err == errTaskLocked)
+}
+
+func TestTravailAssignments(t *testing.T) {
+	travail := ho.Travail{Id: 1, IdSheet: 10}
+	ta := travailAssignments{
+		groups: map[ho.IdTravail]ho.TravailGroups{
+			1: {
+				{IdTravail: 1, IdStudentGroup: 2, IdSheet: ho.IdSheet(20).AsOptional()},
+				{IdTravail: 1, IdStudentGroup: 1},
+			},
+		},
+		members: map[teacher.IdStudentGroup]utils.Set[teacher.IdStudent]{
+			1: utils.NewSet[teacher.IdStudent](1, 2),
+			2: utils.NewSet[teacher.IdStudent](2, 3),
+		},
+	}
+	for _, test := range []struct {
+		idStudent teacher.IdStudent
+		idSheet   ho.IdSheet
+		assigned  bool
+	}{
+		{1, 10, true},
+		{2, 10, true}, // lowest group wins
+		{3, 20, true},
+		{4, 0, false},
+	} {
+		idSheet, ok := ta.sheetFor(travail, test.idStudent)
+		tu.Assert(t, idSheet == test.idSheet && ok == test.assigned)
+	}
+
+	// no groups : the whole classroom
+	idSheet, ok := ta.sheetFor(ho.Travail{Id: 2, IdSheet: 10}, 4)
+	tu.Assert(t, idSheet == 10 && ok)
+}
+
+func TestTravailGroups(t *testing.T) {
+	db, sp := setupDB(t)
+	defer db.Remove()
+	ct := NewController(db.DB, teacher.Teacher{Id: sp.userID}, pass.Encrypter{})
+
+	sh1, err := ct.createSheet(sp.userID)
+	tu.AssertNoErr(t, err)
+	_, err = ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh1.Sheet.Id, IdExercice: sp.exe1.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	sh2, err := ct.createSheet(sp.userID)
+	tu.AssertNoErr(t, err)
+	task2, err := ct.addExerciceTo(AddExerciceToTaskIn{IdSheet: sh2.Sheet.Id, IdExercice: sp.exe2.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	tr, err := ct.assignSheetTo(CreateTravailWithIn{IdSheet: sh1.Sheet.Id, IdClassroom: sp.class.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tr.ShowAfter = ho.Time(time.Now().Add(-time.Second))
+	err = ct.updateTravail(tr, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	st1, err := teacher.Student{IdClassroom: sp.class.Id}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+	st2, err := teacher.Student{IdClassroom: sp.class.Id}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+	st3, err := teacher.Student{IdClassroom: sp.class.Id}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+
+	g1, err := teacher.StudentGroup{IdClassroom: sp.class.Id, Name: "A"}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+	g2, err := teacher.StudentGroup{IdClassroom: sp.class.Id, Name: "B"}.Insert(ct.db)
+	tu.AssertNoErr(t, err)
+	tx, err := ct.db.Begin()
+	tu.AssertNoErr(t, err)
+	err = teacher.InsertManyStudentGroupMembers(tx,
+		teacher.StudentGroupMember{IdStudentGroup: g1.Id, IdStudent: st1.Id},
+		teacher.StudentGroupMember{IdStudentGroup: g2.Id, IdStudent: st2.Id},
+	)
+	tu.AssertNoErr(t, err)
+	err = tx.Commit()
+	tu.AssertNoErr(t, err)
+
+	err = ct.setTravailGroups(SetTravailGroupsIn{IdTravail: tr.Id, Assignments: ho.TravailGroups{
+		{IdStudentGroup: g1.Id},
+		{IdStudentGroup: g2.Id, IdSheet: sh2.Sheet.Id.AsOptional()},
+	}}, sp.userID)
+	tu.AssertNoErr(t, err)
+
+	groups, err := ct.getTravailGroups(tr.Id, sp.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(groups.Assignments) == 2 && len(groups.Groups) == 2)
+
+	l, err := ct.getStudentSheets(st1.Id, true)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1 && l[0].Sheet.Id == sh1.Sheet.Id)
+	l, err = ct.getStudentSheets(st2.Id, true)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1 && l[0].Sheet.Id == sh2.Sheet.Id)
+	l, err = ct.getStudentSheets(st3.Id, true)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 0)
+
+	_, err = ct.getStudentTravail(st3.Id, tr.Id)
+	tu.Assert(t, err != nil)
+
+	// the tasks of the group B sheet are not accessible to group A
+	_, err = resolveStudentTask(ct.db, tr.Id, st2.Id, task2.Id)
+	tu.AssertNoErr(t, err)
+	_, err = resolveStudentTask(ct.db, tr.Id, st1.Id, task2.Id)
+	tu.Assert(t, err == errAccessForbidden)
+
+	marks, err := ct.getMarks(HowemorkMarksIn{IdClassroom: sp.class.Id, IdTravaux: []ho.IdTravail{tr.Id}}, sp.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(marks.Marks[tr.Id].TaskStats) == 2)
+	tu.Assert(t, marks.Marks[tr.Id].Marks[st3.Id].NotAssigned)
+
+	// deleting the replacement sheet keeps the group assigned, to the travail sheet
+	err = ct.deleteSheet(sh2.Sheet.Id, sp.userID)
+	tu.AssertNoErr(t, err)
+	groups, err = ct.getTravailGroups(tr.Id, sp.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(groups.Assignments) == 2)
+	l, err = ct.getStudentSheets(st2.Id, true)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1 && l[0].Sheet.Id == sh1.Sheet.Id)
+
+	// back to the whole classroom
+	err = ct.setTravailGroups(SetTravailGroupsIn{IdTravail: tr.Id}, sp.userID)
+	tu.AssertNoErr(t, err)
+	l, err = ct.getStudentSheets(st3.Id, true)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1)
+}
@@ -0,0 +1,418 @@
+package homework
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	htmlT "html/template"
+	"sort"
+	textT "text/template"
+	"time"
+
+	"github.com/benoitkugler/maths-online/server/src/mailer"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/svg"
+	"github.com/benoitkugler/maths-online/server/src/pass"
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	"github.com/benoitkugler/maths-online/server/src/sql/editor"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	re "github.com/benoitkugler/maths-online/server/src/sql/reviews"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+)
+
+// this file implements the weekly digest mail, sent
+// to the teachers who opted in (see [teacher.TeacherDigest])
+
+const (
+	digestWeek = 7 * 24 * time.Hour
+	// digestInterval is the delay between two checks of the pending digests
+	digestInterval = time.Hour
+	// digestHour is the hour (on monday) after which the digests are sent
+	digestHour = 7
+
+	// questions answered at least [digestMinAnswers] times,
+	// with a success rate below [digestLowSuccess] are reported
+	digestMinAnswers = 5
+	digestLowSuccess = 0.3
+)
+
+// digestSender is implemented by [mailer.Pool]
+type digestSender interface {
+	SendMailHTML(to, subject, htmlBody, textBody string) error
+}
+
+// RunDigests periodically sends the weekly digests, every monday morning.
+// It never returns and should be called in its own goroutine.
+func (ct *Controller) RunDigests(smtp pass.SMTP) {
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		if now.Weekday() == time.Monday && now.Hour() >= digestHour {
+			if err := ct.sendDueDigests(smtp, now); err != nil {
+				Logger.Printf("sending digests: %s", err)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// dueDigests returns the digests not sent during the current week
+func (ct *Controller) dueDigests(now time.Time) ([]teacher.TeacherDigest, error) {
+	digests, err := teacher.SelectAllTeacherDigests(ct.db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	var out []teacher.TeacherDigest
+	for _, digest := range digests {
+		// leave some margin so that the sending hour may vary
+		if now.Sub(time.Time(digest.LastSent)) < digestWeek-12*time.Hour {
+			continue
+		}
+		out = append(out, digest)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IdTeacher < out[j].IdTeacher })
+	return out, nil
+}
+
+func (ct *Controller) sendDueDigests(smtp pass.SMTP, now time.Time) error {
+	digests, err := ct.dueDigests(now)
+	if err != nil {
+		return err
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	pool, err := mailer.NewPool(smtp, nil)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	nb, err := ct.sendDigests(pool, digests, now)
+	Logger.Printf("%d weekly digests sent", nb)
+	return err
+}
+
+// sendDigests builds and sends the given digests, returning
+// the number of mails actually sent.
+// Empty digests are not sent.
+func (ct *Controller) sendDigests(sender digestSender, digests []teacher.TeacherDigest, now time.Time) (int, error) {
+	nbSent := 0
+	for _, digest := range digests {
+		user, err := teacher.SelectTeacher(ct.db, digest.IdTeacher)
+		if err != nil {
+			return nbSent, utils.SQLError(err)
+		}
+		content, err := ct.buildDigest(user, now)
+		if err != nil {
+			return nbSent, err
+		}
+		digest.LastError = ""
+		if !content.isEmpty() {
+			html, text, err := content.render()
+			if err != nil {
+				return nbSent, err
+			}
+			if err = sender.SendMailHTML(user.Mail, "[Isyro] - Résumé hebdomadaire", html, text); err != nil {
+				// do not block the other teachers, and record the failure
+				// so that the mail is not sent again until next week
+				Logger.Printf("sending digest to %s: %s", user.Mail, err)
+				digest.LastError = err.Error()
+			} else {
+				nbSent++
+			}
+		}
+
+		digest.LastSent = teacher.Time(now)
+		_, err = teacher.DeleteTeacherDigestsByIdTeachers(ct.db, digest.IdTeacher)
+		if err != nil {
+			return nbSent, utils.SQLError(err)
+		}
+		if err = digest.Insert(ct.db); err != nil {
+			return nbSent, utils.SQLError(err)
+		}
+	}
+	return nbSent, nil
+}
+
+type digestTravail struct {
+	Title    string
+	Deadline string
+	Upcoming bool // false for the travaux closed during the last week
+
+	NbCompleted, NbStudents int
+	Average                 string // on 20, empty if no student
+}
+
+type digestQuestion struct {
+	Travail     string
+	Description string
+	SuccessRate int // in percent
+	NbAnswers   int
+	Figure      htmlT.URL // optional SVG image (as data URL), only used in the HTML version
+}
+
+type digestClassroom struct {
+	Name             string
+	Travaux          []digestTravail
+	InactiveStudents []string
+	HardQuestions    []digestQuestion
+}
+
+func (dc digestClassroom) isEmpty() bool {
+	return len(dc.Travaux) == 0 && len(dc.InactiveStudents) == 0 && len(dc.HardQuestions) == 0
+}
+
+type digestReview struct {
+	Kind  string
+	Title string
+}
+
+// teacherDigest is the content of one digest mail
+type teacherDigest struct {
+	Name       string
+	Classrooms []digestClassroom
+	Reviews    []digestReview
+}
+
+func (td teacherDigest) isEmpty() bool {
+	return len(td.Classrooms) == 0 && len(td.Reviews) == 0
+}
+
+func (ct *Controller) buildDigest(user teacher.Teacher, now time.Time) (teacherDigest, error) {
+	out := teacherDigest{Name: user.Contact.Name}
+
+	classrooms, err := teacher.SelectClassroomsByIdTeacher(ct.db, user.Id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	keys := classrooms.IDs()
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	for _, id := range keys {
+		classroom, err := ct.buildDigestClassroom(classrooms[id], now)
+		if err != nil {
+			return out, err
+		}
+		if !classroom.isEmpty() {
+			out.Classrooms = append(out.Classrooms, classroom)
+		}
+	}
+
+	out.Reviews, err = ct.pendingReviews(user)
+	if err != nil {
+		return out, err
+	}
+
+	return out, nil
+}
+
+func (ct *Controller) buildDigestClassroom(classroom teacher.Classroom, now time.Time) (digestClassroom, error) {
+	out := digestClassroom{Name: classroom.Name}
+
+	students, err := teacher.SelectStudentsByIdClassrooms(ct.db, classroom.Id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	for _, student := range students {
+		if !hasConnectedSince(student, now.Add(-digestWeek)) {
+			out.InactiveStudents = append(out.InactiveStudents, tcAPI.PronoteName(student))
+		}
+	}
+	sort.Strings(out.InactiveStudents)
+
+	travaux, err := ho.SelectTravailsByIdClassrooms(ct.db, classroom.Id)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+	var selected []ho.Travail
+	for _, travail := range travaux {
+		deadline := time.Time(travail.Deadline)
+		if !travail.Noted || deadline.Before(now.Add(-digestWeek)) || deadline.After(now.Add(digestWeek)) {
+			continue
+		}
+		selected = append(selected, travail)
+	}
+	if len(selected) == 0 {
+		return out, nil
+	}
+	sort.Slice(selected, func(i, j int) bool { return time.Time(selected[i].Deadline).Before(time.Time(selected[j].Deadline)) })
+
+	args := HowemorkMarksIn{IdClassroom: classroom.Id}
+	for _, travail := range selected {
+		args.IdTravaux = append(args.IdTravaux, travail.Id)
+	}
+	marks, err := ct.computeMarks(args)
+	if err != nil {
+		return out, err
+	}
+	sheets, err := ho.SelectSheets(ct.db, travaux.IdSheets()...)
+	if err != nil {
+		return out, utils.SQLError(err)
+	}
+
+	for _, travail := range selected {
+		title := sheets[travail.IdSheet].Title
+		item := digestTravail{
+			Title:    title,
+			Deadline: time.Time(travail.Deadline).Format("02/01/2006 15:04"),
+			Upcoming: time.Time(travail.Deadline).After(now),
+		}
+		var sum float64
+		for _, mark := range marks.Marks[travail.Id].Marks {
+			if mark.NotAssigned || mark.Dispensed {
+				continue
+			}
+			item.NbStudents++
+			if mark.Completed {
+				item.NbCompleted++
+			}
+			sum += mark.Mark
+		}
+		if item.NbStudents != 0 {
+			item.Average = fmt.Sprintf("%.1f", sum/float64(item.NbStudents))
+		}
+		out.Travaux = append(out.Travaux, item)
+
+		for _, task := range marks.Marks[travail.Id].TaskStats {
+			for _, qu := range task.QuestionStats {
+				total := qu.NbSuccess + qu.NbFailure
+				if total < digestMinAnswers {
+					continue
+				}
+				rate := float64(qu.NbSuccess) / float64(total)
+				if rate >= digestLowSuccess {
+					continue
+				}
+				question, err := editor.SelectQuestion(ct.db, qu.Id)
+				if err != nil {
+					return out, utils.SQLError(err)
+				}
+				out.HardQuestions = append(out.HardQuestions, digestQuestion{
+					Travail:     title,
+					Description: qu.Description,
+					SuccessRate: int(rate * 100),
+					NbAnswers:   total,
+					Figure:      questionFigure(question.Page()),
+				})
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// questionFigure instantiates the question and returns its first
+// graphical block, rendered as SVG, or an empty string if the question
+// has no figure (or is invalid).
+func questionFigure(page questions.QuestionPage) htmlT.URL {
+	instance, _, err := page.InstantiateErr()
+	if err != nil {
+		return ""
+	}
+	for _, block := range instance.ToClient().Enonce {
+		if content, ok := svg.Render(block); ok {
+			return htmlT.URL("data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(content)))
+		}
+	}
+	return ""
+}
+
+// hasConnectedSince returns true if one of the student devices
+// was connected after [limit]
+func hasConnectedSince(student teacher.Student, limit time.Time) bool {
+	for _, client := range student.Clients {
+		if client.Time.After(limit) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingReviews returns the reviews targeting a resource owned by [user],
+// or all the reviews for the admin account.
+func (ct *Controller) pendingReviews(user teacher.Teacher) ([]digestReview, error) {
+	reviews, err := re.SelectAllReviews(ct.db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	targets, err := re.LoadTargets(ct.db)
+	if err != nil {
+		return nil, err
+	}
+	keys := reviews.IDs()
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var out []digestReview
+	for _, id := range keys {
+		target, ok := targets[id]
+		if !ok {
+			continue
+		}
+		header, err := target.Load(ct.db)
+		if err != nil {
+			return nil, utils.SQLError(err)
+		}
+		if header.Owner != user.Id && !user.IsAdmin {
+			continue
+		}
+		out = append(out, digestReview{Kind: reviews[id].Kind.String(), Title: header.Title})
+	}
+	return out, nil
+}
+
+var (
+	digestHTMLTemplate = htmlT.Must(htmlT.New("").Parse(`Bonjour{{ with .Name }} {{ . }}{{ end }}, <br/><br/>
+Voici le résumé de la semaine sur Isyro. <br/><br/>
+{{ range .Classrooms }}
+<b>{{ .Name }}</b>
+{{ if .Travaux }}<ul>
+{{ range .Travaux }}<li>{{ .Title }} ({{ if .Upcoming }}à rendre le{{ else }}clôturé le{{ end }} {{ .Deadline }}) : {{ .NbCompleted }} / {{ .NbStudents }} élève(s) ont terminé{{ with .Average }}, moyenne {{ . }} / 20{{ end }}</li>
+{{ end }}</ul>{{ end }}
+{{ if .InactiveStudents }}Élèves non connectés depuis une semaine : {{ range $i, $s := .InactiveStudents }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}<br/>{{ end }}
+{{ if .HardQuestions }}Questions difficiles :<ul>
+{{ range .HardQuestions }}<li>{{ .Description }} ({{ .Travail }}) : {{ .SuccessRate }} % de réussite sur {{ .NbAnswers }} réponses{{ with .Figure }}<br/><img src="{{ . }}" alt="Figure de la question" style="max-width: 300px"/>{{ end }}</li>
+{{ end }}</ul>{{ end }}
+<br/>
+{{ end }}
+{{ if .Reviews }}<b>Publications en attente</b><ul>
+{{ range .Reviews }}<li>{{ .Kind }} : {{ .Title }}</li>
+{{ end }}</ul><br/>{{ end }}
+Vous pouvez désactiver ce résumé dans les paramètres de votre compte. <br/><br/>
+Bonne semaine, <br/>
+L'équipe Isyro`))
+
+	digestTextTemplate = textT.Must(textT.New("").Parse(`Bonjour{{ with .Name }} {{ . }}{{ end }},
+
+Voici le résumé de la semaine sur Isyro.
+{{ range .Classrooms }}
+{{ .Name }}
+{{ range .Travaux }}  - {{ .Title }} ({{ if .Upcoming }}à rendre le{{ else }}clôturé le{{ end }} {{ .Deadline }}) : {{ .NbCompleted }} / {{ .NbStudents }} élève(s) ont terminé{{ with .Average }}, moyenne {{ . }} / 20{{ end }}
+{{ end }}{{ if .InactiveStudents }}  Élèves non connectés depuis une semaine : {{ range $i, $s := .InactiveStudents }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}
+{{ end }}{{ if .HardQuestions }}  Questions difficiles :
+{{ range .HardQuestions }}  - {{ .Description }} ({{ .Travail }}) : {{ .SuccessRate }} % de réussite sur {{ .NbAnswers }} réponses
+{{ end }}{{ end }}{{ end }}{{ if .Reviews }}
+Publications en attente :
+{{ range .Reviews }}  - {{ .Kind }} : {{ .Title }}
+{{ end }}{{ end }}
+Vous pouvez désactiver ce résumé dans les paramètres de votre compte.
+
+Bonne semaine,
+L'équipe Isyro`))
+)
+
+// render returns the HTML and plain text versions of the mail
+func (td teacherDigest) render() (html, text string, err error) {
+	var buf bytes.Buffer
+	if err = digestHTMLTemplate.Execute(&buf, td); err != nil {
+		return "", "", err
+	}
+	html = buf.String()
+	buf.Reset()
+	if err = digestTextTemplate.Execute(&buf, td); err != nil {
+		return "", "", err
+	}
+	return html, buf.String(), nil
+}
@@ -0,0 +1,140 @@
+package homework
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+	"github.com/benoitkugler/maths-online/server/src/pass"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestDigestRender(t *testing.T) {
+	digest := teacherDigest{
+		Name: "M. Dupont",
+		Classrooms: []digestClassroom{{
+			Name: "2nde 3",
+			Travaux: []digestTravail{
+				{Title: "DM <Fonctions>", Deadline: "12/10/2024 18:00", Upcoming: true, NbCompleted: 3, NbStudents: 24, Average: "11.5"},
+			},
+			InactiveStudents: []string{"MARTIN Léa", "PETIT Paul"},
+			HardQuestions: []digestQuestion{
+				{Travail: "DM <Fonctions>", Description: "Calcul d'image", SuccessRate: 12, NbAnswers: 17, Figure: "data:image/svg+xml;base64,PHN2Zz48L3N2Zz4="},
+			},
+		}},
+		Reviews: []digestReview{{Kind: "Question", Title: "Thalès"}},
+	}
+	tu.Assert(t, !digest.isEmpty())
+	tu.Assert(t, teacherDigest{Name: "M. Dupont"}.isEmpty())
+
+	html, text, err := digest.render()
+	tu.AssertNoErr(t, err)
+
+	tu.Assert(t, strings.Contains(html, "DM &lt;Fonctions&gt;"))
+	tu.Assert(t, strings.Contains(html, "3 / 24 élève(s)"))
+	tu.Assert(t, strings.Contains(html, "MARTIN Léa, PETIT Paul"))
+	tu.Assert(t, strings.Contains(html, "12 % de réussite sur 17"))
+	tu.Assert(t, strings.Contains(html, "Question : Thalès"))
+	tu.Assert(t, strings.Contains(html, `<img src="data:image/svg`))
+
+	tu.Assert(t, strings.Contains(text, "DM <Fonctions>"))
+	tu.Assert(t, strings.Contains(text, "moyenne 11.5 / 20"))
+	tu.Assert(t, strings.Contains(text, "Publications en attente"))
+	tu.Assert(t, !strings.Contains(text, "<br/>"))
+	tu.Assert(t, !strings.Contains(text, "data:image"))
+}
+
+func TestQuestionFigure(t *testing.T) {
+	page := questions.QuestionPage{Enonce: questions.Enonce{questions.NumberFieldBlock{Expression: "4"}}}
+	tu.Assert(t, questionFigure(page) == "")
+
+	page.Enonce = append(page.Enonce, questions.FigureBlock{ShowGrid: true, Bounds: repere.RepereBounds{Width: 10, Height: 10}})
+	figure := string(questionFigure(page))
+	tu.Assert(t, strings.HasPrefix(figure, "data:image/svg+xml;base64,"))
+	content, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(figure, "data:image/svg+xml;base64,"))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, strings.HasPrefix(string(content), "<svg"))
+
+	// invalid questions are ignored
+	page.Enonce = questions.Enonce{questions.NumberFieldBlock{Expression: "4/0"}}
+	tu.Assert(t, questionFigure(page) == "")
+}
+
+func TestHasConnectedSince(t *testing.T) {
+	now := time.Now()
+	student := teacher.Student{Clients: teacher.Clients{{Time: now.Add(-10 * 24 * time.Hour)}}}
+	tu.Assert(t, !hasConnectedSince(student, now.Add(-digestWeek)))
+	student.Clients = append(student.Clients, teacher.Client{Time: now.Add(-time.Hour)})
+	tu.Assert(t, hasConnectedSince(student, now.Add(-digestWeek)))
+	tu.Assert(t, !hasConnectedSince(teacher.Student{}, now.Add(-digestWeek)))
+}
+
+type sentMail struct{ to, subject, html, text string }
+
+type senderStub []sentMail
+
+func (s *senderStub) SendMailHTML(to, subject, html, text string) error {
+	*s = append(*s, sentMail{to, subject, html, text})
+	return nil
+}
+
+type failingSender struct{}
+
+func (failingSender) SendMailHTML(to, subject, html, text string) error {
+	return errors.New("invalid address")
+}
+
+func TestSendDigests(t *testing.T) {
+	db, sample := setupDB(t)
+	defer db.Remove()
+
+	ct := NewController(db.DB, teacher.Teacher{Id: sample.userID}, pass.Encrypter{})
+
+	now := time.Now()
+	sheet, err := ho.Sheet{IdTeacher: sample.userID, Title: "DM 1"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = ho.Travail{IdClassroom: sample.class.Id, IdSheet: sheet.Id, Noted: true, Deadline: ho.Time(now.Add(48 * time.Hour))}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = teacher.Student{IdClassroom: sample.class.Id, Name: "Martin", Surname: "Léa"}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	err = teacher.TeacherDigest{IdTeacher: sample.userID}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	due, err := ct.dueDigests(now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(due) == 1)
+
+	var sender senderStub
+	nb, err := ct.sendDigests(&sender, due, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, nb == 1 && len(sender) == 1)
+	tu.Assert(t, strings.Contains(sender[0].text, "DM 1"))
+	tu.Assert(t, strings.Contains(sender[0].text, "MARTIN Léa"))
+
+	// already sent this week
+	due, err = ct.dueDigests(now.Add(time.Hour))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(due) == 0)
+	due, err = ct.dueDigests(now.Add(digestWeek))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(due) == 1)
+
+	// a failed send is recorded, and not retried before next week
+	now = now.Add(digestWeek)
+	nb, err = ct.sendDigests(failingSender{}, due, now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, nb == 0)
+	digest, _, err := teacher.SelectTeacherDigestByIdTeacher(ct.db, sample.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, digest.LastError == "invalid address")
+	due, err = ct.dueDigests(now.Add(time.Hour))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(due) == 0)
+}
@@ -0,0 +1,206 @@
+package homework
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file implements the export of the marks computed in stats.go,
+// to files usable in spreadsheets or Pronote
+
+const (
+	// codes used by Pronote
+	markDispensed   = "Disp"
+	markNotAssigned = "NNot"
+)
+
+// HomeworkExportMarks returns a file with the marks of the given
+// travaux, using the following query params :
+//   - id-classroom
+//   - id-travaux, as a comma separated list
+//   - format, either csv or xlsx
+//
+// It is authenticated by query token, so that it may be used as a link.
+func (ct *Controller) HomeworkExportMarks(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	idClassroom, err := utils.QueryParamInt[teacher.IdClassroom](c, "id-classroom")
+	if err != nil {
+		return err
+	}
+	var idTravaux []ho.IdTravail
+	for _, s := range strings.Split(c.QueryParam("id-travaux"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid travail ID %s: %s", s, err)
+		}
+		idTravaux = append(idTravaux, ho.IdTravail(id))
+	}
+
+	file, filename, err := ct.exportMarks(HowemorkMarksIn{IdClassroom: idClassroom, IdTravaux: idTravaux}, c.QueryParam("format"), userID)
+	if err != nil {
+		return err
+	}
+
+	mimeType := utils.SetBlobHeader(c, file, filename)
+	if mimeType == "" && strings.HasSuffix(filename, ".xlsx") {
+		mimeType = utils.XlsxMimeType
+	}
+	return c.Blob(200, mimeType, file)
+}
+
+func (ct *Controller) exportMarks(args HowemorkMarksIn, format string, userID uID) ([]byte, string, error) {
+	if format != "csv" && format != "xlsx" {
+		return nil, "", errors.New("internal error: invalid export format")
+	}
+
+	// getMarks also checks the access
+	marks, err := ct.getMarks(args, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	classroom, err := teacher.SelectClassroom(ct.db, args.IdClassroom)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	students, err := teacher.SelectStudentsByIdClassrooms(ct.db, args.IdClassroom)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	travaux, err := ho.SelectTravails(ct.db, args.IdTravaux...)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	sheets, err := ho.SelectSheets(ct.db, travaux.IdSheets()...)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+
+	ex := marksExport{marks: marks, titles: make(map[ho.IdTravail]string)}
+	for _, student := range students {
+		ex.students = append(ex.students, student)
+	}
+	sort.Slice(ex.students, func(i, j int) bool {
+		return tcAPI.PronoteName(ex.students[i]) < tcAPI.PronoteName(ex.students[j])
+	})
+	// respect the order of the request
+	for _, id := range args.IdTravaux {
+		travail, ok := travaux[id]
+		if !ok {
+			return nil, "", errors.New("internal error: unknown travail")
+		}
+		ex.travaux = append(ex.travaux, travail)
+		ex.titles[id] = fmt.Sprintf("%s (%s)", sheets[travail.IdSheet].Title, time.Time(travail.Deadline).Format("02/01/2006"))
+	}
+
+	filename := fmt.Sprintf("Notes %s", classroom.Name)
+	if format == "csv" {
+		content, err := ex.csv()
+		return content, filename + ".csv", err
+	}
+	content, err := utils.WriteXlsx([]utils.XlsxSheet{
+		{Name: "Notes", Rows: ex.marksRows()},
+		{Name: "Statistiques", Rows: ex.statsRows()},
+	})
+	return content, filename + ".xlsx", err
+}
+
+type marksExport struct {
+	students []teacher.Student // in display order
+	travaux  []ho.Travail      // in display order
+	titles   map[ho.IdTravail]string
+	marks    HomeworkMarksOut
+}
+
+// marksRows returns one row per student, one column per travail
+func (ex marksExport) marksRows() [][]utils.XlsxCell {
+	header := []utils.XlsxCell{utils.XlsxText("Elève")}
+	for _, travail := range ex.travaux {
+		header = append(header, utils.XlsxText(ex.titles[travail.Id]))
+	}
+	out := [][]utils.XlsxCell{header}
+	for _, student := range ex.students {
+		row := []utils.XlsxCell{utils.XlsxText(tcAPI.PronoteName(student))}
+		for _, travail := range ex.travaux {
+			mark := ex.marks.Marks[travail.Id].Marks[student.Id]
+			switch {
+			case mark.NotAssigned:
+				row = append(row, utils.XlsxText(markNotAssigned))
+			case mark.Dispensed:
+				row = append(row, utils.XlsxText(markDispensed))
+			default:
+				row = append(row, utils.XlsxNumber(math.Round(mark.Mark*100)/100))
+			}
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// statsRows returns one row per question
+func (ex marksExport) statsRows() [][]utils.XlsxCell {
+	out := [][]utils.XlsxCell{{
+		utils.XlsxText("Travail"), utils.XlsxText("Tâche"), utils.XlsxText("Question"),
+		utils.XlsxText("Difficulté"), utils.XlsxText("Réussites"), utils.XlsxText("Échecs"),
+		utils.XlsxText("Taux de réussite (%)"),
+	}}
+	for _, travail := range ex.travaux {
+		for _, task := range ex.marks.Marks[travail.Id].TaskStats {
+			for _, qu := range task.QuestionStats {
+				rate := utils.XlsxText("")
+				if total := qu.NbSuccess + qu.NbFailure; total != 0 {
+					rate = utils.XlsxNumber(math.Round(1000*float64(qu.NbSuccess)/float64(total)) / 10)
+				}
+				out = append(out, []utils.XlsxCell{
+					utils.XlsxText(ex.titles[travail.Id]), utils.XlsxText(task.Title), utils.XlsxText(qu.Description),
+					utils.XlsxText(string(qu.Difficulty)), utils.XlsxNumber(float64(qu.NbSuccess)), utils.XlsxNumber(float64(qu.NbFailure)),
+					rate,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// csv only exports the marks, using the french conventions
+// (semicolon separator and decimal comma), as expected by Pronote
+func (ex marksExport) csv() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	for _, row := range ex.marksRows() {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			if cell.IsNumber {
+				record[i] = strings.ReplaceAll(strconv.FormatFloat(cell.Number, 'f', -1, 64), ".", ",")
+			} else {
+				record[i] = cell.Text
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
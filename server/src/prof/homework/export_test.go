@@ -0,0 +1,41 @@
+package homework
+
+import (
+	"strings"
+	"testing"
+
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestMarksExport(t *testing.T) {
+	ex := marksExport{
+		students: []teacher.Student{{Id: 1, Name: "Dupont", Surname: "Jean"}, {Id: 2, Name: "MARTIN", Surname: "Léa"}, {Id: 3, Name: "Petit", Surname: "Paul"}},
+		travaux:  []ho.Travail{{Id: 10}},
+		titles:   map[ho.IdTravail]string{10: "DM 1 (01/10/2024)"},
+		marks: HomeworkMarksOut{Marks: map[ho.IdTravail]TravailMarks{
+			10: {
+				Marks: map[teacher.IdStudent]StudentTravailMark{
+					1: {Mark: 12.456},
+					2: {Mark: 5, Dispensed: true},
+					3: {NotAssigned: true},
+				},
+				TaskStats: []TaskStat{{Title: "Exercice", QuestionStats: []QuestionStat{{Description: "Question 1", NbSuccess: 1, NbFailure: 2}}}},
+			},
+		}},
+	}
+
+	content, err := ex.csv()
+	tu.AssertNoErr(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	tu.Assert(t, len(lines) == 4)
+	tu.Assert(t, lines[0] == "Elève;DM 1 (01/10/2024)")
+	tu.Assert(t, lines[1] == "DUPONT Jean;12,46")
+	tu.Assert(t, lines[2] == "MARTIN Léa;"+markDispensed)
+	tu.Assert(t, lines[3] == "PETIT Paul;"+markNotAssigned)
+
+	stats := ex.statsRows()
+	tu.Assert(t, len(stats) == 2)
+	tu.Assert(t, stats[1][6].IsNumber && stats[1][6].Number == 33.3)
+}
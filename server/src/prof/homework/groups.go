@@ -0,0 +1,223 @@
+package homework
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/tasks"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file handles the assignment of [Travail]s to
+// groups of students
+
+// travailAssignments stores the group restrictions
+// for a list of travaux.
+type travailAssignments struct {
+	groups  map[ho.IdTravail]ho.TravailGroups
+	members map[teacher.IdStudentGroup]utils.Set[teacher.IdStudent]
+}
+
+func loadTravailAssignments(db ho.DB, idTravaux []ho.IdTravail) (travailAssignments, error) {
+	links, err := ho.SelectTravailGroupsByIdTravails(db, idTravaux...)
+	if err != nil {
+		return travailAssignments{}, utils.SQLError(err)
+	}
+	links2, err := teacher.SelectStudentGroupMembersByIdStudentGroups(db, links.IdStudentGroups()...)
+	if err != nil {
+		return travailAssignments{}, utils.SQLError(err)
+	}
+	out := travailAssignments{
+		groups:  links.ByIdTravail(),
+		members: make(map[teacher.IdStudentGroup]utils.Set[teacher.IdStudent]),
+	}
+	for idGroup, l := range links2.ByIdStudentGroup() {
+		out.members[idGroup] = utils.NewSet(l.IdStudents()...)
+	}
+	return out, nil
+}
+
+// sheetFor returns the [Sheet] the student should work on for [travail],
+// or false if the travail is not assigned to the student.
+// If the student belongs to several groups with different sheets, the
+// group with the lowest ID is used.
+func (ta travailAssignments) sheetFor(travail ho.Travail, idStudent teacher.IdStudent) (ho.IdSheet, bool) {
+	groups := ta.groups[travail.Id]
+	if len(groups) == 0 { // the whole classroom
+		return travail.IdSheet, true
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].IdStudentGroup < groups[j].IdStudentGroup })
+	for _, group := range groups {
+		if !ta.members[group.IdStudentGroup].Has(idStudent) {
+			continue
+		}
+		if group.IdSheet.Valid {
+			return group.IdSheet.ID, true
+		}
+		return travail.IdSheet, true
+	}
+	return 0, false
+}
+
+// restrictTo removes the travaux not assigned to the given student, and
+// updates the [IdSheet] field according to the student groups.
+func (ta travailAssignments) restrictTo(travaux ho.Travails, idStudent teacher.IdStudent) {
+	for id, travail := range travaux {
+		idSheet, ok := ta.sheetFor(travail, idStudent)
+		if !ok {
+			delete(travaux, id)
+			continue
+		}
+		travail.IdSheet = idSheet
+		travaux[id] = travail
+	}
+}
+
+// resolveStudentTravail returns [travail] with the [Sheet] assigned to the student,
+// or an error if the student is not concerned by [travail].
+func resolveStudentTravail(db ho.DB, travail ho.Travail, idStudent teacher.IdStudent) (ho.Travail, error) {
+	assignments, err := loadTravailAssignments(db, []ho.IdTravail{travail.Id})
+	if err != nil {
+		return ho.Travail{}, err
+	}
+	idSheet, ok := assignments.sheetFor(travail, idStudent)
+	if !ok {
+		return ho.Travail{}, errAccessForbidden
+	}
+	travail.IdSheet = idSheet
+	return travail, nil
+}
+
+// resolveStudentTask loads the travail with [resolveStudentTravail], and
+// checks that [idTask] belongs to the sheet assigned to the student, so that
+// a task is never accessed with the settings of an unrelated travail.
+func resolveStudentTask(db ho.DB, idTravail ho.IdTravail, idStudent teacher.IdStudent, idTask tasks.IdTask) (ho.Travail, error) {
+	travail, err := ho.SelectTravail(db, idTravail)
+	if err != nil {
+		return ho.Travail{}, utils.SQLError(err)
+	}
+	travail, err = resolveStudentTravail(db, travail, idStudent)
+	if err != nil {
+		return ho.Travail{}, err
+	}
+	link, found, err := ho.SelectSheetTaskByIdTask(db, idTask)
+	if err != nil {
+		return ho.Travail{}, utils.SQLError(err)
+	}
+	if !found || link.IdSheet != travail.IdSheet {
+		return ho.Travail{}, errAccessForbidden
+	}
+	return travail, nil
+}
+
+func (ct *Controller) HomeworkGetTravailGroups(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	id, err := utils.QueryParamInt[ho.IdTravail](c, "id-travail")
+	if err != nil {
+		return err
+	}
+
+	out, err := ct.getTravailGroups(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+type TravailGroupsOut struct {
+	Assignments ho.TravailGroups        // empty for a travail assigned to the whole classroom
+	Groups      []tcAPI.StudentGroupExt // for the classroom
+}
+
+func (ct *Controller) getTravailGroups(idTravail ho.IdTravail, userID uID) (TravailGroupsOut, error) {
+	travail, err := ct.checkTravailOwner(idTravail, userID)
+	if err != nil {
+		return TravailGroupsOut{}, err
+	}
+
+	links, err := ho.SelectTravailGroupsByIdTravails(ct.db, travail.Id)
+	if err != nil {
+		return TravailGroupsOut{}, utils.SQLError(err)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].IdStudentGroup < links[j].IdStudentGroup })
+
+	groups, err := tcAPI.LoadStudentGroups(ct.db, travail.IdClassroom)
+	if err != nil {
+		return TravailGroupsOut{}, err
+	}
+
+	return TravailGroupsOut{Assignments: links, Groups: groups}, nil
+}
+
+type SetTravailGroupsIn struct {
+	IdTravail ho.IdTravail
+	// Assignments may be empty to assign the travail to the whole classroom.
+	// The [IdTravail] field of each item is ignored.
+	Assignments ho.TravailGroups
+}
+
+// HomeworkSetTravailGroups restricts a travail to some groups of students,
+// optionally with a different sheet for each group.
+func (ct *Controller) HomeworkSetTravailGroups(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args SetTravailGroupsIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	err := ct.setTravailGroups(args, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(200)
+}
+
+func (ct *Controller) setTravailGroups(args SetTravailGroupsIn, userID uID) error {
+	travail, err := ct.checkTravailOwner(args.IdTravail, userID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := teacher.SelectStudentGroupsByIdClassrooms(ct.db, travail.IdClassroom)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+
+	links := make(ho.TravailGroups, len(args.Assignments))
+	for i, link := range args.Assignments {
+		if _, ok := groups[link.IdStudentGroup]; !ok {
+			return errors.New("internal error: group not in the travail classroom")
+		}
+		if link.IdSheet.Valid {
+			sheet, err := ho.SelectSheet(ct.db, link.IdSheet.ID)
+			if err != nil {
+				return utils.SQLError(err)
+			}
+			if !sheet.IsVisibleBy(userID) {
+				return errAccessForbidden
+			}
+			if sheet.Anonymous.Valid {
+				return errors.New("internal error: anonymous sheet may not be used for a group")
+			}
+		}
+		link.IdTravail = travail.Id
+		links[i] = link
+	}
+
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		_, err := ho.DeleteTravailGroupsByIdTravails(tx, travail.Id)
+		if err != nil {
+			return err
+		}
+		return ho.InsertManyTravailGroups(tx, links...)
+	})
+}
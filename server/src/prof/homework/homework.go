@@ -5,6 +5,7 @@ package homework
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -44,10 +45,15 @@ type TaskExt struct {
 	GroupID        int64       // of the underlying group (exercice or question)
 	Bareme         taAPI.TaskBareme
 	NbProgressions int // the number of student having started this task
+
+	// access settings, as defined in the sheet
+	Optional    bool
+	Requirement ho.TaskRequirement
+	MinMark     int
 }
 
 // [progressions] is the list of all links item related to [task]
-func newTaskExt(task tasks.Task, work taAPI.WorkMeta, progressions tasks.Progressions,
+func newTaskExt(link ho.SheetTask, task tasks.Task, work taAPI.WorkMeta, progressions tasks.Progressions,
 	exTags map[editor.IdExercicegroup]editor.ExercicegroupTags, quTags map[editor.IdQuestiongroup]editor.QuestiongroupTags,
 ) TaskExt {
 	baremes := work.Bareme()
@@ -74,10 +80,21 @@ func newTaskExt(task tasks.Task, work taAPI.WorkMeta, progressions tasks.Progres
 		Title:          work.Title(),
 		NbProgressions: len(progressions.ByIdStudent()),
 		Bareme:         baremes,
+		Optional:       link.Optional,
+		Requirement:    link.Requirement,
+		MinMark:        link.MinMark,
 	}
 }
 
 func loadTaskExt(db ho.DB, idTask tasks.IdTask) (TaskExt, error) {
+	link, found, err := ho.SelectSheetTaskByIdTask(db, idTask)
+	if err != nil {
+		return TaskExt{}, utils.SQLError(err)
+	}
+	if !found {
+		return TaskExt{}, fmt.Errorf("internal error: task %d without sheet", idTask)
+	}
+
 	loader, err := taAPI.NewTasksContents(db, []tasks.IdTask{idTask})
 	if err != nil {
 		return TaskExt{}, err
@@ -90,7 +107,7 @@ func loadTaskExt(db ho.DB, idTask tasks.IdTask) (TaskExt, error) {
 		return TaskExt{}, utils.SQLError(err)
 	}
 
-	return newTaskExt(task, loader.GetWork(task), progressions, loader.ExerciceTags, loader.QuestionTags), nil
+	return newTaskExt(link, task, loader.GetWork(task), progressions, loader.ExerciceTags, loader.QuestionTags), nil
 }
 
 type SheetExt struct {
@@ -174,7 +191,7 @@ func (loader sheetLoader) newSheetExt(sheet ho.Sheet, userID, adminID uID) Sheet
 	for _, link := range links {
 		task := loader.tasks.Tasks[link.IdTask]
 		work := loader.tasks.GetWork(task)
-		out.Tasks = append(out.Tasks, newTaskExt(task, work, loader.progressions[task.Id], loader.tasks.ExerciceTags, loader.tasks.QuestionTags))
+		out.Tasks = append(out.Tasks, newTaskExt(link, task, work, loader.progressions[task.Id], loader.tasks.ExerciceTags, loader.tasks.QuestionTags))
 	}
 	return out
 }
@@ -217,15 +234,19 @@ func LoadSheet(db ho.DB, id ho.IdSheet, userID, adminID uID) (SheetExt, error) {
 }
 
 func updateSheetTasksOrder(tx *sql.Tx, idSheet ho.IdSheet, l []tasks.IdTask) error {
-	links := make(ho.SheetTasks, len(l))
-	for i, idTask := range l { // enforce correct index
-		links[i] = ho.SheetTask{IdTask: idTask, IdSheet: idSheet, Index: i}
-	}
-
-	_, err := ho.DeleteSheetTasksByIdSheets(tx, idSheet)
+	current, err := ho.DeleteSheetTasksByIdSheets(tx, idSheet)
 	if err != nil {
 		return utils.SQLError(err)
 	}
+	byTask := current.ByIdTask()
+
+	links := make(ho.SheetTasks, len(l))
+	for i, idTask := range l {
+		link := byTask[idTask] // preserve the access settings
+		// enforce correct index
+		link.IdSheet, link.IdTask, link.Index = idSheet, idTask, i
+		links[i] = link
+	}
 
 	err = ho.InsertManySheetTasks(tx, links...)
 	if err != nil {
@@ -247,3 +268,84 @@ func sheetFromTask(db tasks.DB, idTask tasks.IdTask) (ho.Sheet, error) {
 
 	return ho.SelectSheet(db, link.IdSheet)
 }
+
+// isTaskComplete returns true if every question of the task
+// has been successfully answered.
+func isTaskComplete(pr taAPI.TaskProgressionHeader) bool {
+	return pr.HasProgression && pr.Progression.IsComplete()
+}
+
+// hasMinMark returns true if the mark of [pr], with the penalty
+// for the hints used, is at least [minMark] percent of its bareme.
+func hasMinMark(pr taAPI.TaskProgressionHeader, minMark int, hintPenalty int) bool {
+	return 100*pr.WithHintPenalty(hintPenalty).Mark >= minMark*pr.Bareme
+}
+
+// resolveTasksStatus returns the access status of the tasks of one sheet,
+// according to the progressions of one student.
+// [links] must be sorted by index.
+// [hintPenalty] is the penalty of the travail, see [ho.Travail.HintPenalty].
+func resolveTasksStatus(links ho.SheetTasks, progressions map[tasks.IdTask]taAPI.TaskProgressionHeader, hintPenalty int) []TaskStatus {
+	out := make([]TaskStatus, len(links))
+	for i, link := range links {
+		out[i].Optional = link.Optional
+		if link.Requirement == ho.NoRequirement {
+			continue
+		}
+		// check the previous, non optional tasks
+		for _, previous := range links[:i] {
+			if previous.Optional {
+				continue
+			}
+			pr := progressions[previous.IdTask]
+			var ok bool
+			switch link.Requirement {
+			case ho.RequireCompletion:
+				ok = isTaskComplete(pr)
+			case ho.RequireMinMark:
+				ok = hasMinMark(pr, link.MinMark, hintPenalty)
+			}
+			if !ok {
+				out[i].Locked = true
+				break
+			}
+		}
+	}
+	return out
+}
+
+var errTaskLocked = errors.New("Cette tâche n'est pas encore accessible : les tâches précédentes doivent d'abord être réalisées.")
+
+// checkTaskAccess returns an error if [idTask] is locked for the given student,
+// working on [travail].
+func checkTaskAccess(db ho.DB, travail ho.Travail, idStudent teacher.IdStudent, idTask tasks.IdTask) error {
+	link, found, err := ho.SelectSheetTaskByIdTask(db, idTask)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if !found {
+		return fmt.Errorf("internal error: task %d without sheet", idTask)
+	}
+	if link.Requirement == ho.NoRequirement { // no need to load the progressions
+		return nil
+	}
+
+	links, err := ho.SelectSheetTasksByIdSheets(db, link.IdSheet)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	links.EnsureOrder()
+
+	progressions, err := taAPI.LoadTasksProgression(db, idStudent, links.IdTasks())
+	if err != nil {
+		return err
+	}
+
+	status := resolveTasksStatus(links, progressions, travail.HintPenalty)
+	for i, link := range links {
+		if link.IdTask == idTask && status[i].Locked {
+			return errTaskLocked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package homework
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/tasks"
+	taAPI "github.com/benoitkugler/maths-online/server/src/tasks"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file implements the export of a sheet as printable
+// test papers (one LaTeX document per copy), with their answer keys
+
+// maxPaperCopies is the maximum number of different copies
+// (labeled from A to Z)
+const maxPaperCopies = 26
+
+// HomeworkExportPapers returns a zip archive containing several randomized
+// versions of a sheet, with their answer keys, using the following query params :
+//   - id-sheet
+//   - copies, the number of different versions
+//   - shuffle, optional, if not empty, the order of the tasks is randomized for each copy
+//
+// It is authenticated by query token, so that it may be used as a link.
+func (ct *Controller) HomeworkExportPapers(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	idSheet, err := utils.QueryParamInt[ho.IdSheet](c, "id-sheet")
+	if err != nil {
+		return err
+	}
+	nbCopies, err := utils.QueryParamInt[int](c, "copies")
+	if err != nil {
+		return err
+	}
+	shuffle := utils.QueryParamBool(c, "shuffle")
+
+	file, filename, err := ct.exportPapers(idSheet, nbCopies, shuffle, userID)
+	if err != nil {
+		return err
+	}
+
+	mimeType := utils.SetBlobHeader(c, file, filename)
+	return c.Blob(200, mimeType, file)
+}
+
+func (ct *Controller) exportPapers(idSheet ho.IdSheet, nbCopies int, shuffle bool, userID uID) ([]byte, string, error) {
+	if nbCopies < 1 || nbCopies > maxPaperCopies {
+		return nil, "", fmt.Errorf("Le nombre de sujets doit être compris entre 1 et %d.", maxPaperCopies)
+	}
+
+	sheet, err := ho.SelectSheet(ct.db, idSheet)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	// personnal or public sheets
+	if !(sheet.IdTeacher == userID || (sheet.Public && sheet.IdTeacher == ct.admin.Id)) {
+		return nil, "", errAccessForbidden
+	}
+
+	links, err := ho.SelectSheetTasksByIdSheets(ct.db, idSheet)
+	if err != nil {
+		return nil, "", utils.SQLError(err)
+	}
+	links.EnsureOrder()
+	if len(links) == 0 {
+		return nil, "", errors.New("La feuille ne contient aucun exercice.")
+	}
+	idTasks := make([]tasks.IdTask, len(links))
+	for i, link := range links {
+		idTasks[i] = link.IdTask
+	}
+	contents, err := taAPI.NewTasksContents(ct.db, idTasks)
+	if err != nil {
+		return nil, "", err
+	}
+
+	copies := make([][]questions.QuestionInstance, nbCopies)
+	for i := range copies {
+		order := append([]tasks.IdTask(nil), idTasks...)
+		if shuffle {
+			rand.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		}
+		for _, idTask := range order {
+			// each call generates new random parameters
+			instances, err := contents.InstantiatePaper(contents.Tasks[idTask])
+			if err != nil {
+				return nil, "", err
+			}
+			copies[i] = append(copies[i], instances...)
+		}
+	}
+
+	content, err := papersArchive(sheet.Title, copies)
+	return content, fmt.Sprintf("Sujets %s.zip", sheet.Title), err
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`, "{", `\{`, "}", `\}`,
+)
+
+// papersArchive returns a zip archive with one subject and
+// one answer key for each copy.
+func papersArchive(title string, copies [][]questions.QuestionInstance) ([]byte, error) {
+	title = latexEscaper.Replace(title)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeFile := func(name, content string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte(content))
+		return err
+	}
+
+	// the figures are shared by all the copies
+	figures := questions.NewLatexFigures()
+	for i, paper := range copies {
+		label := questions.PaperCopyLabel(i)
+		files := [2]struct {
+			name, code string
+		}{
+			{fmt.Sprintf("Sujet %s.tex", label), questions.PaperDocument(fmt.Sprintf("%s -- Sujet %s", title, label), paper, false, figures)},
+			{fmt.Sprintf("Corrigé %s.tex", label), questions.PaperDocument(fmt.Sprintf("%s -- Corrigé du sujet %s", title, label), paper, true, figures)},
+		}
+		for _, file := range files {
+			if err := writeFile(file.name, file.code); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(figures.Files))
+	for path := range figures.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths) // deterministic archive
+	for _, path := range paths {
+		if err := writeFile(path, figures.Files[path]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
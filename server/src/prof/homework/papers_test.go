@@ -0,0 +1,50 @@
+package homework
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/repere"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestPapersArchive(t *testing.T) {
+	page := questions.QuestionPage{
+		Parameters: questions.Parameters{questions.Rp{Expression: "randInt(1;100)", Variable: expression.NewVar('a')}},
+		Enonce: questions.Enonce{
+			questions.FigureBlock{ShowGrid: true, Bounds: repere.RepereBounds{Width: 10, Height: 10}},
+			questions.NumberFieldBlock{Expression: "a"},
+		},
+	}
+	var copies [][]questions.QuestionInstance
+	for i := 0; i < 3; i++ {
+		instance, _, err := page.InstantiateErr()
+		tu.AssertNoErr(t, err)
+		copies = append(copies, []questions.QuestionInstance{instance, instance})
+	}
+
+	content, err := papersArchive("DS n°1 : 50% & plus", copies)
+	tu.AssertNoErr(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(r.File) == 7)
+	names := make([]string, len(r.File))
+	for i, file := range r.File {
+		names[i] = file.Name
+	}
+	tu.Assert(t, strings.Join(names, ",") == "Sujet A.tex,Corrigé A.tex,Sujet B.tex,Corrigé B.tex,Sujet C.tex,Corrigé C.tex,figures/figure-1.svg")
+
+	f, err := r.File[1].Open()
+	tu.AssertNoErr(t, err)
+	code, err := io.ReadAll(f)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, strings.Contains(string(code), `DS n°1 : 50\% \& plus -- Corrigé du sujet A`))
+	tu.Assert(t, strings.Contains(string(code), "Réponses attendues"))
+	tu.Assert(t, strings.Contains(string(code), `\includesvg[inkscapelatex=false]{figures/figure-1}`))
+}
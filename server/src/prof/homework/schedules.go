@@ -0,0 +1,299 @@
+package homework
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file implements recurring travaux, defined by [ho.TravailSchedule]s
+// and periodically converted into real [ho.Travail]s
+
+const (
+	// schedulesInterval is the delay between two checks of the schedules
+	schedulesInterval = 30 * time.Minute
+	// schedulesLookAhead is how much in advance [Travail]s are created,
+	// so that teachers may see (and adjust) them before students do.
+	schedulesLookAhead = 24 * time.Hour
+)
+
+// RunSchedules periodically creates the [Travail]s defined by
+// the [ho.TravailSchedule]s. It never returns and should be
+// called in its own goroutine.
+func (ct *Controller) RunSchedules() {
+	ticker := time.NewTicker(schedulesInterval)
+	defer ticker.Stop()
+	for {
+		travaux, err := ct.materializeSchedules(time.Now())
+		if err != nil {
+			Logger.Printf("materializing schedules: %s", err)
+		} else if len(travaux) != 0 {
+			Logger.Printf("%d travaux created from schedules", len(travaux))
+		}
+		<-ticker.C
+	}
+}
+
+// materializeSchedules creates the [Travail]s due at [now],
+// returning the new ones
+func (ct *Controller) materializeSchedules(now time.Time) ([]ho.Travail, error) {
+	schedules, err := ho.SelectAllTravailSchedules(ct.db)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	// sort for reproducibility
+	keys := schedules.IDs()
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var out []ho.Travail
+	for _, id := range keys {
+		var travaux []ho.Travail
+		err = utils.InTx(ct.db, func(tx *sql.Tx) error {
+			// the schedule may have been modified (or deleted) since
+			// the first select, by the teacher or a concurrent run
+			schedule, found, err := ho.SelectTravailScheduleForUpdate(tx, id)
+			if err != nil || !found {
+				return err
+			}
+			travaux, err = ct.materializeSchedule(tx, schedule, now)
+			return err
+		})
+		if err != nil {
+			// do not block the other schedules
+			Logger.Printf("materializing schedule %d: %s", id, err)
+			continue
+		}
+		out = append(out, travaux...)
+	}
+	return out, nil
+}
+
+// materializeSchedule creates the [Travail]s for one schedule,
+// and updates or deletes it.
+// Occurrences which would already be expired (for instance after a server downtime)
+// are skipped.
+func (ct *Controller) materializeSchedule(tx *sql.Tx, schedule ho.TravailSchedule, now time.Time) ([]ho.Travail, error) {
+	var out []ho.Travail
+	limit := now.Add(schedulesLookAhead)
+	for {
+		showAfter := time.Time(schedule.NextShowAfter)
+		if showAfter.After(limit) { // not yet
+			_, err := schedule.Update(tx)
+			return out, err
+		}
+		if until := time.Time(schedule.Until); !until.IsZero() && showAfter.After(until) { // done
+			_, err := ho.DeleteTravailScheduleById(tx, schedule.Id)
+			return out, err
+		}
+
+		deadline := showAfter.Add(time.Duration(schedule.DeadlineDelay) * time.Hour)
+		if deadline.After(now) {
+			travail, err := ct.createScheduledTravail(tx, schedule, showAfter, deadline)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, travail)
+		}
+
+		if schedule.Period <= 0 { // one-time schedule
+			_, err := ho.DeleteTravailScheduleById(tx, schedule.Id)
+			return out, err
+		}
+		schedule.NextShowAfter = ho.Time(showAfter.AddDate(0, 0, schedule.Period))
+	}
+}
+
+// createScheduledTravail uses a copy of the model sheet,
+// so that each [Travail] has its own progression.
+func (ct *Controller) createScheduledTravail(tx *sql.Tx, schedule ho.TravailSchedule, showAfter, deadline time.Time) (ho.Travail, error) {
+	sheet, err := duplicateSheetTx(tx, schedule.IdSheet, schedule.IdTeacher)
+	if err != nil {
+		return ho.Travail{}, err
+	}
+
+	travail, err := ho.Travail{
+		IdClassroom:       schedule.IdClassroom,
+		IdSheet:           sheet.Id,
+		Noted:             schedule.Noted,
+		Deadline:          ho.Time(deadline),
+		ShowAfter:         ho.Time(showAfter),
+		QuestionRepeat:    schedule.QuestionRepeat,
+		QuestionTimeLimit: schedule.QuestionTimeLimit,
+	}.Insert(tx)
+	if err != nil {
+		return ho.Travail{}, utils.SQLError(err)
+	}
+
+	// map the new sheet to its new travail
+	sheet.Anonymous = travail.Id.AsOptional()
+	_, err = sheet.Update(tx)
+	if err != nil {
+		return ho.Travail{}, utils.SQLError(err)
+	}
+
+	return travail, nil
+}
+
+// HomeworkGetSchedules returns the schedules defined for a classroom.
+func (ct *Controller) HomeworkGetSchedules(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	idClassroom, err := utils.QueryParamInt[teacher.IdClassroom](c, "id-classroom")
+	if err != nil {
+		return err
+	}
+
+	out, err := ct.getSchedules(idClassroom, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) getSchedules(idClassroom teacher.IdClassroom, userID uID) ([]ho.TravailSchedule, error) {
+	if err := ct.checkClassroomOwner(userID, idClassroom); err != nil {
+		return nil, err
+	}
+
+	schedules, err := ho.SelectTravailSchedulesByIdClassrooms(ct.db, idClassroom)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+
+	out := make([]ho.TravailSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		out = append(out, schedule)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out, nil
+}
+
+// HomeworkCreateSchedule creates a new schedule, using the
+// given sheet as model.
+// The [Id] and [IdTeacher] fields are ignored.
+func (ct *Controller) HomeworkCreateSchedule(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args ho.TravailSchedule
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.createSchedule(args, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) checkSchedule(schedule ho.TravailSchedule, userID uID) error {
+	if err := ct.checkClassroomOwner(userID, schedule.IdClassroom); err != nil {
+		return err
+	}
+	sheet, err := ho.SelectSheet(ct.db, schedule.IdSheet)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if !sheet.IsVisibleBy(userID) {
+		return errAccessForbidden
+	}
+	if schedule.DeadlineDelay <= 0 {
+		return errors.New("La durée d'une occurrence doit être d'au moins une heure.")
+	}
+	if schedule.Period < 0 {
+		return errors.New("internal error: negative period")
+	}
+	return nil
+}
+
+func (ct *Controller) createSchedule(args ho.TravailSchedule, userID uID) (ho.TravailSchedule, error) {
+	if err := ct.checkSchedule(args, userID); err != nil {
+		return ho.TravailSchedule{}, err
+	}
+
+	args.IdTeacher = userID
+	out, err := args.Insert(ct.db)
+	if err != nil {
+		return ho.TravailSchedule{}, utils.SQLError(err)
+	}
+	return out, nil
+}
+
+func (ct *Controller) checkScheduleOwner(id ho.IdTravailSchedule, userID uID) (ho.TravailSchedule, error) {
+	schedule, err := ho.SelectTravailSchedule(ct.db, id)
+	if err != nil {
+		return ho.TravailSchedule{}, utils.SQLError(err)
+	}
+	if schedule.IdTeacher != userID {
+		return ho.TravailSchedule{}, errAccessForbidden
+	}
+	return schedule, nil
+}
+
+// HomeworkUpdateSchedule updates the settings of a schedule.
+// The [IdClassroom] and [IdTeacher] fields are ignored.
+func (ct *Controller) HomeworkUpdateSchedule(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args ho.TravailSchedule
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.updateSchedule(args, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) updateSchedule(args ho.TravailSchedule, userID uID) (ho.TravailSchedule, error) {
+	schedule, err := ct.checkScheduleOwner(args.Id, userID)
+	if err != nil {
+		return ho.TravailSchedule{}, err
+	}
+
+	args.IdClassroom = schedule.IdClassroom
+	args.IdTeacher = schedule.IdTeacher
+	if err := ct.checkSchedule(args, userID); err != nil {
+		return ho.TravailSchedule{}, err
+	}
+
+	out, err := args.Update(ct.db)
+	if err != nil {
+		return ho.TravailSchedule{}, utils.SQLError(err)
+	}
+	return out, nil
+}
+
+// HomeworkDeleteSchedule removes the schedule. The
+// [Travail]s already created are not modified.
+func (ct *Controller) HomeworkDeleteSchedule(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	id, err := utils.QueryParamInt[ho.IdTravailSchedule](c, "id")
+	if err != nil {
+		return err
+	}
+
+	if _, err = ct.checkScheduleOwner(id, userID); err != nil {
+		return err
+	}
+
+	_, err = ho.DeleteTravailScheduleById(ct.db, id)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+
+	return c.NoContent(200)
+}
@@ -0,0 +1,97 @@
+package homework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benoitkugler/maths-online/server/src/pass"
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	tu "github.com/benoitkugler/maths-online/server/src/utils/testutils"
+)
+
+func TestSchedules(t *testing.T) {
+	db, sample := setupDB(t)
+	defer db.Remove()
+
+	ct := NewController(db.DB, teacher.Teacher{Id: sample.userID}, pass.Encrypter{})
+
+	sheet, err := ho.Sheet{IdTeacher: sample.userID, Title: "Rituel"}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	now := time.Date(2024, 10, 7, 8, 0, 0, 0, time.UTC)
+	_, err = ct.createSchedule(ho.TravailSchedule{IdClassroom: sample.class.Id, IdSheet: sheet.Id}, sample.userID)
+	tu.Assert(t, err != nil) // invalid delay
+
+	schedule, err := ct.createSchedule(ho.TravailSchedule{
+		IdClassroom:   sample.class.Id,
+		IdSheet:       sheet.Id,
+		Noted:         true,
+		NextShowAfter: ho.Time(now.AddDate(0, 0, -14)), // two occurrences already expired
+		DeadlineDelay: 24 * 3,
+		Period:        7,
+	}, sample.userID)
+	tu.AssertNoErr(t, err)
+
+	l, err := ct.getSchedules(sample.class.Id, sample.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 1)
+
+	travaux, err := ct.materializeSchedules(now)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(travaux) == 1) // only the current week
+	tu.Assert(t, travaux[0].IdSheet != sheet.Id && travaux[0].Noted)
+
+	// idempotent
+	travaux, err = ct.materializeSchedules(now.Add(time.Hour))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(travaux) == 0)
+
+	schedule, err = ho.SelectTravailSchedule(db, schedule.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, time.Time(schedule.NextShowAfter).Equal(now.AddDate(0, 0, 7)))
+
+	// one time schedule are removed once used
+	schedule.Period = 0
+	_, err = ct.updateSchedule(schedule, sample.userID)
+	tu.AssertNoErr(t, err)
+	travaux, err = ct.materializeSchedules(now.AddDate(0, 0, 7))
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(travaux) == 1)
+	l, err = ct.getSchedules(sample.class.Id, sample.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(l) == 0)
+}
+
+func TestCopyTravailMany(t *testing.T) {
+	db, sample := setupDB(t)
+	defer db.Remove()
+
+	ct := NewController(db.DB, teacher.Teacher{Id: sample.userID}, pass.Encrypter{})
+
+	class2, err := teacher.Classroom{Name: "test2"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	err = teacher.TeacherClassroom{IdTeacher: sample.userID, IdClassroom: class2.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	sheet, err := ho.Sheet{IdTeacher: sample.userID, Title: "DM"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	deadline := time.Date(2024, 10, 7, 8, 0, 0, 0, time.UTC)
+	travail, err := ho.Travail{IdClassroom: sample.class.Id, IdSheet: sheet.Id, Deadline: ho.Time(deadline)}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	out, err := ct.copyTravailToMany(CopyTravailManyIn{IdTravail: travail.Id, IdClassrooms: []teacher.IdClassroom{sample.class.Id, class2.Id}, Shift: 2}, sample.userID)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(out) == 2)
+	tu.Assert(t, time.Time(out[0].Travail.Deadline).Equal(deadline))
+	tu.Assert(t, time.Time(out[1].Travail.Deadline).Equal(deadline.Add(2*time.Hour)))
+	tu.Assert(t, out[1].Travail.IdClassroom == class2.Id)
+
+	// the travail of another teacher may not be copied
+	class3, err := teacher.Classroom{Name: "test3"}.Insert(db)
+	tu.AssertNoErr(t, err)
+	travail3, err := ho.Travail{IdClassroom: class3.Id, IdSheet: sheet.Id}.Insert(db)
+	tu.AssertNoErr(t, err)
+	_, err = ct.copyTravailToMany(CopyTravailManyIn{IdTravail: travail3.Id, IdClassrooms: []teacher.IdClassroom{class2.Id}}, sample.userID)
+	tu.Assert(t, err != nil)
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
 	tcAPI "github.com/benoitkugler/maths-online/server/src/prof/teacher"
 	"github.com/benoitkugler/maths-online/server/src/sql/editor"
 	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	"github.com/benoitkugler/maths-online/server/src/sql/tasks"
 	tc "github.com/benoitkugler/maths-online/server/src/sql/teacher"
 	taAPI "github.com/benoitkugler/maths-online/server/src/tasks"
 	"github.com/benoitkugler/maths-online/server/src/utils"
@@ -26,6 +28,12 @@ type StudentTravailMark struct {
 	Mark      float64 // /20
 	Dispensed bool    // true if the student is dispensed for this travail
 	NbTries   int     // the total number of tries (both success and failures) on this sheet
+	NbHints   int     // the total number of hints used on this sheet
+	// NotAssigned is true if the travail is restricted to groups
+	// the student does not belong to.
+	NotAssigned bool
+	// Completed is true if all the (non optional) tasks are completed
+	Completed bool
 }
 
 type TravailMarks struct {
@@ -34,6 +42,7 @@ type TravailMarks struct {
 }
 
 type TaskStat struct {
+	IdTask tasks.IdTask
 	IdWork taAPI.WorkID
 	Title  string // title
 
@@ -83,7 +92,74 @@ func (ct *Controller) getMarks(args HowemorkMarksIn, userID uID) (HomeworkMarksO
 	if err := ct.checkClassroomOwner(userID, args.IdClassroom); err != nil {
 		return HomeworkMarksOut{}, err
 	}
+	return ct.computeMarks(args)
+}
+
+type HomeworkStudentAttemptIn struct {
+	IdTravail ho.IdTravail
+	IdStudent tc.IdStudent
+	IdTask    tasks.IdTask
+	Index     int // the question index in the task
+}
+
+type HomeworkStudentAttemptOut struct {
+	HasAnswer bool   // false if the student has not answered the question yet
+	HTML      string // standalone HTML page, empty if HasAnswer is false
+}
+
+// HomeworkStudentAttempt returns the question as seen by a student
+// on their last answer, with the answers given.
+func (ct *Controller) HomeworkStudentAttempt(c echo.Context) error {
+	userID := tcAPI.JWTTeacher(c)
+
+	var args HomeworkStudentAttemptIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.studentAttempt(args, userID)
+	if err != nil {
+		return err
+	}
 
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) studentAttempt(args HomeworkStudentAttemptIn, userID uID) (HomeworkStudentAttemptOut, error) {
+	travail, err := ct.checkTravailOwner(args.IdTravail, userID)
+	if err != nil {
+		return HomeworkStudentAttemptOut{}, err
+	}
+	student, err := tc.SelectStudent(ct.db, args.IdStudent)
+	if err != nil {
+		return HomeworkStudentAttemptOut{}, utils.SQLError(err)
+	}
+	if student.IdClassroom != travail.IdClassroom {
+		return HomeworkStudentAttemptOut{}, errAccessForbidden
+	}
+	link, found, err := ho.SelectSheetTaskByIdTask(ct.db, args.IdTask)
+	if err != nil {
+		return HomeworkStudentAttemptOut{}, utils.SQLError(err)
+	}
+	if !found || link.IdSheet != travail.IdSheet {
+		return HomeworkStudentAttemptOut{}, errAccessForbidden
+	}
+
+	attempt, found, err := taAPI.LoadQuestionAttempt(ct.db, args.IdStudent, args.IdTask, args.Index)
+	if err != nil {
+		return HomeworkStudentAttemptOut{}, err
+	}
+	if !found {
+		return HomeworkStudentAttemptOut{}, nil
+	}
+
+	title := fmt.Sprintf("%s - %s, question %d", tcAPI.PronoteName(student), attempt.Title, args.Index+1)
+	html := questions.QuestionToHTML(attempt.Question.ToClient(), questions.HTMLOptions{Title: title, Answers: attempt.Answers})
+	return HomeworkStudentAttemptOut{HasAnswer: true, HTML: html}, nil
+}
+
+// computeMarks does not check the classroom ownership
+func (ct *Controller) computeMarks(args HowemorkMarksIn) (HomeworkMarksOut, error) {
 	// returns the students for one classroom,
 	// sorted alphabetically.
 	stds, err := tc.SelectStudentsByIdClassrooms(ct.db, args.IdClassroom)
@@ -114,13 +190,27 @@ func (ct *Controller) getMarks(args HowemorkMarksIn, userID uID) (HomeworkMarksO
 
 	sort.Slice(out.Students, func(i, j int) bool { return out.Students[i].Label < out.Students[j].Label })
 
+	// resolve the sheet used by each student
+	assignments, err := loadTravailAssignments(ct.db, travaux.IDs())
+	if err != nil {
+		return HomeworkMarksOut{}, err
+	}
+	idSheets := utils.NewSet(travaux.IdSheets()...)
+	for _, l := range assignments.groups {
+		for _, link := range l {
+			if link.IdSheet.Valid {
+				idSheets.Add(link.IdSheet.ID)
+			}
+		}
+	}
+
 	// compute the sheets marks :
-	loader, err := newSheetsLoader(ct.db, travaux.IdSheets())
+	loader, err := newSheetsLoader(ct.db, idSheets.Keys())
 	if err != nil {
 		return HomeworkMarksOut{}, err
 	}
 	// load all the progressions : for each task and student
-	progressions, err := loader.tasks.LoadProgressions(ct.db)
+	progressions, hints, err := loader.tasks.LoadProgressionsAndHints(ct.db)
 	if err != nil {
 		return HomeworkMarksOut{}, err
 	}
@@ -131,78 +221,116 @@ func (ct *Controller) getMarks(args HowemorkMarksIn, userID uID) (HomeworkMarksO
 		}
 
 		markByStudent := make(map[tc.IdStudent]StudentTravailMark)
-		var sheetTotal int
-		// for each student, get its progression for each task
-		tasks := loader.tasksForSheet(travail.IdSheet)
-
 		tm := TravailMarks{
 			Marks: markByStudent,
 		}
 
-		for _, link := range tasks {
-			task := loader.tasks.Tasks[link.IdTask]
-			work := loader.tasks.GetWork(task)
-			bareme := work.Bareme()
-			taskTotal := bareme.Total()
-			sheetTotal += taskTotal
-			byStudent := progressions[link.IdTask]
-
-			questionsRes := make(map[editor.IdQuestion][2]int) // success, failure
-			questions := make(editor.Questions)                // success, failure
-
-			// add each progression to the student note
-			for _, student := range stds { // make sure to consider all students
-				studentProg := byStudent[student.Id]
-				studentMark := bareme.ComputeMark(studentProg)
-				item := markByStudent[student.Id]
-				item.Mark += float64(studentMark)
-				item.NbTries += studentProg.NbTries()
-				markByStudent[student.Id] = item
-
-				// map each question to its origin and compute its stats
-				questionOrigins := loader.tasks.ResolveQuestions(student.Id, work)
-				for questionIndex, origin := range questionOrigins {
-					questions[origin.Id] = origin
-
-					if len(studentProg) != 0 {
-						succes, failure := studentProg[questionIndex].Stats()
-						v := questionsRes[origin.Id]
-						v[0] += succes
-						v[1] += failure
-						questionsRes[origin.Id] = v
-					}
-				}
+		// group the students by sheet, the main sheet first
+		studentsBySheet := make(map[ho.IdSheet][]tc.IdStudent)
+		sheetsOrder := []ho.IdSheet{travail.IdSheet}
+		for _, student := range stds {
+			idSheet, ok := assignments.sheetFor(travail, student.Id)
+			if !ok {
+				markByStudent[student.Id] = StudentTravailMark{NotAssigned: true}
+				continue
+			}
+			if _, has := studentsBySheet[idSheet]; !has && idSheet != travail.IdSheet {
+				sheetsOrder = append(sheetsOrder, idSheet)
+			}
+			studentsBySheet[idSheet] = append(studentsBySheet[idSheet], student.Id)
+		}
+		sort.Slice(sheetsOrder[1:], func(i, j int) bool { return sheetsOrder[1+i] < sheetsOrder[1+j] })
+
+		sheetTotals := make(map[tc.IdStudent]int)
+		for _, idSheet := range sheetsOrder {
+			students := studentsBySheet[idSheet]
+			if idSheet != travail.IdSheet && len(students) == 0 {
+				continue
 			}
 
-			taskStat := TaskStat{
-				IdWork: taAPI.NewWorkID(task),
-				Title:  work.Title(),
+			var sheetTotal int
+			for _, idStudent := range students {
+				markByStudent[idStudent] = StudentTravailMark{Completed: true}
 			}
-			for index, qu := range loader.tasks.OrderQuestions(work) {
-				res := questionsRes[qu.Id]
-				stat := QuestionStat{
-					Id:         qu.Id,
-					Difficulty: qu.Difficulty,
-					NbSuccess:  res[0], NbFailure: res[1],
+			// for each student, get its progression for each task
+			tasks := loader.tasksForSheet(idSheet)
+			for _, link := range tasks {
+				task := loader.tasks.Tasks[link.IdTask]
+				work := loader.tasks.GetWork(task)
+				bareme := work.Bareme()
+				if !link.Optional { // optional tasks are ignored in the mark
+					sheetTotal += bareme.Total()
+				}
+				byStudent := progressions[link.IdTask]
+				hintsByStudent := hints[link.IdTask]
+
+				questionsRes := make(map[editor.IdQuestion][2]int) // success, failure
+				questions := make(editor.Questions)                // success, failure
+
+				// add each progression to the student note
+				for _, idStudent := range students { // make sure to consider all students
+					studentProg, studentHints := byStudent[idStudent], hintsByStudent[idStudent]
+					item := markByStudent[idStudent]
+					if !link.Optional {
+						item.Mark += float64(bareme.ComputeMarkWithHints(studentProg, studentHints, travail.HintPenalty))
+						item.Completed = item.Completed && studentProg.IsComplete()
+					}
+					item.NbTries += studentProg.NbTries()
+					item.NbHints += studentHints.Total()
+					markByStudent[idStudent] = item
+
+					// map each question to its origin and compute its stats
+					questionOrigins := loader.tasks.ResolveQuestions(idStudent, work)
+					for questionIndex, origin := range questionOrigins {
+						questions[origin.Id] = origin
+
+						if len(studentProg) != 0 {
+							succes, failure := studentProg[questionIndex].Stats()
+							v := questionsRes[origin.Id]
+							v[0] += succes
+							v[1] += failure
+							questionsRes[origin.Id] = v
+						}
+					}
 				}
-				switch taskStat.IdWork.Kind {
-				case taAPI.WorkExercice:
-					stat.Description = fmt.Sprintf("Question %d", index+1)
-				case taAPI.WorkMonoquestion, taAPI.WorkRandomMonoquestion:
-					stat.Description = qu.Subtitle
+
+				taskStat := TaskStat{
+					IdTask: task.Id,
+					IdWork: taAPI.NewWorkID(task),
+					Title:  work.Title(),
 				}
+				for index, qu := range loader.tasks.OrderQuestions(work) {
+					res := questionsRes[qu.Id]
+					stat := QuestionStat{
+						Id:         qu.Id,
+						Difficulty: qu.Difficulty,
+						NbSuccess:  res[0], NbFailure: res[1],
+					}
+					switch taskStat.IdWork.Kind {
+					case taAPI.WorkExercice:
+						stat.Description = fmt.Sprintf("Question %d", index+1)
+					case taAPI.WorkMonoquestion, taAPI.WorkRandomMonoquestion:
+						stat.Description = qu.Subtitle
+					}
 
-				taskStat.QuestionStats = append(taskStat.QuestionStats, stat)
+					taskStat.QuestionStats = append(taskStat.QuestionStats, stat)
+				}
+
+				taskStat.inferTotal()
+				tm.TaskStats = append(tm.TaskStats, taskStat)
 			}
 
-			taskStat.inferTotal()
-			tm.TaskStats = append(tm.TaskStats, taskStat)
+			for _, idStudent := range students {
+				sheetTotals[idStudent] = sheetTotal
+			}
 		}
 
 		// normalize the mark / 20 and add dispenses
 		exceptions := expects[idTravail].ByIdStudent()
 		for id, item := range markByStudent {
-			item.Mark = 20 * item.Mark / float64(sheetTotal)
+			if sheetTotal := sheetTotals[id]; sheetTotal != 0 {
+				item.Mark = 20 * item.Mark / float64(sheetTotal)
+			}
 			if l := exceptions[id]; len(l) != 0 {
 				item.Dispensed = l[0].IgnoreForMark
 			}
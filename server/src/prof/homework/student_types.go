@@ -1,6 +1,7 @@
 package homework
 
 import (
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	"github.com/benoitkugler/maths-online/server/src/pass"
 	"github.com/benoitkugler/maths-online/server/src/sql/events"
 	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
@@ -25,12 +26,24 @@ type Sheet struct {
 
 	QuestionRepeat    ho.QuestionRepeat // new in version 1.9
 	QuestionTimeLimit int               // new in version 1.9
+
+	// HintPenalty is the number of points removed for each hint used
+	HintPenalty int // new in version 1.10
+}
+
+// TaskStatus exposes the access settings of a task,
+// resolved for one student.
+type TaskStatus struct {
+	Optional bool // optional tasks are not taken into account in the mark
+	Locked   bool // locked tasks may not be started yet
 }
 
 type SheetProgression struct {
 	IdTravail ho.IdTravail // new in version 1.5
 	Sheet     Sheet
 	Tasks     []taAPI.TaskProgressionHeader
+	// TasksStatus has the same length as [Tasks]
+	TasksStatus []TaskStatus // new in version 1.10
 }
 
 type StudentSheets []SheetProgression
@@ -51,6 +64,22 @@ type StudentEvaluateTaskOut struct {
 	Advance                 events.EventNotification // new in v1.7
 }
 
+type StudentShowHintIn struct {
+	StudentID     pass.EncryptedID
+	IdTravail     ho.IdTravail
+	IdTask        sql.IdTask
+	QuestionIndex int          // index of the question in the task
+	Params        taAPI.Params // as sent with the question
+	HintIndex     int          // index of the hint to show, starting at 0
+}
+
+type StudentShowHintOut struct {
+	Hint client.Enonce
+	// WasHintRegistred is false if the progression may
+	// not be modified anymore (see [StudentEvaluateTaskOut]).
+	WasHintRegistred bool
+}
+
 type StudentResetTaskIn struct {
 	StudentID pass.EncryptedID
 	IdTravail ho.IdTravail
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/benoitkugler/maths-online/server/src/pass"
+	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
 	"github.com/benoitkugler/maths-online/server/src/sql/events"
 	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
 	"github.com/benoitkugler/maths-online/server/src/sql/tasks"
@@ -96,9 +97,10 @@ func loadSheetProgressions(db ho.DB, idStudent teacher.IdStudent, travaux ho.Tra
 
 		sheet := sheets[travail.IdSheet]
 		tasksForSheet := sheetToTasks[sheet.Id] // defined exercices
+		tasksForSheet.EnsureOrder()
 		taskList := make([]taAPI.TaskProgressionHeader, len(tasksForSheet))
 		for i, exLink := range tasksForSheet {
-			taskList[i] = progMap[exLink.IdTask]
+			taskList[i] = progMap[exLink.IdTask].WithHintPenalty(travail.HintPenalty)
 		}
 
 		matiere := sheet.Matiere
@@ -119,8 +121,10 @@ func loadSheetProgressions(db ho.DB, idStudent teacher.IdStudent, travaux ho.Tra
 				matiere,
 				travail.QuestionRepeat,
 				travail.QuestionTimeLimit,
+				travail.HintPenalty,
 			},
-			Tasks: taskList,
+			Tasks:       taskList,
+			TasksStatus: resolveTasksStatus(tasksForSheet, progMap, travail.HintPenalty),
 		})
 	}
 	return out, nil
@@ -148,6 +152,13 @@ func (ct *Controller) getStudentSheets(idStudent teacher.IdStudent, noted bool)
 		}
 	}
 
+	// restrict to the travaux assigned to the student groups
+	assignments, err := loadTravailAssignments(ct.db, travaux.IDs())
+	if err != nil {
+		return nil, err
+	}
+	assignments.restrictTo(travaux, idStudent)
+
 	out, err := loadSheetProgressions(ct.db, idStudent, travaux)
 	if err != nil {
 		return nil, err
@@ -186,6 +197,10 @@ func (ct *Controller) getStudentTravail(idStudent teacher.IdStudent, idTravail h
 	if err != nil {
 		return SheetProgression{}, utils.SQLError(err)
 	}
+	travail, err = resolveStudentTravail(ct.db, travail, idStudent)
+	if err != nil {
+		return SheetProgression{}, err
+	}
 	out, err := loadSheetProgressions(ct.db, idStudent, ho.Travails{travail.Id: travail})
 	if err != nil {
 		return SheetProgression{}, err
@@ -204,12 +219,25 @@ func (ct *Controller) StudentInstantiateTask(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	idTravail, err := utils.QueryParamInt[ho.IdTravail](c, "idTravail")
+	if err != nil {
+		return err
+	}
+
+	travail, err := resolveStudentTask(ct.db, idTravail, teacher.IdStudent(idStudent), tasks.IdTask(idTask))
+	if err != nil {
+		return err
+	}
 
 	task, err := tasks.SelectTask(ct.db, tasks.IdTask(idTask))
 	if err != nil {
 		return utils.SQLError(err)
 	}
 
+	if err = checkTaskAccess(ct.db, travail, teacher.IdStudent(idStudent), task.Id); err != nil {
+		return err
+	}
+
 	out, err := taAPI.InstantiateWork(ct.db, taAPI.NewWorkID(task), teacher.IdStudent(idStudent))
 	if err != nil {
 		return err
@@ -244,36 +272,27 @@ func (ct *Controller) studentEvaluateTask(args StudentEvaluateTaskIn) (StudentEv
 
 	Logger.Printf("evaluating Task %d (Travail %d) for Student %d", args.IdTask, args.IdTravail, idStudent)
 
-	pr, err := taAPI.LoadTaskProgression(ct.db, idStudent, args.IdTask)
+	// use the sheet of the student group
+	travail, err := resolveStudentTask(ct.db, args.IdTravail, idStudent, args.IdTask)
 	if err != nil {
 		return StudentEvaluateTaskOut{}, err
 	}
-	isTaskComplete := pr.HasProgression && pr.Progression.IsComplete()
 
-	travail, err := ho.SelectTravail(ct.db, args.IdTravail)
-	if err != nil {
-		return StudentEvaluateTaskOut{}, utils.SQLError(err)
+	if err = checkTaskAccess(ct.db, travail, idStudent, args.IdTask); err != nil {
+		return StudentEvaluateTaskOut{}, err
 	}
-	var registerProgression bool
-	if travail.Noted {
-		exp, has, err := ho.SelectTravailExceptionByIdStudentAndIdTravail(ct.db, idStudent, travail.Id)
-		if err != nil {
-			return StudentEvaluateTaskOut{}, utils.SQLError(err)
-		}
-		deadline := time.Time(travail.Deadline)
-		if has && exp.Deadline.Valid {
-			deadline = exp.Deadline.Time
-		}
-		isExpired := deadline.Before(time.Now())
 
-		// only register progression for non expired, non completed
-		registerProgression = !isTaskComplete && !isExpired
-	} else {
-		// Always register progression for free travail
-		registerProgression = true
+	pr, err := taAPI.LoadTaskProgression(ct.db, idStudent, args.IdTask)
+	if err != nil {
+		return StudentEvaluateTaskOut{}, err
+	}
+	isComplete := pr.HasProgression && pr.Progression.IsComplete()
+	registerProgression, err := shouldRegisterProgression(ct.db, travail, idStudent, isComplete)
+	if err != nil {
+		return StudentEvaluateTaskOut{}, err
 	}
 
-	ex, mark, err := taAPI.EvaluateTaskExercice(ct.db, args.IdTask, idStudent, travail.QuestionRepeat == ho.OneTry, args.Ex, registerProgression)
+	ex, mark, err := taAPI.EvaluateTaskExercice(ct.db, args.IdTask, idStudent, travail.QuestionRepeat == ho.OneTry, args.Ex, registerProgression, travail.HintPenalty)
 	if err != nil {
 		return StudentEvaluateTaskOut{}, err
 	}
@@ -310,6 +329,151 @@ func (ct *Controller) studentEvaluateTask(args StudentEvaluateTaskIn) (StudentEv
 	return StudentEvaluateTaskOut{Ex: ex, Mark: mark, WasProgressionRegistred: registerProgression, Advance: notif}, nil
 }
 
+// shouldRegisterProgression returns true if the progression of the student
+// on [travail] may still be modified.
+func shouldRegisterProgression(db ho.DB, travail ho.Travail, idStudent teacher.IdStudent, isComplete bool) (bool, error) {
+	if !travail.Noted {
+		// Always register progression for free travail
+		return true, nil
+	}
+
+	exp, has, err := ho.SelectTravailExceptionByIdStudentAndIdTravail(db, idStudent, travail.Id)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	deadline := time.Time(travail.Deadline)
+	if has && exp.Deadline.Valid {
+		deadline = exp.Deadline.Time
+	}
+	isExpired := deadline.Before(time.Now())
+
+	// only register progression for non expired, non completed
+	return !isComplete && !isExpired, nil
+}
+
+// StudentShowHint returns the next hint of a question, and
+// records its usage, so that the hint penalty is applied
+// and teachers may see who needed help.
+// As for [StudentEvaluateTask], the usage is not recorded for
+// expired travaux.
+func (ct *Controller) StudentShowHint(c echo.Context) error {
+	var args StudentShowHintIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	out, err := ct.studentShowHint(args)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) studentShowHint(args StudentShowHintIn) (StudentShowHintOut, error) {
+	idStudent_, err := ct.studentKey.DecryptID(args.StudentID)
+	if err != nil {
+		return StudentShowHintOut{}, err
+	}
+	idStudent := teacher.IdStudent(idStudent_)
+
+	travail, err := resolveStudentTask(ct.db, args.IdTravail, idStudent, args.IdTask)
+	if err != nil {
+		return StudentShowHintOut{}, err
+	}
+
+	if err = checkTaskAccess(ct.db, travail, idStudent, args.IdTask); err != nil {
+		return StudentShowHintOut{}, err
+	}
+
+	task, err := tasks.SelectTask(ct.db, args.IdTask)
+	if err != nil {
+		return StudentShowHintOut{}, utils.SQLError(err)
+	}
+
+	hint, err := taAPI.InstantiateHint(ct.db, taAPI.NewWorkID(task), idStudent, args.QuestionIndex, args.Params, args.HintIndex)
+	if err != nil {
+		return StudentShowHintOut{}, err
+	}
+
+	pr, err := taAPI.LoadTaskProgression(ct.db, idStudent, args.IdTask)
+	if err != nil {
+		return StudentShowHintOut{}, err
+	}
+	isComplete := pr.HasProgression && pr.Progression.IsComplete()
+
+	register, err := shouldRegisterProgression(ct.db, travail, idStudent, isComplete)
+	if err != nil {
+		return StudentShowHintOut{}, err
+	}
+
+	if register {
+		err = taAPI.RecordHintUsage(ct.db, idStudent, args.IdTask, int16(args.QuestionIndex), args.HintIndex)
+		if err != nil {
+			return StudentShowHintOut{}, err
+		}
+	}
+
+	return StudentShowHintOut{Hint: hint, WasHintRegistred: register}, nil
+}
+
+// IsWorkNoted returns true if [work] is used by a task of a noted travail
+// (either as main sheet or as group sheet).
+// The hints of such works must only be revealed by [Controller.StudentShowHint],
+// so that their usage is recorded.
+func IsWorkNoted(db ho.DB, work taAPI.WorkID) (bool, error) {
+	var (
+		tas tasks.Tasks
+		err error
+	)
+	switch work.Kind {
+	case taAPI.WorkExercice:
+		tas, err = tasks.SelectTasksByIdExercices(db, ed.IdExercice(work.ID))
+	case taAPI.WorkMonoquestion:
+		tas, err = tasks.SelectTasksByIdMonoquestions(db, tasks.IdMonoquestion(work.ID))
+	case taAPI.WorkRandomMonoquestion:
+		tas, err = tasks.SelectTasksByIdRandomMonoquestions(db, tasks.IdRandomMonoquestion(work.ID))
+	default: // backward compatiblity
+		if work.IsExercice {
+			tas, err = tasks.SelectTasksByIdExercices(db, ed.IdExercice(work.ID))
+		} else {
+			tas, err = tasks.SelectTasksByIdMonoquestions(db, tasks.IdMonoquestion(work.ID))
+		}
+	}
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	if len(tas) == 0 {
+		return false, nil
+	}
+
+	links, err := ho.SelectSheetTasksByIdTasks(db, tas.IDs()...)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	idSheets := links.IdSheets()
+	travaux, err := ho.SelectTravailsByIdSheets(db, idSheets...)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	groups, err := ho.SelectTravailGroupsByIdSheets(db, idSheets...)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	groupTravaux, err := ho.SelectTravails(db, groups.IdTravails()...)
+	if err != nil {
+		return false, utils.SQLError(err)
+	}
+	for _, m := range [2]ho.Travails{travaux, groupTravaux} {
+		for _, travail := range m {
+			if travail.Noted {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // StudentResetTask remove the progression for the given student
 // and task. It is only allowed for free travaux.
 func (ct *Controller) StudentResetTask(c echo.Context) error {
@@ -415,6 +415,12 @@ func parsePronoteName(s string) (name, surname string) {
 	return chunks[0], strings.Join(chunks[1:], " ")
 }
 
+// PronoteName returns the name of the student
+// formatted as in Pronote exports : NAME Surname
+func PronoteName(student tc.Student) string {
+	return strings.TrimSpace(strings.ToUpper(student.Name) + " " + student.Surname)
+}
+
 func parsePronoteStudentList(file io.Reader) ([]tc.Student, error) {
 	r := csv.NewReader(file)
 	r.Comma = ';'
@@ -227,3 +227,52 @@ func TestClassroomCode(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	fmt.Println(time.Time(codes[0].ExpiresAt))
 }
+
+func TestStudentGroupsCRUD(t *testing.T) {
+	db := tu.NewTestDB(t, "../../sql/teacher/gen_create.sql", "../../sql/editor/gen_create.sql", "../../sql/tasks/gen_create.sql",
+		"../../sql/homework/gen_create.sql", "../../sql/events/gen_create.sql")
+	defer db.Remove()
+
+	t1, err := tc.Teacher{FavoriteMatiere: tc.Mathematiques}.Insert(db)
+	tu.AssertNoErr(t, err)
+
+	ct := Controller{db: db.DB, admin: tc.Teacher{Id: t1.Id}}
+	cl1, err := ct.createClassroom(t1.Id)
+	tu.AssertNoErr(t, err)
+	cl2, err := ct.createClassroom(t1.Id)
+	tu.AssertNoErr(t, err)
+	st1, err := ct.addStudent(cl1.Id, t1.Id)
+	tu.AssertNoErr(t, err)
+	st2, err := ct.addStudent(cl2.Id, t1.Id)
+	tu.AssertNoErr(t, err)
+
+	group, err := ct.createStudentGroup(cl1.Id, t1.Id)
+	tu.AssertNoErr(t, err)
+
+	group.Group.Name = "Groupe A"
+	group.Students = []tc.IdStudent{st1.Student.Id}
+	err = ct.updateStudentGroup(group, t1.Id)
+	tu.AssertNoErr(t, err)
+
+	// students from another classroom are rejected
+	group.Students = []tc.IdStudent{st2.Student.Id}
+	err = ct.updateStudentGroup(group, t1.Id)
+	tu.Assert(t, err != nil)
+
+	groups, err := LoadStudentGroups(ct.db, cl1.Id)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, len(groups) == 1 && groups[0].Group.Name == "Groupe A" && len(groups[0].Students) == 1)
+
+	err = ct.deleteStudentGroup(group.Group.Id, t1.Id+1)
+	tu.Assert(t, err != nil)
+	err = ct.deleteStudentGroup(group.Group.Id, t1.Id)
+	tu.AssertNoErr(t, err)
+}
+
+func TestPronoteName(t *testing.T) {
+	for _, name := range []string{"DEIARE Matthéa", "DEMANS-HAUC Jode", "PONCLVES ROHA Oceli"} {
+		n, s := parsePronoteName(name)
+		tu.Assert(t, PronoteName(tc.Student{Name: n, Surname: s}) == name)
+	}
+	tu.Assert(t, PronoteName(tc.Student{Name: "Dupont", Surname: "Jean"}) == "DUPONT Jean")
+}
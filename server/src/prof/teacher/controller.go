@@ -324,6 +324,7 @@ type TeacherSettings struct {
 	HasEditorSimplified bool
 	Contact             tc.Contact
 	FavoriteMatiere     teacher.MatiereTag
+	WeeklyDigest        bool // opt-in for the weekly summary mail
 }
 
 // TeacherGetSettings returns the teacher global settings.
@@ -346,12 +347,18 @@ func (ct *Controller) getSettings(userID teacher.IdTeacher) (TeacherSettings, er
 
 	password := ct.teacherKey.DecryptPassword(teach.PasswordCrypted)
 
+	_, hasDigest, err := teacher.SelectTeacherDigestByIdTeacher(ct.db, userID)
+	if err != nil {
+		return TeacherSettings{}, utils.SQLError(err)
+	}
+
 	return TeacherSettings{
 		Mail:                teach.Mail,
 		Password:            password,
 		HasEditorSimplified: teach.HasSimplifiedEditor,
 		Contact:             teach.Contact,
 		FavoriteMatiere:     teach.FavoriteMatiere,
+		WeeklyDigest:        hasDigest,
 	}, nil
 }
 
@@ -387,12 +394,23 @@ func (ct *Controller) updateSettings(args TeacherSettings, userID teacher.IdTeac
 	teach.Contact = args.Contact
 	teach.FavoriteMatiere = args.FavoriteMatiere
 
-	_, err = teach.Update(ct.db)
-	if err != nil {
-		return utils.SQLError(err)
-	}
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		_, err = teach.Update(tx)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		_, hasDigest, err := teacher.SelectTeacherDigestByIdTeacher(tx, userID)
+		if err != nil {
+			return err
+		}
+		if args.WeeklyDigest && !hasDigest {
+			err = teacher.TeacherDigest{IdTeacher: userID}.Insert(tx)
+		} else if !args.WeeklyDigest && hasDigest {
+			_, err = teacher.DeleteTeacherDigestsByIdTeachers(tx, userID)
+		}
+		return err
+	})
 }
 
 // shared types
@@ -0,0 +1,202 @@
+package teacher
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	ho "github.com/benoitkugler/maths-online/server/src/sql/homework"
+	tc "github.com/benoitkugler/maths-online/server/src/sql/teacher"
+	"github.com/benoitkugler/maths-online/server/src/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// this file provides the managment of groups of students,
+// inside a classroom
+
+// StudentGroupExt is a group with its members
+type StudentGroupExt struct {
+	Group    tc.StudentGroup
+	Students []tc.IdStudent
+}
+
+// LoadStudentGroups returns the groups defined for the given classroom,
+// with their members, sorted by name.
+func LoadStudentGroups(db tc.DB, idClassroom tc.IdClassroom) ([]StudentGroupExt, error) {
+	groups, err := tc.SelectStudentGroupsByIdClassrooms(db, idClassroom)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	links, err := tc.SelectStudentGroupMembersByIdStudentGroups(db, groups.IDs()...)
+	if err != nil {
+		return nil, utils.SQLError(err)
+	}
+	byGroup := links.ByIdStudentGroup()
+
+	out := make([]StudentGroupExt, 0, len(groups))
+	for _, group := range groups {
+		students := byGroup[group.Id].IdStudents()
+		sort.Slice(students, func(i, j int) bool { return students[i] < students[j] })
+		out = append(out, StudentGroupExt{Group: group, Students: students})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Group.Name == out[j].Group.Name {
+			return out[i].Group.Id < out[j].Group.Id
+		}
+		return out[i].Group.Name < out[j].Group.Name
+	})
+	return out, nil
+}
+
+func (ct *Controller) TeacherGetStudentGroups(c echo.Context) error {
+	userID := JWTTeacher(c)
+
+	idClassroom, err := utils.QueryParamInt[tc.IdClassroom](c, "id-classroom")
+	if err != nil {
+		return err
+	}
+
+	if _, err := ct.checkAcces(userID, idClassroom); err != nil {
+		return err
+	}
+
+	out, err := LoadStudentGroups(ct.db, idClassroom)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) TeacherCreateStudentGroup(c echo.Context) error {
+	userID := JWTTeacher(c)
+
+	idClassroom, err := utils.QueryParamInt[tc.IdClassroom](c, "id-classroom")
+	if err != nil {
+		return err
+	}
+
+	out, err := ct.createStudentGroup(idClassroom, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
+
+func (ct *Controller) createStudentGroup(idClassroom tc.IdClassroom, userID tc.IdTeacher) (StudentGroupExt, error) {
+	if _, err := ct.checkAcces(userID, idClassroom); err != nil {
+		return StudentGroupExt{}, err
+	}
+
+	group, err := tc.StudentGroup{IdClassroom: idClassroom, Name: "Nouveau groupe"}.Insert(ct.db)
+	if err != nil {
+		return StudentGroupExt{}, utils.SQLError(err)
+	}
+
+	return StudentGroupExt{Group: group}, nil
+}
+
+// TeacherUpdateStudentGroup updates the name and the
+// members of a group.
+func (ct *Controller) TeacherUpdateStudentGroup(c echo.Context) error {
+	userID := JWTTeacher(c)
+
+	var args StudentGroupExt
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	err := ct.updateStudentGroup(args, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(200)
+}
+
+func (ct *Controller) checkStudentGroupAccess(idGroup tc.IdStudentGroup, userID tc.IdTeacher) (tc.StudentGroup, error) {
+	group, err := tc.SelectStudentGroup(ct.db, idGroup)
+	if err != nil {
+		return tc.StudentGroup{}, utils.SQLError(err)
+	}
+	if _, err := ct.checkAcces(userID, group.IdClassroom); err != nil {
+		return tc.StudentGroup{}, err
+	}
+	return group, nil
+}
+
+func (ct *Controller) updateStudentGroup(args StudentGroupExt, userID tc.IdTeacher) error {
+	group, err := ct.checkStudentGroupAccess(args.Group.Id, userID)
+	if err != nil {
+		return err
+	}
+
+	// only accept students from the classroom
+	students, err := tc.SelectStudentsByIdClassrooms(ct.db, group.IdClassroom)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	members := utils.NewSet(args.Students...)
+	links := make(tc.StudentGroupMembers, 0, len(members))
+	for idStudent := range members {
+		if _, ok := students[idStudent]; !ok {
+			return errors.New("internal error: student not in the group classroom")
+		}
+		links = append(links, tc.StudentGroupMember{IdStudentGroup: group.Id, IdStudent: idStudent})
+	}
+
+	group.Name = args.Group.Name
+	return utils.InTx(ct.db, func(tx *sql.Tx) error {
+		_, err := group.Update(tx)
+		if err != nil {
+			return err
+		}
+		_, err = tc.DeleteStudentGroupMembersByIdStudentGroups(tx, group.Id)
+		if err != nil {
+			return err
+		}
+		return tc.InsertManyStudentGroupMembers(tx, links...)
+	})
+}
+
+// TeacherDeleteStudentGroup removes the group, which must not
+// be used by a travail.
+func (ct *Controller) TeacherDeleteStudentGroup(c echo.Context) error {
+	userID := JWTTeacher(c)
+
+	id, err := utils.QueryParamInt[tc.IdStudentGroup](c, "id")
+	if err != nil {
+		return err
+	}
+
+	err = ct.deleteStudentGroup(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(200)
+}
+
+func (ct *Controller) deleteStudentGroup(idGroup tc.IdStudentGroup, userID tc.IdTeacher) error {
+	if _, err := ct.checkStudentGroupAccess(idGroup, userID); err != nil {
+		return err
+	}
+
+	// deleting a group used by a travail would silently
+	// assign the travail to the whole classroom
+	links, err := ho.SelectTravailGroupsByIdStudentGroups(ct.db, idGroup)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	if len(links) != 0 {
+		return errors.New("Ce groupe est utilisé par au moins un travail et ne peut pas être supprimé.")
+	}
+
+	// members are deleted by cascade
+	_, err = tc.DeleteStudentGroupById(ct.db, idGroup)
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	return nil
+}
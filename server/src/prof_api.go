@@ -22,6 +22,8 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	e.GET("/api/prof/reset", tc.TeacherResetPassword)
 
 	e.GET("/api/prof/classrooms/students-csv", tc.TeacherExportStudentsAdvance, tc.JWTMiddlewareForQuery()) // url-only
+	e.GET("/api/prof/homework/marks/export", home.HomeworkExportMarks, tc.JWTMiddlewareForQuery())          // url-only
+	e.GET("/api/prof/homework/sheet/export/papers", home.HomeworkExportPapers, tc.JWTMiddlewareForQuery())  // url-only
 
 	gr := e.Group("", tc.JWTMiddleware())
 
@@ -44,6 +46,11 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	gr.POST("/api/prof/classrooms/students/import", tc.TeacherImportStudents)
 	gr.GET("/api/prof/classrooms/students/connect", tc.TeacherGenerateClassroomCode)
 
+	gr.GET("/api/prof/classrooms/groups", tc.TeacherGetStudentGroups)
+	gr.PUT("/api/prof/classrooms/groups", tc.TeacherCreateStudentGroup)
+	gr.POST("/api/prof/classrooms/groups", tc.TeacherUpdateStudentGroup)
+	gr.DELETE("/api/prof/classrooms/groups", tc.TeacherDeleteStudentGroup)
+
 	// trivial activity
 	gr.GET("/api/prof/trivial/config", tvc.GetTrivialPoursuit)
 	gr.PUT("/api/prof/trivial/config", tvc.CreateTrivialPoursuit)
@@ -66,6 +73,7 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	// question editor
 	gr.GET("/api/prof/editor/tags", edit.EditorGetTags)
 	gr.POST("/api/prof/editor/syntax-hint", edit.EditorGenerateSyntaxHint)
+	gr.POST("/api/prof/editor/latex", edit.EditorConvertLaTeX)
 
 	gr.GET("/api/prof/editor/questiongroups", edit.EditorGetQuestionsIndex)
 	gr.POST("/api/prof/editor/questiongroups", edit.EditorSearchQuestions)
@@ -75,12 +83,16 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	gr.POST("/api/prof/editor/questiongroup", edit.EditorUpdateQuestiongroup)
 	gr.DELETE("/api/prof/editor/questiongroup", edit.EditorDeleteQuestiongroup)
 	gr.POST("/api/prof/editor/questiongroup/visibility", edit.EditorUpdateQuestiongroupVis)
+	gr.GET("/api/prof/editor/questiongroup/export-lms", edit.EditorExportQuestiongroupLMS)
+	gr.POST("/api/prof/editor/questiongroup/import-lms", edit.EditorImportQuestiongroupsLMS)
 	gr.GET("/api/prof/editor/question", edit.EditorGetQuestions)
 	gr.DELETE("/api/prof/editor/question", edit.EditorDeleteQuestion)
 	gr.POST("/api/prof/editor/question/variant", edit.EditorSaveQuestionMeta)
 	gr.POST("/api/prof/editor/question/check-params", edit.EditorCheckQuestionParameters)
 	gr.POST("/api/prof/editor/question/preview", edit.EditorSaveQuestionAndPreview)
 	gr.POST("/api/prof/editor/question/export/latex", edit.EditorQuestionExportLateX)
+	gr.POST("/api/prof/editor/question/export/svg", edit.EditorQuestionExportSVG)
+	gr.POST("/api/prof/editor/question/export/html", edit.EditorQuestionExportHTML)
 
 	// exercice editor
 	gr.GET("/api/prof/editor/exercicegroups", edit.EditorGetExercicesIndex)
@@ -113,6 +125,13 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	gr.POST("/api/prof/homework/travail", home.HomeworkUpdateTravail)
 	gr.DELETE("/api/prof/homework/travail", home.HomeworkDeleteTravail)
 	gr.POST("/api/prof/homework/travail/copy", home.HomeworkCopyTravail)
+	gr.POST("/api/prof/homework/travail/copy-many", home.HomeworkCopyTravailMany)
+	gr.GET("/api/prof/homework/travail/groups", home.HomeworkGetTravailGroups)
+	gr.POST("/api/prof/homework/travail/groups", home.HomeworkSetTravailGroups)
+	gr.GET("/api/prof/homework/schedules", home.HomeworkGetSchedules)
+	gr.PUT("/api/prof/homework/schedules", home.HomeworkCreateSchedule)
+	gr.POST("/api/prof/homework/schedules", home.HomeworkUpdateSchedule)
+	gr.DELETE("/api/prof/homework/schedules", home.HomeworkDeleteSchedule)
 	gr.DELETE("/api/prof/homework/sheet", home.HomeworkRemoveTask)
 	gr.PUT("/api/prof/homework/sheet/exercice", home.HomeworkAddExercice)
 	gr.GET("/api/prof/homework/sheet/monoquestion", home.HomeworkGetMonoquestion)
@@ -122,8 +141,10 @@ func setupProfAPI(e *echo.Echo, tvc *trivial.Controller,
 	gr.POST("/api/prof/homework/sheet/monoquestion", home.HomeworkUpdateMonoquestion)
 	gr.POST("/api/prof/homework/sheet/randommonoquestion", home.HomeworkUpdateRandomMonoquestion)
 	gr.POST("/api/prof/homework/sheet", home.HomeworkReorderSheetTasks)
+	gr.POST("/api/prof/homework/sheet/task", home.HomeworkUpdateSheetTask)
 	gr.GET("/api/prof/homework/sheet/missing-hint", home.HomeworkMissingTasksHint)
 	gr.POST("/api/prof/homework/marks", home.HomeworkGetMarks)
+	gr.POST("/api/prof/homework/marks/attempt", home.HomeworkStudentAttempt)
 	gr.GET("/api/prof/homework/dispences", home.HomeworkGetDispenses)
 	gr.POST("/api/prof/homework/dispences", home.HomeworkSetDispense)
 
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"errors"
+
 	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/prof/homework"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
 	"github.com/benoitkugler/maths-online/server/src/tasks"
 	"github.com/labstack/echo/v4"
@@ -80,3 +83,30 @@ func evaluateExercice(db ed.DB, c echo.Context) error {
 
 	return c.JSON(200, out)
 }
+
+type ShowWorkHintIn = tasks.ShowWorkHintIn
+
+// standalone endpoint to reveal a question hint;
+// as [evaluateExercice], it does not handle progression persistence,
+// and thus refuses works used in noted travaux (see [homework.Controller.StudentShowHint])
+func showExerciceHint(db ed.DB, c echo.Context) error {
+	var args ShowWorkHintIn
+	if err := c.Bind(&args); err != nil {
+		return err
+	}
+
+	isNoted, err := homework.IsWorkNoted(db, args.ID)
+	if err != nil {
+		return err
+	}
+	if isNoted {
+		return errors.New("Les indices de cet exercice ne sont accessibles que depuis la feuille d'exercices.")
+	}
+
+	out, err := tasks.InstantiateHint(db, args.ID, -1, args.QuestionIndex, args.Params, args.HintIndex)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, out)
+}
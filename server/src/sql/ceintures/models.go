@@ -33,6 +33,9 @@ type Beltquestion struct {
 	// Correction an optional content describing the expected solution,
 	// to be instantiated with the same parameters as [Enonce]
 	Correction questions.Enonce
+	// Hints is an optional list of contents, revealed one by one
+	// to the students on demand
+	Hints questions.Hints
 
 	// Repeat is the number of times the question is proposed,
 	// defaulting to 1
@@ -44,5 +47,5 @@ type Beltquestion struct {
 }
 
 func (qu Beltquestion) Page() questions.QuestionPage {
-	return questions.QuestionPage{Enonce: qu.Enonce, Correction: qu.Correction, Parameters: qu.Parameters}
+	return questions.QuestionPage{Enonce: qu.Enonce, Correction: qu.Correction, Hints: qu.Hints, Parameters: qu.Parameters}
 }
@@ -56,11 +56,13 @@ const (
 type Stat struct {
 	Success uint16 // number of questions answered with success
 	Failure uint16 // number of questions answered with failure
+	Hints   uint16 // number of hints revealed in training mode
 }
 
 func (s *Stat) Add(other Stat) {
 	s.Success += other.Success
 	s.Failure += other.Failure
+	s.Hints += other.Hints
 }
 
 // Advance stores, for each [Domain], the rank
@@ -35,7 +35,8 @@ CREATE TABLE questions (
     IdGroup integer,
     Enonce jsonb NOT NULL,
     Parameters jsonb NOT NULL,
-    Correction jsonb NOT NULL
+    Correction jsonb NOT NULL,
+    Hints jsonb NOT NULL
 );
 
 CREATE TABLE questiongroups (
@@ -258,6 +259,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_array_ques_Block (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_array_ques_Block (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_FunctionArea (data jsonb)
     RETURNS boolean
     AS $$
@@ -465,6 +489,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_ques_WrongAnswerHint (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_repe_NamedRandomLabeledPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -694,7 +741,9 @@ BEGIN
     IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
         RETURN FALSE;
     END IF;
-    CASE WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
+    CASE WHEN data ->> 'Kind' = 'CategoriesFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_CategoriesFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'ExpressionFieldBlock' THEN
         RETURN gomacro_validate_json_ques_ExpressionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'FigureBlock' THEN
         RETURN gomacro_validate_json_ques_FigureBlock (data -> 'Data');
@@ -708,8 +757,14 @@ BEGIN
         RETURN gomacro_validate_json_ques_GeometricConstructionFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ImageBlock' THEN
         RETURN gomacro_validate_json_ques_ImageBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'MatchingFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_MatchingFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'NumberFieldBlock' THEN
         RETURN gomacro_validate_json_ques_NumberFieldBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'NumberLineFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_NumberLineFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'OrderedListFieldBlock' THEN
         RETURN gomacro_validate_json_ques_OrderedListFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'ProofFieldBlock' THEN
@@ -728,6 +783,8 @@ BEGIN
         RETURN gomacro_validate_json_ques_TableFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TextBlock' THEN
         RETURN gomacro_validate_json_ques_TextBlock (data -> 'Data');
+    WHEN data ->> 'Kind' = 'TextFieldBlock' THEN
+        RETURN gomacro_validate_json_ques_TextFieldBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeBlock' THEN
         RETURN gomacro_validate_json_ques_TreeBlock (data -> 'Data');
     WHEN data ->> 'Kind' = 'TreeFieldBlock' THEN
@@ -746,12 +803,34 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_CategoriesFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Categories', 'Items'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Categories')
+        AND gomacro_validate_json_array_array_string (data -> 'Items');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ComparisonLevel (data jsonb)
     RETURNS boolean
     AS $$
 DECLARE
     is_valid boolean := jsonb_typeof(data) = 'number'
-    AND data::int IN (102, 2, 1, 0);
+    AND data::int IN (102, 103, 104, 2, 1, 0);
 BEGIN
     IF NOT is_valid THEN
         RAISE WARNING '% is not a ques_ComparisonLevel', data;
@@ -795,13 +874,14 @@ BEGIN
     END IF;
     is_valid := (
         SELECT
-            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp'))
+            bool_and(key IN ('Expression', 'Label', 'ComparisonLevel', 'ShowFractionHelp', 'Hints'))
         FROM
             jsonb_each(data))
         AND gomacro_validate_json_string (data -> 'Expression')
         AND gomacro_validate_json_string (data -> 'Label')
         AND gomacro_validate_json_ques_ComparisonLevel (data -> 'ComparisonLevel')
-        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp');
+        AND gomacro_validate_json_boolean (data -> 'ShowFractionHelp')
+        AND gomacro_validate_json_array_ques_WrongAnswerHint (data -> 'Hints');
     RETURN is_valid;
 END;
 $$
@@ -1064,6 +1144,76 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFAngle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Measure', 'Vertex', 'MustHaveVertex'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Measure')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Vertex')
+        AND gomacro_validate_json_boolean (data -> 'MustHaveVertex');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFCircle (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Center', 'Radius'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Center')
+        AND gomacro_validate_json_string (data -> 'Radius');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFLine (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Label', 'Through', 'A', 'B', 'Perpendicular'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Label')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'Through')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'A')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'B')
+        AND gomacro_validate_json_boolean (data -> 'Perpendicular');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPoint (data jsonb)
     RETURNS boolean
     AS $$
@@ -1085,6 +1235,52 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFPointOnCurve (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Function', 'Variable'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Function')
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFSegment (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('From', 'To', 'LabelFrom', 'LabelTo'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'From')
+        AND gomacro_validate_json_ques_CoordExpression (data -> 'To')
+        AND gomacro_validate_json_string (data -> 'LabelFrom')
+        AND gomacro_validate_json_string (data -> 'LabelTo');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_GFVector (data jsonb)
     RETURNS boolean
     AS $$
@@ -1138,8 +1334,18 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'GFAffineLine' THEN
         RETURN gomacro_validate_json_ques_GFAffineLine (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFAngle' THEN
+        RETURN gomacro_validate_json_ques_GFAngle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFCircle' THEN
+        RETURN gomacro_validate_json_ques_GFCircle (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFLine' THEN
+        RETURN gomacro_validate_json_ques_GFLine (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFPoint' THEN
         RETURN gomacro_validate_json_ques_GFPoint (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFPointOnCurve' THEN
+        RETURN gomacro_validate_json_ques_GFPointOnCurve (data -> 'Data');
+    WHEN data ->> 'Kind' = 'GFSegment' THEN
+        RETURN gomacro_validate_json_ques_GFSegment (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVector' THEN
         RETURN gomacro_validate_json_ques_GFVector (data -> 'Data');
     WHEN data ->> 'Kind' = 'GFVectorPair' THEN
@@ -1196,6 +1402,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_MatchingFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Left', 'Right', 'AdditionalRight'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Left')
+        AND gomacro_validate_json_array_string (data -> 'Right')
+        AND gomacro_validate_json_array_string (data -> 'AdditionalRight');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1217,6 +1446,49 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Set', 'Ticks'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Set')
+        AND gomacro_validate_json_array_string (data -> 'Ticks');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_NumberLineFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_ques_NumberLineBlock (data -> 'Answer');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_OrderedListFieldBlock (data jsonb)
     RETURNS boolean
     AS $$
@@ -1240,6 +1512,29 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_Fn (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('function', 'variable', 'expression'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'function')
+        AND gomacro_validate_json_expr_Variable (data -> 'variable')
+        AND gomacro_validate_json_string (data -> 'expression');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_ParameterEntry (data jsonb)
     RETURNS boolean
     AS $$
@@ -1249,6 +1544,10 @@ BEGIN
     END IF;
     CASE WHEN data ->> 'Kind' = 'Co' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Ct' THEN
+        RETURN gomacro_validate_json_string (data -> 'Data');
+    WHEN data ->> 'Kind' = 'Fn' THEN
+        RETURN gomacro_validate_json_ques_Fn (data -> 'Data');
     WHEN data ->> 'Kind' = 'In' THEN
         RETURN gomacro_validate_json_string (data -> 'Data');
     WHEN data ->> 'Kind' = 'Rp' THEN
@@ -1593,6 +1892,33 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextFieldBlock (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answers', 'IgnoreCase', 'IgnoreAccents', 'IgnorePunctuation', 'UseRegexp', 'Correction', 'MaxTypos'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_string (data -> 'Answers')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreCase')
+        AND gomacro_validate_json_boolean (data -> 'IgnoreAccents')
+        AND gomacro_validate_json_boolean (data -> 'IgnorePunctuation')
+        AND gomacro_validate_json_boolean (data -> 'UseRegexp')
+        AND gomacro_validate_json_string (data -> 'Correction')
+        AND gomacro_validate_json_number (data -> 'MaxTypos');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_TextKind (data jsonb)
     RETURNS boolean
     AS $$
@@ -1780,6 +2106,28 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_ques_WrongAnswerHint (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Answer', 'Hint'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Answer')
+        AND gomacro_validate_json_string (data -> 'Hint');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_repe_Coord (data jsonb)
     RETURNS boolean
     AS $$
@@ -2064,6 +2412,9 @@ IMMUTABLE;
 ALTER TABLE questions
     ADD CONSTRAINT Correction_gomacro CHECK (gomacro_validate_json_array_ques_Block (Correction));
 
+ALTER TABLE questions
+    ADD CONSTRAINT Hints_gomacro CHECK (gomacro_validate_json_array_array_ques_Block (Hints));
+
 ALTER TABLE questions
     ADD CONSTRAINT Enonce_gomacro CHECK (gomacro_validate_json_array_ques_Block (Enonce));
 
@@ -112,6 +112,7 @@ func randQuestion() Question {
 	s.Enonce = randque_Enonce()
 	s.Parameters = randque_Parameters()
 	s.Correction = randque_Enonce()
+	s.Hints = randque_Hints()
 
 	return s
 }
@@ -141,6 +142,15 @@ func randSection() Section {
 	return choix[i]
 }
 
+func randSliceSliceque_Interpolated() [][]questions.Interpolated {
+	l := 3 + rand.Intn(5)
+	out := make([][]questions.Interpolated, l)
+	for i := range out {
+		out[i] = randSliceque_Interpolated()
+	}
+	return out
+}
+
 func randSliceSliceque_TextPart() [][]questions.TextPart {
 	l := 3 + rand.Intn(5)
 	out := make([][]questions.TextPart, l)
@@ -186,6 +196,15 @@ func randSliceque_Block() []questions.Block {
 	return out
 }
 
+func randSliceque_Enonce() []questions.Enonce {
+	l := 3 + rand.Intn(5)
+	out := make([]questions.Enonce, l)
+	for i := range out {
+		out[i] = randque_Enonce()
+	}
+	return out
+}
+
 func randSliceque_FunctionArea() []questions.FunctionArea {
 	l := 3 + rand.Intn(5)
 	out := make([]questions.FunctionArea, l)
@@ -258,6 +277,15 @@ func randSliceque_VariationTableBlock() []questions.VariationTableBlock {
 	return out
 }
 
+func randSliceque_WrongAnswerHint() []questions.WrongAnswerHint {
+	l := 3 + rand.Intn(5)
+	out := make([]questions.WrongAnswerHint, l)
+	for i := range out {
+		out[i] = randque_WrongAnswerHint()
+	}
+	return out
+}
+
 func randSlicerep_NamedRandomLabeledPoint() []repere.NamedRandomLabeledPoint {
 	l := 3 + rand.Intn(5)
 	out := make([]repere.NamedRandomLabeledPoint, l)
@@ -349,6 +377,7 @@ func randint64() int64 {
 
 func randque_Block() questions.Block {
 	choix := [...]questions.Block{
+		randque_CategoriesFieldBlock(),
 		randque_ExpressionFieldBlock(),
 		randque_FigureBlock(),
 		randque_FormulaBlock(),
@@ -356,7 +385,10 @@ func randque_Block() questions.Block {
 		randque_FunctionsGraphBlock(),
 		randque_GeometricConstructionFieldBlock(),
 		randque_ImageBlock(),
+		randque_MatchingFieldBlock(),
 		randque_NumberFieldBlock(),
+		randque_NumberLineBlock(),
+		randque_NumberLineFieldBlock(),
 		randque_OrderedListFieldBlock(),
 		randque_ProofFieldBlock(),
 		randque_RadioFieldBlock(),
@@ -366,22 +398,35 @@ func randque_Block() questions.Block {
 		randque_TableBlock(),
 		randque_TableFieldBlock(),
 		randque_TextBlock(),
+		randque_TextFieldBlock(),
 		randque_TreeBlock(),
 		randque_TreeFieldBlock(),
 		randque_VariationTableBlock(),
 		randque_VariationTableFieldBlock(),
 		randque_VectorFieldBlock(),
 	}
-	i := rand.Intn(22)
+	i := rand.Intn(27)
 	return choix[i]
 }
 
+func randque_CategoriesFieldBlock() questions.CategoriesFieldBlock {
+	var s questions.CategoriesFieldBlock
+	s.Categories = randSliceque_Interpolated()
+	s.Items = randSliceSliceque_Interpolated()
+
+	return s
+}
+
 func randque_Co() questions.Co {
 	return questions.Co(randstring())
 }
 
+func randque_Ct() questions.Ct {
+	return questions.Ct(randstring())
+}
+
 func randque_ComparisonLevel() questions.ComparisonLevel {
-	choix := [...]questions.ComparisonLevel{questions.AsLinearEquation, questions.ExpandedSubstitutions, questions.SimpleSubstitutions, questions.Strict}
+	choix := [...]questions.ComparisonLevel{questions.AsLinearEquation, questions.AsSolutionSet, questions.AsComplexNumber, questions.ExpandedSubstitutions, questions.SimpleSubstitutions, questions.Strict}
 	i := rand.Intn(len(choix))
 	return choix[i]
 }
@@ -404,6 +449,7 @@ func randque_ExpressionFieldBlock() questions.ExpressionFieldBlock {
 	s.Label = randque_Interpolated()
 	s.ComparisonLevel = randque_ComparisonLevel()
 	s.ShowFractionHelp = randbool()
+	s.Hints = randSliceque_WrongAnswerHint()
 
 	return s
 }
@@ -427,6 +473,15 @@ func randque_FiguresOrGraphs() questions.FiguresOrGraphs {
 	return choix[i]
 }
 
+func randque_Fn() questions.Fn {
+	var s questions.Fn
+	s.Function = randexp_Variable()
+	s.Variable = randexp_Variable()
+	s.Expression = randstring()
+
+	return s
+}
+
 func randque_FormulaBlock() questions.FormulaBlock {
 	var s questions.FormulaBlock
 	s.Parts = randque_Interpolated()
@@ -514,6 +569,34 @@ func randque_GFAffineLine() questions.GFAffineLine {
 	return s
 }
 
+func randque_GFAngle() questions.GFAngle {
+	var s questions.GFAngle
+	s.Measure = randstring()
+	s.Vertex = randque_CoordExpression()
+	s.MustHaveVertex = randbool()
+
+	return s
+}
+
+func randque_GFCircle() questions.GFCircle {
+	var s questions.GFCircle
+	s.Center = randque_CoordExpression()
+	s.Radius = randstring()
+
+	return s
+}
+
+func randque_GFLine() questions.GFLine {
+	var s questions.GFLine
+	s.Label = randstring()
+	s.Through = randque_CoordExpression()
+	s.A = randque_CoordExpression()
+	s.B = randque_CoordExpression()
+	s.Perpendicular = randbool()
+
+	return s
+}
+
 func randque_GFPoint() questions.GFPoint {
 	var s questions.GFPoint
 	s.Answer = randque_CoordExpression()
@@ -521,6 +604,24 @@ func randque_GFPoint() questions.GFPoint {
 	return s
 }
 
+func randque_GFPointOnCurve() questions.GFPointOnCurve {
+	var s questions.GFPointOnCurve
+	s.Function = randstring()
+	s.Variable = randexp_Variable()
+
+	return s
+}
+
+func randque_GFSegment() questions.GFSegment {
+	var s questions.GFSegment
+	s.From = randque_CoordExpression()
+	s.To = randque_CoordExpression()
+	s.LabelFrom = randstring()
+	s.LabelTo = randstring()
+
+	return s
+}
+
 func randque_GFVector() questions.GFVector {
 	var s questions.GFVector
 	s.Answer = randque_CoordExpression()
@@ -540,11 +641,16 @@ func randque_GFVectorPair() questions.GFVectorPair {
 func randque_GeoField() questions.GeoField {
 	choix := [...]questions.GeoField{
 		randque_GFAffineLine(),
+		randque_GFAngle(),
+		randque_GFCircle(),
+		randque_GFLine(),
 		randque_GFPoint(),
+		randque_GFPointOnCurve(),
+		randque_GFSegment(),
 		randque_GFVector(),
 		randque_GFVectorPair(),
 	}
-	i := rand.Intn(4)
+	i := rand.Intn(9)
 	return choix[i]
 }
 
@@ -556,6 +662,10 @@ func randque_GeometricConstructionFieldBlock() questions.GeometricConstructionFi
 	return s
 }
 
+func randque_Hints() questions.Hints {
+	return questions.Hints(randSliceque_Enonce())
+}
+
 func randque_ImageBlock() questions.ImageBlock {
 	var s questions.ImageBlock
 	s.URL = randstring()
@@ -572,6 +682,15 @@ func randque_Interpolated() questions.Interpolated {
 	return questions.Interpolated(randstring())
 }
 
+func randque_MatchingFieldBlock() questions.MatchingFieldBlock {
+	var s questions.MatchingFieldBlock
+	s.Left = randSliceque_Interpolated()
+	s.Right = randSliceque_Interpolated()
+	s.AdditionalRight = randSliceque_Interpolated()
+
+	return s
+}
+
 func randque_NumberFieldBlock() questions.NumberFieldBlock {
 	var s questions.NumberFieldBlock
 	s.Expression = randstring()
@@ -579,6 +698,21 @@ func randque_NumberFieldBlock() questions.NumberFieldBlock {
 	return s
 }
 
+func randque_NumberLineBlock() questions.NumberLineBlock {
+	var s questions.NumberLineBlock
+	s.Set = randstring()
+	s.Ticks = randSlicestring()
+
+	return s
+}
+
+func randque_NumberLineFieldBlock() questions.NumberLineFieldBlock {
+	var s questions.NumberLineFieldBlock
+	s.Answer = randque_NumberLineBlock()
+
+	return s
+}
+
 func randque_OrderedListFieldBlock() questions.OrderedListFieldBlock {
 	var s questions.OrderedListFieldBlock
 	s.Label = randque_Interpolated()
@@ -591,10 +725,12 @@ func randque_OrderedListFieldBlock() questions.OrderedListFieldBlock {
 func randque_ParameterEntry() questions.ParameterEntry {
 	choix := [...]questions.ParameterEntry{
 		randque_Co(),
+		randque_Ct(),
+		randque_Fn(),
 		randque_In(),
 		randque_Rp(),
 	}
-	i := rand.Intn(3)
+	i := rand.Intn(5)
 	return choix[i]
 }
 
@@ -683,6 +819,19 @@ func randque_TextBlock() questions.TextBlock {
 	return s
 }
 
+func randque_TextFieldBlock() questions.TextFieldBlock {
+	var s questions.TextFieldBlock
+	s.Answers = randSlicestring()
+	s.IgnoreCase = randbool()
+	s.IgnoreAccents = randbool()
+	s.IgnorePunctuation = randbool()
+	s.UseRegexp = randbool()
+	s.Correction = randstring()
+	s.MaxTypos = randint()
+
+	return s
+}
+
 func randque_TextKind() questions.TextKind {
 	choix := [...]questions.TextKind{questions.Text, questions.StaticMath, questions.Expression}
 	i := rand.Intn(len(choix))
@@ -751,6 +900,14 @@ func randque_VectorPairCriterion() questions.VectorPairCriterion {
 	return choix[i]
 }
 
+func randque_WrongAnswerHint() questions.WrongAnswerHint {
+	var s questions.WrongAnswerHint
+	s.Answer = randstring()
+	s.Hint = randstring()
+
+	return s
+}
+
 func randrep_ColorHex() repere.ColorHex {
 	return repere.ColorHex(randstring())
 }
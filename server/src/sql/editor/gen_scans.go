@@ -681,6 +681,7 @@ func scanOneQuestion(row scanner) (Question, error) {
 		&item.Enonce,
 		&item.Parameters,
 		&item.Correction,
+		&item.Hints,
 	)
 	return item, err
 }
@@ -689,7 +690,7 @@ func ScanQuestion(row *sql.Row) (Question, error) { return scanOneQuestion(row)
 
 // SelectAll returns all the items in the questions table.
 func SelectAllQuestions(db DB) (Questions, error) {
-	rows, err := db.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions")
+	rows, err := db.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions")
 	if err != nil {
 		return nil, err
 	}
@@ -698,13 +699,13 @@ func SelectAllQuestions(db DB) (Questions, error) {
 
 // SelectQuestion returns the entry matching 'id'.
 func SelectQuestion(tx DB, id IdQuestion) (Question, error) {
-	row := tx.QueryRow("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions WHERE id = $1", id)
 	return ScanQuestion(row)
 }
 
 // SelectQuestions returns the entry matching the given 'ids'.
 func SelectQuestions(tx DB, ids ...IdQuestion) (Questions, error) {
-	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions WHERE id = ANY($1)", IdQuestionArrayToPQ(ids))
+	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions WHERE id = ANY($1)", IdQuestionArrayToPQ(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -749,28 +750,28 @@ func ScanQuestions(rs *sql.Rows) (Questions, error) {
 // Insert one Question in the database and returns the item with id filled.
 func (item Question) Insert(tx DB) (out Question, err error) {
 	row := tx.QueryRow(`INSERT INTO questions (
-		subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction
+		subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints
 		) VALUES (
-		$1, $2, $3, $4, $5, $6, $7
-		) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction;
-		`, item.Subtitle, item.Difficulty, item.NeedExercice, item.IdGroup, item.Enonce, item.Parameters, item.Correction)
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints;
+		`, item.Subtitle, item.Difficulty, item.NeedExercice, item.IdGroup, item.Enonce, item.Parameters, item.Correction, item.Hints)
 	return ScanQuestion(row)
 }
 
 // Update Question in the database and returns the new version.
 func (item Question) Update(tx DB) (out Question, err error) {
 	row := tx.QueryRow(`UPDATE questions SET (
-		subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction
+		subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints
 		) = (
-		$1, $2, $3, $4, $5, $6, $7
-		) WHERE id = $8 RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction;
-		`, item.Subtitle, item.Difficulty, item.NeedExercice, item.IdGroup, item.Enonce, item.Parameters, item.Correction, item.Id)
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) WHERE id = $9 RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints;
+		`, item.Subtitle, item.Difficulty, item.NeedExercice, item.IdGroup, item.Enonce, item.Parameters, item.Correction, item.Hints, item.Id)
 	return ScanQuestion(row)
 }
 
 // Deletes the Question and returns the item
 func DeleteQuestionById(tx DB, id IdQuestion) (Question, error) {
-	row := tx.QueryRow("DELETE FROM questions WHERE id = $1 RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction;", id)
+	row := tx.QueryRow("DELETE FROM questions WHERE id = $1 RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints;", id)
 	return ScanQuestion(row)
 }
 
@@ -797,7 +798,7 @@ func (items Questions) NeedExercices() []IdExercice {
 }
 
 func SelectQuestionsByNeedExercices(tx DB, needExercices_ ...IdExercice) (Questions, error) {
-	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions WHERE needexercice = ANY($1)", IdExerciceArrayToPQ(needExercices_))
+	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions WHERE needexercice = ANY($1)", IdExerciceArrayToPQ(needExercices_))
 	if err != nil {
 		return nil, err
 	}
@@ -805,7 +806,7 @@ func SelectQuestionsByNeedExercices(tx DB, needExercices_ ...IdExercice) (Questi
 }
 
 func DeleteQuestionsByNeedExercices(tx DB, needExercices_ ...IdExercice) (Questions, error) {
-	rows, err := tx.Query("DELETE FROM questions WHERE needexercice = ANY($1) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction", IdExerciceArrayToPQ(needExercices_))
+	rows, err := tx.Query("DELETE FROM questions WHERE needexercice = ANY($1) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints", IdExerciceArrayToPQ(needExercices_))
 	if err != nil {
 		return nil, err
 	}
@@ -826,7 +827,7 @@ func (items Questions) IdGroups() []IdQuestiongroup {
 }
 
 func SelectQuestionsByIdGroups(tx DB, idGroups_ ...IdQuestiongroup) (Questions, error) {
-	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions WHERE idgroup = ANY($1)", IdQuestiongroupArrayToPQ(idGroups_))
+	rows, err := tx.Query("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions WHERE idgroup = ANY($1)", IdQuestiongroupArrayToPQ(idGroups_))
 	if err != nil {
 		return nil, err
 	}
@@ -834,7 +835,7 @@ func SelectQuestionsByIdGroups(tx DB, idGroups_ ...IdQuestiongroup) (Questions,
 }
 
 func DeleteQuestionsByIdGroups(tx DB, idGroups_ ...IdQuestiongroup) (Questions, error) {
-	rows, err := tx.Query("DELETE FROM questions WHERE idgroup = ANY($1) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction", IdQuestiongroupArrayToPQ(idGroups_))
+	rows, err := tx.Query("DELETE FROM questions WHERE idgroup = ANY($1) RETURNING id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints", IdQuestiongroupArrayToPQ(idGroups_))
 	if err != nil {
 		return nil, err
 	}
@@ -843,7 +844,7 @@ func DeleteQuestionsByIdGroups(tx DB, idGroups_ ...IdQuestiongroup) (Questions,
 
 // SelectQuestionByIdAndNeedExercice return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectQuestionByIdAndNeedExercice(tx DB, id IdQuestion, needExercice OptionalIdExercice) (item Question, found bool, err error) {
-	row := tx.QueryRow("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction FROM questions WHERE Id = $1 AND NeedExercice = $2", id, needExercice)
+	row := tx.QueryRow("SELECT id, subtitle, difficulty, needexercice, idgroup, enonce, parameters, correction, hints FROM questions WHERE Id = $1 AND NeedExercice = $2", id, needExercice)
 	item, err = ScanQuestion(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -40,10 +40,14 @@ type Question struct {
 	// Correction an optional content describing the expected solution,
 	// to be instantiated with the same parameters as [Enonce]
 	Correction questions.Enonce
+
+	// Hints is an optional list of contents, revealed one by one
+	// to the students on demand
+	Hints questions.Hints
 }
 
 func (qu Question) Page() questions.QuestionPage {
-	return questions.QuestionPage{Enonce: qu.Enonce, Parameters: qu.Parameters, Correction: qu.Correction}
+	return questions.QuestionPage{Enonce: qu.Enonce, Parameters: qu.Parameters, Correction: qu.Correction, Hints: qu.Hints}
 }
 
 // Questiongroup groups several variant of the same question
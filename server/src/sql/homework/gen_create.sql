@@ -12,7 +12,10 @@ CREATE TABLE sheets (
 CREATE TABLE sheet_tasks (
     IdSheet integer NOT NULL,
     Index integer NOT NULL,
-    IdTask integer NOT NULL
+    IdTask integer NOT NULL,
+    Optional boolean NOT NULL,
+    Requirement smallint CHECK (Requirement IN (0, 1, 2)) NOT NULL,
+    MinMark integer NOT NULL
 );
 
 CREATE TABLE travails (
@@ -23,7 +26,8 @@ CREATE TABLE travails (
     Deadline timestamp(0) with time zone NOT NULL,
     ShowAfter timestamp(0) with time zone NOT NULL,
     QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
-    QuestionTimeLimit integer NOT NULL
+    QuestionTimeLimit integer NOT NULL,
+    HintPenalty integer NOT NULL
 );
 
 CREATE TABLE travail_exceptions (
@@ -33,6 +37,26 @@ CREATE TABLE travail_exceptions (
     IgnoreForMark boolean NOT NULL
 );
 
+CREATE TABLE travail_groups (
+    IdTravail integer NOT NULL,
+    IdStudentGroup integer NOT NULL,
+    IdSheet integer
+);
+
+CREATE TABLE travail_schedules (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    IdSheet integer NOT NULL,
+    IdTeacher integer NOT NULL,
+    Noted boolean NOT NULL,
+    QuestionRepeat smallint CHECK (QuestionRepeat IN (0, 1)) NOT NULL,
+    QuestionTimeLimit integer NOT NULL,
+    NextShowAfter timestamp(0) with time zone NOT NULL,
+    DeadlineDelay integer NOT NULL,
+    Period integer NOT NULL,
+    Until timestamp(0) with time zone NOT NULL
+);
+
 -- constraints
 ALTER TABLE travails
     ADD UNIQUE (Id, IdSheet);
@@ -73,3 +97,23 @@ ALTER TABLE travail_exceptions
 ALTER TABLE travail_exceptions
     ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
 
+ALTER TABLE travail_groups
+    ADD UNIQUE (IdTravail, IdStudentGroup);
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdTravail) REFERENCES travails ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE travail_groups
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE SET NULL;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdSheet) REFERENCES sheets ON DELETE CASCADE;
+
+ALTER TABLE travail_schedules
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
@@ -19,6 +19,10 @@ func randIdTravail() IdTravail {
 	return IdTravail(randint64())
 }
 
+func randIdTravailSchedule() IdTravailSchedule {
+	return IdTravailSchedule(randint64())
+}
+
 func randOptionalIdTravail() OptionalIdTravail {
 	var s OptionalIdTravail
 	s.Valid = randbool()
@@ -27,6 +31,14 @@ func randOptionalIdTravail() OptionalIdTravail {
 	return s
 }
 
+func randOptionalIdSheet() OptionalIdSheet {
+	var s OptionalIdSheet
+	s.Valid = randbool()
+	s.ID = randIdSheet()
+
+	return s
+}
+
 func randQuestionRepeat() QuestionRepeat {
 	choix := [...]QuestionRepeat{Unlimited, OneTry}
 	i := rand.Intn(len(choix))
@@ -51,10 +63,19 @@ func randSheetTask() SheetTask {
 	s.IdSheet = randIdSheet()
 	s.Index = randint()
 	s.IdTask = randtas_IdTask()
+	s.Optional = randbool()
+	s.Requirement = randTaskRequirement()
+	s.MinMark = randint()
 
 	return s
 }
 
+func randTaskRequirement() TaskRequirement {
+	choix := [...]TaskRequirement{NoRequirement, RequireCompletion, RequireMinMark}
+	i := rand.Intn(len(choix))
+	return choix[i]
+}
+
 func randTime() Time {
 	return Time(randtTime())
 }
@@ -69,6 +90,7 @@ func randTravail() Travail {
 	s.ShowAfter = randTime()
 	s.QuestionRepeat = randQuestionRepeat()
 	s.QuestionTimeLimit = randint()
+	s.HintPenalty = randint()
 
 	return s
 }
@@ -83,6 +105,32 @@ func randTravailException() TravailException {
 	return s
 }
 
+func randTravailGroup() TravailGroup {
+	var s TravailGroup
+	s.IdTravail = randIdTravail()
+	s.IdStudentGroup = randtea_IdStudentGroup()
+	s.IdSheet = randOptionalIdSheet()
+
+	return s
+}
+
+func randTravailSchedule() TravailSchedule {
+	var s TravailSchedule
+	s.Id = randIdTravailSchedule()
+	s.IdClassroom = randtea_IdClassroom()
+	s.IdSheet = randIdSheet()
+	s.IdTeacher = randtea_IdTeacher()
+	s.Noted = randbool()
+	s.QuestionRepeat = randQuestionRepeat()
+	s.QuestionTimeLimit = randint()
+	s.NextShowAfter = randTime()
+	s.DeadlineDelay = randint()
+	s.Period = randint()
+	s.Until = randTime()
+
+	return s
+}
+
 func randbool() bool {
 	i := rand.Int31n(2)
 	return i == 1
@@ -131,6 +179,10 @@ func randtea_IdStudent() teacher.IdStudent {
 	return teacher.IdStudent(randint64())
 }
 
+func randtea_IdStudentGroup() teacher.IdStudentGroup {
+	return teacher.IdStudentGroup(randint64())
+}
+
 func randtea_IdTeacher() teacher.IdTeacher {
 	return teacher.IdTeacher(randint64())
 }
@@ -143,6 +143,9 @@ func scanOneSheetTask(row scanner) (SheetTask, error) {
 		&item.IdSheet,
 		&item.Index,
 		&item.IdTask,
+		&item.Optional,
+		&item.Requirement,
+		&item.MinMark,
 	)
 	return item, err
 }
@@ -151,7 +154,7 @@ func ScanSheetTask(row *sql.Row) (SheetTask, error) { return scanOneSheetTask(ro
 
 // SelectAll returns all the items in the sheet_tasks table.
 func SelectAllSheetTasks(db DB) (SheetTasks, error) {
-	rows, err := db.Query("SELECT idsheet, index, idtask FROM sheet_tasks")
+	rows, err := db.Query("SELECT idsheet, index, idtask, optional, requirement, minmark FROM sheet_tasks")
 	if err != nil {
 		return nil, err
 	}
@@ -187,11 +190,11 @@ func ScanSheetTasks(rs *sql.Rows) (SheetTasks, error) {
 
 func (item SheetTask) Insert(db DB) error {
 	_, err := db.Exec(`INSERT INTO sheet_tasks (
-			idsheet, index, idtask
+			idsheet, index, idtask, optional, requirement, minmark
 			) VALUES (
-			$1, $2, $3
+			$1, $2, $3, $4, $5, $6
 			);
-			`, item.IdSheet, item.Index, item.IdTask)
+			`, item.IdSheet, item.Index, item.IdTask, item.Optional, item.Requirement, item.MinMark)
 	if err != nil {
 		return err
 	}
@@ -209,13 +212,16 @@ func InsertManySheetTasks(tx *sql.Tx, items ...SheetTask) error {
 		"idsheet",
 		"index",
 		"idtask",
+		"optional",
+		"requirement",
+		"minmark",
 	))
 	if err != nil {
 		return err
 	}
 
 	for _, item := range items {
-		_, err = stmt.Exec(item.IdSheet, item.Index, item.IdTask)
+		_, err = stmt.Exec(item.IdSheet, item.Index, item.IdTask, item.Optional, item.Requirement, item.MinMark)
 		if err != nil {
 			return err
 		}
@@ -259,7 +265,7 @@ func (items SheetTasks) IdSheets() []IdSheet {
 }
 
 func SelectSheetTasksByIdSheets(tx DB, idSheets_ ...IdSheet) (SheetTasks, error) {
-	rows, err := tx.Query("SELECT idsheet, index, idtask FROM sheet_tasks WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
+	rows, err := tx.Query("SELECT idsheet, index, idtask, optional, requirement, minmark FROM sheet_tasks WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +273,7 @@ func SelectSheetTasksByIdSheets(tx DB, idSheets_ ...IdSheet) (SheetTasks, error)
 }
 
 func DeleteSheetTasksByIdSheets(tx DB, idSheets_ ...IdSheet) (SheetTasks, error) {
-	rows, err := tx.Query("DELETE FROM sheet_tasks WHERE idsheet = ANY($1) RETURNING idsheet, index, idtask", IdSheetArrayToPQ(idSheets_))
+	rows, err := tx.Query("DELETE FROM sheet_tasks WHERE idsheet = ANY($1) RETURNING idsheet, index, idtask, optional, requirement, minmark", IdSheetArrayToPQ(idSheets_))
 	if err != nil {
 		return nil, err
 	}
@@ -296,7 +302,7 @@ func (items SheetTasks) IdTasks() []tasks.IdTask {
 
 // SelectSheetTaskByIdTask return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectSheetTaskByIdTask(tx DB, idTask tasks.IdTask) (item SheetTask, found bool, err error) {
-	row := tx.QueryRow("SELECT idsheet, index, idtask FROM sheet_tasks WHERE idtask = $1", idTask)
+	row := tx.QueryRow("SELECT idsheet, index, idtask, optional, requirement, minmark FROM sheet_tasks WHERE idtask = $1", idTask)
 	item, err = ScanSheetTask(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -305,7 +311,7 @@ func SelectSheetTaskByIdTask(tx DB, idTask tasks.IdTask) (item SheetTask, found
 }
 
 func SelectSheetTasksByIdTasks(tx DB, idTasks_ ...tasks.IdTask) (SheetTasks, error) {
-	rows, err := tx.Query("SELECT idsheet, index, idtask FROM sheet_tasks WHERE idtask = ANY($1)", tasks.IdTaskArrayToPQ(idTasks_))
+	rows, err := tx.Query("SELECT idsheet, index, idtask, optional, requirement, minmark FROM sheet_tasks WHERE idtask = ANY($1)", tasks.IdTaskArrayToPQ(idTasks_))
 	if err != nil {
 		return nil, err
 	}
@@ -313,7 +319,7 @@ func SelectSheetTasksByIdTasks(tx DB, idTasks_ ...tasks.IdTask) (SheetTasks, err
 }
 
 func DeleteSheetTasksByIdTasks(tx DB, idTasks_ ...tasks.IdTask) (SheetTasks, error) {
-	rows, err := tx.Query("DELETE FROM sheet_tasks WHERE idtask = ANY($1) RETURNING idsheet, index, idtask", tasks.IdTaskArrayToPQ(idTasks_))
+	rows, err := tx.Query("DELETE FROM sheet_tasks WHERE idtask = ANY($1) RETURNING idsheet, index, idtask, optional, requirement, minmark", tasks.IdTaskArrayToPQ(idTasks_))
 	if err != nil {
 		return nil, err
 	}
@@ -322,7 +328,7 @@ func DeleteSheetTasksByIdTasks(tx DB, idTasks_ ...tasks.IdTask) (SheetTasks, err
 
 // SelectSheetTaskByIdSheetAndIndex return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectSheetTaskByIdSheetAndIndex(tx DB, idSheet IdSheet, index int) (item SheetTask, found bool, err error) {
-	row := tx.QueryRow("SELECT idsheet, index, idtask FROM sheet_tasks WHERE IdSheet = $1 AND Index = $2", idSheet, index)
+	row := tx.QueryRow("SELECT idsheet, index, idtask, optional, requirement, minmark FROM sheet_tasks WHERE IdSheet = $1 AND Index = $2", idSheet, index)
 	item, err = ScanSheetTask(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -411,6 +417,7 @@ func scanOneTravail(row scanner) (Travail, error) {
 		&item.ShowAfter,
 		&item.QuestionRepeat,
 		&item.QuestionTimeLimit,
+		&item.HintPenalty,
 	)
 	return item, err
 }
@@ -419,7 +426,7 @@ func ScanTravail(row *sql.Row) (Travail, error) { return scanOneTravail(row) }
 
 // SelectAll returns all the items in the travails table.
 func SelectAllTravails(db DB) (Travails, error) {
-	rows, err := db.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails")
+	rows, err := db.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails")
 	if err != nil {
 		return nil, err
 	}
@@ -428,13 +435,13 @@ func SelectAllTravails(db DB) (Travails, error) {
 
 // SelectTravail returns the entry matching 'id'.
 func SelectTravail(tx DB, id IdTravail) (Travail, error) {
-	row := tx.QueryRow("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails WHERE id = $1", id)
 	return ScanTravail(row)
 }
 
 // SelectTravails returns the entry matching the given 'ids'.
 func SelectTravails(tx DB, ids ...IdTravail) (Travails, error) {
-	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails WHERE id = ANY($1)", IdTravailArrayToPQ(ids))
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails WHERE id = ANY($1)", IdTravailArrayToPQ(ids))
 	if err != nil {
 		return nil, err
 	}
@@ -479,28 +486,28 @@ func ScanTravails(rs *sql.Rows) (Travails, error) {
 // Insert one Travail in the database and returns the item with id filled.
 func (item Travail) Insert(tx DB) (out Travail, err error) {
 	row := tx.QueryRow(`INSERT INTO travails (
-		idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit
+		idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty
 		) VALUES (
-		$1, $2, $3, $4, $5, $6, $7
-		) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit;
-		`, item.IdClassroom, item.IdSheet, item.Noted, item.Deadline, item.ShowAfter, item.QuestionRepeat, item.QuestionTimeLimit)
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty;
+		`, item.IdClassroom, item.IdSheet, item.Noted, item.Deadline, item.ShowAfter, item.QuestionRepeat, item.QuestionTimeLimit, item.HintPenalty)
 	return ScanTravail(row)
 }
 
 // Update Travail in the database and returns the new version.
 func (item Travail) Update(tx DB) (out Travail, err error) {
 	row := tx.QueryRow(`UPDATE travails SET (
-		idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit
+		idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty
 		) = (
-		$1, $2, $3, $4, $5, $6, $7
-		) WHERE id = $8 RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit;
-		`, item.IdClassroom, item.IdSheet, item.Noted, item.Deadline, item.ShowAfter, item.QuestionRepeat, item.QuestionTimeLimit, item.Id)
+		$1, $2, $3, $4, $5, $6, $7, $8
+		) WHERE id = $9 RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty;
+		`, item.IdClassroom, item.IdSheet, item.Noted, item.Deadline, item.ShowAfter, item.QuestionRepeat, item.QuestionTimeLimit, item.HintPenalty, item.Id)
 	return ScanTravail(row)
 }
 
 // Deletes the Travail and returns the item
 func DeleteTravailById(tx DB, id IdTravail) (Travail, error) {
-	row := tx.QueryRow("DELETE FROM travails WHERE id = $1 RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit;", id)
+	row := tx.QueryRow("DELETE FROM travails WHERE id = $1 RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty;", id)
 	return ScanTravail(row)
 }
 
@@ -745,7 +752,7 @@ func (items Travails) IdClassrooms() []teacher.IdClassroom {
 }
 
 func SelectTravailsByIdClassrooms(tx DB, idClassrooms_ ...teacher.IdClassroom) (Travails, error) {
-	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails WHERE idclassroom = ANY($1)", teacher.IdClassroomArrayToPQ(idClassrooms_))
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails WHERE idclassroom = ANY($1)", teacher.IdClassroomArrayToPQ(idClassrooms_))
 	if err != nil {
 		return nil, err
 	}
@@ -753,7 +760,7 @@ func SelectTravailsByIdClassrooms(tx DB, idClassrooms_ ...teacher.IdClassroom) (
 }
 
 func DeleteTravailsByIdClassrooms(tx DB, idClassrooms_ ...teacher.IdClassroom) (Travails, error) {
-	rows, err := tx.Query("DELETE FROM travails WHERE idclassroom = ANY($1) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit", teacher.IdClassroomArrayToPQ(idClassrooms_))
+	rows, err := tx.Query("DELETE FROM travails WHERE idclassroom = ANY($1) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty", teacher.IdClassroomArrayToPQ(idClassrooms_))
 	if err != nil {
 		return nil, err
 	}
@@ -786,7 +793,7 @@ func (items Travails) IdSheets() []IdSheet {
 }
 
 func SelectTravailsByIdSheets(tx DB, idSheets_ ...IdSheet) (Travails, error) {
-	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
 	if err != nil {
 		return nil, err
 	}
@@ -794,7 +801,7 @@ func SelectTravailsByIdSheets(tx DB, idSheets_ ...IdSheet) (Travails, error) {
 }
 
 func DeleteTravailsByIdSheets(tx DB, idSheets_ ...IdSheet) (Travails, error) {
-	rows, err := tx.Query("DELETE FROM travails WHERE idsheet = ANY($1) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit", IdSheetArrayToPQ(idSheets_))
+	rows, err := tx.Query("DELETE FROM travails WHERE idsheet = ANY($1) RETURNING id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty", IdSheetArrayToPQ(idSheets_))
 	if err != nil {
 		return nil, err
 	}
@@ -803,7 +810,7 @@ func DeleteTravailsByIdSheets(tx DB, idSheets_ ...IdSheet) (Travails, error) {
 
 // SelectTravailByIdAndIdSheet return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectTravailByIdAndIdSheet(tx DB, id IdTravail, idSheet IdSheet) (item Travail, found bool, err error) {
-	row := tx.QueryRow("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit FROM travails WHERE Id = $1 AND IdSheet = $2", id, idSheet)
+	row := tx.QueryRow("SELECT id, idclassroom, idsheet, noted, deadline, showafter, questionrepeat, questiontimelimit, hintpenalty FROM travails WHERE Id = $1 AND IdSheet = $2", id, idSheet)
 	item, err = ScanTravail(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -825,6 +832,457 @@ func (s Time) Value() (driver.Value, error) {
 	return pq.NullTime{Time: time.Time(s), Valid: true}.Value()
 }
 
+func scanOneTravailGroup(row scanner) (TravailGroup, error) {
+	var item TravailGroup
+	err := row.Scan(
+		&item.IdTravail,
+		&item.IdStudentGroup,
+		&item.IdSheet,
+	)
+	return item, err
+}
+
+func ScanTravailGroup(row *sql.Row) (TravailGroup, error) { return scanOneTravailGroup(row) }
+
+// SelectAll returns all the items in the travail_groups table.
+func SelectAllTravailGroups(db DB) (TravailGroups, error) {
+	rows, err := db.Query("SELECT idtravail, idstudentgroup, idsheet FROM travail_groups")
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+type TravailGroups []TravailGroup
+
+func ScanTravailGroups(rs *sql.Rows) (TravailGroups, error) {
+	var (
+		item TravailGroup
+		err  error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(TravailGroups, 0, 16)
+	for rs.Next() {
+		item, err = scanOneTravailGroup(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, item)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func (item TravailGroup) Insert(db DB) error {
+	_, err := db.Exec(`INSERT INTO travail_groups (
+			idtravail, idstudentgroup, idsheet
+			) VALUES (
+			$1, $2, $3
+			);
+			`, item.IdTravail, item.IdStudentGroup, item.IdSheet)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Insert the links TravailGroup in the database.
+// It is a no-op if 'items' is empty.
+func InsertManyTravailGroups(tx *sql.Tx, items ...TravailGroup) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("travail_groups",
+		"idtravail",
+		"idstudentgroup",
+		"idsheet",
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = stmt.Exec(item.IdTravail, item.IdStudentGroup, item.IdSheet)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete the link TravailGroup from the database.
+// Only the foreign keys IdTravail, IdStudentGroup fields are used in 'item'.
+func (item TravailGroup) Delete(tx DB) error {
+	_, err := tx.Exec(`DELETE FROM travail_groups WHERE IdTravail = $1 AND IdStudentGroup = $2;`, item.IdTravail, item.IdStudentGroup)
+	return err
+}
+
+// ByIdTravail returns a map with 'IdTravail' as keys.
+func (items TravailGroups) ByIdTravail() map[IdTravail]TravailGroups {
+	out := make(map[IdTravail]TravailGroups)
+	for _, target := range items {
+		out[target.IdTravail] = append(out[target.IdTravail], target)
+	}
+	return out
+}
+
+// IdTravails returns the list of ids of IdTravail
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailGroups) IdTravails() []IdTravail {
+	out := make([]IdTravail, len(items))
+	for index, target := range items {
+		out[index] = target.IdTravail
+	}
+	return out
+}
+
+func SelectTravailGroupsByIdTravails(tx DB, idTravails_ ...IdTravail) (TravailGroups, error) {
+	rows, err := tx.Query("SELECT idtravail, idstudentgroup, idsheet FROM travail_groups WHERE idtravail = ANY($1)", IdTravailArrayToPQ(idTravails_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+func DeleteTravailGroupsByIdTravails(tx DB, idTravails_ ...IdTravail) (TravailGroups, error) {
+	rows, err := tx.Query("DELETE FROM travail_groups WHERE idtravail = ANY($1) RETURNING idtravail, idstudentgroup, idsheet", IdTravailArrayToPQ(idTravails_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+// ByIdStudentGroup returns a map with 'IdStudentGroup' as keys.
+func (items TravailGroups) ByIdStudentGroup() map[teacher.IdStudentGroup]TravailGroups {
+	out := make(map[teacher.IdStudentGroup]TravailGroups)
+	for _, target := range items {
+		out[target.IdStudentGroup] = append(out[target.IdStudentGroup], target)
+	}
+	return out
+}
+
+// IdStudentGroups returns the list of ids of IdStudentGroup
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailGroups) IdStudentGroups() []teacher.IdStudentGroup {
+	out := make([]teacher.IdStudentGroup, len(items))
+	for index, target := range items {
+		out[index] = target.IdStudentGroup
+	}
+	return out
+}
+
+func SelectTravailGroupsByIdStudentGroups(tx DB, idStudentGroups_ ...teacher.IdStudentGroup) (TravailGroups, error) {
+	rows, err := tx.Query("SELECT idtravail, idstudentgroup, idsheet FROM travail_groups WHERE idstudentgroup = ANY($1)", teacher.IdStudentGroupArrayToPQ(idStudentGroups_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+func DeleteTravailGroupsByIdStudentGroups(tx DB, idStudentGroups_ ...teacher.IdStudentGroup) (TravailGroups, error) {
+	rows, err := tx.Query("DELETE FROM travail_groups WHERE idstudentgroup = ANY($1) RETURNING idtravail, idstudentgroup, idsheet", teacher.IdStudentGroupArrayToPQ(idStudentGroups_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+// IdSheets returns the list of non null IdSheet
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailGroups) IdSheets() []IdSheet {
+	var out []IdSheet
+	for _, target := range items {
+		if id := target.IdSheet; id.Valid {
+			out = append(out, id.ID)
+		}
+	}
+	return out
+}
+
+func SelectTravailGroupsByIdSheets(tx DB, idSheets_ ...IdSheet) (TravailGroups, error) {
+	rows, err := tx.Query("SELECT idtravail, idstudentgroup, idsheet FROM travail_groups WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+func DeleteTravailGroupsByIdSheets(tx DB, idSheets_ ...IdSheet) (TravailGroups, error) {
+	rows, err := tx.Query("DELETE FROM travail_groups WHERE idsheet = ANY($1) RETURNING idtravail, idstudentgroup, idsheet", IdSheetArrayToPQ(idSheets_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailGroups(rows)
+}
+
+// SelectTravailGroupByIdTravailAndIdStudentGroup return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectTravailGroupByIdTravailAndIdStudentGroup(tx DB, idTravail IdTravail, idStudentGroup teacher.IdStudentGroup) (item TravailGroup, found bool, err error) {
+	row := tx.QueryRow("SELECT idtravail, idstudentgroup, idsheet FROM travail_groups WHERE IdTravail = $1 AND IdStudentGroup = $2", idTravail, idStudentGroup)
+	item, err = ScanTravailGroup(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func scanOneTravailSchedule(row scanner) (TravailSchedule, error) {
+	var item TravailSchedule
+	err := row.Scan(
+		&item.Id,
+		&item.IdClassroom,
+		&item.IdSheet,
+		&item.IdTeacher,
+		&item.Noted,
+		&item.QuestionRepeat,
+		&item.QuestionTimeLimit,
+		&item.NextShowAfter,
+		&item.DeadlineDelay,
+		&item.Period,
+		&item.Until,
+	)
+	return item, err
+}
+
+func ScanTravailSchedule(row *sql.Row) (TravailSchedule, error) { return scanOneTravailSchedule(row) }
+
+// SelectAll returns all the items in the travail_schedules table.
+func SelectAllTravailSchedules(db DB) (TravailSchedules, error) {
+	rows, err := db.Query("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules")
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+// SelectTravailSchedule returns the entry matching 'id'.
+func SelectTravailSchedule(tx DB, id IdTravailSchedule) (TravailSchedule, error) {
+	row := tx.QueryRow("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE id = $1", id)
+	return ScanTravailSchedule(row)
+}
+
+// SelectTravailSchedules returns the entry matching the given 'ids'.
+func SelectTravailSchedules(tx DB, ids ...IdTravailSchedule) (TravailSchedules, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE id = ANY($1)", IdTravailScheduleArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+type TravailSchedules map[IdTravailSchedule]TravailSchedule
+
+func (m TravailSchedules) IDs() []IdTravailSchedule {
+	out := make([]IdTravailSchedule, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanTravailSchedules(rs *sql.Rows) (TravailSchedules, error) {
+	var (
+		s   TravailSchedule
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(TravailSchedules, 16)
+	for rs.Next() {
+		s, err = scanOneTravailSchedule(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one TravailSchedule in the database and returns the item with id filled.
+func (item TravailSchedule) Insert(tx DB) (out TravailSchedule, err error) {
+	row := tx.QueryRow(`INSERT INTO travail_schedules (
+		idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until
+		) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until;
+		`, item.IdClassroom, item.IdSheet, item.IdTeacher, item.Noted, item.QuestionRepeat, item.QuestionTimeLimit, item.NextShowAfter, item.DeadlineDelay, item.Period, item.Until)
+	return ScanTravailSchedule(row)
+}
+
+// Update TravailSchedule in the database and returns the new version.
+func (item TravailSchedule) Update(tx DB) (out TravailSchedule, err error) {
+	row := tx.QueryRow(`UPDATE travail_schedules SET (
+		idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until
+		) = (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) WHERE id = $11 RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until;
+		`, item.IdClassroom, item.IdSheet, item.IdTeacher, item.Noted, item.QuestionRepeat, item.QuestionTimeLimit, item.NextShowAfter, item.DeadlineDelay, item.Period, item.Until, item.Id)
+	return ScanTravailSchedule(row)
+}
+
+// Deletes the TravailSchedule and returns the item
+func DeleteTravailScheduleById(tx DB, id IdTravailSchedule) (TravailSchedule, error) {
+	row := tx.QueryRow("DELETE FROM travail_schedules WHERE id = $1 RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until;", id)
+	return ScanTravailSchedule(row)
+}
+
+// Deletes the TravailSchedule in the database and returns the ids.
+func DeleteTravailSchedulesByIDs(tx DB, ids ...IdTravailSchedule) ([]IdTravailSchedule, error) {
+	rows, err := tx.Query("DELETE FROM travail_schedules WHERE id = ANY($1) RETURNING id", IdTravailScheduleArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdTravailScheduleArray(rows)
+}
+
+// ByIdClassroom returns a map with 'IdClassroom' as keys.
+func (items TravailSchedules) ByIdClassroom() map[teacher.IdClassroom]TravailSchedules {
+	out := make(map[teacher.IdClassroom]TravailSchedules)
+	for _, target := range items {
+		dict := out[target.IdClassroom]
+		if dict == nil {
+			dict = make(TravailSchedules)
+		}
+		dict[target.Id] = target
+		out[target.IdClassroom] = dict
+	}
+	return out
+}
+
+// IdClassrooms returns the list of ids of IdClassroom
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailSchedules) IdClassrooms() []teacher.IdClassroom {
+	out := make([]teacher.IdClassroom, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdClassroom)
+	}
+	return out
+}
+
+func SelectTravailSchedulesByIdClassrooms(tx DB, idClassrooms_ ...teacher.IdClassroom) (TravailSchedules, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE idclassroom = ANY($1)", teacher.IdClassroomArrayToPQ(idClassrooms_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+func DeleteTravailSchedulesByIdClassrooms(tx DB, idClassrooms_ ...teacher.IdClassroom) (TravailSchedules, error) {
+	rows, err := tx.Query("DELETE FROM travail_schedules WHERE idclassroom = ANY($1) RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until", teacher.IdClassroomArrayToPQ(idClassrooms_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+// ByIdSheet returns a map with 'IdSheet' as keys.
+func (items TravailSchedules) ByIdSheet() map[IdSheet]TravailSchedules {
+	out := make(map[IdSheet]TravailSchedules)
+	for _, target := range items {
+		dict := out[target.IdSheet]
+		if dict == nil {
+			dict = make(TravailSchedules)
+		}
+		dict[target.Id] = target
+		out[target.IdSheet] = dict
+	}
+	return out
+}
+
+// IdSheets returns the list of ids of IdSheet
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailSchedules) IdSheets() []IdSheet {
+	out := make([]IdSheet, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdSheet)
+	}
+	return out
+}
+
+func SelectTravailSchedulesByIdSheets(tx DB, idSheets_ ...IdSheet) (TravailSchedules, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE idsheet = ANY($1)", IdSheetArrayToPQ(idSheets_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+func DeleteTravailSchedulesByIdSheets(tx DB, idSheets_ ...IdSheet) (TravailSchedules, error) {
+	rows, err := tx.Query("DELETE FROM travail_schedules WHERE idsheet = ANY($1) RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until", IdSheetArrayToPQ(idSheets_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+// ByIdTeacher returns a map with 'IdTeacher' as keys.
+func (items TravailSchedules) ByIdTeacher() map[teacher.IdTeacher]TravailSchedules {
+	out := make(map[teacher.IdTeacher]TravailSchedules)
+	for _, target := range items {
+		dict := out[target.IdTeacher]
+		if dict == nil {
+			dict = make(TravailSchedules)
+		}
+		dict[target.Id] = target
+		out[target.IdTeacher] = dict
+	}
+	return out
+}
+
+// IdTeachers returns the list of ids of IdTeacher
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TravailSchedules) IdTeachers() []teacher.IdTeacher {
+	out := make([]teacher.IdTeacher, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdTeacher)
+	}
+	return out
+}
+
+func SelectTravailSchedulesByIdTeachers(tx DB, idTeachers_ ...teacher.IdTeacher) (TravailSchedules, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE idteacher = ANY($1)", teacher.IdTeacherArrayToPQ(idTeachers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
+func DeleteTravailSchedulesByIdTeachers(tx DB, idTeachers_ ...teacher.IdTeacher) (TravailSchedules, error) {
+	rows, err := tx.Query("DELETE FROM travail_schedules WHERE idteacher = ANY($1) RETURNING id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until", teacher.IdTeacherArrayToPQ(idTeachers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTravailSchedules(rows)
+}
+
 func IdSheetArrayToPQ(ids []IdSheet) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -879,6 +1337,33 @@ func ScanIdTravailArray(rs *sql.Rows) ([]IdTravail, error) {
 	return ints, nil
 }
 
+func IdTravailScheduleArrayToPQ(ids []IdTravailSchedule) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdTravailScheduleArray scans the result of a query returning a
+// list of ID's.
+func ScanIdTravailScheduleArray(rs *sql.Rows) ([]IdTravailSchedule, error) {
+	defer rs.Close()
+	ints := make([]IdTravailSchedule, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdTravailSchedule
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func (s *OptionalIdTravail) Scan(src any) error {
 	var tmp sql.NullInt64
 	err := tmp.Scan(src)
@@ -897,3 +1382,22 @@ func (s OptionalIdTravail) Value() (driver.Value, error) {
 		Int64: int64(s.ID),
 		Valid: s.Valid}.Value()
 }
+
+func (s *OptionalIdSheet) Scan(src any) error {
+	var tmp sql.NullInt64
+	err := tmp.Scan(src)
+	if err != nil {
+		return err
+	}
+	*s = OptionalIdSheet{
+		Valid: tmp.Valid,
+		ID:    IdSheet(tmp.Int64),
+	}
+	return nil
+}
+
+func (s OptionalIdSheet) Value() (driver.Value, error) {
+	return sql.NullInt64{
+		Int64: int64(s.ID),
+		Valid: s.Valid}.Value()
+}
@@ -12,8 +12,9 @@ import (
 //go:generate ../../../../../gomacro/cmd/gomacro models.go sql:gen_create.sql go/sqlcrud:gen_scans.go go/randdata:gen_randdata_test.go
 
 type (
-	IdSheet   int64
-	IdTravail int64
+	IdSheet           int64
+	IdTravail         int64
+	IdTravailSchedule int64
 )
 
 // Time is an instant in a day.
@@ -50,6 +51,11 @@ type Travail struct {
 	// When not zero, every question is time limited.
 	// (in seconds, zero means no limit)
 	QuestionTimeLimit int
+
+	// HintPenalty is the number of points removed from
+	// a question mark for each hint used (zero means no penalty).
+	// A question mark is never negative.
+	HintPenalty int
 }
 
 // Sheet is a list of exercices.
@@ -82,6 +88,19 @@ type SheetTask struct {
 	IdSheet IdSheet `gomacro-sql-on-delete:"CASCADE"`
 	Index   int     `json:"-"` // order in the list
 	IdTask  tasks.IdTask
+
+	// Optional tasks are bonus : they are not taken into account
+	// in the sheet mark, and never lock the following tasks.
+	Optional bool
+
+	// Requirement, if not [NoRequirement], restricts the access
+	// to the task until the (non optional) previous tasks are done.
+	Requirement TaskRequirement
+
+	// MinMark is the minimum mark required on each previous task,
+	// as a percentage of its bareme.
+	// It is only used when [Requirement] is [RequireMinMark].
+	MinMark int
 }
 
 // EnsureOrder enforce the slice order indicated by `Index`
@@ -106,3 +125,46 @@ type TravailException struct {
 	// when displaying the average.
 	IgnoreForMark bool
 }
+
+// TravailGroup restricts a [Travail] to some groups of students.
+// A [Travail] without any TravailGroup is assigned to the whole classroom.
+//
+// gomacro:SQL ADD UNIQUE(IdTravail, IdStudentGroup)
+type TravailGroup struct {
+	IdTravail      IdTravail              `gomacro-sql-on-delete:"CASCADE"`
+	IdStudentGroup teacher.IdStudentGroup `gomacro-sql-on-delete:"CASCADE"`
+
+	// [IdSheet] is an optionnal [Sheet] replacing the one
+	// setup in the related [Travail], for the students of the group.
+	// Deleting this sheet makes the group fall back to the [Travail] one.
+	IdSheet OptionalIdSheet `gomacro-sql-on-delete:"SET NULL" gomacro-sql-foreign:"Sheet"`
+}
+
+// TravailSchedule defines recurring [Travail]s : a copy of the model [Sheet]
+// is assigned to the classroom every [Period] days,
+// starting at [NextShowAfter].
+type TravailSchedule struct {
+	Id          IdTravailSchedule
+	IdClassroom teacher.IdClassroom `gomacro-sql-on-delete:"CASCADE"`
+	IdSheet     IdSheet             `gomacro-sql-on-delete:"CASCADE"` // the model sheet
+	// IdTeacher is the owner of the sheets created
+	IdTeacher teacher.IdTeacher `gomacro-sql-on-delete:"CASCADE"`
+
+	// settings copied in each [Travail]
+	Noted             bool
+	QuestionRepeat    QuestionRepeat
+	QuestionTimeLimit int
+
+	// NextShowAfter is the [Travail.ShowAfter] field of the
+	// next [Travail] to create.
+	NextShowAfter Time
+	// DeadlineDelay is the number of hours between [Travail.ShowAfter]
+	// and [Travail.Deadline].
+	DeadlineDelay int
+	// Period is the number of days between two [Travail]s.
+	// A zero value means the schedule is only used once.
+	Period int
+	// Until is the last date a [Travail] may be shown,
+	// or the zero value for a schedule without limit.
+	Until Time
+}
@@ -1,6 +1,7 @@
 package homework
 
 import (
+	"database/sql"
 	"errors"
 
 	"github.com/benoitkugler/maths-online/server/src/sql/tasks"
@@ -17,6 +18,15 @@ func (id IdTravail) AsOptional() OptionalIdTravail {
 	return OptionalIdTravail{ID: id, Valid: true}
 }
 
+type OptionalIdSheet struct {
+	Valid bool
+	ID    IdSheet
+}
+
+func (id IdSheet) AsOptional() OptionalIdSheet {
+	return OptionalIdSheet{ID: id, Valid: true}
+}
+
 func LoadMonoquestionSheet(db DB, idMono tasks.IdMonoquestion) (tasks.IdTask, IdSheet, error) {
 	ts, err := tasks.SelectTasksByIdMonoquestions(db, idMono)
 	if err != nil {
@@ -53,6 +63,17 @@ func LoadRandomMonoquestionSheet(db DB, idMono tasks.IdRandomMonoquestion) (task
 	return link.IdTask, link.IdSheet, nil
 }
 
+// SelectTravailScheduleForUpdate locks the schedule row until the end of [tx].
+// It returns false if the schedule does not exist (anymore).
+func SelectTravailScheduleForUpdate(tx *sql.Tx, id IdTravailSchedule) (TravailSchedule, bool, error) {
+	row := tx.QueryRow("SELECT id, idclassroom, idsheet, idteacher, noted, questionrepeat, questiontimelimit, nextshowafter, deadlinedelay, period, until FROM travail_schedules WHERE id = $1 FOR UPDATE", id)
+	item, err := ScanTravailSchedule(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
 // IsVisibleBy returns `true` if the Sheet is public or
 // owned by `userID`
 func (qu Sheet) IsVisibleBy(userID teacher.IdTeacher) bool {
@@ -74,3 +95,13 @@ const (
 	Unlimited QuestionRepeat = iota // Illimité
 	OneTry                          // Un seul
 )
+
+// TaskRequirement defines the condition
+// to unlock a task in a sheet.
+type TaskRequirement uint8
+
+const (
+	NoRequirement     TaskRequirement = iota // Aucune
+	RequireCompletion                        // Tâches précédentes terminées
+	RequireMinMark                           // Note minimale aux tâches précédentes
+)
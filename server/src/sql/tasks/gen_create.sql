@@ -10,7 +10,9 @@ CREATE TABLE progressions (
     IdStudent integer NOT NULL,
     IdTask integer NOT NULL,
     Index smallint NOT NULL,
-    History boolean[]
+    History boolean[],
+    HintsUsed smallint NOT NULL,
+    LastAttempt jsonb NOT NULL
 );
 
 CREATE TABLE random_monoquestions (
@@ -107,6 +109,42 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_array_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'array' THEN
+        RETURN FALSE;
+    END IF;
+    IF jsonb_array_length(data) = 0 THEN
+        RETURN TRUE;
+    END IF;
+    RETURN (
+        SELECT
+            bool_and(gomacro_validate_json_task_AttemptParam (value))
+        FROM
+            jsonb_array_elements(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) != 'object' OR jsonb_typeof(data -> 'Kind') != 'string' OR jsonb_typeof(data -> 'Data') = 'null' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN data ->> 'Kind' IN ('CategoriesAnswer', 'DoublePointAnswer', 'DoublePointPairAnswer', 'ExpressionAnswer', 'FunctionPointsAnswer', 'MatchingAnswer', 'NumberAnswer', 'NumberLineAnswer', 'OrderedListAnswer', 'PointAnswer', 'ProofAnswer', 'RadioAnswer', 'SetAnswer', 'SignTableAnswer', 'TableAnswer', 'TextAnswer', 'TreeAnswer', 'VariationTableAnswer');
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_edit_DifficultyTag (data jsonb)
     RETURNS boolean
     AS $$
@@ -123,6 +161,125 @@ $$
 LANGUAGE 'plpgsql'
 IMMUTABLE;
 
+CREATE OR REPLACE FUNCTION gomacro_validate_json_expr_Variable (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Indice', 'Name'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_string (data -> 'Indice')
+        AND gomacro_validate_json_number (data -> 'Name');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_map_clie_Answer (data jsonb)
+    RETURNS boolean
+    AS $$
+BEGIN
+    IF jsonb_typeof(data) = 'null' THEN
+        RETURN TRUE;
+    END IF;
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    RETURN (
+        SELECT
+            coalesce(bool_and(gomacro_validate_json_clie_Answer (value)), TRUE)
+        FROM
+            jsonb_each(data));
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_number (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'number';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a number', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_string (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean := jsonb_typeof(data) = 'string';
+BEGIN
+    IF NOT is_valid THEN
+        RAISE WARNING '% is not a string', data;
+    END IF;
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_AttemptParam (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Variable', 'Resolved'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_expr_Variable (data -> 'Variable')
+        AND gomacro_validate_json_string (data -> 'Resolved');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION gomacro_validate_json_task_QuestionAttempt (data jsonb)
+    RETURNS boolean
+    AS $$
+DECLARE
+    is_valid boolean;
+BEGIN
+    IF jsonb_typeof(data) != 'object' THEN
+        RETURN FALSE;
+    END IF;
+    is_valid := (
+        SELECT
+            bool_and(key IN ('Params', 'Answers'))
+        FROM
+            jsonb_each(data))
+        AND gomacro_validate_json_array_task_AttemptParam (data -> 'Params')
+        AND gomacro_validate_json_map_clie_Answer (data -> 'Answers');
+    RETURN is_valid;
+END;
+$$
+LANGUAGE 'plpgsql'
+IMMUTABLE;
+
+ALTER TABLE progressions
+    ADD CONSTRAINT LastAttempt_gomacro CHECK (gomacro_validate_json_task_QuestionAttempt (LastAttempt));
+
 ALTER TABLE random_monoquestions
     ADD CONSTRAINT Difficulty_gomacro CHECK (gomacro_validate_json_array_edit_DifficultyTag (Difficulty));
 
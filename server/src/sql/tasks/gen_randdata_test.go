@@ -3,6 +3,8 @@ package tasks
 import (
 	"math/rand"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	"github.com/benoitkugler/maths-online/server/src/sql/editor"
 	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
 )
@@ -57,6 +59,8 @@ func randProgression() Progression {
 	s.IdTask = randIdTask()
 	s.Index = randint16()
 	s.History = randQuestionHistory()
+	s.HintsUsed = randint16()
+	s.LastAttempt = randQuestionAttempt()
 
 	return s
 }
@@ -65,6 +69,14 @@ func randQuestionHistory() QuestionHistory {
 	return QuestionHistory(randSlicebool())
 }
 
+func randQuestionAttempt() QuestionAttempt {
+	var s QuestionAttempt
+	s.Params = randSliceAttemptParam()
+	s.Answers = randcli_Answers()
+
+	return s
+}
+
 func randRandomMonoquestion() RandomMonoquestion {
 	var s RandomMonoquestion
 	s.Id = randIdRandomMonoquestion()
@@ -86,6 +98,15 @@ func randRandomMonoquestionVariant() RandomMonoquestionVariant {
 	return s
 }
 
+func randSliceAttemptParam() []AttemptParam {
+	l := 3 + rand.Intn(5)
+	out := make([]AttemptParam, l)
+	for i := range out {
+		out[i] = randAttemptParam()
+	}
+	return out
+}
+
 func randSlicebool() []bool {
 	l := 3 + rand.Intn(5)
 	out := make([]bool, l)
@@ -114,11 +135,60 @@ func randTask() Task {
 	return s
 }
 
+func randAttemptParam() AttemptParam {
+	var s AttemptParam
+	s.Variable = randexp_Variable()
+	s.Resolved = randstring()
+
+	return s
+}
+
 func randbool() bool {
 	i := rand.Int31n(2)
 	return i == 1
 }
 
+func randcli_Answer() client.Answer {
+	choix := [...]client.Answer{
+		randcli_ExpressionAnswer(),
+		randcli_NumberAnswer(),
+		randcli_RadioAnswer(),
+	}
+	i := rand.Intn(3)
+
+	return choix[i]
+}
+
+func randcli_Answers() client.Answers {
+	l := 3 + rand.Intn(5)
+	out := make(client.Answers, l)
+	for i := 0; i < l; i++ {
+		out[randint()] = randcli_Answer()
+	}
+	return out
+}
+
+func randcli_ExpressionAnswer() client.ExpressionAnswer {
+	var s client.ExpressionAnswer
+	s.Expression = randstring()
+
+	return s
+}
+
+func randcli_NumberAnswer() client.NumberAnswer {
+	var s client.NumberAnswer
+	s.Value = randfloat64()
+
+	return s
+}
+
+func randcli_RadioAnswer() client.RadioAnswer {
+	var s client.RadioAnswer
+	s.Index = randint()
+
+	return s
+}
+
 func randedi_DifficultyQuery() editor.DifficultyQuery {
 	return editor.DifficultyQuery(randSliceedi_DifficultyTag())
 }
@@ -149,6 +219,18 @@ func randedi_OptionalIdExercice() editor.OptionalIdExercice {
 	return s
 }
 
+func randexp_Variable() expression.Variable {
+	var s expression.Variable
+	s.Indice = randstring()
+	s.Name = randint32()
+
+	return s
+}
+
+func randfloat64() float64 {
+	return rand.Float64() * float64(rand.Int31())
+}
+
 func randint() int {
 	return int(rand.Intn(1000000))
 }
@@ -157,6 +239,10 @@ func randint16() int16 {
 	return int16(rand.Intn(1000000))
 }
 
+func randint32() int32 {
+	return int32(rand.Intn(1000000))
+}
+
 func randint64() int64 {
 	return int64(rand.Intn(1000000))
 }
@@ -164,3 +250,14 @@ func randint64() int64 {
 func randtea_IdStudent() teacher.IdStudent {
 	return teacher.IdStudent(randint64())
 }
+
+var letterRunes2 = []rune("azertyuiopqsdfghjklmwxcvbn123456789é@!?&èïab ")
+
+func randstring() string {
+	b := make([]rune, 10)
+	maxLength := len(letterRunes2)
+	for i := range b {
+		b[i] = letterRunes2[rand.Intn(maxLength)]
+	}
+	return string(b)
+}
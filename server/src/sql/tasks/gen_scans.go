@@ -5,6 +5,8 @@ package tasks
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
 
 	"github.com/benoitkugler/maths-online/server/src/sql/editor"
 	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
@@ -181,6 +183,8 @@ func scanOneProgression(row scanner) (Progression, error) {
 		&item.IdTask,
 		&item.Index,
 		&item.History,
+		&item.HintsUsed,
+		&item.LastAttempt,
 	)
 	return item, err
 }
@@ -189,7 +193,7 @@ func ScanProgression(row *sql.Row) (Progression, error) { return scanOneProgress
 
 // SelectAll returns all the items in the progressions table.
 func SelectAllProgressions(db DB) (Progressions, error) {
-	rows, err := db.Query("SELECT idstudent, idtask, index, history FROM progressions")
+	rows, err := db.Query("SELECT idstudent, idtask, index, history, hintsused, lastattempt FROM progressions")
 	if err != nil {
 		return nil, err
 	}
@@ -225,11 +229,11 @@ func ScanProgressions(rs *sql.Rows) (Progressions, error) {
 
 func (item Progression) Insert(db DB) error {
 	_, err := db.Exec(`INSERT INTO progressions (
-			idstudent, idtask, index, history
+			idstudent, idtask, index, history, hintsused, lastattempt
 			) VALUES (
-			$1, $2, $3, $4
+			$1, $2, $3, $4, $5, $6
 			);
-			`, item.IdStudent, item.IdTask, item.Index, item.History)
+			`, item.IdStudent, item.IdTask, item.Index, item.History, item.HintsUsed, item.LastAttempt)
 	if err != nil {
 		return err
 	}
@@ -248,13 +252,15 @@ func InsertManyProgressions(tx *sql.Tx, items ...Progression) error {
 		"idtask",
 		"index",
 		"history",
+		"hintsused",
+		"lastattempt",
 	))
 	if err != nil {
 		return err
 	}
 
 	for _, item := range items {
-		_, err = stmt.Exec(item.IdStudent, item.IdTask, item.Index, item.History)
+		_, err = stmt.Exec(item.IdStudent, item.IdTask, item.Index, item.History, item.HintsUsed, item.LastAttempt)
 		if err != nil {
 			return err
 		}
@@ -279,7 +285,7 @@ func (item Progression) Delete(tx DB) error {
 
 // SelectProgressionsByIdStudentAndIdTask selects the items matching the given fields.
 func SelectProgressionsByIdStudentAndIdTask(tx DB, idStudent teacher.IdStudent, idTask IdTask) (item Progressions, err error) {
-	rows, err := tx.Query("SELECT idstudent, idtask, index, history FROM progressions WHERE IdStudent = $1 AND IdTask = $2", idStudent, idTask)
+	rows, err := tx.Query("SELECT idstudent, idtask, index, history, hintsused, lastattempt FROM progressions WHERE IdStudent = $1 AND IdTask = $2", idStudent, idTask)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +295,7 @@ func SelectProgressionsByIdStudentAndIdTask(tx DB, idStudent teacher.IdStudent,
 // DeleteProgressionsByIdStudentAndIdTask deletes the item matching the given fields, returning
 // the deleted items.
 func DeleteProgressionsByIdStudentAndIdTask(tx DB, idStudent teacher.IdStudent, idTask IdTask) (item Progressions, err error) {
-	rows, err := tx.Query("DELETE FROM progressions WHERE IdStudent = $1 AND IdTask = $2 RETURNING idstudent, idtask, index, history", idStudent, idTask)
+	rows, err := tx.Query("DELETE FROM progressions WHERE IdStudent = $1 AND IdTask = $2 RETURNING idstudent, idtask, index, history, hintsused, lastattempt", idStudent, idTask)
 	if err != nil {
 		return nil, err
 	}
@@ -317,7 +323,7 @@ func (items Progressions) IdStudents() []teacher.IdStudent {
 }
 
 func SelectProgressionsByIdStudents(tx DB, idStudents_ ...teacher.IdStudent) (Progressions, error) {
-	rows, err := tx.Query("SELECT idstudent, idtask, index, history FROM progressions WHERE idstudent = ANY($1)", teacher.IdStudentArrayToPQ(idStudents_))
+	rows, err := tx.Query("SELECT idstudent, idtask, index, history, hintsused, lastattempt FROM progressions WHERE idstudent = ANY($1)", teacher.IdStudentArrayToPQ(idStudents_))
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +331,7 @@ func SelectProgressionsByIdStudents(tx DB, idStudents_ ...teacher.IdStudent) (Pr
 }
 
 func DeleteProgressionsByIdStudents(tx DB, idStudents_ ...teacher.IdStudent) (Progressions, error) {
-	rows, err := tx.Query("DELETE FROM progressions WHERE idstudent = ANY($1) RETURNING idstudent, idtask, index, history", teacher.IdStudentArrayToPQ(idStudents_))
+	rows, err := tx.Query("DELETE FROM progressions WHERE idstudent = ANY($1) RETURNING idstudent, idtask, index, history, hintsused, lastattempt", teacher.IdStudentArrayToPQ(idStudents_))
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +359,7 @@ func (items Progressions) IdTasks() []IdTask {
 }
 
 func SelectProgressionsByIdTasks(tx DB, idTasks_ ...IdTask) (Progressions, error) {
-	rows, err := tx.Query("SELECT idstudent, idtask, index, history FROM progressions WHERE idtask = ANY($1)", IdTaskArrayToPQ(idTasks_))
+	rows, err := tx.Query("SELECT idstudent, idtask, index, history, hintsused, lastattempt FROM progressions WHERE idtask = ANY($1)", IdTaskArrayToPQ(idTasks_))
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +367,7 @@ func SelectProgressionsByIdTasks(tx DB, idTasks_ ...IdTask) (Progressions, error
 }
 
 func DeleteProgressionsByIdTasks(tx DB, idTasks_ ...IdTask) (Progressions, error) {
-	rows, err := tx.Query("DELETE FROM progressions WHERE idtask = ANY($1) RETURNING idstudent, idtask, index, history", IdTaskArrayToPQ(idTasks_))
+	rows, err := tx.Query("DELETE FROM progressions WHERE idtask = ANY($1) RETURNING idstudent, idtask, index, history, hintsused, lastattempt", IdTaskArrayToPQ(idTasks_))
 	if err != nil {
 		return nil, err
 	}
@@ -370,7 +376,7 @@ func DeleteProgressionsByIdTasks(tx DB, idTasks_ ...IdTask) (Progressions, error
 
 // SelectProgressionByIdStudentAndIdTaskAndIndex return zero or one item, thanks to a UNIQUE SQL constraint.
 func SelectProgressionByIdStudentAndIdTaskAndIndex(tx DB, idStudent teacher.IdStudent, idTask IdTask, index int16) (item Progression, found bool, err error) {
-	row := tx.QueryRow("SELECT idstudent, idtask, index, history FROM progressions WHERE IdStudent = $1 AND IdTask = $2 AND Index = $3", idStudent, idTask, index)
+	row := tx.QueryRow("SELECT idstudent, idtask, index, history, hintsused, lastattempt FROM progressions WHERE IdStudent = $1 AND IdTask = $2 AND Index = $3", idStudent, idTask, index)
 	item, err = ScanProgression(row)
 	if err == sql.ErrNoRows {
 		return item, false, nil
@@ -986,6 +992,28 @@ func (s QuestionHistory) Value() (driver.Value, error) {
 	return pq.BoolArray(s).Value()
 }
 
+func loadJSON(out any, src any) error {
+	if src == nil {
+		return nil //zero value out
+	}
+	bs, ok := src.([]byte)
+	if !ok {
+		return errors.New("not a []byte")
+	}
+	return json.Unmarshal(bs, out)
+}
+
+func dumpJSON(s any) (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return driver.Value(string(b)), nil
+}
+
+func (s *QuestionAttempt) Scan(src any) error          { return loadJSON(s, src) }
+func (s QuestionAttempt) Value() (driver.Value, error) { return dumpJSON(s) }
+
 func IdMonoquestionArrayToPQ(ids []IdMonoquestion) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -69,4 +69,12 @@ type Progression struct {
 	Index int16 `json:"index"`
 
 	History QuestionHistory `json:"history"`
+
+	// HintsUsed is the number of hints revealed
+	// to the student for this question.
+	HintsUsed int16 `json:"hintsUsed"`
+
+	// LastAttempt is the last answer of the student
+	// for this question.
+	LastAttempt QuestionAttempt `json:"lastAttempt"`
 }
@@ -6,6 +6,8 @@ package tasks
 import (
 	"sort"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	"github.com/benoitkugler/maths-online/server/src/sql/editor"
 	"github.com/benoitkugler/maths-online/server/src/utils"
 )
@@ -60,6 +62,24 @@ func (qh QuestionHistory) Stats() (success, failure int) {
 	return
 }
 
+// QuestionAttempt stores an answer of a student, with the
+// random parameters used to instantiate the question, so that
+// teachers may see exactly what the student saw.
+type QuestionAttempt struct {
+	Params  []AttemptParam // empty if the student has not answered yet
+	Answers client.Answers
+}
+
+// AttemptParam is one serialized random parameter,
+// with the same layout as [tasks.VarEntry].
+type AttemptParam struct {
+	Variable expression.Variable
+	Resolved string
+}
+
+// IsEmpty returns true if no answer has been recorded.
+func (qa QuestionAttempt) IsEmpty() bool { return len(qa.Params) == 0 && len(qa.Answers) == 0 }
+
 // EnsureOrder must be call on the questions of one exercice,
 // to make sure the order in the slice is consistent with the one
 // indicated by `Index`
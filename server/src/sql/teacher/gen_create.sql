@@ -20,6 +20,17 @@ CREATE TABLE students (
     Clients jsonb NOT NULL
 );
 
+CREATE TABLE student_groups (
+    Id serial PRIMARY KEY,
+    IdClassroom integer NOT NULL,
+    Name text NOT NULL
+);
+
+CREATE TABLE student_group_members (
+    IdStudentGroup integer NOT NULL,
+    IdStudent integer NOT NULL
+);
+
 CREATE TABLE teachers (
     Id serial PRIMARY KEY,
     Mail text NOT NULL,
@@ -35,6 +46,12 @@ CREATE TABLE teacher_classrooms (
     IdClassroom integer NOT NULL
 );
 
+CREATE TABLE teacher_digests (
+    IdTeacher integer NOT NULL,
+    LastSent timestamp(0) with time zone NOT NULL,
+    LastError text NOT NULL
+);
+
 -- constraints
 ALTER TABLE teachers
     ADD UNIQUE (Mail);
@@ -57,6 +74,18 @@ ALTER TABLE classroom_codes
 ALTER TABLE students
     ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
 
+ALTER TABLE student_groups
+    ADD FOREIGN KEY (IdClassroom) REFERENCES classrooms ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD UNIQUE (IdStudentGroup, IdStudent);
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudentGroup) REFERENCES student_groups ON DELETE CASCADE;
+
+ALTER TABLE student_group_members
+    ADD FOREIGN KEY (IdStudent) REFERENCES students ON DELETE CASCADE;
+
 CREATE OR REPLACE FUNCTION gomacro_validate_json_array_teac_Client (data jsonb)
     RETURNS boolean
     AS $$
@@ -145,3 +174,8 @@ ALTER TABLE students
 ALTER TABLE teachers
     ADD CONSTRAINT Contact_gomacro CHECK (gomacro_validate_json_teac_Contact (Contact));
 
+ALTER TABLE teacher_digests
+    ADD UNIQUE (IdTeacher);
+
+ALTER TABLE teacher_digests
+    ADD FOREIGN KEY (IdTeacher) REFERENCES teachers ON DELETE CASCADE;
@@ -57,6 +57,10 @@ func randIdStudent() IdStudent {
 	return IdStudent(randint64())
 }
 
+func randIdStudentGroup() IdStudentGroup {
+	return IdStudentGroup(randint64())
+}
+
 func randIdTeacher() IdTeacher {
 	return IdTeacher(randint64())
 }
@@ -97,6 +101,23 @@ func randStudent() Student {
 	return s
 }
 
+func randStudentGroup() StudentGroup {
+	var s StudentGroup
+	s.Id = randIdStudentGroup()
+	s.IdClassroom = randIdClassroom()
+	s.Name = randstring()
+
+	return s
+}
+
+func randStudentGroupMember() StudentGroupMember {
+	var s StudentGroupMember
+	s.IdStudentGroup = randIdStudentGroup()
+	s.IdStudent = randIdStudent()
+
+	return s
+}
+
 func randTeacher() Teacher {
 	var s Teacher
 	s.Id = randIdTeacher()
@@ -118,6 +139,15 @@ func randTeacherClassroom() TeacherClassroom {
 	return s
 }
 
+func randTeacherDigest() TeacherDigest {
+	var s TeacherDigest
+	s.IdTeacher = randIdTeacher()
+	s.LastSent = randTime()
+	s.LastError = randstring()
+
+	return s
+}
+
 func randTime() Time {
 	return Time(randtTime())
 }
@@ -432,6 +432,338 @@ func DeleteStudentsByIdClassrooms(tx DB, idClassrooms_ ...IdClassroom) (Students
 	return ScanStudents(rows)
 }
 
+func scanOneStudentGroup(row scanner) (StudentGroup, error) {
+	var item StudentGroup
+	err := row.Scan(
+		&item.Id,
+		&item.IdClassroom,
+		&item.Name,
+	)
+	return item, err
+}
+
+func ScanStudentGroup(row *sql.Row) (StudentGroup, error) { return scanOneStudentGroup(row) }
+
+// SelectAll returns all the items in the student_groups table.
+func SelectAllStudentGroups(db DB) (StudentGroups, error) {
+	rows, err := db.Query("SELECT id, idclassroom, name FROM student_groups")
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroups(rows)
+}
+
+// SelectStudentGroup returns the entry matching 'id'.
+func SelectStudentGroup(tx DB, id IdStudentGroup) (StudentGroup, error) {
+	row := tx.QueryRow("SELECT id, idclassroom, name FROM student_groups WHERE id = $1", id)
+	return ScanStudentGroup(row)
+}
+
+// SelectStudentGroups returns the entry matching the given 'ids'.
+func SelectStudentGroups(tx DB, ids ...IdStudentGroup) (StudentGroups, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, name FROM student_groups WHERE id = ANY($1)", IdStudentGroupArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroups(rows)
+}
+
+type StudentGroups map[IdStudentGroup]StudentGroup
+
+func (m StudentGroups) IDs() []IdStudentGroup {
+	out := make([]IdStudentGroup, 0, len(m))
+	for i := range m {
+		out = append(out, i)
+	}
+	return out
+}
+
+func ScanStudentGroups(rs *sql.Rows) (StudentGroups, error) {
+	var (
+		s   StudentGroup
+		err error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(StudentGroups, 16)
+	for rs.Next() {
+		s, err = scanOneStudentGroup(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs[s.Id] = s
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+// Insert one StudentGroup in the database and returns the item with id filled.
+func (item StudentGroup) Insert(tx DB) (out StudentGroup, err error) {
+	row := tx.QueryRow(`INSERT INTO student_groups (
+		idclassroom, name
+		) VALUES (
+		$1, $2
+		) RETURNING id, idclassroom, name;
+		`, item.IdClassroom, item.Name)
+	return ScanStudentGroup(row)
+}
+
+// Update StudentGroup in the database and returns the new version.
+func (item StudentGroup) Update(tx DB) (out StudentGroup, err error) {
+	row := tx.QueryRow(`UPDATE student_groups SET (
+		idclassroom, name
+		) = (
+		$1, $2
+		) WHERE id = $3 RETURNING id, idclassroom, name;
+		`, item.IdClassroom, item.Name, item.Id)
+	return ScanStudentGroup(row)
+}
+
+// Deletes the StudentGroup and returns the item
+func DeleteStudentGroupById(tx DB, id IdStudentGroup) (StudentGroup, error) {
+	row := tx.QueryRow("DELETE FROM student_groups WHERE id = $1 RETURNING id, idclassroom, name;", id)
+	return ScanStudentGroup(row)
+}
+
+// Deletes the StudentGroup in the database and returns the ids.
+func DeleteStudentGroupsByIDs(tx DB, ids ...IdStudentGroup) ([]IdStudentGroup, error) {
+	rows, err := tx.Query("DELETE FROM student_groups WHERE id = ANY($1) RETURNING id", IdStudentGroupArrayToPQ(ids))
+	if err != nil {
+		return nil, err
+	}
+	return ScanIdStudentGroupArray(rows)
+}
+
+// ByIdClassroom returns a map with 'IdClassroom' as keys.
+func (items StudentGroups) ByIdClassroom() map[IdClassroom]StudentGroups {
+	out := make(map[IdClassroom]StudentGroups)
+	for _, target := range items {
+		dict := out[target.IdClassroom]
+		if dict == nil {
+			dict = make(StudentGroups)
+		}
+		dict[target.Id] = target
+		out[target.IdClassroom] = dict
+	}
+	return out
+}
+
+// IdClassrooms returns the list of ids of IdClassroom
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items StudentGroups) IdClassrooms() []IdClassroom {
+	out := make([]IdClassroom, 0, len(items))
+	for _, target := range items {
+		out = append(out, target.IdClassroom)
+	}
+	return out
+}
+
+func SelectStudentGroupsByIdClassrooms(tx DB, idClassrooms_ ...IdClassroom) (StudentGroups, error) {
+	rows, err := tx.Query("SELECT id, idclassroom, name FROM student_groups WHERE idclassroom = ANY($1)", IdClassroomArrayToPQ(idClassrooms_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroups(rows)
+}
+
+func DeleteStudentGroupsByIdClassrooms(tx DB, idClassrooms_ ...IdClassroom) (StudentGroups, error) {
+	rows, err := tx.Query("DELETE FROM student_groups WHERE idclassroom = ANY($1) RETURNING id, idclassroom, name", IdClassroomArrayToPQ(idClassrooms_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroups(rows)
+}
+
+func scanOneStudentGroupMember(row scanner) (StudentGroupMember, error) {
+	var item StudentGroupMember
+	err := row.Scan(
+		&item.IdStudentGroup,
+		&item.IdStudent,
+	)
+	return item, err
+}
+
+func ScanStudentGroupMember(row *sql.Row) (StudentGroupMember, error) {
+	return scanOneStudentGroupMember(row)
+}
+
+// SelectAll returns all the items in the student_group_members table.
+func SelectAllStudentGroupMembers(db DB) (StudentGroupMembers, error) {
+	rows, err := db.Query("SELECT idstudentgroup, idstudent FROM student_group_members")
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroupMembers(rows)
+}
+
+type StudentGroupMembers []StudentGroupMember
+
+func ScanStudentGroupMembers(rs *sql.Rows) (StudentGroupMembers, error) {
+	var (
+		item StudentGroupMember
+		err  error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(StudentGroupMembers, 0, 16)
+	for rs.Next() {
+		item, err = scanOneStudentGroupMember(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, item)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func (item StudentGroupMember) Insert(db DB) error {
+	_, err := db.Exec(`INSERT INTO student_group_members (
+			idstudentgroup, idstudent
+			) VALUES (
+			$1, $2
+			);
+			`, item.IdStudentGroup, item.IdStudent)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Insert the links StudentGroupMember in the database.
+// It is a no-op if 'items' is empty.
+func InsertManyStudentGroupMembers(tx *sql.Tx, items ...StudentGroupMember) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("student_group_members",
+		"idstudentgroup",
+		"idstudent",
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = stmt.Exec(item.IdStudentGroup, item.IdStudent)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete the link StudentGroupMember from the database.
+// Only the foreign keys IdStudentGroup, IdStudent fields are used in 'item'.
+func (item StudentGroupMember) Delete(tx DB) error {
+	_, err := tx.Exec(`DELETE FROM student_group_members WHERE IdStudentGroup = $1 AND IdStudent = $2;`, item.IdStudentGroup, item.IdStudent)
+	return err
+}
+
+// ByIdStudentGroup returns a map with 'IdStudentGroup' as keys.
+func (items StudentGroupMembers) ByIdStudentGroup() map[IdStudentGroup]StudentGroupMembers {
+	out := make(map[IdStudentGroup]StudentGroupMembers)
+	for _, target := range items {
+		out[target.IdStudentGroup] = append(out[target.IdStudentGroup], target)
+	}
+	return out
+}
+
+// IdStudentGroups returns the list of ids of IdStudentGroup
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items StudentGroupMembers) IdStudentGroups() []IdStudentGroup {
+	out := make([]IdStudentGroup, len(items))
+	for index, target := range items {
+		out[index] = target.IdStudentGroup
+	}
+	return out
+}
+
+func SelectStudentGroupMembersByIdStudentGroups(tx DB, idStudentGroups_ ...IdStudentGroup) (StudentGroupMembers, error) {
+	rows, err := tx.Query("SELECT idstudentgroup, idstudent FROM student_group_members WHERE idstudentgroup = ANY($1)", IdStudentGroupArrayToPQ(idStudentGroups_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroupMembers(rows)
+}
+
+func DeleteStudentGroupMembersByIdStudentGroups(tx DB, idStudentGroups_ ...IdStudentGroup) (StudentGroupMembers, error) {
+	rows, err := tx.Query("DELETE FROM student_group_members WHERE idstudentgroup = ANY($1) RETURNING idstudentgroup, idstudent", IdStudentGroupArrayToPQ(idStudentGroups_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroupMembers(rows)
+}
+
+// ByIdStudent returns a map with 'IdStudent' as keys.
+func (items StudentGroupMembers) ByIdStudent() map[IdStudent]StudentGroupMembers {
+	out := make(map[IdStudent]StudentGroupMembers)
+	for _, target := range items {
+		out[target.IdStudent] = append(out[target.IdStudent], target)
+	}
+	return out
+}
+
+// IdStudents returns the list of ids of IdStudent
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items StudentGroupMembers) IdStudents() []IdStudent {
+	out := make([]IdStudent, len(items))
+	for index, target := range items {
+		out[index] = target.IdStudent
+	}
+	return out
+}
+
+func SelectStudentGroupMembersByIdStudents(tx DB, idStudents_ ...IdStudent) (StudentGroupMembers, error) {
+	rows, err := tx.Query("SELECT idstudentgroup, idstudent FROM student_group_members WHERE idstudent = ANY($1)", IdStudentArrayToPQ(idStudents_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroupMembers(rows)
+}
+
+func DeleteStudentGroupMembersByIdStudents(tx DB, idStudents_ ...IdStudent) (StudentGroupMembers, error) {
+	rows, err := tx.Query("DELETE FROM student_group_members WHERE idstudent = ANY($1) RETURNING idstudentgroup, idstudent", IdStudentArrayToPQ(idStudents_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanStudentGroupMembers(rows)
+}
+
+// SelectStudentGroupMemberByIdStudentGroupAndIdStudent return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectStudentGroupMemberByIdStudentGroupAndIdStudent(tx DB, idStudentGroup IdStudentGroup, idStudent IdStudent) (item StudentGroupMember, found bool, err error) {
+	row := tx.QueryRow("SELECT idstudentgroup, idstudent FROM student_group_members WHERE IdStudentGroup = $1 AND IdStudent = $2", idStudentGroup, idStudent)
+	item, err = ScanStudentGroupMember(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
 func scanOneTeacher(row scanner) (Teacher, error) {
 	var item Teacher
 	err := row.Scan(
@@ -737,6 +1069,153 @@ func SelectTeacherByMail(tx DB, mail string) (item Teacher, found bool, err erro
 	return item, true, err
 }
 
+func scanOneTeacherDigest(row scanner) (TeacherDigest, error) {
+	var item TeacherDigest
+	err := row.Scan(
+		&item.IdTeacher,
+		&item.LastSent,
+		&item.LastError,
+	)
+	return item, err
+}
+
+func ScanTeacherDigest(row *sql.Row) (TeacherDigest, error) { return scanOneTeacherDigest(row) }
+
+// SelectAll returns all the items in the teacher_digests table.
+func SelectAllTeacherDigests(db DB) (TeacherDigests, error) {
+	rows, err := db.Query("SELECT idteacher, lastsent, lasterror FROM teacher_digests")
+	if err != nil {
+		return nil, err
+	}
+	return ScanTeacherDigests(rows)
+}
+
+type TeacherDigests []TeacherDigest
+
+func ScanTeacherDigests(rs *sql.Rows) (TeacherDigests, error) {
+	var (
+		item TeacherDigest
+		err  error
+	)
+	defer func() {
+		errClose := rs.Close()
+		if err == nil {
+			err = errClose
+		}
+	}()
+	structs := make(TeacherDigests, 0, 16)
+	for rs.Next() {
+		item, err = scanOneTeacherDigest(rs)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, item)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return structs, nil
+}
+
+func (item TeacherDigest) Insert(db DB) error {
+	_, err := db.Exec(`INSERT INTO teacher_digests (
+			idteacher, lastsent, lasterror
+			) VALUES (
+			$1, $2, $3
+			);
+			`, item.IdTeacher, item.LastSent, item.LastError)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Insert the links TeacherDigest in the database.
+// It is a no-op if 'items' is empty.
+func InsertManyTeacherDigests(tx *sql.Tx, items ...TeacherDigest) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("teacher_digests",
+		"idteacher",
+		"lastsent",
+		"lasterror",
+	))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = stmt.Exec(item.IdTeacher, item.LastSent, item.LastError)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete the link TeacherDigest from the database.
+// Only the foreign keys IdTeacher fields are used in 'item'.
+func (item TeacherDigest) Delete(tx DB) error {
+	_, err := tx.Exec(`DELETE FROM teacher_digests WHERE IdTeacher = $1;`, item.IdTeacher)
+	return err
+}
+
+// ByIdTeacher returns a map with 'IdTeacher' as keys.
+func (items TeacherDigests) ByIdTeacher() map[IdTeacher]TeacherDigest {
+	out := make(map[IdTeacher]TeacherDigest, len(items))
+	for _, target := range items {
+		out[target.IdTeacher] = target
+	}
+	return out
+}
+
+// IdTeachers returns the list of ids of IdTeacher
+// contained in this table.
+// They are not garanteed to be distinct.
+func (items TeacherDigests) IdTeachers() []IdTeacher {
+	out := make([]IdTeacher, len(items))
+	for index, target := range items {
+		out[index] = target.IdTeacher
+	}
+	return out
+}
+
+// SelectTeacherDigestByIdTeacher return zero or one item, thanks to a UNIQUE SQL constraint.
+func SelectTeacherDigestByIdTeacher(tx DB, idTeacher IdTeacher) (item TeacherDigest, found bool, err error) {
+	row := tx.QueryRow("SELECT idteacher, lastsent, lasterror FROM teacher_digests WHERE idteacher = $1", idTeacher)
+	item, err = ScanTeacherDigest(row)
+	if err == sql.ErrNoRows {
+		return item, false, nil
+	}
+	return item, true, err
+}
+
+func SelectTeacherDigestsByIdTeachers(tx DB, idTeachers_ ...IdTeacher) (TeacherDigests, error) {
+	rows, err := tx.Query("SELECT idteacher, lastsent, lasterror FROM teacher_digests WHERE idteacher = ANY($1)", IdTeacherArrayToPQ(idTeachers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTeacherDigests(rows)
+}
+
+func DeleteTeacherDigestsByIdTeachers(tx DB, idTeachers_ ...IdTeacher) (TeacherDigests, error) {
+	rows, err := tx.Query("DELETE FROM teacher_digests WHERE idteacher = ANY($1) RETURNING idteacher, lastsent, lasterror", IdTeacherArrayToPQ(idTeachers_))
+	if err != nil {
+		return nil, err
+	}
+	return ScanTeacherDigests(rows)
+}
+
 func loadJSON(out any, src any) error {
 	if src == nil {
 		return nil //zero value out
@@ -838,6 +1317,33 @@ func ScanIdStudentArray(rs *sql.Rows) ([]IdStudent, error) {
 	return ints, nil
 }
 
+func IdStudentGroupArrayToPQ(ids []IdStudentGroup) pq.Int64Array {
+	out := make(pq.Int64Array, len(ids))
+	for i, v := range ids {
+		out[i] = int64(v)
+	}
+	return out
+}
+
+// ScanIdStudentGroupArray scans the result of a query returning a
+// list of ID's.
+func ScanIdStudentGroupArray(rs *sql.Rows) ([]IdStudentGroup, error) {
+	defer rs.Close()
+	ints := make([]IdStudentGroup, 0, 16)
+	var err error
+	for rs.Next() {
+		var s IdStudentGroup
+		if err = rs.Scan(&s); err != nil {
+			return nil, err
+		}
+		ints = append(ints, s)
+	}
+	if err = rs.Err(); err != nil {
+		return nil, err
+	}
+	return ints, nil
+}
+
 func IdTeacherArrayToPQ(ids []IdTeacher) pq.Int64Array {
 	out := make(pq.Int64Array, len(ids))
 	for i, v := range ids {
@@ -4,9 +4,10 @@ package teacher
 //go:generate ../../../../../gomacro/cmd/gomacro models.go sql:gen_create.sql go/sqlcrud:gen_scans.go go/randdata:gen_randdata_test.go
 
 type (
-	IdTeacher   int64
-	IdClassroom int64
-	IdStudent   int64
+	IdTeacher      int64
+	IdClassroom    int64
+	IdStudent      int64
+	IdStudentGroup int64
 )
 
 // Teacher stores the data associated to one teacher account
@@ -65,3 +66,32 @@ type Student struct {
 
 	Clients Clients
 }
+
+// StudentGroup is a named subset of the students of a classroom
+// (for instance "soutien" or "approfondissement"), used to
+// differentiate the travaux.
+type StudentGroup struct {
+	Id          IdStudentGroup
+	IdClassroom IdClassroom `gomacro-sql-on-delete:"CASCADE"`
+	Name        string
+}
+
+// StudentGroupMember is a link table storing the
+// students of a [StudentGroup].
+// A student may belong to several groups.
+//
+// gomacro:SQL ADD UNIQUE(IdStudentGroup, IdStudent)
+type StudentGroupMember struct {
+	IdStudentGroup IdStudentGroup `gomacro-sql-on-delete:"CASCADE"`
+	IdStudent      IdStudent      `gomacro-sql-on-delete:"CASCADE"`
+}
+
+// TeacherDigest stores the teachers who opted in
+// for the weekly digest mail.
+//
+// gomacro:SQL ADD UNIQUE(IdTeacher)
+type TeacherDigest struct {
+	IdTeacher IdTeacher `gomacro-sql-on-delete:"CASCADE"`
+	LastSent  Time      // zero before the first mail
+	LastError string    // empty if the last mail was sent successfully
+}
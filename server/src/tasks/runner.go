@@ -26,6 +26,7 @@ type InstantiatedQuestion struct {
 	Question   client.Question
 	Difficulty ed.DifficultyTag
 	Params     Params
+	NbHints    int // the number of hints available, revealed one by one
 }
 
 type AnswerP struct {
@@ -33,6 +34,15 @@ type AnswerP struct {
 	Answer client.QuestionAnswersIn
 }
 
+// toAttempt returns the answer in the format stored in progressions.
+func (answer AnswerP) toAttempt() ta.QuestionAttempt {
+	params := make([]ta.AttemptParam, len(answer.Params))
+	for i, entry := range answer.Params {
+		params[i] = ta.AttemptParam(entry)
+	}
+	return ta.QuestionAttempt{Params: params, Answers: answer.Answer.Data}
+}
+
 type InstantiatedQuestionsOut []InstantiatedQuestion
 
 type VarEntry struct {
@@ -86,6 +96,7 @@ func InstantiateQuestions(db ed.DB, ids []ed.IdQuestion) (InstantiatedQuestionsO
 			Question:   instance.ToClient(),
 			Difficulty: qu.Difficulty,
 			Params:     NewParams(vars),
+			NbHints:    len(qu.Hints),
 		}
 	}
 
@@ -229,21 +240,58 @@ func InstantiateWork(db *sql.DB, work WorkID, student tc.IdStudent) (Instantiate
 	return loader.Instantiate()
 }
 
+// ShowWorkHintIn is the input of the standalone endpoint
+// revealing a hint, without recording its usage.
+type ShowWorkHintIn struct {
+	ID            WorkID
+	QuestionIndex int
+	Params        Params // as sent with the question
+	HintIndex     int    // index of the hint to show, starting at 0
+}
+
+// InstantiateHint returns the hint [hintIndex] of the question [questionIndex] of the given work,
+// instantiated with [params], which are the parameters sent with the question.
+func InstantiateHint(db ed.DB, work WorkID, student tc.IdStudent, questionIndex int, params Params, hintIndex int) (client.Enonce, error) {
+	loader, err := newWorkLoader(db, work, student)
+	if err != nil {
+		return nil, err
+	}
+
+	qus := loader.Questions()
+	if questionIndex < 0 || questionIndex >= len(qus) {
+		return nil, fmt.Errorf("internal error in InstantiateHint(work=%v,student=%d): invalid question index %d", work, student, questionIndex)
+	}
+
+	vars, err := params.ToMap()
+	if err != nil {
+		return nil, err
+	}
+
+	return qus[questionIndex].Page().InstantiateHint(vars, hintIndex)
+}
+
+// instantiateQuestion generates the random parameters of [question],
+// completed by [sharedVars] for questions in exercices.
+func instantiateQuestion(question ed.Question, sharedVars expression.Vars) (questions.QuestionInstance, expression.Vars, error) {
+	ownVars, err := question.Parameters.ToMap().Instantiate()
+	if err != nil {
+		return questions.QuestionInstance{}, nil, err
+	}
+
+	if question.NeedExercice.Valid {
+		// merge the parameters, given higher precedence to question
+		ownVars.CompleteFrom(sharedVars)
+	}
+
+	instance, err := question.Page().InstantiateWith(ownVars)
+	return instance, ownVars, err
+}
+
 func instantiateQuestions(questions []ed.Question, sharedVars expression.Vars) ([]InstantiatedQuestion, error) {
 	out := make([]InstantiatedQuestion, len(questions))
 
 	for index, question := range questions {
-		ownVars, err := question.Parameters.ToMap().Instantiate()
-		if err != nil {
-			return nil, err
-		}
-
-		if question.NeedExercice.Valid {
-			// merge the parameters, given higher precedence to question
-			ownVars.CompleteFrom(sharedVars)
-		}
-
-		instance, err := question.Page().InstantiateWith(ownVars)
+		instance, ownVars, err := instantiateQuestion(question, sharedVars)
 		if err != nil {
 			return nil, err
 		}
@@ -253,6 +301,7 @@ func instantiateQuestions(questions []ed.Question, sharedVars expression.Vars) (
 			Question:   instance.ToClient(),
 			Difficulty: question.Difficulty,
 			Params:     NewParams(ownVars),
+			NbHints:    len(question.Hints),
 		}
 	}
 
@@ -668,6 +717,7 @@ type InstantiatedBeltQuestion struct {
 	Id       ce.IdBeltquestion
 	Question client.Question
 	Params   Params // for the evaluation
+	NbHints  int    // the number of hints available
 }
 
 type BeltResult []client.QuestionAnswersOut
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions"
 	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
@@ -160,6 +161,27 @@ func Test_inferNextQuestion(t *testing.T) {
 	}
 }
 
+func TestTaskBareme_ComputeMarkWithHints(t *testing.T) {
+	bareme := TaskBareme{2, 3, 1}
+	for _, test := range []struct {
+		progression Progression
+		hints       HintsUsage
+		penalty     int
+		exp         int
+	}{
+		{nil, HintsUsage{1, 1, 1}, 1, 0},
+		{Progression{{true}, {false, true}, {}}, nil, 1, 5},
+		{Progression{{true}, {false, true}, {}}, HintsUsage{0, 0, 0}, 1, 5},
+		{Progression{{true}, {false, true}, {}}, HintsUsage{1, 2, 0}, 0, 5},
+		{Progression{{true}, {false, true}, {}}, HintsUsage{1, 2, 0}, 1, 2},
+		{Progression{{true}, {false, true}, {}}, HintsUsage{3, 0, 4}, 1, 3}, // never negative
+		{Progression{{true}, {false}, {true}}, HintsUsage{0, 2, 0}, 1, 3},   // failed questions are ignored
+	} {
+		tu.Assert(t, bareme.ComputeMarkWithHints(test.progression, test.hints, test.penalty) == test.exp)
+	}
+	tu.Assert(t, bareme.ComputeMark(Progression{{true}, {true}, {true}}) == 6)
+}
+
 func TestProgression(t *testing.T) {
 	db := tu.NewTestDB(t, "../sql/teacher/gen_create.sql", "../sql/editor/gen_create.sql", "../sql/tasks/gen_create.sql")
 	defer db.Remove()
@@ -182,14 +204,14 @@ func TestProgression(t *testing.T) {
 	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
 		{false, true},
 		{},
-	})
+	}, 0, ta.QuestionAttempt{})
 	tu.Assert(t, err != nil) // invalid number of questions
 
 	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
 		{false, true},
 		{},
 		{},
-	})
+	}, 0, ta.QuestionAttempt{})
 	tu.AssertNoErr(t, err)
 
 	out, err := LoadTasksProgression(db, student.Id, []ta.IdTask{task.Id})
@@ -203,19 +225,48 @@ func TestProgression(t *testing.T) {
 	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
 		{false, true},
 		{},
-	})
+	}, 0, ta.QuestionAttempt{})
 	tu.Assert(t, err != nil) // invalid number of questions
 	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
 		{false, true},
 		{},
 		{},
-	})
+	}, 0, ta.QuestionAttempt{})
 	tu.AssertNoErr(t, err)
 
 	out, err = LoadTasksProgression(db, student.Id, []ta.IdTask{task.Id})
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, out[task.Id].HasProgression)
 
+	// hints usage is kept by updates
+	tu.AssertNoErr(t, RecordHintUsage(db, student.Id, task.Id, 1, 0))
+	tu.AssertNoErr(t, RecordHintUsage(db, student.Id, task.Id, 2, 1))
+	tu.AssertNoErr(t, RecordHintUsage(db, student.Id, task.Id, 2, 0)) // already seen
+	attempt := ta.QuestionAttempt{
+		Params:  []ta.AttemptParam{{Variable: expression.NewVar('a'), Resolved: "4"}},
+		Answers: client.Answers{0: client.NumberAnswer{Value: 4}},
+	}
+	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
+		{false, true},
+		{true},
+		{},
+	}, 1, attempt)
+	tu.AssertNoErr(t, err)
+	out, err = LoadTasksProgression(db, student.Id, []ta.IdTask{task.Id})
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, reflect.DeepEqual(out[task.Id].HintsUsed(), HintsUsage{0, 1, 2}))
+
+	// the last answers are kept by updates
+	err = updateProgression(db.DB, student.Id, task.Id, []ta.QuestionHistory{
+		{false, true},
+		{true},
+		{false},
+	}, 2, ta.QuestionAttempt{})
+	tu.AssertNoErr(t, err)
+	link, found, err := ta.SelectProgressionByIdStudentAndIdTaskAndIndex(db, student.Id, task.Id, 1)
+	tu.AssertNoErr(t, err)
+	tu.Assert(t, found && reflect.DeepEqual(link.LastAttempt, attempt))
+
 	// test with random mono
 	task, err = ta.Task{IdRandomMonoquestion: randomMono.Id.AsOptional()}.Insert(db.DB)
 	tu.AssertNoErr(t, err)
@@ -234,7 +285,7 @@ func TestProgression(t *testing.T) {
 		{},
 		{},
 		{false, true},
-	})
+	}, 0, ta.QuestionAttempt{})
 	tu.AssertNoErr(t, err)
 
 	out, err = LoadTasksProgression(db, student.Id, []ta.IdTask{task.Id})
@@ -312,3 +363,20 @@ func TestRandomMonoquestion(t *testing.T) {
 	tu.AssertNoErr(t, err)
 	tu.Assert(t, reflect.DeepEqual(selected, out.selectedQuestions))
 }
+
+func TestAnswerToAttempt(t *testing.T) {
+	answer := AnswerP{
+		Params: Params{{Variable: expression.NewVar('a'), Resolved: "4"}},
+		Answer: client.QuestionAnswersIn{Data: client.Answers{0: client.NumberAnswer{Value: 4}, 2: client.RadioAnswer{Index: 1}}},
+	}
+	attempt := answer.toAttempt()
+	tu.Assert(t, !attempt.IsEmpty())
+	tu.Assert(t, ta.QuestionAttempt{}.IsEmpty())
+
+	// check the DB roundtrip
+	value, err := attempt.Value()
+	tu.AssertNoErr(t, err)
+	var scanned ta.QuestionAttempt
+	tu.AssertNoErr(t, scanned.Scan([]byte(value.(string))))
+	tu.Assert(t, reflect.DeepEqual(scanned, attempt))
+}
@@ -2,9 +2,13 @@ package tasks
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 
+	"github.com/benoitkugler/maths-online/server/src/maths/expression"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions"
+	"github.com/benoitkugler/maths-online/server/src/maths/questions/client"
 	ed "github.com/benoitkugler/maths-online/server/src/sql/editor"
 	ta "github.com/benoitkugler/maths-online/server/src/sql/tasks"
 	"github.com/benoitkugler/maths-online/server/src/sql/teacher"
@@ -28,6 +32,30 @@ func newProgression(progressions ta.Progressions, nbQuestions int) Progression {
 	return out
 }
 
+// HintsUsage stores the number of hints used by a student,
+// for each question of a given task.
+type HintsUsage []int
+
+func newHintsUsage(progressions ta.Progressions, nbQuestions int) HintsUsage {
+	out := make(HintsUsage, nbQuestions)
+	for _, link := range progressions {
+		if link.Index >= int16(nbQuestions) {
+			continue // the progression item is no more usable
+		}
+		out[link.Index] = int(link.HintsUsed)
+	}
+	return out
+}
+
+// Total returns the number of hints used for the whole task.
+func (hu HintsUsage) Total() int {
+	s := 0
+	for _, nb := range hu {
+		s += nb
+	}
+	return s
+}
+
 func (qh Progression) Copy() Progression {
 	return append(Progression(nil), qh...)
 }
@@ -252,15 +280,24 @@ func (contents TasksContents) GetWork(task ta.Task) WorkMeta {
 // LoadProgressions load the question progression related to the tasks
 // in [contents].
 func (contents TasksContents) LoadProgressions(db ta.DB) (map[ta.IdTask]map[teacher.IdStudent]Progression, error) {
+	out, _, err := contents.LoadProgressionsAndHints(db)
+	return out, err
+}
+
+// LoadProgressionsAndHints is the same as [LoadProgressions], but also
+// returns the hints used by the students.
+func (contents TasksContents) LoadProgressionsAndHints(db ta.DB) (map[ta.IdTask]map[teacher.IdStudent]Progression, map[ta.IdTask]map[teacher.IdStudent]HintsUsage, error) {
 	tmp, err := ta.SelectProgressionsByIdTasks(db, contents.Tasks.IDs()...)
 	if err != nil {
-		return nil, utils.SQLError(err)
+		return nil, nil, utils.SQLError(err)
 	}
 	byTask := tmp.ByIdTask() // (incomplete) progression of the students
 
 	out := make(map[ta.IdTask]map[teacher.IdStudent]Progression)
+	hints := make(map[ta.IdTask]map[teacher.IdStudent]HintsUsage)
 	for _, task := range contents.Tasks {
 		taskMap := make(map[teacher.IdStudent]Progression)
+		hintsMap := make(map[teacher.IdStudent]HintsUsage)
 		work := contents.GetWork(task)
 		// get the questions length
 		L := len(work.Bareme())
@@ -270,12 +307,14 @@ func (contents TasksContents) LoadProgressions(db ta.DB) (map[ta.IdTask]map[teac
 			// beware that some questions may not have a link item for the student yet
 			// so that we take L as reference
 			taskMap[idStudent] = newProgression(progressions, L)
+			hintsMap[idStudent] = newHintsUsage(progressions, L)
 		}
 
 		out[task.Id] = taskMap
+		hints[task.Id] = hintsMap
 	}
 
-	return out, nil
+	return out, hints, nil
 }
 
 // ResolveQuestions returns the question variants actually done by the student.
@@ -314,8 +353,55 @@ func (contents TasksContents) OrderQuestions(work WorkMeta) []ed.Question {
 	}
 }
 
+// InstantiatePaper returns a new instance of the questions of [task],
+// used to build printed papers : each call generates new random parameters,
+// and, for [RandomMonoquestion]s, selects new variants.
+func (contents TasksContents) InstantiatePaper(task ta.Task) ([]questions.QuestionInstance, error) {
+	var (
+		selected   []ed.Question
+		sharedVars expression.Vars
+		err        error
+	)
+	switch work := contents.GetWork(task).(type) {
+	case ExerciceData:
+		selected = work.Questions()
+		// the shared parameters are instantiated once per exercice
+		sharedVars, err = work.Exercice.Parameters.ToMap().Instantiate()
+		if err != nil {
+			return nil, err
+		}
+	case MonoquestionData:
+		selected = work.Questions()
+	case RandomMonoquestionData:
+		var filtered []ed.Question
+		for _, qu := range contents.questions.ByGroup()[work.Group.Id] {
+			if work.params.Difficulty.Match(qu.Difficulty) {
+				filtered = append(filtered, qu)
+			}
+		}
+		if len(filtered) == 0 {
+			return nil, errors.New("Aucune question n'est disponible pour ce travail !")
+		}
+		selected = selectVariants(work.params.NbRepeat, filtered)
+	default:
+		return nil, errors.New("internal error: unexpected Work kind")
+	}
+
+	out := make([]questions.QuestionInstance, len(selected))
+	for i, question := range selected {
+		out[i], _, err = instantiateQuestion(question, sharedVars)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
 // updateProgression write the question results for the given progression.
-func updateProgression(db *sql.DB, idStudent teacher.IdStudent, idTask ta.IdTask, questions []ta.QuestionHistory) error {
+// [attemptIndex] and [attempt] record the answer just evaluated.
+func updateProgression(db *sql.DB, idStudent teacher.IdStudent, idTask ta.IdTask, questions []ta.QuestionHistory,
+	attemptIndex int, attempt ta.QuestionAttempt,
+) error {
 	// sanity checks
 	task, err := ta.SelectTask(db, idTask)
 	if err != nil {
@@ -338,19 +424,31 @@ func updateProgression(db *sql.DB, idStudent teacher.IdStudent, idTask ta.IdTask
 		return utils.SQLError(err)
 	}
 
-	_, err = ta.DeleteProgressionsByIdStudentAndIdTask(tx, idStudent, idTask)
+	previous, err := ta.DeleteProgressionsByIdStudentAndIdTask(tx, idStudent, idTask)
 	if err != nil {
 		_ = tx.Rollback()
 		return utils.SQLError(err)
 	}
+	// the hints used are not modified by the evaluation
+	hints := newHintsUsage(previous, len(questions))
+	// keep the last answers of the other questions
+	attempts := make([]ta.QuestionAttempt, len(questions))
+	for _, link := range previous {
+		if int(link.Index) < len(attempts) {
+			attempts[link.Index] = link.LastAttempt
+		}
+	}
+	attempts[attemptIndex] = attempt
 
 	links := make(ta.Progressions, len(questions))
 	for i, qu := range questions {
 		links[i] = ta.Progression{
-			IdStudent: idStudent,
-			IdTask:    idTask,
-			Index:     int16(i),
-			History:   qu,
+			IdStudent:   idStudent,
+			IdTask:      idTask,
+			Index:       int16(i),
+			History:     qu,
+			HintsUsed:   int16(hints[i]),
+			LastAttempt: attempts[i],
 		}
 	}
 	err = ta.InsertManyProgressions(tx, links...)
@@ -367,6 +465,69 @@ func updateProgression(db *sql.DB, idStudent teacher.IdStudent, idTask ta.IdTask
 	return nil
 }
 
+// RecordHintUsage registers that the student has been shown the hint
+// [hintIndex] (and thus all the previous ones) for the question [index] of the task.
+// Showing an hint again has no effect.
+func RecordHintUsage(db ta.DB, idStudent teacher.IdStudent, idTask ta.IdTask, index int16, hintIndex int) error {
+	_, err := db.Exec(`INSERT INTO progressions (idstudent, idtask, index, history, hintsused, lastattempt)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (idstudent, idtask, index) DO UPDATE SET hintsused = GREATEST(progressions.hintsused, EXCLUDED.hintsused);
+		`, idStudent, idTask, index, ta.QuestionHistory{}, hintIndex+1, ta.QuestionAttempt{})
+	if err != nil {
+		return utils.SQLError(err)
+	}
+	return nil
+}
+
+// QuestionAttempt is the last answer of a student to a question,
+// with the question instantiated as the student saw it.
+type QuestionAttempt struct {
+	Title    string // the title of the task
+	Question questions.QuestionInstance
+	Answers  client.Answers
+}
+
+// LoadQuestionAttempt re-instantiates the question [index] of the given task,
+// with the parameters used by the student on their last answer.
+// It returns false if the student has not answered this question yet.
+func LoadQuestionAttempt(db ta.DB, idStudent teacher.IdStudent, idTask ta.IdTask, index int) (QuestionAttempt, bool, error) {
+	link, found, err := ta.SelectProgressionByIdStudentAndIdTaskAndIndex(db, idStudent, idTask, int16(index))
+	if err != nil {
+		return QuestionAttempt{}, false, utils.SQLError(err)
+	}
+	if !found || link.LastAttempt.IsEmpty() {
+		return QuestionAttempt{}, false, nil
+	}
+
+	task, err := ta.SelectTask(db, idTask)
+	if err != nil {
+		return QuestionAttempt{}, false, utils.SQLError(err)
+	}
+	work, err := newWorkLoader(db, NewWorkID(task), idStudent)
+	if err != nil {
+		return QuestionAttempt{}, false, err
+	}
+	qus := work.Questions()
+	if index < 0 || index >= len(qus) {
+		return QuestionAttempt{}, false, fmt.Errorf("internal error in LoadQuestionAttempt(task=%d,student=%d): invalid question index %d", idTask, idStudent, index)
+	}
+
+	params := make(Params, len(link.LastAttempt.Params))
+	for i, param := range link.LastAttempt.Params {
+		params[i] = VarEntry(param)
+	}
+	vars, err := params.ToMap()
+	if err != nil {
+		return QuestionAttempt{}, false, err
+	}
+	instance, err := qus[index].Page().InstantiateWith(vars)
+	if err != nil {
+		return QuestionAttempt{}, false, err
+	}
+
+	return QuestionAttempt{Title: work.Title(), Question: instance, Answers: link.LastAttempt.Answers}, true, nil
+}
+
 // Student API
 
 type TaskProgressionHeader struct {
@@ -383,6 +544,22 @@ type TaskProgressionHeader struct {
 	// empty if HasProgression is false
 	Progression  Progression
 	Mark, Bareme int // student mark / exercice total
+
+	hintsUsed HintsUsage // empty if HasProgression is false
+	baremes   TaskBareme
+}
+
+// HintsUsed returns the number of hints used for each question.
+func (pr TaskProgressionHeader) HintsUsed() HintsUsage { return pr.hintsUsed }
+
+// WithHintPenalty returns a copy of [pr], where [Mark]
+// takes into account the hints used (see [TaskBareme.ComputeMarkWithHints]).
+func (pr TaskProgressionHeader) WithHintPenalty(hintPenalty int) TaskProgressionHeader {
+	if hintPenalty == 0 { // the mark is unchanged
+		return pr
+	}
+	pr.Mark = pr.baremes.ComputeMarkWithHints(pr.Progression, pr.hintsUsed, hintPenalty)
+	return pr
 }
 
 // LoadTaskProgression is a convenience wrapper around [LoadTasksProgression]
@@ -416,6 +593,10 @@ func LoadTasksProgression(db ta.DB, idStudent teacher.IdStudent, idTasks []ta.Id
 		// the progression may be empty if the student has not started it
 		hasProg := len(progs) != 0
 		progression := newProgression(progs, len(baremes))
+		var hints HintsUsage
+		if hasProg {
+			hints = newHintsUsage(progs, len(baremes))
+		}
 
 		out[task.Id] = TaskProgressionHeader{
 			Id:             task.Id,
@@ -426,6 +607,8 @@ func LoadTasksProgression(db ta.DB, idStudent teacher.IdStudent, idTasks []ta.Id
 			Progression:    progression,
 			Bareme:         baremes.Total(),
 			Mark:           baremes.ComputeMark(progression),
+			hintsUsed:      hints,
+			baremes:        baremes,
 		}
 	}
 
@@ -441,10 +624,11 @@ func MatiereFromTasks(tasks []TaskProgressionHeader) teacher.MatiereTag {
 }
 
 // EvaluateTaskExercice calls `EvaluateExercice` and, if [registerProgression] is true, registers
-// the student progression, returning the updated mark.
+// the student progression, returning the updated mark, where each hint used
+// costs [hintPenalty] points.
 // If needed, a new progression item is created.
 // If [registerProgression] is false, no progression is created.
-func EvaluateTaskExercice(db *sql.DB, idTask ta.IdTask, idStudent teacher.IdStudent, isOneTry bool, ex EvaluateWorkIn, registerProgression bool) (out EvaluateWorkOut, mark int, err error) {
+func EvaluateTaskExercice(db *sql.DB, idTask ta.IdTask, idStudent teacher.IdStudent, isOneTry bool, ex EvaluateWorkIn, registerProgression bool, hintPenalty int) (out EvaluateWorkOut, mark int, err error) {
 	out, err = ex.Evaluate(db, idStudent, isOneTry)
 	if err != nil {
 		return
@@ -452,7 +636,7 @@ func EvaluateTaskExercice(db *sql.DB, idTask ta.IdTask, idStudent teacher.IdStud
 
 	if registerProgression {
 		// persists the progression on DB
-		err = updateProgression(db, idStudent, idTask, out.Progression.Questions)
+		err = updateProgression(db, idStudent, idTask, out.Progression.Questions, out.AnswerIndex, ex.Answer.toAttempt())
 		if err != nil {
 			return out, 0, err
 		}
@@ -469,7 +653,13 @@ func EvaluateTaskExercice(db *sql.DB, idTask ta.IdTask, idStudent teacher.IdStud
 		return
 	}
 	baremes := loader.Bareme()
-	mark = baremes.ComputeMark(out.Progression.Questions)
+
+	links, err := ta.SelectProgressionsByIdStudentAndIdTask(db, idStudent, idTask)
+	if err != nil {
+		return out, mark, utils.SQLError(err)
+	}
+	hints := newHintsUsage(links, len(baremes))
+	mark = baremes.ComputeMarkWithHints(out.Progression.Questions, hints, hintPenalty)
 
 	return out, mark, nil
 }
@@ -491,6 +681,13 @@ func (bareme TaskBareme) Total() int {
 // An empty [progression] is supported and returns 0.
 // Otherwise, the length of [progression] must match the length of [bareme]
 func (bareme TaskBareme) ComputeMark(progression Progression) int {
+	return bareme.ComputeMarkWithHints(progression, nil, 0)
+}
+
+// ComputeMarkWithHints is the same as [ComputeMark], but removes [hintPenalty] points
+// per hint used on a successful question. The mark of a question is never negative.
+// [hints] may be empty.
+func (bareme TaskBareme) ComputeMarkWithHints(progression Progression, hints HintsUsage, hintPenalty int) int {
 	if len(progression) == 0 {
 		return 0
 	}
@@ -498,7 +695,13 @@ func (bareme TaskBareme) ComputeMark(progression Progression) int {
 	var out int
 	for index, baremeQuestion := range bareme {
 		results := progression[index]
-		if results.Success() {
+		if !results.Success() {
+			continue
+		}
+		if index < len(hints) {
+			baremeQuestion -= hintPenalty * hints[index]
+		}
+		if baremeQuestion > 0 {
 			out += baremeQuestion
 		}
 	}
@@ -212,6 +212,30 @@ func RemoveAccents(s string) string {
 	return output
 }
 
+// EditDistance returns the Levenshtein distance between [s1] and [s2],
+// that is the minimum number of single rune insertions, deletions
+// or substitutions needed to change [s1] into [s2].
+func EditDistance(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+	previous := make([]int, len(r2)+1)
+	current := make([]int, len(r2)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := range r1 {
+		current[0] = i + 1
+		for j := range r2 {
+			cost := 1
+			if r1[i] == r2[j] {
+				cost = 0
+			}
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(r2)]
+}
+
 type Set[T comparable] map[T]struct{}
 
 func NewSet[T comparable](values ...T) Set[T] {
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"archive/zip"
+	"bytes"
 	"math/rand"
 	"testing"
 )
@@ -49,3 +51,47 @@ func TestRandomID(t *testing.T) {
 		t.Fatal("duplicate!")
 	}
 }
+
+func TestXlsxColumn(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(index); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", index, got, want)
+		}
+	}
+}
+
+func TestWriteXlsx(t *testing.T) {
+	content, err := WriteXlsx([]XlsxSheet{
+		{Name: "Notes", Rows: [][]XlsxCell{{XlsxText("Elève"), XlsxText("DM <1>")}, {XlsxText("DUPONT Jean"), XlsxNumber(12.5)}}},
+		{Name: "Statistiques"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.File) != 6 {
+		t.Fatalf("unexpected number of files %d", len(r.File))
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		s1, s2 string
+		want   int
+	}{
+		{"", "", 0},
+		{"chat", "chat", 0},
+		{"chat", "", 4},
+		{"chat", "chats", 1},
+		{"chien", "chine", 2},
+		{"kitten", "sitting", 3},
+		{"été", "ete", 2},
+	} {
+		if got := EditDistance(test.s1, test.s2); got != test.want {
+			t.Errorf("EditDistance(%s, %s) = %d, want %d", test.s1, test.s2, got, test.want)
+		}
+	}
+}